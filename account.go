@@ -2,6 +2,7 @@ package runpod
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -26,3 +27,212 @@ func (c *Client) GetAccountID(ctx context.Context) (string, error) {
 	}
 	return accountID, nil
 }
+
+const whoamiQuery = `query { myself { id email clientBalance currentSpendPerHr spendLimit } }`
+
+// AccountInfo describes the account authenticated by the client's API key.
+type AccountInfo struct {
+	ID    string `json:"id"`
+	Email string `json:"email,omitempty"`
+	// ClientBalance is the prepaid balance in USD.
+	ClientBalance float64 `json:"clientBalance"`
+	// CurrentSpendPerHr is the USD/hr burn of everything currently running.
+	CurrentSpendPerHr float64 `json:"currentSpendPerHr"`
+	// SpendLimit is the account's hourly spend cap in USD (zero when unset).
+	SpendLimit float64 `json:"spendLimit,omitempty"`
+}
+
+// Whoami returns the account authenticated by the client's API key.
+func (c *Client) Whoami(ctx context.Context) (*AccountInfo, error) {
+	var result struct {
+		Myself AccountInfo `json:"myself"`
+	}
+	if err := c.GraphQL(ctx, whoamiQuery, nil, &result); err != nil {
+		return nil, fmt.Errorf("failed to get account info: %w", err)
+	}
+	if strings.TrimSpace(result.Myself.ID) == "" {
+		return nil, fmt.Errorf("failed to get account info: response omitted myself.id")
+	}
+	return &result.Myself, nil
+}
+
+// APIKeyError reasons.
+const (
+	// APIKeyInvalid: the key is unknown, revoked or malformed.
+	APIKeyInvalid = "invalid"
+	// APIKeyRestricted: the key authenticates but its scope does not allow
+	// reading the account (e.g. a restricted serverless-only key).
+	APIKeyRestricted = "restricted"
+)
+
+// APIKeyError is returned by ValidateAPIKey when RunPod rejects the key.
+// errors.Is(err, ErrUnauthorized) is true for both reasons.
+type APIKeyError struct {
+	Reason string
+	Err    error
+}
+
+func (e *APIKeyError) Error() string {
+	if e.Reason == APIKeyRestricted {
+		return fmt.Sprintf("runpod: API key is restricted: %v", e.Err)
+	}
+	return fmt.Sprintf("runpod: API key is invalid: %v", e.Err)
+}
+
+// Unwrap returns the underlying API error.
+func (e *APIKeyError) Unwrap() error { return e.Err }
+
+// Is matches ErrUnauthorized.
+func (e *APIKeyError) Is(target error) bool { return target == ErrUnauthorized }
+
+// ValidateAPIKey checks the client's API key with one cheap account query,
+// for failing fast at service startup. It returns nil for a full-access key
+// and an *APIKeyError for an invalid or restricted-scope key. Any other
+// error (network failure, RunPod outage) is returned wrapped as-is, so
+// callers can retry those rather than treat them as misconfiguration.
+//
+// A GraphQL-level authorization failure is ambiguous; ValidateAPIKey then
+// probes the REST API once to tell a restricted key from an invalid one.
+func (c *Client) ValidateAPIKey(ctx context.Context) error {
+	if strings.TrimSpace(c.apiKey()) == "" {
+		return &APIKeyError{Reason: APIKeyInvalid, Err: NewValidationError("apiKey", "cannot be empty")}
+	}
+	_, err := c.graphQLData(ctx, accountIDQuery, nil)
+	if err == nil {
+		return nil
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return fmt.Errorf("failed to validate API key: %w", err)
+	}
+	switch {
+	case apiErr.StatusCode == 401:
+		return &APIKeyError{Reason: APIKeyInvalid, Err: err}
+	case apiErr.StatusCode == 403:
+		return &APIKeyError{Reason: APIKeyRestricted, Err: err}
+	case apiErr.StatusCode == 400 && isAuthorizationMessage(apiErr.Details):
+		probeErr := c.Get(ctx, c.buildURL("/pods"), nil)
+		var probeAPIErr *APIError
+		switch {
+		case probeErr == nil, errors.As(probeErr, &probeAPIErr) && probeAPIErr.StatusCode == 403:
+			return &APIKeyError{Reason: APIKeyRestricted, Err: err}
+		case probeAPIErr != nil && probeAPIErr.StatusCode == 401:
+			return &APIKeyError{Reason: APIKeyInvalid, Err: err}
+		}
+		return fmt.Errorf("failed to validate API key: %w", errors.Join(err, probeErr))
+	}
+	return fmt.Errorf("failed to validate API key: %w", err)
+}
+
+func isAuthorizationMessage(msg string) bool {
+	msg = strings.ToLower(msg)
+	for _, marker := range []string{"unauthorized", "not authorized", "permission", "forbidden", "api key"} {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// AccountSettings updates account guardrails. Nil fields are left
+// unchanged.
+type AccountSettings struct {
+	// SpendLimit caps the account's hourly spend in USD; RunPod refuses to
+	// start resources that would exceed it. Zero removes the cap.
+	SpendLimit *float64
+}
+
+// UpdateAccountSettings applies settings and returns the updated account.
+//
+//	limit := 40.0
+//	account, err := client.UpdateAccountSettings(ctx, &runpod.AccountSettings{SpendLimit: &limit})
+func (c *Client) UpdateAccountSettings(ctx context.Context, settings *AccountSettings) (*AccountInfo, error) {
+	if settings == nil || settings.SpendLimit == nil {
+		return nil, NewValidationError("settings", "at least one setting must be set")
+	}
+	if *settings.SpendLimit < 0 {
+		return nil, NewValidationErrorWithValue("spendLimit", "cannot be negative", *settings.SpendLimit)
+	}
+	const query = `mutation UpdateAccountSettings($input: UserSettingsInput!) {
+  updateUserSettings(input: $input) { id email clientBalance currentSpendPerHr spendLimit }
+}`
+	var payload struct {
+		Account *AccountInfo `json:"updateUserSettings"`
+	}
+	if err := c.graphQLMutation(ctx, Mutation{ResourceType: "account", Action: "update-settings"}, query, map[string]interface{}{
+		"input": map[string]interface{}{"spendLimit": *settings.SpendLimit},
+	}, &payload); err != nil {
+		return nil, fmt.Errorf("failed to update account settings: %w", err)
+	}
+	if payload.Account == nil {
+		return nil, fmt.Errorf("failed to update account settings: response omitted account")
+	}
+	return payload.Account, nil
+}
+
+const notificationFields = `notifyLowBalance creditAlertThreshold notifyPodsGeneral notifyPodsStale notifyOther`
+
+// NotificationSettings are the account's email notification preferences.
+type NotificationSettings struct {
+	// LowBalance emails when the balance drops below LowBalanceThreshold
+	// (USD).
+	LowBalance          bool    `json:"notifyLowBalance"`
+	LowBalanceThreshold float64 `json:"creditAlertThreshold"`
+	// PodEvents emails on pod lifecycle events (e.g. stopped for low
+	// balance, host maintenance).
+	PodEvents bool `json:"notifyPodsGeneral"`
+	// StalePods emails about pods left idle.
+	StalePods bool `json:"notifyPodsStale"`
+	// Other covers product and account announcements.
+	Other bool `json:"notifyOther"`
+}
+
+// GetNotificationSettings returns the account's notification preferences.
+func (c *Client) GetNotificationSettings(ctx context.Context) (*NotificationSettings, error) {
+	var payload struct {
+		Myself *NotificationSettings `json:"myself"`
+	}
+	if err := c.GraphQL(ctx, `query { myself { `+notificationFields+` } }`, nil, &payload); err != nil {
+		return nil, fmt.Errorf("failed to get notification settings: %w", err)
+	}
+	if payload.Myself == nil {
+		return nil, fmt.Errorf("failed to get notification settings: response omitted myself")
+	}
+	return payload.Myself, nil
+}
+
+// UpdateNotificationSettings replaces the account's notification preferences
+// with settings, so applying the same value to several accounts leaves them
+// configured identically.
+func (c *Client) UpdateNotificationSettings(ctx context.Context, settings *NotificationSettings) (*NotificationSettings, error) {
+	if settings == nil {
+		return nil, NewValidationError("settings", "cannot be nil")
+	}
+	if settings.LowBalanceThreshold < 0 {
+		return nil, NewValidationErrorWithValue("lowBalanceThreshold", "cannot be negative", settings.LowBalanceThreshold)
+	}
+	if settings.LowBalance && settings.LowBalanceThreshold == 0 {
+		return nil, NewValidationError("lowBalanceThreshold", "must be set when low-balance notifications are enabled")
+	}
+	const query = `mutation UpdateNotificationSettings($input: UserSettingsInput!) {
+  updateUserSettings(input: $input) { ` + notificationFields + ` }
+}`
+	var payload struct {
+		Settings *NotificationSettings `json:"updateUserSettings"`
+	}
+	if err := c.graphQLMutation(ctx, Mutation{ResourceType: "account", Action: "update-notification-settings"}, query, map[string]interface{}{
+		"input": map[string]interface{}{
+			"notifyLowBalance":     settings.LowBalance,
+			"creditAlertThreshold": settings.LowBalanceThreshold,
+			"notifyPodsGeneral":    settings.PodEvents,
+			"notifyPodsStale":      settings.StalePods,
+			"notifyOther":          settings.Other,
+		},
+	}, &payload); err != nil {
+		return nil, fmt.Errorf("failed to update notification settings: %w", err)
+	}
+	if payload.Settings == nil {
+		return nil, fmt.Errorf("failed to update notification settings: response omitted settings")
+	}
+	return payload.Settings, nil
+}
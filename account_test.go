@@ -0,0 +1,117 @@
+package runpod_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestWhoami(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	srv.SetAccount(runpod.AccountInfo{ID: "acct-1", Email: "ops@example.com", ClientBalance: 42.5, CurrentSpendPerHr: 1.25, SpendLimit: 80})
+
+	info, err := srv.MustClient().Whoami(t.Context())
+	if err != nil {
+		t.Fatalf("Whoami: %v", err)
+	}
+	if *info != (runpod.AccountInfo{ID: "acct-1", Email: "ops@example.com", ClientBalance: 42.5, CurrentSpendPerHr: 1.25, SpendLimit: 80}) {
+		t.Fatalf("account = %+v", info)
+	}
+}
+
+func TestValidateAPIKey(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	srv.RevokeAPIKey("revoked-key")
+	srv.RestrictAPIKey("restricted-key")
+
+	if err := srv.MustClient().ValidateAPIKey(t.Context()); err != nil {
+		t.Fatalf("valid key: %v", err)
+	}
+	for key, reason := range map[string]string{"revoked-key": runpod.APIKeyInvalid, "restricted-key": runpod.APIKeyRestricted} {
+		client, err := srv.ClientWithAPIKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = client.ValidateAPIKey(t.Context())
+		var keyErr *runpod.APIKeyError
+		if !errors.As(err, &keyErr) || keyErr.Reason != reason || !errors.Is(err, runpod.ErrUnauthorized) {
+			t.Errorf("%s: error = %v, want %s", key, err, reason)
+		}
+	}
+
+	// A GraphQL-level authorization error is disambiguated through REST.
+	graphQL := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"errors":[{"message":"Not authorized to access myself"}]}`))
+	}))
+	defer graphQL.Close()
+	client, err := srv.ClientWithAPIKey("runpodtest-key", runpod.WithGraphQLBaseURL(graphQL.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keyErr *runpod.APIKeyError
+	if err := client.ValidateAPIKey(t.Context()); !errors.As(err, &keyErr) || keyErr.Reason != runpod.APIKeyRestricted {
+		t.Fatalf("GraphQL-restricted key: %v", err)
+	}
+
+	// Network failures are not key problems.
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+	client = mustClient(t, "test_key", runpod.WithGraphQLBaseURL(down.URL), runpod.WithMaxRetryAttempts(0))
+	if err := client.ValidateAPIKey(t.Context()); err == nil || errors.As(err, &keyErr) || errors.Is(err, runpod.ErrUnauthorized) {
+		t.Fatalf("network failure: %v", err)
+	}
+}
+
+func TestUpdateAccountSettings(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+
+	limit := 40.0
+	account, err := client.UpdateAccountSettings(t.Context(), &runpod.AccountSettings{SpendLimit: &limit})
+	if err != nil || account.SpendLimit != 40 {
+		t.Fatalf("UpdateAccountSettings = %+v, %v", account, err)
+	}
+	if info, err := client.Whoami(t.Context()); err != nil || info.SpendLimit != 40 {
+		t.Fatalf("Whoami after update = %+v, %v", info, err)
+	}
+
+	var validationErr *runpod.ValidationError
+	negative := -1.0
+	for _, settings := range []*runpod.AccountSettings{nil, {}, {SpendLimit: &negative}} {
+		if _, err := client.UpdateAccountSettings(t.Context(), settings); !errors.As(err, &validationErr) {
+			t.Errorf("settings %+v: expected validation error, got %v", settings, err)
+		}
+	}
+}
+
+func TestNotificationSettings(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+
+	current, err := client.GetNotificationSettings(t.Context())
+	if err != nil || *current != srv.NotificationSettings() {
+		t.Fatalf("GetNotificationSettings = %+v, %v", current, err)
+	}
+
+	want := runpod.NotificationSettings{LowBalance: true, LowBalanceThreshold: 50, PodEvents: true, StalePods: true}
+	got, err := client.UpdateNotificationSettings(t.Context(), &want)
+	if err != nil || *got != want || srv.NotificationSettings() != want {
+		t.Fatalf("UpdateNotificationSettings = %+v, %v; fake has %+v", got, err, srv.NotificationSettings())
+	}
+
+	var validationErr *runpod.ValidationError
+	for _, settings := range []*runpod.NotificationSettings{nil, {LowBalance: true}, {LowBalanceThreshold: -1}} {
+		if _, err := client.UpdateNotificationSettings(t.Context(), settings); !errors.As(err, &validationErr) {
+			t.Errorf("settings %+v: expected validation error, got %v", settings, err)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package runpod
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// WithFallbackAPIKeys gives the client more API keys, tried in order after
+// the one passed to NewClient. When RunPod rejects the key in use with 401
+// or 403, the request is sent again with the next key, and the client
+// keeps using whichever key is accepted. A key can so be rotated without
+// restarting a long-running service: create the new key, add it here on
+// the next deploy, and revoke the old one whenever. Each request tries
+// every key at most once, continuing from the active key and wrapping
+// around, so a re-enabled earlier key is picked up again. Rejections are
+// answered before anything is processed, so POSTs are sent again too.
+// Blank keys are ignored.
+func WithFallbackAPIKeys(keys ...string) ClientOption {
+	return func(c *Client) {
+		for _, key := range keys {
+			if strings.TrimSpace(key) != "" {
+				c.apiKeys = append(c.apiKeys, key)
+			}
+		}
+	}
+}
+
+// ActiveAPIKey reports which API key the client is using: 0 for the key
+// passed to NewClient, 1 for the first WithFallbackAPIKeys key, and so on.
+// It moves when a key is rejected.
+func (c *Client) ActiveAPIKey() int {
+	return int(c.activeKey.Load())
+}
+
+func (c *Client) apiKey() string {
+	return c.apiKeys[c.activeKey.Load()]
+}
+
+// doAuthenticated sends a request with the active API key, failing over to
+// the next key on 401 and 403.
+func (c *Client) doAuthenticated(ctx context.Context, method, endpoint string, jsonBody []byte, body interface{}) (*http.Response, error) {
+	for tried := 1; ; tried++ {
+		key := c.activeKey.Load()
+		resp, err := c.doRequest(ctx, method, endpoint, jsonBody, body, c.apiKeys[key])
+		if err != nil || (resp.StatusCode != http.StatusUnauthorized && resp.StatusCode != http.StatusForbidden) || tried >= len(c.apiKeys) {
+			return resp, err
+		}
+		resp.Body.Close()
+		next := (key + 1) % int32(len(c.apiKeys))
+		// Another request may have moved on already; then retry with its
+		// choice.
+		if c.activeKey.CompareAndSwap(key, next) && c.debug {
+			c.logger.Printf("[DEBUG] API key %d rejected with HTTP %d, failing over to key %d", key, resp.StatusCode, next)
+		}
+	}
+}
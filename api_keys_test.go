@@ -0,0 +1,48 @@
+package runpod_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestWithFallbackAPIKeys(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client, err := srv.ClientWithAPIKey("old", runpod.WithFallbackAPIKeys("", "new"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := t.Context()
+
+	if _, err := client.ListPods(ctx, nil); err != nil || client.ActiveAPIKey() != 0 {
+		t.Fatalf("ListPods = %v, active key %d", err, client.ActiveAPIKey())
+	}
+
+	// The old key is revoked: the POST is sent again with the new one,
+	// which is used from then on.
+	srv.RevokeAPIKey("old")
+	if _, err := client.RunAsync(ctx, "ep1", map[string]int{"n": 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ListPods(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"Bearer old", "Bearer old", "Bearer new", "Bearer new"}
+	if got := srv.AuthorizationHeaders(); !reflect.DeepEqual(got, want) || client.ActiveAPIKey() != 1 {
+		t.Fatalf("authorization = %v, active key %d", got, client.ActiveAPIKey())
+	}
+
+	// With every key rejected, each is tried once and the error is the
+	// last rejection.
+	srv.RevokeAPIKey("new")
+	if _, err := client.ListPods(ctx, nil); !errors.Is(err, runpod.ErrUnauthorized) {
+		t.Fatalf("ListPods with every key revoked = %v", err)
+	}
+	if got := srv.AuthorizationHeaders()[len(want):]; !reflect.DeepEqual(got, []string{"Bearer new", "Bearer old"}) {
+		t.Fatalf("authorization = %v", got)
+	}
+}
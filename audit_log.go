@@ -0,0 +1,143 @@
+package runpod
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultAuditLogPageSize is the ListAuditLogs page size when
+// AuditLogFilter.Limit is unset.
+const DefaultAuditLogPageSize = 100
+
+// AuditLogEntry is one account audit event: who did what to which resource.
+type AuditLogEntry struct {
+	ID         string    `json:"id"`
+	Time       *JSONTime `json:"time"`
+	ActorID    string    `json:"actorId"`
+	ActorEmail string    `json:"actorEmail,omitempty"`
+	// Action is the operation, e.g. "pod.create" or "endpoint.delete".
+	Action       string `json:"action"`
+	ResourceType string `json:"resourceType,omitempty"`
+	ResourceID   string `json:"resourceId,omitempty"`
+	IPAddress    string `json:"ipAddress,omitempty"`
+}
+
+// AuditLogFilter constrains ListAuditLogs. Zero values are unconstrained.
+type AuditLogFilter struct {
+	// Since / Until bound the event time (Since inclusive, Until exclusive).
+	Since time.Time
+	Until time.Time
+	// Actors keeps events by these actor IDs or emails.
+	Actors []string
+	// Actions keeps these actions, e.g. "pod.terminate".
+	Actions    []string
+	ResourceID string
+
+	// Limit is the page size (default DefaultAuditLogPageSize).
+	Limit int
+	// Cursor resumes after a previous page's NextCursor.
+	Cursor string
+}
+
+// AuditLogPage is one page of audit events, newest first.
+type AuditLogPage struct {
+	Entries []AuditLogEntry
+	// NextCursor is empty on the last page.
+	NextCursor string
+}
+
+// ListAuditLogs returns one page of the account audit log matching filter
+// (which may be nil). Pass NextCursor back as filter.Cursor for the next
+// page, or use ListAllAuditLogs.
+func (c *Client) ListAuditLogs(ctx context.Context, filter *AuditLogFilter) (*AuditLogPage, error) {
+	if filter == nil {
+		filter = &AuditLogFilter{}
+	}
+	if filter.Limit < 0 {
+		return nil, NewValidationErrorWithValue("limit", "cannot be negative", filter.Limit)
+	}
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && !filter.Until.After(filter.Since) {
+		return nil, NewValidationError("until", "must be after since")
+	}
+	limit := filter.Limit
+	if limit == 0 {
+		limit = DefaultAuditLogPageSize
+	}
+
+	input := map[string]interface{}{"limit": limit}
+	if !filter.Since.IsZero() {
+		input["startTime"] = filter.Since.UTC().Format(time.RFC3339)
+	}
+	if !filter.Until.IsZero() {
+		input["endTime"] = filter.Until.UTC().Format(time.RFC3339)
+	}
+	if actors := trimmedNonEmpty(filter.Actors); len(actors) > 0 {
+		input["actors"] = actors
+	}
+	if actions := trimmedNonEmpty(filter.Actions); len(actions) > 0 {
+		input["actions"] = actions
+	}
+	if id := strings.TrimSpace(filter.ResourceID); id != "" {
+		input["resourceId"] = id
+	}
+	if cursor := strings.TrimSpace(filter.Cursor); cursor != "" {
+		input["cursor"] = cursor
+	}
+
+	const query = `query AuditLogs($input: AuditLogInput) {
+  myself {
+    auditLogs(input: $input) {
+      items { id time actorId actorEmail action resourceType resourceId ipAddress }
+      nextCursor
+    }
+  }
+}`
+	var payload struct {
+		Myself struct {
+			AuditLogs struct {
+				Items      []AuditLogEntry `json:"items"`
+				NextCursor string          `json:"nextCursor"`
+			} `json:"auditLogs"`
+		} `json:"myself"`
+	}
+	if err := c.GraphQL(ctx, query, map[string]interface{}{"input": input}, &payload); err != nil {
+		return nil, fmt.Errorf("failed to list audit logs: %w", err)
+	}
+	return &AuditLogPage{
+		Entries:    payload.Myself.AuditLogs.Items,
+		NextCursor: payload.Myself.AuditLogs.NextCursor,
+	}, nil
+}
+
+// ListAllAuditLogs follows NextCursor until the last page and returns every
+// matching event, newest first.
+func (c *Client) ListAllAuditLogs(ctx context.Context, filter *AuditLogFilter) ([]AuditLogEntry, error) {
+	page := AuditLogFilter{}
+	if filter != nil {
+		page = *filter
+	}
+	var entries []AuditLogEntry
+	for {
+		result, err := c.ListAuditLogs(ctx, &page)
+		if err != nil {
+			return entries, err
+		}
+		entries = append(entries, result.Entries...)
+		if result.NextCursor == "" || result.NextCursor == page.Cursor {
+			return entries, nil
+		}
+		page.Cursor = result.NextCursor
+	}
+}
+
+func trimmedNonEmpty(values []string) []string {
+	var out []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
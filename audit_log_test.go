@@ -0,0 +1,52 @@
+package runpod_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestListAuditLogs(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+	ctx := t.Context()
+
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(h int) *runpod.JSONTime { return &runpod.JSONTime{Time: base.Add(time.Duration(h) * time.Hour)} }
+	srv.AddAuditLogEntries(
+		runpod.AuditLogEntry{ID: "e1", Time: at(0), ActorID: "u1", ActorEmail: "alice@example.com", Action: "pod.create", ResourceID: "pod-1"},
+		runpod.AuditLogEntry{ID: "e2", Time: at(1), ActorID: "u2", ActorEmail: "bob@example.com", Action: "pod.terminate", ResourceID: "pod-1"},
+		runpod.AuditLogEntry{ID: "e3", Time: at(2), ActorID: "u1", ActorEmail: "alice@example.com", Action: "endpoint.create", ResourceID: "ep-1"},
+		runpod.AuditLogEntry{ID: "e4", Time: at(3), ActorID: "u1", ActorEmail: "alice@example.com", Action: "pod.terminate", ResourceID: "pod-2"},
+	)
+
+	page, err := client.ListAuditLogs(ctx, &runpod.AuditLogFilter{Actors: []string{"alice@example.com"}, Limit: 2})
+	if err != nil {
+		t.Fatalf("ListAuditLogs: %v", err)
+	}
+	if len(page.Entries) != 2 || page.Entries[0].ID != "e4" || page.Entries[1].ID != "e3" || page.NextCursor == "" {
+		t.Fatalf("first page = %+v", page)
+	}
+	if !page.Entries[0].Time.Equal(base.Add(3 * time.Hour)) {
+		t.Fatalf("time = %v", page.Entries[0].Time)
+	}
+
+	all, err := client.ListAllAuditLogs(ctx, &runpod.AuditLogFilter{Actors: []string{"u1"}, Limit: 2})
+	if err != nil || len(all) != 3 || all[2].ID != "e1" {
+		t.Fatalf("ListAllAuditLogs = %+v, %v", all, err)
+	}
+
+	window, err := client.ListAllAuditLogs(ctx, &runpod.AuditLogFilter{Since: base.Add(time.Hour), Until: base.Add(3 * time.Hour), Actions: []string{"pod.terminate", "endpoint.create"}})
+	if err != nil || len(window) != 2 || window[0].ID != "e3" || window[1].ID != "e2" {
+		t.Fatalf("time window = %+v, %v", window, err)
+	}
+
+	var validationErr *runpod.ValidationError
+	if _, err := client.ListAuditLogs(ctx, &runpod.AuditLogFilter{Since: base, Until: base}); !errors.As(err, &validationErr) {
+		t.Fatalf("empty range: %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+package runpod
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// DefaultBalanceWatchInterval is the WatchBalance poll interval when
+// BalanceWatchOptions.Interval is unset.
+const DefaultBalanceWatchInterval = time.Minute
+
+// BalanceAlert types.
+const (
+	// BalanceAlertLow: ClientBalance dropped below MinBalance.
+	BalanceAlertLow = "balance_low"
+	// BalanceAlertSpend: CurrentSpendPerHr rose above MaxSpendPerHr.
+	BalanceAlertSpend = "spend_high"
+	// BalanceAlertForecast: the projected month's spend rose above
+	// MaxMonthlySpend.
+	BalanceAlertForecast = "forecast_high"
+)
+
+// BalanceWatchOptions configures WatchBalance. At least one of MinBalance,
+// MaxSpendPerHr and MaxMonthlySpend must be set.
+type BalanceWatchOptions struct {
+	Interval time.Duration
+	// MinBalance alerts when the prepaid balance (USD) drops below it.
+	MinBalance float64
+	// MaxSpendPerHr alerts when the account burn rate (USD/hr) exceeds it.
+	MaxSpendPerHr float64
+	// MaxMonthlySpend alerts when the SpendForecast's Projected month total
+	// (USD) exceeds it. Forecaster makes the forecasts; when nil, one is
+	// created with default options.
+	MaxMonthlySpend float64
+	Forecaster      *SpendForecaster
+
+	// OnAlert is called, from the watcher goroutine, once per threshold
+	// crossing; it fires again only after the account recovers.
+	OnAlert func(BalanceAlert)
+	// OnError is called when a poll fails; the watcher keeps polling.
+	OnError func(error)
+
+	// StopPods is the circuit breaker: when set, every alert stops the
+	// running pods it returns true for. RunPod pods carry no labels, so tag
+	// non-critical pods by name or env, e.g.
+	//
+	//	StopPods: func(p *runpod.Pod) bool { return p.Env["CRITICALITY"] == "low" },
+	StopPods func(*Pod) bool
+}
+
+// BalanceAlert is one threshold crossing reported by WatchBalance.
+type BalanceAlert struct {
+	Type    string
+	Account AccountInfo
+	// Threshold is the MinBalance, MaxSpendPerHr or MaxMonthlySpend that
+	// was crossed.
+	Threshold float64
+	// Forecast is the forecast behind a BalanceAlertForecast.
+	Forecast *SpendForecast
+	// StoppedPods lists the pods the circuit breaker stopped.
+	StoppedPods []string
+	// Err joins circuit-breaker failures (listing or stopping pods).
+	Err error
+	At  time.Time
+}
+
+// WatchBalance polls Whoami until ctx is done, calling OnAlert when the
+// balance drops below MinBalance, the spend rate exceeds MaxSpendPerHr or
+// the month's forecast exceeds MaxMonthlySpend, and optionally stopping
+// non-critical pods. Thresholds already crossed at
+// the first poll alert immediately. It blocks, so run it in a goroutine; it
+// returns a validation error at once, ErrClientClosed if the client is
+// closed, otherwise ctx.Err() (context.Canceled once the client closes).
+//
+//	go client.WatchBalance(ctx, &runpod.BalanceWatchOptions{
+//		MinBalance: 25,
+//		OnAlert:    func(a runpod.BalanceAlert) { pager.Notify(a.Type, a.Account.ClientBalance) },
+//	})
+func (c *Client) WatchBalance(ctx context.Context, opts *BalanceWatchOptions) error {
+	if opts == nil || (opts.MinBalance <= 0 && opts.MaxSpendPerHr <= 0 && opts.MaxMonthlySpend <= 0) {
+		return NewValidationError("thresholds", "minBalance, maxSpendPerHr or maxMonthlySpend must be set")
+	}
+	if opts.MinBalance < 0 || opts.MaxSpendPerHr < 0 || opts.MaxMonthlySpend < 0 {
+		return NewValidationError("thresholds", "cannot be negative")
+	}
+	if opts.OnAlert == nil && opts.StopPods == nil {
+		return NewValidationError("onAlert", "cannot be nil without stopPods")
+	}
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultBalanceWatchInterval
+	}
+	ctx, release, err := c.lifecycle.start(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	forecaster := opts.Forecaster
+	if opts.MaxMonthlySpend > 0 && forecaster == nil {
+		forecaster, _ = c.NewSpendForecaster(nil)
+	}
+
+	var lowFired, spendFired, forecastFired bool
+	for {
+		var account *AccountInfo
+		var forecast *SpendForecast
+		var err error
+		if opts.MaxMonthlySpend > 0 {
+			// The forecast reads the account too.
+			if forecast, err = forecaster.Forecast(ctx); err == nil {
+				account = &forecast.Account
+			}
+		} else {
+			account, err = c.Whoami(ctx)
+		}
+		switch {
+		case ctx.Err() != nil:
+			return ctx.Err()
+		case err != nil:
+			if opts.OnError != nil {
+				opts.OnError(err)
+			}
+		default:
+			low := opts.MinBalance > 0 && account.ClientBalance < opts.MinBalance
+			spend := opts.MaxSpendPerHr > 0 && account.CurrentSpendPerHr > opts.MaxSpendPerHr
+			if low && !lowFired {
+				c.fireBalanceAlert(ctx, opts, BalanceAlert{Type: BalanceAlertLow, Account: *account, Threshold: opts.MinBalance})
+			}
+			if spend && !spendFired {
+				c.fireBalanceAlert(ctx, opts, BalanceAlert{Type: BalanceAlertSpend, Account: *account, Threshold: opts.MaxSpendPerHr})
+			}
+			over := forecast != nil && forecast.Projected > opts.MaxMonthlySpend
+			if over && !forecastFired {
+				c.fireBalanceAlert(ctx, opts, BalanceAlert{Type: BalanceAlertForecast, Account: *account, Threshold: opts.MaxMonthlySpend, Forecast: forecast})
+			}
+			lowFired, spendFired, forecastFired = low, spend, over
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-c.clock.After(interval):
+		}
+	}
+}
+
+func (c *Client) fireBalanceAlert(ctx context.Context, opts *BalanceWatchOptions, alert BalanceAlert) {
+	if opts.StopPods != nil {
+		alert.StoppedPods, alert.Err = c.stopPodsMatching(ctx, opts.StopPods)
+	}
+	alert.At = c.clock.Now()
+	if c.debug {
+		c.logger.Printf("[DEBUG] balance alert %s: balance=%.2f spend=%.2f/hr stopped=%v", alert.Type, alert.Account.ClientBalance, alert.Account.CurrentSpendPerHr, alert.StoppedPods)
+	}
+	if opts.OnAlert != nil {
+		opts.OnAlert(alert)
+	}
+}
+
+// stopPodsMatching stops every running pod match selects, continuing past
+// individual failures.
+func (c *Client) stopPodsMatching(ctx context.Context, match func(*Pod) bool) ([]string, error) {
+	pods, err := c.ListPods(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	var stopped []string
+	var errs []error
+	for _, pod := range pods {
+		if pod.DesiredStatus != "RUNNING" || !match(pod) {
+			continue
+		}
+		if err := c.StopPod(ctx, pod.ID); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		stopped = append(stopped, pod.ID)
+	}
+	return stopped, errors.Join(errs...)
+}
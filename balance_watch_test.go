@@ -0,0 +1,75 @@
+package runpod_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestWatchBalance(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+	srv.SetAccount(runpod.AccountInfo{ID: "acct", ClientBalance: 100, CurrentSpendPerHr: 1})
+	srv.AddPod(&runpod.Pod{ID: "batch", DesiredStatus: "RUNNING", Env: map[string]string{"CRITICALITY": "low"}})
+	srv.AddPod(&runpod.Pod{ID: "api", DesiredStatus: "RUNNING"})
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	alerts := make(chan runpod.BalanceAlert)
+	done := make(chan error)
+	go func() {
+		done <- client.WatchBalance(ctx, &runpod.BalanceWatchOptions{
+			Interval:      10 * time.Millisecond,
+			MinBalance:    20,
+			MaxSpendPerHr: 5,
+			OnAlert:       func(a runpod.BalanceAlert) { alerts <- a },
+			StopPods:      func(p *runpod.Pod) bool { return p.Env["CRITICALITY"] == "low" },
+		})
+	}()
+	next := func() runpod.BalanceAlert {
+		t.Helper()
+		select {
+		case a := <-alerts:
+			return a
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for alert")
+			return runpod.BalanceAlert{}
+		}
+	}
+
+	srv.SetAccount(runpod.AccountInfo{ID: "acct", ClientBalance: 100, CurrentSpendPerHr: 8})
+	a := next()
+	if a.Type != runpod.BalanceAlertSpend || a.Threshold != 5 || a.Account.CurrentSpendPerHr != 8 || a.Err != nil || !reflect.DeepEqual(a.StoppedPods, []string{"batch"}) {
+		t.Fatalf("spend alert = %+v", a)
+	}
+	if pod, err := client.GetPod(ctx, "api"); err != nil || pod.DesiredStatus != "RUNNING" {
+		t.Fatalf("critical pod = %+v, %v", pod, err)
+	}
+
+	// Still over the cap: no repeat. The balance alert fires on its own.
+	srv.SetAccount(runpod.AccountInfo{ID: "acct", ClientBalance: 10, CurrentSpendPerHr: 8})
+	if a := next(); a.Type != runpod.BalanceAlertLow || a.Account.ClientBalance != 10 || len(a.StoppedPods) != 0 {
+		t.Fatalf("balance alert = %+v", a)
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("WatchBalance returned %v", err)
+	}
+}
+
+func TestWatchBalanceValidation(t *testing.T) {
+	client := mustClient(t, "test_key")
+	var validationErr *runpod.ValidationError
+	for _, opts := range []*runpod.BalanceWatchOptions{nil, {OnAlert: func(runpod.BalanceAlert) {}}, {MinBalance: 10}, {MinBalance: -1, MaxSpendPerHr: 1, OnAlert: func(runpod.BalanceAlert) {}}} {
+		if err := client.WatchBalance(t.Context(), opts); !errors.As(err, &validationErr) {
+			t.Errorf("opts %+v: expected validation error, got %v", opts, err)
+		}
+	}
+}
@@ -0,0 +1,322 @@
+package runpod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBudgetInterval is how often a BudgetController evaluates its
+// rules when BudgetControllerOptions.Interval is unset.
+const DefaultBudgetInterval = 5 * time.Minute
+
+// BudgetMetric is the spend figure a BudgetRule watches.
+type BudgetMetric string
+
+const (
+	// BudgetSpendPerHr is the account's CurrentSpendPerHr.
+	BudgetSpendPerHr BudgetMetric = "spend_per_hr"
+	// BudgetSpentToDate is the month's spend so far, SpendForecast.SpentToDate.
+	BudgetSpentToDate BudgetMetric = "spent_to_date"
+	// BudgetProjectedMonthly is the month's projected total,
+	// SpendForecast.Projected.
+	BudgetProjectedMonthly BudgetMetric = "projected_monthly"
+)
+
+// BudgetRule runs its actions when Metric rises above Threshold. It fires
+// once per crossing, and again only after the metric has dropped back to
+// the threshold or below. Actions that fail are run again on each
+// evaluation while the metric stays above the threshold, until they
+// succeed.
+type BudgetRule struct {
+	// Name identifies the rule in events and the audit trail.
+	Name      string
+	Metric    BudgetMetric
+	Threshold float64
+	Actions   []BudgetAction
+}
+
+// BudgetAction is one mitigation, run when its rule fires. Run returns the
+// IDs of the resources it changed, for the audit trail. WarnAction,
+// StopPodsAction, StopTaggedPodsAction and ScaleEndpointsToZeroAction are
+// the built-in ones; any function of this shape will do.
+type BudgetAction struct {
+	// Name labels the action in the audit trail, e.g. "stop-pods".
+	Name string
+	Run  func(ctx context.Context, c *Client, event BudgetEvent) (affected []string, err error)
+}
+
+// BudgetEvent is a rule firing.
+type BudgetEvent struct {
+	Rule      string
+	Metric    BudgetMetric
+	Threshold float64
+	// Value is the metric's value that crossed Threshold.
+	Value    float64
+	Forecast *SpendForecast
+	At       time.Time
+}
+
+// BudgetAuditEntry records one action a BudgetController took, or would
+// have taken in DryRun.
+type BudgetAuditEntry struct {
+	At        time.Time    `json:"at"`
+	Rule      string       `json:"rule"`
+	Metric    BudgetMetric `json:"metric"`
+	Threshold float64      `json:"threshold"`
+	Value     float64      `json:"value"`
+	Action    string       `json:"action"`
+	Affected  []string     `json:"affected,omitempty"`
+	DryRun    bool         `json:"dryRun,omitempty"`
+	Error     string       `json:"error,omitempty"`
+}
+
+// BudgetControllerOptions configures a BudgetController.
+type BudgetControllerOptions struct {
+	Rules []BudgetRule
+	// Interval between evaluations in Run (DefaultBudgetInterval when zero).
+	Interval time.Duration
+	// Forecaster supplies the spend figures; when nil, one is created with
+	// default options.
+	Forecaster *SpendForecaster
+	// DryRun records the actions rules would take without running them.
+	DryRun bool
+	// OnAudit is called with each audit entry as it is recorded, e.g. to
+	// persist the trail or page someone.
+	OnAudit func(BudgetAuditEntry)
+	// OnError is called when an evaluation fails; Run keeps going.
+	OnError func(error)
+}
+
+// BudgetController evaluates spend against budget rules on an interval
+// and runs the mitigations of the rules crossed: warnings, stopping
+// tagged pods, scaling endpoints to zero. Every action is recorded in an
+// audit trail (Audit). Mitigations stop things; try new rules with DryRun
+// first.
+//
+// A BudgetController is safe for concurrent use.
+type BudgetController struct {
+	client     *Client
+	opts       BudgetControllerOptions
+	forecaster *SpendForecaster
+
+	mu    sync.Mutex
+	fired map[string]bool
+	// retry holds, per fired rule, the indexes of the actions that failed
+	// and are run again while the rule stays crossed.
+	retry map[string][]int
+	audit []BudgetAuditEntry
+}
+
+// NewBudgetController returns a controller for opts.Rules. Call Run to
+// evaluate them on an interval, or Evaluate for a single pass.
+func (c *Client) NewBudgetController(opts *BudgetControllerOptions) (*BudgetController, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	b := &BudgetController{client: c, opts: *opts, forecaster: opts.Forecaster, fired: map[string]bool{}, retry: map[string][]int{}}
+	if b.opts.Interval == 0 {
+		b.opts.Interval = DefaultBudgetInterval
+	}
+	if b.forecaster == nil {
+		b.forecaster, _ = c.NewSpendForecaster(nil)
+	}
+	return b, nil
+}
+
+func (o *BudgetControllerOptions) validate() error {
+	if o == nil || len(o.Rules) == 0 {
+		return NewValidationError("rules", "cannot be empty")
+	}
+	if o.Interval < 0 {
+		return NewValidationErrorWithValue("interval", "cannot be negative", o.Interval)
+	}
+	names := map[string]bool{}
+	for _, rule := range o.Rules {
+		name := strings.TrimSpace(rule.Name)
+		switch {
+		case name == "":
+			return NewValidationError("rules.name", "cannot be empty")
+		case names[name]:
+			return NewValidationErrorWithValue("rules.name", "is used twice", name)
+		case rule.Metric != BudgetSpendPerHr && rule.Metric != BudgetSpentToDate && rule.Metric != BudgetProjectedMonthly:
+			return NewValidationErrorWithValue("rules.metric", "must be one of 'spend_per_hr', 'spent_to_date', 'projected_monthly'", string(rule.Metric))
+		case rule.Threshold <= 0:
+			return NewValidationErrorWithValue("rules.threshold", "must be positive", rule.Threshold)
+		case len(rule.Actions) == 0:
+			return NewValidationErrorWithValue("rules.actions", "cannot be empty", name)
+		}
+		for _, action := range rule.Actions {
+			if action.Run == nil {
+				return NewValidationErrorWithValue("rules.actions.run", "cannot be nil", name)
+			}
+		}
+		names[name] = true
+	}
+	return nil
+}
+
+// Run evaluates the rules every Interval until ctx is done, reporting
+// failed evaluations to OnError. It blocks, so run it in a goroutine; it
+// returns ctx.Err(), which is context.Canceled when the client is closed,
+// or ErrClientClosed if it already was.
+func (b *BudgetController) Run(ctx context.Context) error {
+	ctx, release, err := b.client.lifecycle.start(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	for {
+		if _, err := b.Evaluate(ctx); err != nil && ctx.Err() == nil && b.opts.OnError != nil {
+			b.opts.OnError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-b.client.clock.After(b.opts.Interval):
+		}
+	}
+}
+
+// Evaluate forecasts spend once, runs the actions of each rule newly
+// crossed, and returns the audit entries it recorded. A failed action is
+// recorded with its error and does not stop the others; it is run again
+// on the next evaluation if the rule is still crossed. The returned error
+// reports a failed forecast.
+func (b *BudgetController) Evaluate(ctx context.Context) ([]BudgetAuditEntry, error) {
+	forecast, err := b.forecaster.Forecast(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate budget: %w", err)
+	}
+
+	var entries []BudgetAuditEntry
+	for _, rule := range b.opts.Rules {
+		value := budgetValue(rule.Metric, forecast)
+		over := value > rule.Threshold
+		b.mu.Lock()
+		var run []int
+		switch {
+		case !over:
+			delete(b.fired, rule.Name)
+			delete(b.retry, rule.Name)
+		case !b.fired[rule.Name]:
+			for i := range rule.Actions {
+				run = append(run, i)
+			}
+		default:
+			run = b.retry[rule.Name]
+		}
+		if over {
+			b.fired[rule.Name] = true
+			delete(b.retry, rule.Name)
+		}
+		b.mu.Unlock()
+		if len(run) == 0 {
+			continue
+		}
+
+		event := BudgetEvent{Rule: rule.Name, Metric: rule.Metric, Threshold: rule.Threshold, Value: value, Forecast: forecast, At: forecast.At}
+		var failed []int
+		for _, i := range run {
+			action := rule.Actions[i]
+			entry := BudgetAuditEntry{Rule: rule.Name, Metric: rule.Metric, Threshold: rule.Threshold, Value: value, Action: action.Name, DryRun: b.opts.DryRun}
+			if !b.opts.DryRun {
+				affected, err := action.Run(ctx, b.client, event)
+				entry.Affected = affected
+				if err != nil {
+					entry.Error = err.Error()
+					failed = append(failed, i)
+				}
+			}
+			entry.At = b.client.clock.Now()
+			if b.client.debug {
+				b.client.logger.Printf("[DEBUG] budget rule %s (%s %.2f > %.2f): %s affected=%v error=%q", rule.Name, rule.Metric, value, rule.Threshold, action.Name, entry.Affected, entry.Error)
+			}
+			b.mu.Lock()
+			b.audit = append(b.audit, entry)
+			b.mu.Unlock()
+			if b.opts.OnAudit != nil {
+				b.opts.OnAudit(entry)
+			}
+			entries = append(entries, entry)
+		}
+		if len(failed) > 0 {
+			b.mu.Lock()
+			b.retry[rule.Name] = failed
+			b.mu.Unlock()
+		}
+	}
+	return entries, nil
+}
+
+// Audit returns every audit entry recorded so far, oldest first.
+func (b *BudgetController) Audit() []BudgetAuditEntry {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]BudgetAuditEntry(nil), b.audit...)
+}
+
+func budgetValue(metric BudgetMetric, f *SpendForecast) float64 {
+	switch metric {
+	case BudgetSpendPerHr:
+		return f.Account.CurrentSpendPerHr
+	case BudgetSpentToDate:
+		return f.SpentToDate
+	default:
+		return f.Projected
+	}
+}
+
+// WarnAction calls warn and changes nothing.
+func WarnAction(warn func(BudgetEvent)) BudgetAction {
+	return BudgetAction{Name: "warn", Run: func(_ context.Context, _ *Client, event BudgetEvent) ([]string, error) {
+		warn(event)
+		return nil, nil
+	}}
+}
+
+// StopPodsAction stops every running pod match returns true for.
+func StopPodsAction(match func(*Pod) bool) BudgetAction {
+	return BudgetAction{Name: "stop-pods", Run: func(ctx context.Context, c *Client, _ BudgetEvent) ([]string, error) {
+		return c.stopPodsMatching(ctx, match)
+	}}
+}
+
+// StopTaggedPodsAction stops every running pod whose env var key is value,
+// the tags CostReport groups by, e.g. StopTaggedPodsAction("CRITICALITY",
+// "low").
+func StopTaggedPodsAction(key, value string) BudgetAction {
+	action := StopPodsAction(func(p *Pod) bool { return p.Env[key] == value })
+	action.Name = "stop-tagged-pods"
+	return action
+}
+
+// ScaleEndpointsToZeroAction sets workersMin and workersMax to 0 on every
+// endpoint match returns true for (every endpoint when match is nil), so
+// they stop starting workers; jobs queue until the limits are raised
+// again. Endpoints already at zero are left alone.
+func ScaleEndpointsToZeroAction(match func(*Endpoint) bool) BudgetAction {
+	return BudgetAction{Name: "scale-endpoints-to-zero", Run: func(ctx context.Context, c *Client, _ BudgetEvent) ([]string, error) {
+		endpoints, err := c.ListEndpoints(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		var scaled []string
+		var errs []error
+		for i := range endpoints {
+			endpoint := &endpoints[i]
+			if (match != nil && !match(endpoint)) || (endpoint.WorkersMin == 0 && endpoint.WorkersMax == 0) {
+				continue
+			}
+			if _, err := c.UpdateEndpoint(ctx, endpoint.ID, &UpdateEndpointRequest{WorkersMin: Ptr(0), WorkersMax: Ptr(0)}); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			scaled = append(scaled, endpoint.ID)
+		}
+		return scaled, errors.Join(errs...)
+	}}
+}
@@ -0,0 +1,161 @@
+package runpod_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestBudgetController(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	clock := runpodtest.NewClock(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+	client := srv.MustClient(runpod.WithClock(clock))
+	ctx := t.Context()
+
+	srv.SetAccount(runpod.AccountInfo{ID: "acct", CurrentSpendPerHr: 4})
+	srv.AddPod(&runpod.Pod{ID: "batch", DesiredStatus: "RUNNING", CostPerHour: 3, Env: map[string]string{"CRITICALITY": "low"}})
+	srv.AddPod(&runpod.Pod{ID: "api", DesiredStatus: "RUNNING", CostPerHour: 1})
+	srv.AddEndpoint(&runpod.Endpoint{ID: "sd", WorkersMin: 1, WorkersMax: 3})
+	srv.AddEndpoint(&runpod.Endpoint{ID: "off"})
+
+	var warnings []runpod.BudgetEvent
+	var audited []runpod.BudgetAuditEntry
+	budget, err := client.NewBudgetController(&runpod.BudgetControllerOptions{
+		Rules: []runpod.BudgetRule{
+			{Name: "burn", Metric: runpod.BudgetSpendPerHr, Threshold: 10, Actions: []runpod.BudgetAction{
+				runpod.WarnAction(func(e runpod.BudgetEvent) { warnings = append(warnings, e) }),
+			}},
+			// 31 days at $4/hr is $2976.
+			{Name: "month", Metric: runpod.BudgetProjectedMonthly, Threshold: 2500, Actions: []runpod.BudgetAction{
+				runpod.WarnAction(func(e runpod.BudgetEvent) { warnings = append(warnings, e) }),
+				runpod.StopTaggedPodsAction("CRITICALITY", "low"),
+				runpod.ScaleEndpointsToZeroAction(nil),
+			}},
+		},
+		OnAudit: func(e runpod.BudgetAuditEntry) { audited = append(audited, e) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := budget.Evaluate(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(warnings) != 1 || warnings[0].Rule != "month" || warnings[0].Value != 2976 || warnings[0].Forecast == nil {
+		t.Fatalf("warnings = %+v", warnings)
+	}
+	want := []struct {
+		action   string
+		affected []string
+	}{{"warn", nil}, {"stop-tagged-pods", []string{"batch"}}, {"scale-endpoints-to-zero", []string{"sd"}}}
+	if len(entries) != len(want) {
+		t.Fatalf("entries = %+v", entries)
+	}
+	for i, w := range want {
+		e := entries[i]
+		if e.Rule != "month" || e.Action != w.action || !reflect.DeepEqual(e.Affected, w.affected) || e.Error != "" || e.Value != 2976 || e.At.IsZero() {
+			t.Fatalf("entry %d = %+v", i, e)
+		}
+	}
+	if pod := srv.Pod("batch"); pod.DesiredStatus == "RUNNING" {
+		t.Fatal("tagged pod still running")
+	}
+	if pod := srv.Pod("api"); pod.DesiredStatus != "RUNNING" {
+		t.Fatal("untagged pod stopped")
+	}
+	if ep := srv.Endpoint("sd"); ep.WorkersMin != 0 || ep.WorkersMax != 0 {
+		t.Fatalf("endpoint = %+v", ep)
+	}
+	if !reflect.DeepEqual(audited, budget.Audit()) || len(audited) != 3 {
+		t.Fatalf("audit = %+v, OnAudit = %+v", budget.Audit(), audited)
+	}
+
+	// Still over: nothing repeats.
+	if entries, err := budget.Evaluate(ctx); err != nil || len(entries) != 0 {
+		t.Fatalf("second Evaluate = %+v, %v", entries, err)
+	}
+}
+
+func TestBudgetControllerRetriesFailedActions(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+	srv.SetAccount(runpod.AccountInfo{ID: "acct", CurrentSpendPerHr: 20})
+
+	warned, attempts := 0, 0
+	flaky := runpod.BudgetAction{Name: "flaky", Run: func(context.Context, *runpod.Client, runpod.BudgetEvent) ([]string, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, errors.New("api blip")
+		}
+		return []string{"pod-1"}, nil
+	}}
+	budget, err := client.NewBudgetController(&runpod.BudgetControllerOptions{
+		Rules: []runpod.BudgetRule{{Name: "burn", Metric: runpod.BudgetSpendPerHr, Threshold: 10, Actions: []runpod.BudgetAction{
+			runpod.WarnAction(func(runpod.BudgetEvent) { warned++ }),
+			flaky,
+		}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := t.Context()
+
+	entries, err := budget.Evaluate(ctx)
+	if err != nil || len(entries) != 2 || entries[1].Error != "api blip" {
+		t.Fatalf("first Evaluate = %+v, %v", entries, err)
+	}
+	// Still over: only the failed action runs again.
+	entries, err = budget.Evaluate(ctx)
+	if err != nil || len(entries) != 1 || entries[0].Action != "flaky" || entries[0].Error != "" || !reflect.DeepEqual(entries[0].Affected, []string{"pod-1"}) {
+		t.Fatalf("second Evaluate = %+v, %v", entries, err)
+	}
+	if entries, err := budget.Evaluate(ctx); err != nil || len(entries) != 0 {
+		t.Fatalf("third Evaluate = %+v, %v", entries, err)
+	}
+	if warned != 1 || attempts != 2 {
+		t.Fatalf("warned=%d attempts=%d", warned, attempts)
+	}
+}
+
+func TestBudgetControllerDryRun(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+	srv.SetAccount(runpod.AccountInfo{ID: "acct", CurrentSpendPerHr: 20})
+	srv.AddPod(&runpod.Pod{ID: "batch", DesiredStatus: "RUNNING", CostPerHour: 20})
+
+	budget, err := client.NewBudgetController(&runpod.BudgetControllerOptions{
+		Rules:  []runpod.BudgetRule{{Name: "burn", Metric: runpod.BudgetSpendPerHr, Threshold: 10, Actions: []runpod.BudgetAction{runpod.StopPodsAction(func(*runpod.Pod) bool { return true })}}},
+		DryRun: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := budget.Evaluate(t.Context())
+	if err != nil || len(entries) != 1 || !entries[0].DryRun || entries[0].Action != "stop-pods" || entries[0].Affected != nil {
+		t.Fatalf("Evaluate = %+v, %v", entries, err)
+	}
+	if pod := srv.Pod("batch"); pod.DesiredStatus != "RUNNING" {
+		t.Fatal("dry run stopped a pod")
+	}
+
+	var validationErr *runpod.ValidationError
+	for _, opts := range []*runpod.BudgetControllerOptions{
+		nil,
+		{Rules: []runpod.BudgetRule{{Name: "x", Metric: "balance", Threshold: 1, Actions: []runpod.BudgetAction{runpod.WarnAction(func(runpod.BudgetEvent) {})}}}},
+		{Rules: []runpod.BudgetRule{{Name: "x", Metric: runpod.BudgetSpendPerHr, Threshold: 1}}},
+		{Rules: []runpod.BudgetRule{{Name: "x", Metric: runpod.BudgetSpendPerHr, Actions: []runpod.BudgetAction{runpod.WarnAction(func(runpod.BudgetEvent) {})}}}},
+	} {
+		if _, err := client.NewBudgetController(opts); !errors.As(err, &validationErr) {
+			t.Errorf("opts %+v: expected validation error, got %v", opts, err)
+		}
+	}
+}
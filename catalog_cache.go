@@ -0,0 +1,89 @@
+package runpod
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// DefaultCatalogCacheTTL is the CatalogCache TTL used when NewCatalogCache is
+// given a non-positive duration.
+const DefaultCatalogCacheTTL = 5 * time.Minute
+
+// CatalogCache caches GPU type, GPU offer, data center and CPU flavor
+// queries, which rarely change but are read on every provisioning decision.
+// Entries are keyed by query and filter, so differently-filtered reads do not
+// share results. Stock and prices in cached results are as old as the TTL
+// allows; call Invalidate after a stock-out to force a fresh read.
+//
+// A CatalogCache is safe for concurrent use and may be shared by clients
+// talking to the same API.
+type CatalogCache struct {
+	cache *ttlCache[string]
+}
+
+// NewCatalogCache returns an empty cache whose entries live for ttl
+// (DefaultCatalogCacheTTL when ttl <= 0).
+func NewCatalogCache(ttl time.Duration) *CatalogCache {
+	if ttl <= 0 {
+		ttl = DefaultCatalogCacheTTL
+	}
+	return &CatalogCache{cache: newTTLCache[string](ttl)}
+}
+
+// TTL returns how long a catalog query result is reused.
+func (cc *CatalogCache) TTL() time.Duration {
+	return cc.cache.ttl
+}
+
+// Invalidate forgets every cached query result.
+func (cc *CatalogCache) Invalidate() {
+	cc.cache.invalidate(nil)
+}
+
+// WithCatalogCache caches ListGPUTypes, ListGPUOffers, ListDataCenters and
+// ListCPUFlavors (and the helpers built on them) in cache. Without it every
+// call queries RunPod.
+func WithCatalogCache(cache *CatalogCache) ClientOption {
+	return func(c *Client) {
+		c.catalog = cache
+	}
+}
+
+// CatalogCache returns the client's catalog cache, or nil when caching is
+// disabled.
+func (c *Client) CatalogCache() *CatalogCache {
+	return c.catalog
+}
+
+// catalogGraphQL runs a catalog query through the cache when one is
+// configured. Payloads are cached raw and decoded per call, so callers never
+// share result slices; payloads that fail to decode are not cached.
+func (c *Client) catalogGraphQL(ctx context.Context, query string, variables map[string]interface{}, result interface{}) error {
+	if c.catalog == nil {
+		return c.GraphQL(ctx, query, variables, result)
+	}
+	vars, err := json.Marshal(variables)
+	if err != nil {
+		return err
+	}
+	key := query + "\x00" + string(vars)
+	if data, ok := c.catalog.cache.get(key, c.clock.Now()); ok {
+		if c.debug {
+			c.logger.Printf("[DEBUG] Catalog cache hit")
+		}
+		return decodeGraphQLData(data, result)
+	}
+
+	gen := c.catalog.cache.begin(key)
+	defer c.catalog.cache.done(key)
+	data, err := c.graphQLData(ctx, query, variables)
+	if err != nil {
+		return err
+	}
+	if err := decodeGraphQLData(data, result); err != nil {
+		return err
+	}
+	c.catalog.cache.put(key, gen, data, c.clock.Now())
+	return nil
+}
@@ -0,0 +1,100 @@
+package runpod_test
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestCatalogCache(t *testing.T) {
+	var gpuQueries, dcQueries, cpuQueries atomic.Int32
+	server := newGPUTypeGraphQLServer(t, func(req testGraphQLRequest) interface{} {
+		switch {
+		case strings.Contains(req.Query, "cpuFlavors"):
+			cpuQueries.Add(1)
+			return map[string]any{"data": map[string]any{"cpuFlavors": []map[string]any{
+				{"id": "cpu5c", "groupId": "cpu5c", "displayName": "Compute-Optimized", "minVcpu": 2, "maxVcpu": 32},
+			}}}
+		case strings.Contains(req.Query, "dataCenters"):
+			dcQueries.Add(1)
+			return map[string]any{"data": map[string]any{"dataCenters": []map[string]any{{"id": "US-GA-1"}}}}
+		default:
+			gpuQueries.Add(1)
+			return map[string]any{"data": map[string]any{"gpuTypes": []map[string]any{
+				{"id": "NVIDIA GeForce RTX 4090", "memoryInGb": 24, "secureCloud": true},
+			}}}
+		}
+	})
+	defer server.Close()
+
+	cache := runpod.NewCatalogCache(time.Hour)
+	client := mustClient(t, "test_key", runpod.WithGraphQLBaseURL(server.URL), runpod.WithCatalogCache(cache))
+	ctx := t.Context()
+
+	for i := 0; i < 3; i++ {
+		gpus, err := client.ListGPUTypes(ctx, nil)
+		if err != nil || len(gpus) != 1 {
+			t.Fatalf("ListGPUTypes = %v, %v", gpus, err)
+		}
+		gpus[0].ID = "mutated" // results must not share cached state
+		if _, err := client.ListDataCenters(ctx, nil); err != nil {
+			t.Fatalf("ListDataCenters: %v", err)
+		}
+		flavors, err := client.ListCPUFlavors(ctx)
+		if err != nil || len(flavors) != 1 || flavors[0].ID != "cpu5c" {
+			t.Fatalf("ListCPUFlavors = %v, %v", flavors, err)
+		}
+	}
+	if gpuQueries.Load() != 1 || dcQueries.Load() != 1 || cpuQueries.Load() != 1 {
+		t.Fatalf("queries gpu=%d dc=%d cpu=%d, want 1 each", gpuQueries.Load(), dcQueries.Load(), cpuQueries.Load())
+	}
+	if gpu, err := client.GetGPUType(ctx, "NVIDIA GeForce RTX 4090"); err != nil || gpu.ID != "NVIDIA GeForce RTX 4090" {
+		t.Fatalf("GetGPUType from cache = %v, %v", gpu, err)
+	}
+
+	// A different filter is a different entry.
+	if _, err := client.ListGPUTypes(ctx, &runpod.GPUTypeFilter{GPUCount: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if gpuQueries.Load() != 2 {
+		t.Fatalf("gpu queries = %d, want 2", gpuQueries.Load())
+	}
+
+	client.CatalogCache().Invalidate()
+	if _, err := client.ListGPUTypes(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if gpuQueries.Load() != 3 {
+		t.Fatalf("gpu queries after Invalidate = %d, want 3", gpuQueries.Load())
+	}
+}
+
+func TestCatalogCacheExpiry(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	clock := runpodtest.NewClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	cache := runpod.NewCatalogCache(time.Minute)
+	client := srv.MustClient(runpod.WithClock(clock), runpod.WithCatalogCache(cache))
+
+	before, err := client.ListCPUFlavors(t.Context())
+	if err != nil || len(before) == 0 {
+		t.Fatalf("ListCPUFlavors = %v, %v", before, err)
+	}
+	srv.SetCPUFlavors([]runpod.CPUFlavor{{ID: "cpu9x"}})
+	if cached, _ := client.ListCPUFlavors(t.Context()); len(cached) != len(before) {
+		t.Fatalf("expected cached flavors before TTL, got %v", cached)
+	}
+	clock.Advance(61 * time.Second)
+	after, err := client.ListCPUFlavors(t.Context())
+	if err != nil || len(after) != 1 || after[0].ID != "cpu9x" {
+		t.Fatalf("flavors after TTL = %v, %v", after, err)
+	}
+
+	if ttl := runpod.NewCatalogCache(0).TTL(); ttl != runpod.DefaultCatalogCacheTTL {
+		t.Fatalf("default TTL = %v", ttl)
+	}
+}
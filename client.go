@@ -13,6 +13,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -47,7 +48,8 @@ const (
 // Client is the RunPod API client. Construct with NewClient; configure with
 // ClientOption functions. Safe for concurrent use.
 type Client struct {
-	apiKey            string
+	apiKeys           []string     // NewClient's key, then WithFallbackAPIKeys
+	activeKey         atomic.Int32 // index into apiKeys
 	baseURL           string
 	serverlessBaseURL string
 	graphqlBaseURL    string
@@ -60,6 +62,23 @@ type Client struct {
 	retryDelay       time.Duration
 
 	logger Logger
+
+	imageCheck     RegistryProbeFunc
+	catalog        *CatalogCache
+	resources      *ResourceCache
+	skipValidation bool
+	strictDecoding bool
+	localEndpoints map[string]http.Handler // see WithLocalEndpoint
+	mutationHook   MutationHook
+	inputDefaults  map[string]interface{} // see WithInputDefaults
+
+	executionTimes executionTimes // see EstimateQueueDelay
+	redactions     redactions     // see Redact
+	lifecycle      *lifecycle     // see Close
+	metrics        MetricsSink
+	clock          Clock
+	deadlineMargin time.Duration // see WithDeadlinePolicy
+	longPollWait   time.Duration // see WithLongPoll
 }
 
 // Logger interface for custom logging
@@ -140,6 +159,28 @@ func WithRetryDelay(delay time.Duration) ClientOption {
 	}
 }
 
+// WithRequestValidation enables or disables running a request's Validate
+// before it is sent (enabled by default). Disabling it lets the API judge
+// values the SDK does not know yet, such as a new CUDA version; nil
+// requests and empty IDs are still rejected.
+func WithRequestValidation(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.skipValidation = !enabled
+	}
+}
+
+// WithStrictDecoding makes a call fail with *ShapeError when its response
+// has a field the SDK does not model, or lacks one it requires (a pod's id
+// and desiredStatus, say), instead of ignoring or zeroing it. It is meant
+// for CI runs against the live API, to flag RunPod changing a response
+// shape; leave it off in production. GraphQL responses are not checked,
+// since the SDK's queries select their fields.
+func WithStrictDecoding() ClientOption {
+	return func(c *Client) {
+		c.strictDecoding = true
+	}
+}
+
 // WithLogger sets a custom logger for debug output
 func WithLogger(logger Logger) ClientOption {
 	return func(c *Client) {
@@ -154,7 +195,7 @@ func NewClient(apiKey string, opts ...ClientOption) (*Client, error) {
 	}
 
 	c := &Client{
-		apiKey:            apiKey,
+		apiKeys:           []string{apiKey},
 		baseURL:           DefaultBaseURL,
 		serverlessBaseURL: DefaultServerlessBaseURL,
 		graphqlBaseURL:    DefaultGraphQLBaseURL,
@@ -165,6 +206,8 @@ func NewClient(apiKey string, opts ...ClientOption) (*Client, error) {
 		maxRetryAttempts: DefaultMaxRetryAttempts,
 		retryDelay:       DefaultRetryDelay,
 		logger:           &defaultLogger{},
+		clock:            realClock{},
+		lifecycle:        newLifecycle(),
 	}
 
 	for _, opt := range opts {
@@ -176,6 +219,9 @@ func NewClient(apiKey string, opts ...ClientOption) (*Client, error) {
 	if c.logger == nil {
 		c.logger = &defaultLogger{}
 	}
+	if c.clock == nil {
+		c.clock = realClock{}
+	}
 
 	return c, nil
 }
@@ -187,8 +233,25 @@ func NewClient(apiKey string, opts ...ClientOption) (*Client, error) {
 // only for non-POST requests: POSTs are not idempotent (retrying POST /pods or
 // /v2/{id}/run can create duplicate pods or jobs), and RunPod signals ordinary
 // stock-outs as 500 "no instances available". 429 is safe to retry for any
-// method since the request was rejected before processing.
-func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body interface{}) (*http.Response, error) {
+// method since the request was rejected before processing, as are
+// Cloudflare's 521-523, sent when it could not reach the origin at all.
+func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body interface{}) (resp *http.Response, err error) {
+	release, err := c.lifecycle.begin()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if c.metrics != nil {
+		defer func(start time.Time) {
+			statusCode := 0
+			if resp != nil {
+				statusCode = resp.StatusCode
+			}
+			c.recordRequest(method, endpoint, start, statusCode, err)
+		}(time.Now())
+	}
+
 	var jsonBody []byte
 	if body != nil {
 		var err error
@@ -206,12 +269,12 @@ func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body
 			select {
 			case <-ctx.Done():
 				return nil, ctx.Err()
-			case <-time.After(c.backoff(attempt, retryAfter)):
+			case <-c.clock.After(c.backoff(attempt, retryAfter)):
 			}
 			retryAfter = 0
 		}
 
-		resp, err := c.doRequest(ctx, method, endpoint, jsonBody, body)
+		resp, err := c.doAuthenticated(ctx, method, endpoint, jsonBody, body)
 		if err != nil {
 			lastErr = err
 
@@ -282,7 +345,7 @@ func parseRetryAfter(v string) time.Duration {
 }
 
 // doRequest performs a single HTTP request.
-func (c *Client) doRequest(ctx context.Context, method, endpoint string, jsonBody []byte, body interface{}) (*http.Response, error) {
+func (c *Client) doRequest(ctx context.Context, method, endpoint string, jsonBody []byte, body interface{}, apiKey string) (*http.Response, error) {
 	var buf io.Reader
 	if jsonBody != nil {
 		buf = bytes.NewReader(jsonBody)
@@ -295,16 +358,19 @@ func (c *Client) doRequest(ctx context.Context, method, endpoint string, jsonBod
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	c.setRequestHeaders(req, jsonBody != nil)
+	c.setRequestHeaders(req, apiKey, jsonBody != nil)
 
 	if c.debug {
-		c.logger.Printf("[DEBUG] %s %s", method, fullURL)
+		c.logger.Printf("[DEBUG] %s %s", method, c.Redact(fullURL))
 		if body != nil {
 			bodyJSON, _ := json.MarshalIndent(body, "", "  ")
-			c.logger.Printf("[DEBUG] Request Body: %s", string(bodyJSON))
+			c.logger.Printf("[DEBUG] Request Body: %s", c.Redact(string(bodyJSON)))
 		}
 	}
 
+	if handler := c.localEndpoint(fullURL); handler != nil {
+		return serveLocal(handler, req), nil
+	}
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("HTTP request failed: %w", err)
@@ -329,8 +395,8 @@ func (c *Client) serverlessURL(path string) string {
 }
 
 // setRequestHeaders sets the required headers for the request
-func (c *Client) setRequestHeaders(req *http.Request, hasBody bool) {
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+func (c *Client) setRequestHeaders(req *http.Request, apiKey string, hasBody bool) {
+	req.Header.Set("Authorization", "Bearer "+apiKey)
 	req.Header.Set("User-Agent", c.userAgent)
 
 	if hasBody {
@@ -349,24 +415,36 @@ func (c *Client) handleResponse(resp *http.Response, v interface{}) error {
 
 	if c.debug {
 		c.logger.Printf("[DEBUG] Response Status: %d", resp.StatusCode)
-		c.logger.Printf("[DEBUG] Response Body: %s", string(body))
+		c.logger.Printf("[DEBUG] Response Body: %s", c.Redact(string(body)))
 	}
 
 	if resp.StatusCode >= 400 {
-		return c.parseErrorResponse(resp.StatusCode, resp.Header, body)
+		return c.parseErrorResponse(resp, body)
 	}
 
 	if v != nil && len(body) > 0 {
+		if gwErr := c.gatewayError(resp, body); gwErr != nil {
+			return gwErr
+		}
 		if err := json.Unmarshal(body, v); err != nil {
 			return fmt.Errorf("failed to unmarshal response: %w", err)
 		}
+		if err := c.checkShape(body, v); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-// parseErrorResponse parses error responses from the API into *APIError.
-func (c *Client) parseErrorResponse(statusCode int, header http.Header, body []byte) error {
+// parseErrorResponse parses error responses from the API into *APIError,
+// or *GatewayError for a 5xx whose body is not JSON.
+func (c *Client) parseErrorResponse(resp *http.Response, body []byte) error {
+	if gwErr := c.gatewayError(resp, body); gwErr != nil {
+		return gwErr
+	}
+	statusCode, header := resp.StatusCode, resp.Header
+
 	var retryAfter time.Duration
 	if statusCode == 429 {
 		retryAfter = parseRetryAfter(header.Get("Retry-After"))
@@ -377,6 +455,8 @@ func (c *Client) parseErrorResponse(statusCode int, header http.Header, body []b
 	if err := json.Unmarshal(body, &structured); err == nil && structured.Message != "" {
 		structured.StatusCode = statusCode
 		structured.RetryAfter = retryAfter
+		structured.Message = c.Redact(structured.Message)
+		structured.Details = c.Redact(structured.Details)
 		return &structured
 	}
 
@@ -407,9 +487,10 @@ func (c *Client) parseErrorResponse(statusCode int, header http.Header, body []b
 		case 500, 502, 503, 504:
 			apiErr.Message = "server error"
 		default:
-			apiErr.Message = strings.TrimSpace(string(body))
+			apiErr.Message = bodyExcerpt(bytes.TrimSpace(body))
 		}
 	}
+	apiErr.Message = c.Redact(apiErr.Message)
 	return apiErr
 }
 
@@ -434,15 +515,24 @@ func (c *Client) isRetryableHTTPStatus(method string, statusCode int) bool {
 	switch statusCode {
 	case 429:
 		return true
-	case 500, 502, 503, 504:
+	case 521, 522, 523:
+		// Cloudflare could not connect to the origin, so the request was
+		// never processed.
+		return true
+	case 500, 502, 503, 504, 520, 524, 525, 526, 527:
 		return method != http.MethodPost
 	default:
 		return false
 	}
 }
 
-// validateRequired checks if required fields are present
+// validateRequired checks a required argument, such as a path ID.
 func (c *Client) validateRequired(fieldName string, value interface{}) error {
+	return validateRequired(fieldName, value)
+}
+
+// validateRequired checks if required fields are present
+func validateRequired(fieldName string, value interface{}) error {
 	if value == nil {
 		return NewValidationError(fieldName, "is required")
 	}
@@ -462,7 +552,7 @@ func (c *Client) validateRequired(fieldName string, value interface{}) error {
 }
 
 // validatePositive checks if a number is positive
-func (c *Client) validatePositive(fieldName string, value int) error {
+func validatePositive(fieldName string, value int) error {
 	if value <= 0 {
 		return NewValidationErrorWithValue(fieldName, "must be positive", value)
 	}
@@ -470,7 +560,7 @@ func (c *Client) validatePositive(fieldName string, value int) error {
 }
 
 // validatePositiveFloat checks if a float is positive
-func (c *Client) validatePositiveFloat(fieldName string, value float64) error {
+func validatePositiveFloat(fieldName string, value float64) error {
 	if value <= 0 {
 		return NewValidationErrorWithValue(fieldName, "must be positive", value)
 	}
@@ -507,13 +597,25 @@ func (c *Client) buildListURL(endpoint string, opts *ListOptions) string {
 		return c.buildURL(endpoint)
 	}
 
-	params := make(map[string]string)
+	// Empty values are dropped by buildURLWithParams.
+	params := opts.Filter.params()
+
+	if opts.SortBy != "" {
+		params["sortBy"] = opts.SortBy
+		params["sortOrder"] = string(opts.SortOrder)
+	}
+
+	if len(opts.IncludeFields) > 0 {
+		params["includeFields"] = strings.Join(opts.IncludeFields, ",")
+	}
 
 	if opts.Limit > 0 {
 		params["limit"] = strconv.Itoa(opts.Limit)
 	}
 
-	if opts.Offset > 0 {
+	if opts.Cursor != "" {
+		params["cursor"] = opts.Cursor
+	} else if opts.Offset > 0 {
 		params["offset"] = strconv.Itoa(opts.Offset)
 	}
 
@@ -531,36 +633,20 @@ func (c *Client) Get(ctx context.Context, endpoint string, result interface{}) e
 
 // Post performs a POST request
 func (c *Client) Post(ctx context.Context, endpoint string, body interface{}, result interface{}) error {
-	resp, err := c.makeRequest(ctx, "POST", endpoint, body)
-	if err != nil {
-		return err
-	}
-	return c.handleResponse(resp, result)
+	return c.send(ctx, "POST", endpoint, body, result)
 }
 
 // Put performs a PUT request
 func (c *Client) Put(ctx context.Context, endpoint string, body interface{}, result interface{}) error {
-	resp, err := c.makeRequest(ctx, "PUT", endpoint, body)
-	if err != nil {
-		return err
-	}
-	return c.handleResponse(resp, result)
+	return c.send(ctx, "PUT", endpoint, body, result)
 }
 
 // Delete performs a DELETE request
 func (c *Client) Delete(ctx context.Context, endpoint string) error {
-	resp, err := c.makeRequest(ctx, "DELETE", endpoint, nil)
-	if err != nil {
-		return err
-	}
-	return c.handleResponse(resp, nil)
+	return c.send(ctx, "DELETE", endpoint, nil, nil)
 }
 
 // Patch performs a PATCH request
 func (c *Client) Patch(ctx context.Context, endpoint string, body interface{}, result interface{}) error {
-	resp, err := c.makeRequest(ctx, "PATCH", endpoint, body)
-	if err != nil {
-		return err
-	}
-	return c.handleResponse(resp, result)
+	return c.send(ctx, "PATCH", endpoint, body, result)
 }
@@ -0,0 +1,29 @@
+package runpod
+
+import "time"
+
+// Clock is the time source of the client's polling and waiting helpers:
+// WaitForJobCompletion, WaitForPodReady, WatchGPUAvailability,
+// WatchBalance and the retry backoff. Tests substitute a fake one
+// (runpodtest.Clock) to drive their intervals and timeouts without real
+// sleeps.
+type Clock interface {
+	Now() time.Time
+	// After is time.After: the channel receives the clock's time once d
+	// has passed.
+	After(d time.Duration) <-chan time.Time
+}
+
+// WithClock replaces the wall clock the client waits on. A nil clock
+// restores the default.
+func WithClock(clock Clock) ClientOption {
+	return func(c *Client) {
+		c.clock = clock
+	}
+}
+
+// realClock is the wall clock.
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
@@ -0,0 +1,60 @@
+package runpod_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestWithClock(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	clock := runpodtest.NewClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	client := srv.MustClient(runpod.WithClock(clock))
+	ctx := t.Context()
+
+	job, err := client.RunAsync(ctx, "ep1", map[string]int{"n": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	type result struct {
+		job *runpod.Job
+		err error
+	}
+	wait := func(maxWait time.Duration) <-chan result {
+		done := make(chan result, 1)
+		go func() {
+			j, err := client.WaitForJobCompletion(ctx, "ep1", job.ID, maxWait)
+			done <- result{j, err}
+		}()
+		return done
+	}
+
+	// The job finishes between polls; the next poll, 5s later, sees it.
+	done := wait(time.Minute)
+	clock.BlockUntil(1)
+	if err := srv.CompleteJob("ep1", job.ID, "ok"); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(5 * time.Second)
+	if r := <-done; r.err != nil || r.job.Status != "COMPLETED" {
+		t.Fatalf("WaitForJobCompletion = %+v, %v", r.job, r.err)
+	}
+
+	// A job that never finishes times out once the clock passes maxWait.
+	job, err = client.RunAsync(ctx, "ep1", map[string]int{"n": 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	done = wait(7 * time.Second)
+	for range 2 {
+		clock.BlockUntil(1)
+		clock.Advance(5 * time.Second)
+	}
+	if r := <-done; r.err == nil || !strings.Contains(r.err.Error(), "did not complete within 7s") {
+		t.Fatalf("WaitForJobCompletion timeout = %+v, %v", r.job, r.err)
+	}
+}
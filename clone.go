@@ -0,0 +1,49 @@
+package runpod
+
+//go:generate go run ./internal/clonegen
+
+// The Clone methods in clone_gen.go copy the request and model types deeply
+// enough that changing the copy's slices, maps and pointed-to values never
+// changes the original, so a shared base request can be cloned and adjusted
+// per call:
+//
+//	req := base.Clone()
+//	req.Env["MODEL"] = model
+//
+// Values held in interface{} fields (RunJobRequest.Input, say) are shared.
+
+// clonePtr copies the value p points to.
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+// cloneEach clones every element of s.
+func cloneEach[S ~[]E, E interface{ Clone() E }](s S) S {
+	if s == nil {
+		return nil
+	}
+	out := make(S, len(s))
+	for i, v := range s {
+		out[i] = v.Clone()
+	}
+	return out
+}
+
+// cloneValues clones every element of a slice of structs by value.
+func cloneValues[S ~[]E, E any, P interface {
+	*E
+	Clone() *E
+}](s S) S {
+	if s == nil {
+		return nil
+	}
+	out := make(S, len(s))
+	for i := range s {
+		out[i] = *P(&s[i]).Clone()
+	}
+	return out
+}
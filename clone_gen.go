@@ -0,0 +1,196 @@
+// Code generated by clonegen; DO NOT EDIT.
+
+package runpod
+
+import (
+	"maps"
+	"slices"
+)
+
+// Clone returns a deep copy of x; nil for nil.
+func (x *CreatePodRequest) Clone() *CreatePodRequest {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.GPUTypeIDs = slices.Clone(x.GPUTypeIDs)
+	c.CPUFlavorIDs = slices.Clone(x.CPUFlavorIDs)
+	c.DataCenterIDs = slices.Clone(x.DataCenterIDs)
+	c.Env = maps.Clone(x.Env)
+	c.Ports = slices.Clone(x.Ports)
+	c.AllowedCudaVersions = slices.Clone(x.AllowedCudaVersions)
+	c.DockerEntrypoint = slices.Clone(x.DockerEntrypoint)
+	c.DockerStartCmd = slices.Clone(x.DockerStartCmd)
+	return &c
+}
+
+// Clone returns a deep copy of x; nil for nil.
+func (x *CreateEndpointRequest) Clone() *CreateEndpointRequest {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.GPUTypeIDs = slices.Clone(x.GPUTypeIDs)
+	c.CPUFlavorIDs = slices.Clone(x.CPUFlavorIDs)
+	c.AllowedCudaVersions = slices.Clone(x.AllowedCudaVersions)
+	c.DataCenterIDs = slices.Clone(x.DataCenterIDs)
+	return &c
+}
+
+// Clone returns a deep copy of x; nil for nil.
+func (x *UpdateEndpointRequest) Clone() *UpdateEndpointRequest {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Name = clonePtr(x.Name)
+	c.TemplateID = clonePtr(x.TemplateID)
+	c.GPUTypeIDs = slices.Clone(x.GPUTypeIDs)
+	c.GPUCount = clonePtr(x.GPUCount)
+	c.CPUFlavorIDs = slices.Clone(x.CPUFlavorIDs)
+	c.VCPUCount = clonePtr(x.VCPUCount)
+	c.AllowedCudaVersions = slices.Clone(x.AllowedCudaVersions)
+	c.DataCenterIDs = slices.Clone(x.DataCenterIDs)
+	c.NetworkVolumeID = clonePtr(x.NetworkVolumeID)
+	c.WorkersMin = clonePtr(x.WorkersMin)
+	c.WorkersMax = clonePtr(x.WorkersMax)
+	c.ScalerType = clonePtr(x.ScalerType)
+	c.ScalerValue = clonePtr(x.ScalerValue)
+	c.IdleTimeout = clonePtr(x.IdleTimeout)
+	c.ExecutionTimeout = clonePtr(x.ExecutionTimeout)
+	c.Flashboot = clonePtr(x.Flashboot)
+	return &c
+}
+
+// Clone returns a deep copy of x; nil for nil.
+func (x *CreateTemplateRequest) Clone() *CreateTemplateRequest {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.DockerEntrypoint = slices.Clone(x.DockerEntrypoint)
+	c.DockerStartCmd = slices.Clone(x.DockerStartCmd)
+	c.Env = maps.Clone(x.Env)
+	c.Ports = slices.Clone(x.Ports)
+	return &c
+}
+
+// Clone returns a deep copy of x; nil for nil.
+func (x *UpdateTemplateRequest) Clone() *UpdateTemplateRequest {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Name = clonePtr(x.Name)
+	c.ImageName = clonePtr(x.ImageName)
+	c.ContainerDiskInGB = clonePtr(x.ContainerDiskInGB)
+	c.VolumeInGB = clonePtr(x.VolumeInGB)
+	c.VolumeMountPath = clonePtr(x.VolumeMountPath)
+	c.ContainerRegistryAuthID = clonePtr(x.ContainerRegistryAuthID)
+	c.DockerEntrypoint = slices.Clone(x.DockerEntrypoint)
+	c.DockerStartCmd = slices.Clone(x.DockerStartCmd)
+	c.Env = maps.Clone(x.Env)
+	c.Ports = slices.Clone(x.Ports)
+	c.IsPublic = clonePtr(x.IsPublic)
+	c.Readme = clonePtr(x.Readme)
+	return &c
+}
+
+// Clone returns a deep copy of x; nil for nil.
+func (x *Pod) Clone() *Pod {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.GPU = clonePtr(x.GPU)
+	c.CreatedAt = clonePtr(x.CreatedAt)
+	c.Env = maps.Clone(x.Env)
+	c.Ports = slices.Clone(x.Ports)
+	c.LastStartedAt = clonePtr(x.LastStartedAt)
+	c.Runtime = x.Runtime.Clone()
+	c.Machine = clonePtr(x.Machine)
+	c.NetworkVolume = x.NetworkVolume.Clone()
+	c.SavingsPlans = cloneValues(x.SavingsPlans)
+	c.Template = x.Template.Clone()
+	return &c
+}
+
+// Clone returns a deep copy of x; nil for nil.
+func (x *Endpoint) Clone() *Endpoint {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Template = x.Template.Clone()
+	c.GPUTypeIDs = slices.Clone(x.GPUTypeIDs)
+	c.CPUFlavorIDs = slices.Clone(x.CPUFlavorIDs)
+	c.AllowedCudaVersions = slices.Clone(x.AllowedCudaVersions)
+	c.DataCenterIDs = slices.Clone(x.DataCenterIDs)
+	c.Env = maps.Clone(x.Env)
+	c.Workers = cloneEach(x.Workers)
+	c.CreatedAt = clonePtr(x.CreatedAt)
+	return &c
+}
+
+// Clone returns a deep copy of x; nil for nil.
+func (x *Template) Clone() *Template {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.DockerEntrypoint = slices.Clone(x.DockerEntrypoint)
+	c.DockerStartCmd = slices.Clone(x.DockerStartCmd)
+	c.Env = maps.Clone(x.Env)
+	c.Ports = slices.Clone(x.Ports)
+	return &c
+}
+
+// Clone returns a deep copy of x; nil for nil.
+func (x *Job) Clone() *Job {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Input = slices.Clone(x.Input)
+	c.Output = slices.Clone(x.Output)
+	c.Stream = slices.Clone(x.Stream)
+	c.CreatedAt = clonePtr(x.CreatedAt)
+	c.StartedAt = clonePtr(x.StartedAt)
+	c.CompletedAt = clonePtr(x.CompletedAt)
+	return &c
+}
+
+// Clone returns a deep copy of x; nil for nil.
+func (x *PodRuntime) Clone() *PodRuntime {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.Ports = slices.Clone(x.Ports)
+	c.GPUs = slices.Clone(x.GPUs)
+	c.Container = clonePtr(x.Container)
+	c.ContainerExitCode = clonePtr(x.ContainerExitCode)
+	return &c
+}
+
+// Clone returns a deep copy of x; nil for nil.
+func (x *NetworkVolume) Clone() *NetworkVolume {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.CreatedAt = clonePtr(x.CreatedAt)
+	c.PodIds = slices.Clone(x.PodIds)
+	return &c
+}
+
+// Clone returns a deep copy of x; nil for nil.
+func (x *SavingsPlan) Clone() *SavingsPlan {
+	if x == nil {
+		return nil
+	}
+	c := *x
+	c.StartTime = clonePtr(x.StartTime)
+	c.EndTime = clonePtr(x.EndTime)
+	return &c
+}
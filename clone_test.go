@@ -0,0 +1,93 @@
+package runpod_test
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+)
+
+func TestClone(t *testing.T) {
+	base := &runpod.CreatePodRequest{
+		Name:       "worker",
+		ImageName:  "acme/worker:1",
+		GPUTypeIDs: []string{"NVIDIA A40"},
+		Env:        map[string]string{"MODEL": "sdxl"},
+	}
+	req := base.Clone()
+	if !reflect.DeepEqual(req, base) {
+		t.Fatalf("clone = %+v, want %+v", req, base)
+	}
+	req.Env["MODEL"] = "flux"
+	req.GPUTypeIDs[0] = "NVIDIA L4"
+	if base.Env["MODEL"] != "sdxl" || base.GPUTypeIDs[0] != "NVIDIA A40" {
+		t.Fatalf("base changed: %+v", base)
+	}
+
+	exit := 1
+	endpoint := &runpod.Endpoint{
+		ID:       "ep1",
+		Template: &runpod.Template{ID: "tpl1", Env: map[string]string{"A": "1"}},
+		Workers: []*runpod.Pod{{ID: "w1", Runtime: &runpod.PodRuntime{
+			Ports:             runpod.PodPorts{{PrivatePort: 22, PublicPort: 10022}},
+			ContainerExitCode: &exit,
+		}}},
+	}
+	clone := endpoint.Clone()
+	clone.Template.Env["A"] = "2"
+	clone.Workers[0].Runtime.Ports[0].PublicPort = 1
+	*clone.Workers[0].Runtime.ContainerExitCode = 0
+	worker := endpoint.Workers[0]
+	if endpoint.Template.Env["A"] != "1" || worker.Runtime.Ports[0].PublicPort != 10022 || *worker.Runtime.ContainerExitCode != 1 {
+		t.Fatalf("endpoint changed: template %+v, runtime %+v", endpoint.Template, worker.Runtime)
+	}
+
+	pod := &runpod.Pod{
+		ID:           "p1",
+		Template:     &runpod.Template{ID: "tpl1", Env: map[string]string{"A": "1"}},
+		SavingsPlans: []runpod.SavingsPlan{{ID: "sp1", EndTime: &runpod.JSONTime{}}},
+	}
+	podClone := pod.Clone()
+	podClone.Template.Env["A"] = "2"
+	podClone.SavingsPlans[0].ID = "sp2"
+	podClone.SavingsPlans[0].EndTime.Time = podClone.SavingsPlans[0].EndTime.AddDate(1, 0, 0)
+	if pod.Template.Env["A"] != "1" || pod.SavingsPlans[0].ID != "sp1" || !pod.SavingsPlans[0].EndTime.IsZero() {
+		t.Fatalf("pod changed: template %+v, savings plans %+v", pod.Template, pod.SavingsPlans)
+	}
+
+	var nilPod *runpod.Pod
+	if nilPod.Clone() != nil || (&runpod.Job{}).Clone().Output != nil {
+		t.Fatal("nil fields should stay nil")
+	}
+}
+
+// TestCloneGenCurrent fails when clone_gen.go no longer matches what
+// clonegen writes for the current types; run go generate to fix it.
+func TestCloneGenCurrent(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs the generator")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool not found")
+	}
+	out := filepath.Join(t.TempDir(), "clone_gen.go")
+	if msg, err := exec.Command(goTool, "run", "./internal/clonegen", "-o", out).CombinedOutput(); err != nil {
+		t.Fatalf("clonegen: %v\n%s", err, msg)
+	}
+	want, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile("clone_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatal("clone_gen.go is out of date; run go generate")
+	}
+}
@@ -0,0 +1,82 @@
+package runpod
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// lifecycle tracks the requests and background loops Close waits for.
+type lifecycle struct {
+	mu     sync.Mutex
+	closed bool
+	active sync.WaitGroup
+
+	// done is cancelled by Close, stopping the background loops.
+	done context.Context
+	stop context.CancelFunc
+}
+
+func newLifecycle() *lifecycle {
+	l := &lifecycle{}
+	l.done, l.stop = context.WithCancel(context.Background())
+	return l
+}
+
+// begin registers an in-flight request; call release when it is done.
+func (l *lifecycle) begin() (release func(), err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return nil, ErrClientClosed
+	}
+	l.active.Add(1)
+	return l.active.Done, nil
+}
+
+// start registers a background loop and returns its context, which is
+// cancelled when ctx is done or the client closes; call release when the
+// loop has returned.
+func (l *lifecycle) start(ctx context.Context) (loopCtx context.Context, release func(), err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return nil, nil, ErrClientClosed
+	}
+	l.active.Add(1)
+	loopCtx, cancel := context.WithCancel(ctx)
+	stopAfter := context.AfterFunc(l.done, cancel)
+	return loopCtx, func() {
+		stopAfter()
+		cancel()
+		l.active.Done()
+	}, nil
+}
+
+// Close shuts the client down so a service can exit cleanly. Calls made
+// after Close fail with ErrClientClosed. The watchers and loops the client
+// runs (WatchPod, WatchEndpointHealth, WatchJob, WatchGPUAvailability,
+// WatchBalance, BudgetController.Run) stop as if their context were
+// cancelled. Close waits for them, and for requests already in flight, until
+// ctx is done, and then closes the HTTP client's idle connections. It
+// returns ctx's error if the wait was cut short. Close may be called more
+// than once.
+func (c *Client) Close(ctx context.Context) error {
+	c.lifecycle.mu.Lock()
+	c.lifecycle.closed = true
+	c.lifecycle.mu.Unlock()
+	c.lifecycle.stop()
+	defer c.httpClient.CloseIdleConnections()
+
+	idle := make(chan struct{})
+	go func() {
+		c.lifecycle.active.Wait()
+		close(idle)
+	}()
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to close client: %w", ctx.Err())
+	}
+}
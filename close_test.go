@@ -0,0 +1,70 @@
+package runpod_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+)
+
+func TestClientClose(t *testing.T) {
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/pods/slow" {
+			close(started)
+			<-unblock
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": "p1", "desiredStatus": "RUNNING"})
+	}))
+	defer server.Close()
+	client := mustClient(t, "test_key", runpod.WithBaseURL(server.URL))
+	ctx := t.Context()
+
+	pods, err := client.WatchPod(ctx, "p1", 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev := <-pods.Events(); ev.Err != nil {
+		t.Fatalf("first event = %+v", ev)
+	}
+	slow := make(chan error)
+	go func() {
+		_, err := client.GetPod(ctx, "slow")
+		slow <- err
+	}()
+	<-started
+
+	// The request is still in flight, so the wait is cut short; the watcher
+	// stops regardless.
+	short, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if err := client.Close(short); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Close with a request in flight = %v", err)
+	}
+	for range pods.Events() {
+	}
+	if !errors.Is(pods.Err(), context.Canceled) {
+		t.Fatalf("watcher Err after Close = %v", pods.Err())
+	}
+
+	close(unblock)
+	if err := <-slow; err != nil {
+		t.Fatalf("in-flight request = %v", err)
+	}
+	if err := client.Close(ctx); err != nil {
+		t.Fatalf("second Close = %v", err)
+	}
+	if _, err := client.GetPod(ctx, "p1"); !errors.Is(err, runpod.ErrClientClosed) {
+		t.Fatalf("GetPod after Close = %v", err)
+	}
+	if _, err := client.WatchJob(ctx, "ep1", "job1", 0); !errors.Is(err, runpod.ErrClientClosed) {
+		t.Fatalf("WatchJob after Close = %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+// Command endpointgen writes a typed client for a serverless endpoint
+// from its worker's input and output JSON Schemas. Use it from
+// go:generate in the package that calls the endpoint:
+//
+//	//go:generate go run github.com/cozy-creator/runpod-go-sdk/cmd/endpointgen -name SDXL -input sdxl_input.json -output sdxl_output.json
+//
+// This writes sdxl_client.go with SDXLInput, SDXLOutput and an SDXLClient
+// whose Run, RunSync and Result methods take and return those types.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cozy-creator/runpod-go-sdk/endpointgen"
+	"github.com/cozy-creator/runpod-go-sdk/jsonschema"
+)
+
+func main() {
+	var (
+		name    = flag.String("name", "", "type name `prefix`, such as SDXL (required)")
+		input   = flag.String("input", "", "input JSON Schema `file` (required)")
+		output  = flag.String("output", "", "output JSON Schema `file`; without it output is json.RawMessage")
+		pkg     = flag.String("package", os.Getenv("GOPACKAGE"), "package `name` (default $GOPACKAGE)")
+		outFile = flag.String("o", "", "output `file` (default lower(name)_client.go)")
+	)
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: endpointgen -name NAME -input SCHEMA [-output SCHEMA] [-package PKG] [-o FILE]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *name == "" || *input == "" || *pkg == "" || flag.NArg() > 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	opts := endpointgen.Options{Package: *pkg, Name: *name}
+	var err error
+	if opts.Input, err = jsonschema.ReadFile(*input); err != nil {
+		fatal(err)
+	}
+	sources := []string{filepath.Base(*input)}
+	if *output != "" {
+		if opts.Output, err = jsonschema.ReadFile(*output); err != nil {
+			fatal(err)
+		}
+		sources = append(sources, filepath.Base(*output))
+	}
+	opts.Source = strings.Join(sources, " and ")
+
+	src, err := endpointgen.Generate(opts)
+	if err != nil {
+		fatal(err)
+	}
+	if *outFile == "" {
+		*outFile = strings.ToLower(*name) + "_client.go"
+	}
+	if err := os.WriteFile(*outFile, src, 0o644); err != nil {
+		fatal(err)
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "endpointgen:", err)
+	os.Exit(1)
+}
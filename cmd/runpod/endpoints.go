@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cozy-creator/runpod-go-sdk/manifest"
+)
+
+// endpointsDeploy applies a manifest, which declares the endpoints along
+// with the templates, volumes and secrets they use.
+func (c *cli) endpointsDeploy(ctx context.Context, args []string) error {
+	fs := c.flags("endpoints deploy")
+	file := fs.String("f", "", "manifest file (YAML)")
+	dryRun := fs.Bool("dry-run", false, "print the plan without applying it")
+	var opts manifest.ApplyOptions
+	fs.BoolVar(&opts.Prune, "prune", false, "delete resources of the manifest's kinds that it does not declare")
+	fs.BoolVar(&opts.AllowReplace, "allow-replace", false, "recreate pods whose spec has drifted")
+	if err := parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	if *file == "" {
+		fs.Usage()
+		return errUsage
+	}
+	m, err := manifest.ReadFile(*file)
+	if err != nil {
+		return err
+	}
+	client, err := c.api()
+	if err != nil {
+		return err
+	}
+
+	apply := manifest.Apply
+	if *dryRun {
+		apply = manifest.BuildPlan
+	}
+	plan, err := apply(ctx, client, m, &opts)
+	if plan != nil {
+		fmt.Fprint(c.stdout, plan.String())
+	}
+	return err
+}
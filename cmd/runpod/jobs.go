@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+)
+
+func (c *cli) jobsRun(ctx context.Context, args []string) error {
+	fs := c.flags("jobs run")
+	sync := fs.Bool("sync", false, "use /runsync and print the finished job")
+	wait := fs.Bool("wait", false, "submit, then poll until the job finishes")
+	timeout := fs.Duration("timeout", 10*time.Minute, "how long -wait polls")
+	if err := parse(fs, args, 1, 2); err != nil {
+		return err
+	}
+	input, err := c.input(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	client, err := c.api()
+	if err != nil {
+		return err
+	}
+
+	endpointID := fs.Arg(0)
+	var job *runpod.Job
+	switch {
+	case *sync:
+		job, err = client.RunSync(ctx, endpointID, input)
+	case *wait:
+		job, err = client.RunAndWait(ctx, endpointID, input, *timeout)
+	default:
+		job, err = client.RunAsync(ctx, endpointID, input)
+	}
+	if job != nil {
+		if perr := c.printJob(ctx, client, job); perr != nil && err == nil {
+			err = perr
+		}
+	}
+	return err
+}
+
+// input reads the job input from arg, or from stdin when arg is "-" or
+// empty, and checks that it is JSON.
+func (c *cli) input(arg string) (json.RawMessage, error) {
+	data := []byte(arg)
+	if arg == "" || arg == "-" {
+		var err error
+		if data, err = io.ReadAll(c.stdin); err != nil {
+			return nil, err
+		}
+	}
+	data = bytes.TrimSpace(data)
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("job input is not valid JSON")
+	}
+	return data, nil
+}
+
+func (c *cli) jobsStatus(ctx context.Context, args []string) error {
+	fs := c.flags("jobs status")
+	if err := parse(fs, args, 2, 2); err != nil {
+		return err
+	}
+	client, err := c.api()
+	if err != nil {
+		return err
+	}
+	job, err := client.GetJobStatus(ctx, fs.Arg(0), fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	return c.printJob(ctx, client, job)
+}
+
+// jobsStream prints each streamed chunk's output on its own line until the
+// job finishes.
+func (c *cli) jobsStream(ctx context.Context, args []string) error {
+	fs := c.flags("jobs stream")
+	interval := fs.Duration("interval", time.Second, "poll interval")
+	if err := parse(fs, args, 2, 2); err != nil {
+		return err
+	}
+	client, err := c.api()
+	if err != nil {
+		return err
+	}
+
+	endpointID, jobID := fs.Arg(0), fs.Arg(1)
+	for {
+		job, err := client.StreamResults(ctx, endpointID, jobID)
+		if err != nil {
+			return err
+		}
+		var chunks []struct {
+			Output json.RawMessage `json:"output"`
+		}
+		_ = json.Unmarshal(job.Stream, &chunks)
+		for _, chunk := range chunks {
+			fmt.Fprintln(c.stdout, text(chunk.Output))
+		}
+		if client.IsJobTerminal(job.Status) {
+			if job.Status != string(runpod.JobStatusCompleted) {
+				return fmt.Errorf("job %s %s: %s", jobID, job.Status, job.Error)
+			}
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(*interval):
+		}
+	}
+}
+
+// printJob prints job as JSON, with its full output if it was too large to
+// return inline.
+func (c *cli) printJob(ctx context.Context, client *runpod.Client, job *runpod.Job) error {
+	if _, err := client.FetchFullOutput(ctx, job); err != nil {
+		return err
+	}
+	return c.printJSON(job)
+}
+
+// text unquotes a JSON string and leaves other JSON as is.
+func text(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	return string(raw)
+}
@@ -0,0 +1,167 @@
+// Command runpod is a small command-line client built on the SDK. It
+// covers the everyday operations (pods, endpoint deploys from manifests,
+// serverless jobs and volume sync) and doubles as an example of the
+// SDK's public API.
+//
+//	runpod pods list [-status RUNNING] [-json]
+//	runpod pods create -name NAME -image IMAGE [-gpu TYPE]... [flags]
+//	runpod pods stop POD_ID...
+//	runpod endpoints deploy -f MANIFEST [-dry-run] [-prune]
+//	runpod jobs run [-sync | -wait] ENDPOINT_ID [INPUT_JSON | -]
+//	runpod jobs status ENDPOINT_ID JOB_ID
+//	runpod jobs stream ENDPOINT_ID JOB_ID
+//	runpod volumes sync [-prefix P] [-delete] [-dry-run] VOLUME_ID DIR
+//
+// The API key is read from RUNPOD_API_KEY; volumes sync also needs the S3
+// keys in RUNPOD_S3_ACCESS_KEY_ID and RUNPOD_S3_SECRET_ACCESS_KEY.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/storage"
+)
+
+// errUsage marks command-line mistakes; the usage has already been printed.
+var errUsage = errors.New("usage")
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	c := &cli{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}
+	if err := c.run(ctx, os.Args[1:]); err != nil {
+		if errors.Is(err, errUsage) {
+			os.Exit(2)
+		}
+		fmt.Fprintln(os.Stderr, "runpod:", err)
+		os.Exit(1)
+	}
+}
+
+// cli holds a command's I/O. client and storage are built from the
+// environment on first use unless already set, as tests do.
+type cli struct {
+	stdin          io.Reader
+	stdout, stderr io.Writer
+	client         *runpod.Client
+	storage        *storage.Options
+
+	usage string // of the running command
+}
+
+type command struct {
+	usage string
+	run   func(c *cli, ctx context.Context, args []string) error
+}
+
+var commands = map[string]command{
+	"pods list":        {"[-status STATUS] [-json]", (*cli).podsList},
+	"pods create":      {"-name NAME -image IMAGE [-gpu TYPE]... [flags]", (*cli).podsCreate},
+	"pods stop":        {"POD_ID...", (*cli).podsStop},
+	"endpoints deploy": {"-f MANIFEST [-dry-run] [-prune] [-allow-replace]", (*cli).endpointsDeploy},
+	"jobs run":         {"[-sync | -wait] ENDPOINT_ID [INPUT_JSON | -]", (*cli).jobsRun},
+	"jobs status":      {"ENDPOINT_ID JOB_ID", (*cli).jobsStatus},
+	"jobs stream":      {"[-interval D] ENDPOINT_ID JOB_ID", (*cli).jobsStream},
+	"volumes sync":     {"[-prefix P] [-delete] [-dry-run] [-exclude PATTERN]... VOLUME_ID DIR", (*cli).volumesSync},
+}
+
+func (c *cli) run(ctx context.Context, args []string) error {
+	if len(args) >= 2 {
+		if cmd, ok := commands[args[0]+" "+args[1]]; ok {
+			c.usage = cmd.usage
+			return cmd.run(c, ctx, args[2:])
+		}
+	}
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(c.stderr, "usage:")
+	for _, name := range names {
+		fmt.Fprintf(c.stderr, "  runpod %s %s\n", name, commands[name].usage)
+	}
+	return errUsage
+}
+
+// flags returns a flag set for the named command that reports errors as
+// errUsage.
+func (c *cli) flags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("runpod "+name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(c.stderr, "usage: runpod %s %s\n", name, c.usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parse parses args and checks the number of positional arguments, max < 0
+// meaning no limit.
+func parse(fs *flag.FlagSet, args []string, min, max int) error {
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	if n := fs.NArg(); n < min || (max >= 0 && n > max) {
+		fs.Usage()
+		return errUsage
+	}
+	return nil
+}
+
+func (c *cli) api() (*runpod.Client, error) {
+	if c.client != nil {
+		return c.client, nil
+	}
+	apiKey := os.Getenv("RUNPOD_API_KEY")
+	if apiKey == "" {
+		return nil, errors.New("RUNPOD_API_KEY is not set")
+	}
+	client, err := runpod.NewClient(apiKey)
+	if err != nil {
+		return nil, err
+	}
+	c.client = client
+	return client, nil
+}
+
+func (c *cli) printJSON(v any) error {
+	enc := json.NewEncoder(c.stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// listFlag collects a repeated string flag.
+type listFlag []string
+
+func (l *listFlag) String() string { return strings.Join(*l, ",") }
+
+func (l *listFlag) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
+
+// envFlag collects repeated KEY=VALUE flags.
+type envFlag map[string]string
+
+func (e envFlag) String() string { return "" }
+
+func (e envFlag) Set(v string) error {
+	key, value, ok := strings.Cut(v, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("want KEY=VALUE, got %q", v)
+	}
+	e[key] = value
+	return nil
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+	"github.com/cozy-creator/runpod-go-sdk/storage/storagetest"
+)
+
+// runCLI runs one command against srv and returns its stdout.
+func runCLI(t *testing.T, srv *runpodtest.Server, stdin string, args ...string) (string, error) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	c := &cli{stdin: strings.NewReader(stdin), stdout: &stdout, stderr: &stderr, client: srv.MustClient()}
+	err := c.run(context.Background(), args)
+	if errors.Is(err, errUsage) {
+		return stderr.String(), err
+	}
+	return stdout.String(), err
+}
+
+func compact(raw json.RawMessage) string {
+	var buf bytes.Buffer
+	_ = json.Compact(&buf, raw)
+	return buf.String()
+}
+
+func TestPods(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+
+	out, err := runCLI(t, srv, "", "pods", "create", "-name", "dev", "-image", "ubuntu:22.04", "-gpu", "NVIDIA A40", "-env", "A=1", "-port", "22/tcp")
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	id := strings.TrimSpace(out)
+	srv.AddPod(&runpod.Pod{ID: "zzz-old", Name: "old", DesiredStatus: "EXITED"})
+
+	out, err = runCLI(t, srv, "", "pods", "list", "-status", "RUNNING")
+	if err != nil || !strings.Contains(out, id) || !strings.Contains(out, "ubuntu:22.04") || strings.Contains(out, "zzz-old") {
+		t.Fatalf("list = %q, %v", out, err)
+	}
+
+	if _, err := runCLI(t, srv, "", "pods", "stop", id); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+	out, err = runCLI(t, srv, "", "pods", "list", "-json")
+	var pods []*runpod.Pod
+	if err != nil || json.Unmarshal([]byte(out), &pods) != nil || len(pods) != 2 || pods[0].DesiredStatus != "EXITED" {
+		t.Fatalf("list -json = %q, %v", out, err)
+	}
+
+	if out, err := runCLI(t, srv, "", "pods", "stop"); !errors.Is(err, errUsage) || !strings.Contains(out, "POD_ID...") {
+		t.Fatalf("stop without IDs = %q, %v", out, err)
+	}
+	if out, err := runCLI(t, srv, "", "pods", "explode"); !errors.Is(err, errUsage) || !strings.Contains(out, "runpod jobs stream") {
+		t.Fatalf("unknown command = %q, %v", out, err)
+	}
+}
+
+func TestJobs(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+
+	out, err := runCLI(t, srv, `{"prompt":"hi"}`, "jobs", "run", "-sync", "ep1", "-")
+	var job runpod.Job
+	if err != nil || json.Unmarshal([]byte(out), &job) != nil || job.Status != "COMPLETED" || compact(job.Output) != `{"prompt":"hi"}` {
+		t.Fatalf("run -sync = %q, %v", out, err)
+	}
+
+	out, err = runCLI(t, srv, "", "jobs", "run", "ep1", `{"n":1}`)
+	if err != nil || json.Unmarshal([]byte(out), &job) != nil || job.Status != "IN_QUEUE" {
+		t.Fatalf("run = %q, %v", out, err)
+	}
+	if err := srv.CompleteJob("ep1", job.ID, "done"); err != nil {
+		t.Fatal(err)
+	}
+	out, err = runCLI(t, srv, "", "jobs", "status", "ep1", job.ID)
+	if err != nil || !strings.Contains(out, `"COMPLETED"`) || !strings.Contains(out, `"done"`) {
+		t.Fatalf("status = %q, %v", out, err)
+	}
+	if _, err := runCLI(t, srv, "", "jobs", "stream", "-interval", "1ms", "ep1", job.ID); err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+
+	if _, err := runCLI(t, srv, "{not json", "jobs", "run", "ep1"); err == nil {
+		t.Fatal("invalid input should fail")
+	}
+}
+
+func TestEndpointsDeploy(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	file := filepath.Join(t.TempDir(), "stack.yaml")
+	stack := `apiVersion: runpod-go-sdk/v1
+kind: Template
+metadata:
+  name: worker
+spec:
+  imageName: acme/worker:1
+  isServerless: true
+---
+apiVersion: runpod-go-sdk/v1
+kind: Endpoint
+metadata:
+  name: worker
+spec:
+  template: worker
+  gpuTypeIds: [NVIDIA A40]
+  workersMax: 2
+`
+	if err := os.WriteFile(file, []byte(stack), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runCLI(t, srv, "", "endpoints", "deploy", "-f", file, "-dry-run")
+	if err != nil || !strings.Contains(out, "create") {
+		t.Fatalf("dry run = %q, %v", out, err)
+	}
+	if endpoints, _ := srv.MustClient().ListEndpoints(context.Background(), nil); len(endpoints) != 0 {
+		t.Fatalf("dry run created %d endpoints", len(endpoints))
+	}
+	if _, err := runCLI(t, srv, "", "endpoints", "deploy", "-f", file); err != nil {
+		t.Fatalf("deploy: %v", err)
+	}
+	if endpoints, _ := srv.MustClient().ListEndpoints(context.Background(), nil); len(endpoints) != 1 || endpoints[0].WorkersMax != 2 {
+		t.Fatalf("endpoints = %+v", endpoints)
+	}
+}
+
+func TestVolumesSync(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	s3 := storagetest.New()
+	defer s3.Close()
+	srv.AddNetworkVolume(&runpod.NetworkVolume{ID: "vol-1", Name: "models", Size: 10, DataCenterID: storagetest.DataCenterID})
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("hello"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var stdout bytes.Buffer
+	opts := s3.Options()
+	c := &cli{stdout: &stdout, stderr: &stdout, client: srv.MustClient(), storage: &opts}
+	if err := c.run(context.Background(), []string{"volumes", "sync", "-prefix", "data/", "vol-1", dir}); err != nil {
+		t.Fatalf("sync: %v\n%s", err, stdout.String())
+	}
+	if data, ok := s3.Object("vol-1", "data/a.txt"); !ok || string(data) != "hello" {
+		t.Fatalf("object = %q, %v; output:\n%s", data, ok, stdout.String())
+	}
+}
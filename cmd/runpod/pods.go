@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+)
+
+func (c *cli) podsList(ctx context.Context, args []string) error {
+	fs := c.flags("pods list")
+	status := fs.String("status", "", "only pods with this desired status, e.g. RUNNING")
+	asJSON := fs.Bool("json", false, "print JSON")
+	if err := parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	client, err := c.api()
+	if err != nil {
+		return err
+	}
+
+	var pods []*runpod.Pod
+	for pod, err := range client.Pods(ctx, &runpod.ListOptions{Filter: runpod.ListFilter{DesiredStatus: *status}}) {
+		if err != nil {
+			return err
+		}
+		pods = append(pods, pod)
+	}
+	if *asJSON {
+		return c.printJSON(pods)
+	}
+
+	tw := tabwriter.NewWriter(c.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tSTATUS\tGPU\t$/HR\tIMAGE")
+	for _, pod := range pods {
+		gpu := "-"
+		if pod.GPU != nil {
+			gpu = fmt.Sprintf("%dx %s", pod.GPU.Count, pod.GPU.DisplayName)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%.3f\t%s\n", pod.ID, pod.Name, pod.DesiredStatus, gpu, pod.CostPerHour, pod.ImageName)
+	}
+	return tw.Flush()
+}
+
+func (c *cli) podsCreate(ctx context.Context, args []string) error {
+	req := &runpod.CreatePodRequest{Env: map[string]string{}}
+	var gpus, ports listFlag
+	fs := c.flags("pods create")
+	fs.StringVar(&req.Name, "name", "", "pod name")
+	fs.StringVar(&req.ImageName, "image", "", "container image")
+	fs.StringVar(&req.TemplateID, "template", "", "template ID")
+	fs.Var(&gpus, "gpu", "GPU type ID, in order of preference (repeatable)")
+	fs.IntVar(&req.GPUCount, "gpu-count", 1, "GPUs per pod")
+	cloud := fs.String("cloud", "", "SECURE or COMMUNITY")
+	fs.IntVar(&req.ContainerDiskInGB, "disk", 20, "container disk in GB")
+	fs.IntVar(&req.VolumeInGB, "volume", 0, "pod volume in GB")
+	fs.StringVar(&req.NetworkVolumeID, "network-volume", "", "network volume ID to attach")
+	fs.Var(envFlag(req.Env), "env", "environment variable KEY=VALUE (repeatable)")
+	fs.Var(&ports, "port", "exposed port such as 8888/http (repeatable)")
+	fs.BoolVar(&req.Interruptible, "spot", false, "create an interruptible (spot) pod")
+	asJSON := fs.Bool("json", false, "print the created pod as JSON")
+	if err := parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	req.GPUTypeIDs, req.Ports, req.CloudType = gpus, ports, runpod.CloudType(*cloud)
+	client, err := c.api()
+	if err != nil {
+		return err
+	}
+
+	pod, err := client.CreatePod(ctx, req)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return c.printJSON(pod)
+	}
+	fmt.Fprintln(c.stdout, pod.ID)
+	return nil
+}
+
+func (c *cli) podsStop(ctx context.Context, args []string) error {
+	fs := c.flags("pods stop")
+	if err := parse(fs, args, 1, -1); err != nil {
+		return err
+	}
+	client, err := c.api()
+	if err != nil {
+		return err
+	}
+	for _, id := range fs.Args() {
+		if err := client.StopPod(ctx, id); err != nil {
+			return err
+		}
+		fmt.Fprintln(c.stdout, "stopped", id)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cozy-creator/runpod-go-sdk/storage"
+)
+
+func (c *cli) volumesSync(ctx context.Context, args []string) error {
+	fs := c.flags("volumes sync")
+	prefix := fs.String("prefix", "", "key prefix on the volume")
+	var opts storage.SyncOptions
+	fs.BoolVar(&opts.Delete, "delete", false, "delete objects under the prefix that no longer exist locally")
+	fs.BoolVar(&opts.DryRun, "dry-run", false, "report what would change without changing it")
+	fs.Var((*listFlag)(&opts.Exclude), "exclude", "path.Match pattern to skip (repeatable)")
+	if err := parse(fs, args, 2, 2); err != nil {
+		return err
+	}
+	client, err := c.api()
+	if err != nil {
+		return err
+	}
+	storageOpts := storage.Options{Credentials: storage.CredentialsFromEnv()}
+	if c.storage != nil {
+		storageOpts = *c.storage
+	}
+	vol, err := storage.OpenVolume(ctx, client, fs.Arg(0), storageOpts)
+	if err != nil {
+		return err
+	}
+
+	opts.OnFile = func(key, action string) { fmt.Fprintln(c.stdout, action, key) }
+	result, err := storage.SyncDir(ctx, fs.Arg(1), vol, *prefix, &opts)
+	if result != nil {
+		fmt.Fprintln(c.stdout, result)
+	}
+	return err
+}
@@ -0,0 +1,251 @@
+package runpod
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// RunPod's published storage rates (USD per GB per month) and the month
+// length used to convert them. Storage is billed while the resource exists;
+// container disk only while the pod runs.
+const (
+	ContainerDiskRatePerGBMonth = 0.10
+	VolumeRatePerGBMonth        = 0.10
+	NetworkVolumeRatePerGBMonth = 0.07
+	HoursPerMonth               = 730.0
+)
+
+// CostItem is one line of a CostEstimate.
+type CostItem struct {
+	Name    string
+	PerHour float64
+}
+
+// CostEstimate is an estimated running cost. PerHour / PerMonth price the
+// GPU type the request would most likely get: the first candidate in
+// GPUTypeIDs order that is in stock (or the first priced one when none is).
+// MaxPerHour / MaxPerMonth price the most expensive candidate, the safer
+// bound for CI cost gates.
+type CostEstimate struct {
+	GPUTypeID string
+	CloudType CloudType
+	Items     []CostItem
+
+	PerHour     float64
+	PerMonth    float64
+	MaxPerHour  float64
+	MaxPerMonth float64
+	// Approximate is set when part of the estimate comes from indicative
+	// rather than live prices (CPU families, serverless workers); Notes say
+	// which.
+	Approximate bool
+	Notes       []string
+}
+
+func (e *CostEstimate) add(name string, perHour float64) {
+	e.Items = append(e.Items, CostItem{Name: name, PerHour: perHour})
+	e.PerHour += perHour
+	e.MaxPerHour += perHour
+}
+
+func (e *CostEstimate) finish() *CostEstimate {
+	e.PerHour = roundCost(e.PerHour)
+	e.MaxPerHour = roundCost(e.MaxPerHour)
+	e.PerMonth = roundCost(e.PerHour * HoursPerMonth)
+	e.MaxPerMonth = roundCost(e.MaxPerHour * HoursPerMonth)
+	return e
+}
+
+// roundCost rounds to 1/10000 of a dollar to hide float noise.
+func roundCost(v float64) float64 {
+	return math.Round(v*10000) / 10000
+}
+
+// EstimateCost estimates what running the pod described by req costs, from
+// live GPU pricing plus container disk, volume and network volume storage.
+// Interruptible pods are priced at BidPerGPU, or the bid floor when unset.
+// CPU pods use the static family catalog's indicative prices.
+func (c *Client) EstimateCost(ctx context.Context, req *CreatePodRequest) (*CostEstimate, error) {
+	if req == nil {
+		return nil, NewValidationError("request", "cannot be nil")
+	}
+	est := &CostEstimate{CloudType: req.CloudType.normalize()}
+
+	if req.ComputeType.isCPU() {
+		c.estimateCPU(est, req.CPUFlavorIDs, req.VCPUCount)
+	} else {
+		count := req.GPUCount
+		if count <= 0 {
+			count = 1
+		}
+		if err := c.estimateGPU(ctx, est, req.GPUTypeIDs, count, req.DataCenterIDs, est.CloudType, req.Interruptible, req.BidPerGPU); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.ContainerDiskInGB > 0 {
+		est.add("container disk", float64(req.ContainerDiskInGB)*ContainerDiskRatePerGBMonth/HoursPerMonth)
+	}
+	if req.VolumeInGB > 0 {
+		est.add("volume", float64(req.VolumeInGB)*VolumeRatePerGBMonth/HoursPerMonth)
+	}
+	if err := c.estimateNetworkVolume(ctx, est, req.NetworkVolumeID); err != nil {
+		return nil, err
+	}
+	return est.finish(), nil
+}
+
+// EstimateEndpointCost estimates a serverless endpoint's cost for an
+// expected amount of job execution per day. WorkersMin active workers are
+// billed around the clock; execution they cannot absorb is billed on flex
+// workers. The result's PerHour is the daily cost spread over 24 hours.
+//
+// RunPod's serverless per-second rates are not exposed by the API, so
+// workers are priced at the pod on-demand rate of the same GPU type and the
+// estimate is marked Approximate. Idle-timeout and cold-start time is not
+// included.
+func (c *Client) EstimateEndpointCost(ctx context.Context, req *CreateEndpointRequest, expectedSecondsPerDay float64) (*CostEstimate, error) {
+	if req == nil {
+		return nil, NewValidationError("request", "cannot be nil")
+	}
+	if expectedSecondsPerDay < 0 {
+		return nil, NewValidationErrorWithValue("expectedSecondsPerDay", "cannot be negative", expectedSecondsPerDay)
+	}
+
+	worker := &CostEstimate{}
+	if req.ComputeType.isCPU() {
+		c.estimateCPU(worker, req.CPUFlavorIDs, req.VCPUCount)
+	} else {
+		count := req.GPUCount
+		if count <= 0 {
+			count = 1
+		}
+		if err := c.estimateGPU(ctx, worker, req.GPUTypeIDs, count, req.DataCenterIDs, "", false, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	est := &CostEstimate{GPUTypeID: worker.GPUTypeID, CloudType: worker.CloudType, Approximate: true, Notes: worker.Notes}
+	est.Notes = append(est.Notes, "serverless workers priced at the pod on-demand rate")
+	activeSeconds := float64(req.WorkersMin) * 86400
+	flexSeconds := math.Max(0, expectedSecondsPerDay-activeSeconds)
+	if req.WorkersMin > 0 {
+		est.Items = append(est.Items, CostItem{Name: fmt.Sprintf("%d active workers", req.WorkersMin), PerHour: worker.PerHour * float64(req.WorkersMin)})
+	}
+	if flexSeconds > 0 {
+		est.Items = append(est.Items, CostItem{Name: "flex workers", PerHour: worker.PerHour * flexSeconds / 86400})
+	}
+	if req.WorkersMax > 0 && flexSeconds > float64(req.WorkersMax-req.WorkersMin)*86400 {
+		est.Notes = append(est.Notes, "expected execution exceeds workersMax capacity; jobs will queue")
+	}
+	scale := (activeSeconds + flexSeconds) / 86400
+	est.PerHour = worker.PerHour * scale
+	est.MaxPerHour = worker.MaxPerHour * scale
+
+	if err := c.estimateNetworkVolume(ctx, est, req.NetworkVolumeID); err != nil {
+		return nil, err
+	}
+	return est.finish(), nil
+}
+
+// estimateGPU adds the compute line for count GPUs of the first in-stock
+// candidate type, tracking the most expensive candidate in MaxPerHour.
+func (c *Client) estimateGPU(ctx context.Context, est *CostEstimate, gpuTypeIDs []string, count int, dataCenterIDs []string, cloud CloudType, interruptible bool, bidPerGPU float64) error {
+	if len(gpuTypeIDs) == 0 {
+		return NewValidationError("gpuTypeIds", "cannot be empty")
+	}
+	filter := &GPUOfferFilter{GPUCount: count, IDs: gpuTypeIDs}
+	if len(dataCenterIDs) == 1 {
+		filter.DataCenterID = dataCenterIDs[0]
+	}
+	offers, err := c.ListGPUOffers(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	price := func(o GPUOffer) float64 {
+		if !interruptible {
+			return o.OnDemandPrice
+		}
+		if bidPerGPU > 0 {
+			return bidPerGPU * float64(count)
+		}
+		return o.MinimumBidPrice
+	}
+	var chosen, fallback, highest *GPUOffer
+	for _, id := range gpuTypeIDs {
+		for i := range offers {
+			o := &offers[i]
+			if o.GPUTypeID != strings.TrimSpace(id) || (cloud != "" && o.CloudType != cloud) || price(*o) <= 0 {
+				continue
+			}
+			if highest == nil || price(*o) > price(*highest) {
+				highest = o
+			}
+			if fallback == nil || (fallback.GPUTypeID == o.GPUTypeID && price(*o) < price(*fallback)) {
+				fallback = o
+			}
+			if isAvailableStockStatus(o.StockStatus) && (chosen == nil || (chosen.GPUTypeID == o.GPUTypeID && price(*o) < price(*chosen))) {
+				chosen = o
+			}
+		}
+	}
+	if chosen == nil {
+		if fallback == nil {
+			return fmt.Errorf("%w: no price quoted for any of %s", ErrNoCapacity, strings.Join(gpuTypeIDs, ", "))
+		}
+		chosen = fallback
+		est.Notes = append(est.Notes, "no candidate GPU type is in stock; priced "+chosen.GPUTypeID)
+	}
+
+	est.GPUTypeID, est.CloudType = chosen.GPUTypeID, chosen.CloudType
+	name := fmt.Sprintf("%d x %s (%s)", count, chosen.GPUTypeID, strings.ToLower(string(chosen.CloudType)))
+	if interruptible {
+		name += " spot"
+	}
+	est.add(name, price(*chosen))
+	est.MaxPerHour += price(*highest) - price(*chosen)
+	return nil
+}
+
+// estimateCPU adds an indicative compute line from the static CPU family
+// catalog. IndicativeHr prices the smallest (2 vCPU) size.
+func (c *Client) estimateCPU(est *CostEstimate, flavorIDs []string, vcpus int) {
+	if len(flavorIDs) == 0 {
+		flavorIDs = DefaultCPUFlavorIDs()
+	}
+	if vcpus < 2 {
+		vcpus = 2
+	}
+	var first, highest float64
+	for _, id := range flavorIDs {
+		for _, family := range runpodCPUFamilyCatalog {
+			if family.ID != strings.TrimSpace(id) {
+				continue
+			}
+			perHour := family.IndicativeHr * float64(vcpus) / 2
+			if first == 0 {
+				first = perHour
+				est.add(fmt.Sprintf("%d vCPU %s", vcpus, family.ID), perHour)
+			}
+			highest = math.Max(highest, perHour)
+		}
+	}
+	est.MaxPerHour += highest - first
+	est.Approximate = true
+	est.Notes = append(est.Notes, "CPU compute priced from indicative family rates")
+}
+
+func (c *Client) estimateNetworkVolume(ctx context.Context, est *CostEstimate, volumeID string) error {
+	if strings.TrimSpace(volumeID) == "" {
+		return nil
+	}
+	volume, err := c.GetNetworkVolume(ctx, volumeID)
+	if err != nil {
+		return fmt.Errorf("failed to price network volume: %w", err)
+	}
+	est.add(fmt.Sprintf("network volume %s (%d GB)", volume.Name, volume.Size), float64(volume.Size)*NetworkVolumeRatePerGBMonth/HoursPerMonth)
+	return nil
+}
@@ -0,0 +1,260 @@
+package runpod
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cost record resource types.
+const (
+	CostResourcePod      = "pod"
+	CostResourceEndpoint = "endpoint"
+)
+
+// UntaggedCostGroup is the report group of records without the grouping
+// tag.
+const UntaggedCostGroup = "(untagged)"
+
+// CostRecord is the cost of one resource over part of a report period.
+type CostRecord struct {
+	ResourceType string            `json:"resourceType"`
+	ResourceID   string            `json:"resourceId"`
+	Name         string            `json:"name,omitempty"`
+	Tags         map[string]string `json:"tags,omitempty"`
+	Start        time.Time         `json:"start"`
+	End          time.Time         `json:"end"`
+	PerHour      float64           `json:"perHour"`
+	Hours        float64           `json:"hours"`
+	Cost         float64           `json:"cost"`
+}
+
+// CostReportOptions configures CostReport and NewCostReport.
+type CostReportOptions struct {
+	// Since / Until bound the period; Until defaults to now.
+	Since time.Time
+	Until time.Time
+	// TagKey groups records by this tag. Pod and endpoint tags are their env
+	// vars, so set e.g. TEAM=ml on the template or pod and group by "TEAM".
+	// Empty groups by resource type.
+	TagKey string
+	// GroupBy overrides TagKey with a custom grouping.
+	GroupBy func(CostRecord) string
+	// Records adds costs the live API cannot reconstruct, such as resources
+	// terminated during the period from your own tracking.
+	Records []CostRecord
+}
+
+// CostReportLine is one group's total.
+type CostReportLine struct {
+	Group     string  `json:"group"`
+	Resources int     `json:"resources"`
+	Hours     float64 `json:"hours"`
+	Cost      float64 `json:"cost"`
+}
+
+// CostReport aggregates resource costs over a period.
+type CostReport struct {
+	Since   time.Time        `json:"since"`
+	Until   time.Time        `json:"until"`
+	Lines   []CostReportLine `json:"lines"`
+	Total   float64          `json:"total"`
+	Records []CostRecord     `json:"records"`
+}
+
+// CostReport builds a report for the period from the account's running
+// pods and serverless workers, each billed at its current hourly rate from
+// its last start (or Since, if later) to Until, plus opts.Records. Stopped
+// pods' volume storage and resources terminated before the call are not
+// visible to the API; pass those in opts.Records.
+func (c *Client) CostReport(ctx context.Context, opts *CostReportOptions) (*CostReport, error) {
+	if opts == nil {
+		opts = &CostReportOptions{}
+	}
+	since, until, err := costReportPeriod(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := c.ListPods(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build cost report: %w", err)
+	}
+	var records []CostRecord
+	for _, pod := range pods {
+		if record, ok := podCostRecord(pod, CostResourcePod, pod.ID, pod.Name, pod.Env, since, until); ok {
+			records = append(records, record)
+		}
+	}
+	endpoints, err := c.ListEndpoints(ctx, &GetEndpointOptions{IncludeWorkers: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build cost report: %w", err)
+	}
+	for _, endpoint := range endpoints {
+		for _, worker := range endpoint.Workers {
+			if worker == nil {
+				continue
+			}
+			if record, ok := podCostRecord(worker, CostResourceEndpoint, endpoint.ID, endpoint.Name, endpoint.Env, since, until); ok {
+				records = append(records, record)
+			}
+		}
+	}
+
+	withRecords := *opts
+	withRecords.Since, withRecords.Until = since, until
+	withRecords.Records = append(records, opts.Records...)
+	return NewCostReport(&withRecords)
+}
+
+// NewCostReport aggregates opts.Records without calling the API, clipping
+// each record to the period.
+func NewCostReport(opts *CostReportOptions) (*CostReport, error) {
+	if opts == nil {
+		opts = &CostReportOptions{}
+	}
+	since, until, err := costReportPeriod(opts)
+	if err != nil {
+		return nil, err
+	}
+	groupBy := opts.GroupBy
+	if groupBy == nil {
+		groupBy = func(r CostRecord) string {
+			if opts.TagKey == "" {
+				return r.ResourceType
+			}
+			if v := strings.TrimSpace(r.Tags[opts.TagKey]); v != "" {
+				return v
+			}
+			return UntaggedCostGroup
+		}
+	}
+
+	report := &CostReport{Since: since, Until: until}
+	lines := map[string]*CostReportLine{}
+	resources := map[string]map[string]bool{}
+	for _, record := range opts.Records {
+		if !since.IsZero() && record.Start.Before(since) {
+			record.Start = since
+		}
+		if record.End.IsZero() || record.End.After(until) {
+			record.End = until
+		}
+		if !record.End.After(record.Start) || record.PerHour < 0 {
+			continue
+		}
+		record.Hours = roundCost(record.End.Sub(record.Start).Hours())
+		record.Cost = roundCost(record.PerHour * record.End.Sub(record.Start).Hours())
+		report.Records = append(report.Records, record)
+
+		group := groupBy(record)
+		line := lines[group]
+		if line == nil {
+			line = &CostReportLine{Group: group}
+			lines[group] = line
+			resources[group] = map[string]bool{}
+		}
+		if key := record.ResourceType + "/" + record.ResourceID; !resources[group][key] {
+			resources[group][key] = true
+			line.Resources++
+		}
+		line.Hours = roundCost(line.Hours + record.Hours)
+		line.Cost = roundCost(line.Cost + record.Cost)
+		report.Total = roundCost(report.Total + record.Cost)
+	}
+	for _, line := range lines {
+		report.Lines = append(report.Lines, *line)
+	}
+	sort.Slice(report.Lines, func(i, j int) bool {
+		if report.Lines[i].Cost != report.Lines[j].Cost {
+			return report.Lines[i].Cost > report.Lines[j].Cost
+		}
+		return report.Lines[i].Group < report.Lines[j].Group
+	})
+	return report, nil
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *CostReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteCSV writes one row per group plus a total row:
+// group,resources,hours,cost.
+func (r *CostReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	format := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	rows := [][]string{{"group", "resources", "hours", "cost"}}
+	resources, hours := 0, 0.0
+	for _, line := range r.Lines {
+		rows = append(rows, []string{line.Group, strconv.Itoa(line.Resources), format(line.Hours), format(line.Cost)})
+		resources += line.Resources
+		hours += line.Hours
+	}
+	rows = append(rows, []string{"total", strconv.Itoa(resources), format(roundCost(hours)), format(r.Total)})
+	if err := cw.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write cost report CSV: %w", err)
+	}
+	return nil
+}
+
+func costReportPeriod(opts *CostReportOptions) (time.Time, time.Time, error) {
+	until := opts.Until
+	if until.IsZero() {
+		until = time.Now()
+	}
+	if !opts.Since.IsZero() && !until.After(opts.Since) {
+		return time.Time{}, time.Time{}, NewValidationError("until", "must be after since")
+	}
+	return opts.Since, until, nil
+}
+
+// podCostRecord bills a running pod (or worker) from its last start.
+func podCostRecord(pod *Pod, resourceType, resourceID, name string, tags map[string]string, since, until time.Time) (CostRecord, bool) {
+	perHour := runningCostPerHr(pod)
+	var start time.Time
+	switch {
+	case pod.LastStartedAt != nil && !pod.LastStartedAt.IsZero():
+		start = pod.LastStartedAt.Time
+	case pod.CreatedAt != nil:
+		start = pod.CreatedAt.Time
+	}
+	if start.IsZero() || perHour <= 0 {
+		return CostRecord{}, false
+	}
+	if start.Before(since) {
+		start = since
+	}
+	if !until.After(start) {
+		return CostRecord{}, false
+	}
+	return CostRecord{
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Name:         name,
+		Tags:         tags,
+		Start:        start,
+		End:          until,
+		PerHour:      perHour,
+	}, true
+}
+
+// runningCostPerHr is a running pod's (or worker's) hourly rate, discounts
+// applied, and zero for a pod that is not running.
+func runningCostPerHr(pod *Pod) float64 {
+	if pod.DesiredStatus != "RUNNING" {
+		return 0
+	}
+	if pod.AdjustedCostPerHr > 0 {
+		return pod.AdjustedCostPerHr
+	}
+	return pod.CostPerHour
+}
@@ -0,0 +1,73 @@
+package runpod_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestCostReport(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+
+	until := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	since := until.Add(-24 * time.Hour)
+	started := func(d time.Duration) *runpod.JSONTime { return &runpod.JSONTime{Time: until.Add(-d)} }
+	srv.AddPod(&runpod.Pod{ID: "train", Name: "train", DesiredStatus: "RUNNING", CostPerHour: 2, LastStartedAt: started(10 * time.Hour), Env: map[string]string{"TEAM": "ml"}})
+	srv.AddPod(&runpod.Pod{ID: "notebook", Name: "notebook", DesiredStatus: "RUNNING", CostPerHour: 0.5, LastStartedAt: started(48 * time.Hour), Env: map[string]string{"TEAM": "research"}})
+	srv.AddPod(&runpod.Pod{ID: "scratch", DesiredStatus: "RUNNING", CostPerHour: 1, LastStartedAt: started(time.Hour)})
+	srv.AddPod(&runpod.Pod{ID: "stopped", DesiredStatus: "EXITED", CostPerHour: 3, LastStartedAt: started(time.Hour), Env: map[string]string{"TEAM": "ml"}})
+
+	report, err := client.CostReport(t.Context(), &runpod.CostReportOptions{
+		Since:  since,
+		Until:  until,
+		TagKey: "TEAM",
+		// A pod terminated mid-period, from the caller's own tracking.
+		Records: []runpod.CostRecord{{ResourceType: runpod.CostResourcePod, ResourceID: "old", Tags: map[string]string{"TEAM": "ml"},
+			Start: since.Add(-time.Hour), End: since.Add(2 * time.Hour), PerHour: 1.5}},
+	})
+	if err != nil {
+		t.Fatalf("CostReport: %v", err)
+	}
+	// ml: 10h x $2 + 2h x $1.5 (clipped to the period); research: 24h x $0.5.
+	want := []runpod.CostReportLine{
+		{Group: "ml", Resources: 2, Hours: 12, Cost: 23},
+		{Group: "research", Resources: 1, Hours: 24, Cost: 12},
+		{Group: runpod.UntaggedCostGroup, Resources: 1, Hours: 1, Cost: 1},
+	}
+	if len(report.Lines) != len(want) || report.Total != 36 || len(report.Records) != 4 {
+		t.Fatalf("report = %+v", report)
+	}
+	for i := range want {
+		if report.Lines[i] != want[i] {
+			t.Fatalf("line %d = %+v, want %+v", i, report.Lines[i], want[i])
+		}
+	}
+
+	var csv bytes.Buffer
+	if err := report.WriteCSV(&csv); err != nil {
+		t.Fatal(err)
+	}
+	if got := csv.String(); got != "group,resources,hours,cost\nml,2,12,23\nresearch,1,24,12\n(untagged),1,1,1\ntotal,4,37,36\n" {
+		t.Fatalf("csv = %q", got)
+	}
+	var js bytes.Buffer
+	if err := report.WriteJSON(&js); err != nil {
+		t.Fatal(err)
+	}
+	var decoded runpod.CostReport
+	if err := json.Unmarshal(js.Bytes(), &decoded); err != nil || decoded.Total != 36 || len(decoded.Lines) != 3 {
+		t.Fatalf("json round trip = %+v, %v", decoded, err)
+	}
+
+	var validationErr *runpod.ValidationError
+	if _, err := runpod.NewCostReport(&runpod.CostReportOptions{Since: until, Until: since}); !errors.As(err, &validationErr) {
+		t.Fatalf("inverted period: %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package runpod_test
+
+import (
+	"errors"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func newCostServer(t *testing.T) (*runpodtest.Server, *runpod.Client) {
+	t.Helper()
+	srv := runpodtest.New()
+	t.Cleanup(srv.Close)
+	srv.SetGPUTypes([]runpod.GPUType{
+		{ID: "NVIDIA GeForce RTX 4090", MemoryInGB: 24, SecureCloud: true,
+			LowestPrice: &runpod.Price{UninterruptablePrice: 0.70, MinimumBidPrice: 0.40, StockStatus: "None"}},
+		{ID: "NVIDIA L40S", MemoryInGB: 48, SecureCloud: true,
+			LowestPrice: &runpod.Price{UninterruptablePrice: 0.90, MinimumBidPrice: 0.50, StockStatus: "High"}},
+	})
+	srv.AddNetworkVolume(&runpod.NetworkVolume{ID: "vol-1", Name: "models", Size: 730, DataCenterID: "US-KS-2"})
+	return srv, srv.MustClient()
+}
+
+func TestEstimateCost(t *testing.T) {
+	_, client := newCostServer(t)
+	ctx := t.Context()
+
+	est, err := client.EstimateCost(ctx, &runpod.CreatePodRequest{
+		GPUTypeIDs:        []string{"NVIDIA GeForce RTX 4090", "NVIDIA L40S"},
+		GPUCount:          1,
+		CloudType:         "SECURE",
+		ContainerDiskInGB: 73,
+		VolumeInGB:        146,
+		NetworkVolumeID:   "vol-1",
+	})
+	if err != nil {
+		t.Fatalf("EstimateCost: %v", err)
+	}
+	// The 4090 is out of stock, so the pod lands on the L40S:
+	// 0.90 GPU + 0.01 container disk + 0.02 volume + 0.07 network volume.
+	if est.GPUTypeID != "NVIDIA L40S" || est.CloudType != "SECURE" || len(est.Items) != 4 {
+		t.Fatalf("estimate = %+v", est)
+	}
+	if est.PerHour != 1.0 || est.PerMonth != 730 || est.MaxPerHour != 1.0 || est.Approximate {
+		t.Fatalf("totals = %+v", est)
+	}
+
+	spot, err := client.EstimateCost(ctx, &runpod.CreatePodRequest{
+		GPUTypeIDs: []string{"NVIDIA L40S"}, GPUCount: 1, Interruptible: true, BidPerGPU: 0.6,
+	})
+	if err != nil || spot.PerHour != 0.6 {
+		t.Fatalf("spot estimate = %+v, %v", spot, err)
+	}
+
+	cpu, err := client.EstimateCost(ctx, &runpod.CreatePodRequest{ComputeType: "CPU", CPUFlavorIDs: []string{"cpu3c"}, VCPUCount: 4})
+	if err != nil || !cpu.Approximate || cpu.PerHour != 0.12 {
+		t.Fatalf("cpu estimate = %+v, %v", cpu, err)
+	}
+
+	if _, err := client.EstimateCost(ctx, &runpod.CreatePodRequest{GPUTypeIDs: []string{"NVIDIA B200"}, GPUCount: 1}); !errors.Is(err, runpod.ErrNoCapacity) {
+		t.Fatalf("unpriced type: expected ErrNoCapacity, got %v", err)
+	}
+}
+
+func TestEstimateEndpointCost(t *testing.T) {
+	_, client := newCostServer(t)
+
+	// One active worker all day plus 12h of flex execution on the L40S.
+	est, err := client.EstimateEndpointCost(t.Context(), &runpod.CreateEndpointRequest{
+		TemplateID:      "tpl",
+		GPUTypeIDs:      []string{"NVIDIA L40S"},
+		WorkersMin:      1,
+		WorkersMax:      3,
+		NetworkVolumeID: "vol-1",
+	}, 86400+43200)
+	if err != nil {
+		t.Fatalf("EstimateEndpointCost: %v", err)
+	}
+	if !est.Approximate || len(est.Items) != 3 || est.PerHour != 1.42 || est.PerMonth != 1036.6 {
+		t.Fatalf("estimate = %+v", est)
+	}
+
+	if _, err := client.EstimateEndpointCost(t.Context(), &runpod.CreateEndpointRequest{GPUTypeIDs: []string{"NVIDIA L40S"}}, -1); err == nil {
+		t.Fatal("negative expected seconds must fail")
+	}
+}
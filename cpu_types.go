@@ -1,6 +1,8 @@
 package runpod
 
 import (
+	"context"
+	"fmt"
 	"sort"
 	"strings"
 )
@@ -101,3 +103,43 @@ func SelectCPUFamilies(preferred, familyFilter string) []string {
 func DefaultCPUFlavorIDs() []string {
 	return SelectCPUFamilies("", "")
 }
+
+// CPUFlavor is one live CPU instance flavor as reported by the GraphQL
+// cpuFlavors query. ID is the family-level ID accepted by
+// CreatePodRequest.CPUFlavorIDs.
+type CPUFlavor struct {
+	ID               string  `json:"id"`
+	GroupID          string  `json:"groupId"`
+	GroupName        string  `json:"groupName"`
+	DisplayName      string  `json:"displayName"`
+	MinVCPU          int     `json:"minVcpu"`
+	MaxVCPU          int     `json:"maxVcpu"`
+	RAMMultiplier    float64 `json:"ramMultiplier"`
+	DiskLimitPerVCPU int     `json:"diskLimitPerVcpu"`
+}
+
+// ListCPUFlavors returns the live CPU flavor catalog, the runtime
+// counterpart of the static CPUFamilies list.
+func (c *Client) ListCPUFlavors(ctx context.Context) ([]CPUFlavor, error) {
+	const query = `
+query {
+  cpuFlavors {
+    id
+    groupId
+    groupName
+    displayName
+    minVcpu
+    maxVcpu
+    ramMultiplier
+    diskLimitPerVcpu
+  }
+}`
+
+	var payload struct {
+		CPUFlavors []CPUFlavor `json:"cpuFlavors"`
+	}
+	if err := c.catalogGraphQL(ctx, query, nil, &payload); err != nil {
+		return nil, fmt.Errorf("failed to list CPU flavors: %w", err)
+	}
+	return payload.CPUFlavors, nil
+}
@@ -3,6 +3,8 @@ package runpod
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 )
 
 // DataCenter is one RunPod placement location together with its current
@@ -13,6 +15,15 @@ type DataCenter struct {
 	Name            string                        `json:"name"`
 	Location        string                        `json:"location"`
 	GPUAvailability []GPUAvailabilityInDataCenter `json:"gpuAvailability"`
+
+	// Region and Country are derived from the ID ("EU-RO-1" is region "EU",
+	// country "RO"; "US-GA-1" is region and country "US").
+	Region  string `json:"-"`
+	Country string `json:"-"`
+	// StorageSupport (network volumes) and GlobalNetwork are populated by
+	// SelectDataCenters only when its filter asks for them.
+	StorageSupport bool `json:"storageSupport,omitempty"`
+	GlobalNetwork  bool `json:"globalNetwork,omitempty"`
 }
 
 // GPUAvailabilityInDataCenter is RunPod's current stock observation for one
@@ -43,8 +54,9 @@ type graphQLDataCenterPayload struct {
 }
 
 // ListDataCenters returns RunPod's authoritative per-datacenter GPU stock
-// snapshot. It performs one bounded GraphQL request; caching, refresh cadence,
-// and placement-failure policy belong to the orchestrator, not the SDK.
+// snapshot. It performs one bounded GraphQL request, served from the client's
+// CatalogCache when one is configured; refresh cadence and placement-failure
+// policy belong to the orchestrator, not the SDK.
 func (c *Client) ListDataCenters(ctx context.Context, filter *GPUAvailabilityFilter) ([]DataCenter, error) {
 	if filter != nil {
 		switch {
@@ -78,8 +90,254 @@ query($input: GpuAvailabilityInput) {
 	if filter != nil {
 		variables["input"] = filter
 	}
-	if err := c.GraphQL(ctx, query, variables, &payload); err != nil {
+	if err := c.catalogGraphQL(ctx, query, variables, &payload); err != nil {
 		return nil, fmt.Errorf("failed to list data centers: %w", err)
 	}
+	for i := range payload.DataCenters {
+		dc := &payload.DataCenters[i]
+		dc.Region, dc.Country = dataCenterRegion(dc.ID)
+	}
 	return payload.DataCenters, nil
 }
+
+// dataCenterRegion derives region and country from a data center ID. IDs are
+// "<region>-<area>-<n>" where area is a country code inside multi-country
+// regions (EU, AP, OC) and a state/city inside US and CA.
+func dataCenterRegion(id string) (region, country string) {
+	parts := strings.Split(strings.ToUpper(strings.TrimSpace(id)), "-")
+	if len(parts) < 2 {
+		return "", ""
+	}
+	region = parts[0]
+	if region == "EUR" {
+		region = "EU"
+	}
+	switch region {
+	case "US", "CA":
+		return region, region
+	default:
+		return region, parts[1]
+	}
+}
+
+// DataCenterFilter constrains SelectDataCenters, e.g. for data residency.
+// Zero values are unconstrained.
+type DataCenterFilter struct {
+	// Regions keeps data centers in these regions ("US", "CA", "EU", "AP",
+	// "OC").
+	Regions []string
+	// Countries keeps data centers in these ISO 3166 alpha-2 countries
+	// ("US", "RO", "SE", ...).
+	Countries []string
+	// SecureCloud keeps data centers where at least one GPU type is
+	// rentable on Secure Cloud (availability is queried with
+	// secureCloud=true).
+	SecureCloud bool
+	// NetworkVolumes keeps data centers that support network volumes.
+	NetworkVolumes bool
+	// GlobalNetworking keeps data centers that support global networking.
+	GlobalNetworking bool
+	// Availability is passed to the availability query.
+	Availability *GPUAvailabilityFilter
+}
+
+// SelectDataCenters lists data centers matching filter (which may be nil).
+// Capability flags are fetched with a second query only when NetworkVolumes
+// or GlobalNetworking is requested.
+func (c *Client) SelectDataCenters(ctx context.Context, filter *DataCenterFilter) ([]DataCenter, error) {
+	if filter == nil {
+		filter = &DataCenterFilter{}
+	}
+	availability := filter.Availability
+	if filter.SecureCloud {
+		secureFilter := GPUAvailabilityFilter{}
+		if availability != nil {
+			secureFilter = *availability
+		}
+		secure := true
+		secureFilter.SecureCloud = &secure
+		availability = &secureFilter
+	}
+	dataCenters, err := c.ListDataCenters(ctx, availability)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.NetworkVolumes || filter.GlobalNetworking {
+		const query = `
+query {
+  dataCenters {
+    id
+    storageSupport
+    globalNetwork
+  }
+}`
+		var payload graphQLDataCenterPayload
+		if err := c.catalogGraphQL(ctx, query, nil, &payload); err != nil {
+			return nil, fmt.Errorf("failed to list data center capabilities: %w", err)
+		}
+		caps := make(map[string]DataCenter, len(payload.DataCenters))
+		for _, dc := range payload.DataCenters {
+			caps[dc.ID] = dc
+		}
+		for i := range dataCenters {
+			dataCenters[i].StorageSupport = caps[dataCenters[i].ID].StorageSupport
+			dataCenters[i].GlobalNetwork = caps[dataCenters[i].ID].GlobalNetwork
+		}
+	}
+
+	regions := upperSet(filter.Regions)
+	countries := upperSet(filter.Countries)
+	out := make([]DataCenter, 0, len(dataCenters))
+	for _, dc := range dataCenters {
+		if len(regions) > 0 && !regions[dc.Region] {
+			continue
+		}
+		if len(countries) > 0 && !countries[dc.Country] {
+			continue
+		}
+		if filter.NetworkVolumes && !dc.StorageSupport {
+			continue
+		}
+		if filter.GlobalNetworking && !dc.GlobalNetwork {
+			continue
+		}
+		if filter.SecureCloud && !anyGPUAvailable(dc.GPUAvailability) {
+			continue
+		}
+		out = append(out, dc)
+	}
+	return out, nil
+}
+
+func upperSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		if v = strings.ToUpper(strings.TrimSpace(v)); v != "" {
+			if v == "EUR" {
+				v = "EU"
+			}
+			set[v] = true
+		}
+	}
+	return set
+}
+
+func anyGPUAvailable(availability []GPUAvailabilityInDataCenter) bool {
+	for _, gpu := range availability {
+		if gpu.Available {
+			return true
+		}
+	}
+	return false
+}
+
+// GPUDataCenterStock is one GPU type's stock observation in one data center.
+type GPUDataCenterStock struct {
+	DataCenterID   string
+	DataCenterName string
+	Location       string
+	StockStatus    string
+	Available      bool
+}
+
+// GPUTypeAvailability is one GPU type's stock across data centers, best
+// stocked first.
+type GPUTypeAvailability struct {
+	GPUTypeID   string
+	DisplayName string
+	DataCenters []GPUDataCenterStock
+}
+
+// AvailableDataCenterIDs returns the IDs of data centers reporting the GPU
+// type available, best stocked first — usable as
+// CreatePodRequest.DataCenterIDs.
+func (a GPUTypeAvailability) AvailableDataCenterIDs() []string {
+	var ids []string
+	for _, dc := range a.DataCenters {
+		if dc.Available {
+			ids = append(ids, dc.DataCenterID)
+		}
+	}
+	return ids
+}
+
+// ListGPUAvailability pivots ListDataCenters into per-GPU-type stock, so a
+// scheduler can see where a type is rentable before creating a pod. With
+// gpuTypeIDs only those types are returned (in the given order, including
+// types no data center reports); otherwise every reported type is returned
+// sorted by ID. filter has the same meaning as for ListDataCenters.
+func (c *Client) ListGPUAvailability(ctx context.Context, filter *GPUAvailabilityFilter, gpuTypeIDs ...string) ([]GPUTypeAvailability, error) {
+	dataCenters, err := c.ListDataCenters(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	byType := map[string]*GPUTypeAvailability{}
+	var order []string
+	for _, id := range gpuTypeIDs {
+		if id = strings.TrimSpace(id); id != "" && byType[id] == nil {
+			byType[id] = &GPUTypeAvailability{GPUTypeID: id}
+			order = append(order, id)
+		}
+	}
+	requested := len(order) > 0
+
+	for _, dc := range dataCenters {
+		for _, gpu := range dc.GPUAvailability {
+			entry := byType[gpu.GPUTypeID]
+			if entry == nil {
+				if requested {
+					continue
+				}
+				entry = &GPUTypeAvailability{GPUTypeID: gpu.GPUTypeID}
+				byType[gpu.GPUTypeID] = entry
+				order = append(order, gpu.GPUTypeID)
+			}
+			if entry.DisplayName == "" {
+				entry.DisplayName = gpu.DisplayName
+			}
+			entry.DataCenters = append(entry.DataCenters, GPUDataCenterStock{
+				DataCenterID:   dc.ID,
+				DataCenterName: dc.Name,
+				Location:       dc.Location,
+				StockStatus:    gpu.StockStatus,
+				Available:      gpu.Available,
+			})
+		}
+	}
+	if !requested {
+		sort.Strings(order)
+	}
+
+	out := make([]GPUTypeAvailability, 0, len(order))
+	for _, id := range order {
+		entry := byType[id]
+		sort.SliceStable(entry.DataCenters, func(i, j int) bool {
+			left, right := entry.DataCenters[i], entry.DataCenters[j]
+			if left.Available != right.Available {
+				return left.Available
+			}
+			if l, r := stockLevel(left.StockStatus), stockLevel(right.StockStatus); l != r {
+				return l > r
+			}
+			return left.DataCenterID < right.DataCenterID
+		})
+		out = append(out, *entry)
+	}
+	return out, nil
+}
+
+// stockLevel ranks a provider stock status; higher is better stocked.
+func stockLevel(status string) int {
+	switch strings.ToUpper(strings.TrimSpace(status)) {
+	case "HIGH":
+		return 3
+	case "MEDIUM", "AVAILABLE", "IN_STOCK":
+		return 2
+	case "LOW", "LOW_STOCK":
+		return 1
+	default:
+		return 0
+	}
+}
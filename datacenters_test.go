@@ -6,11 +6,13 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
 )
 
 func TestListDataCenters(t *testing.T) {
@@ -155,3 +157,89 @@ func TestListDataCentersRejectsInvalidShape(t *testing.T) {
 		t.Fatalf("error = %v", err)
 	}
 }
+
+func TestListGPUAvailability(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+
+	srv.SetDataCenters([]runpod.DataCenter{
+		{ID: "US-GA-1", Name: "US-GA-1", Location: "United States", GPUAvailability: []runpod.GPUAvailabilityInDataCenter{
+			{GPUTypeID: "NVIDIA GeForce RTX 4090", DisplayName: "RTX 4090", StockStatus: "Low", Available: true},
+			{GPUTypeID: "NVIDIA H100 80GB HBM3", DisplayName: "H100 SXM", StockStatus: "High", Available: true},
+		}},
+		{ID: "EU-RO-1", Name: "EU-RO-1", Location: "Europe", GPUAvailability: []runpod.GPUAvailabilityInDataCenter{
+			{GPUTypeID: "NVIDIA GeForce RTX 4090", DisplayName: "RTX 4090", StockStatus: "High", Available: true},
+		}},
+		{ID: "CA-MTL-1", Name: "CA-MTL-1", Location: "Canada", GPUAvailability: []runpod.GPUAvailabilityInDataCenter{
+			{GPUTypeID: "NVIDIA GeForce RTX 4090", DisplayName: "RTX 4090", StockStatus: "High"},
+		}},
+	})
+
+	all, err := client.ListGPUAvailability(t.Context(), nil)
+	if err != nil {
+		t.Fatalf("ListGPUAvailability: %v", err)
+	}
+	if len(all) != 2 || all[0].GPUTypeID != "NVIDIA GeForce RTX 4090" || all[1].GPUTypeID != "NVIDIA H100 80GB HBM3" {
+		t.Fatalf("availability = %#v", all)
+	}
+	rtx := all[0]
+	if rtx.DisplayName != "RTX 4090" || len(rtx.DataCenters) != 3 || rtx.DataCenters[2].DataCenterID != "CA-MTL-1" {
+		t.Fatalf("rtx 4090 availability = %#v", rtx)
+	}
+	if ids := rtx.AvailableDataCenterIDs(); len(ids) != 2 || ids[0] != "EU-RO-1" || ids[1] != "US-GA-1" {
+		t.Fatalf("available data centers = %v, want best stocked first", ids)
+	}
+
+	some, err := client.ListGPUAvailability(t.Context(), nil, "NVIDIA H100 80GB HBM3", "NVIDIA B200")
+	if err != nil {
+		t.Fatalf("ListGPUAvailability by ID: %v", err)
+	}
+	if len(some) != 2 || some[0].GPUTypeID != "NVIDIA H100 80GB HBM3" || some[1].GPUTypeID != "NVIDIA B200" || len(some[1].DataCenters) != 0 {
+		t.Fatalf("requested availability = %#v", some)
+	}
+}
+
+func TestSelectDataCenters(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+
+	gpu := []runpod.GPUAvailabilityInDataCenter{{GPUTypeID: "NVIDIA GeForce RTX 4090", StockStatus: "High", Available: true}}
+	srv.SetDataCenters([]runpod.DataCenter{
+		{ID: "US-GA-1", Name: "US-GA-1", Location: "United States", GPUAvailability: gpu, StorageSupport: true, GlobalNetwork: true},
+		{ID: "EU-RO-1", Name: "EU-RO-1", Location: "Europe", GPUAvailability: gpu, StorageSupport: true},
+		{ID: "EUR-IS-1", Name: "EUR-IS-1", Location: "Europe", GPUAvailability: gpu},
+		{ID: "CA-MTL-1", Name: "CA-MTL-1", Location: "Canada", GPUAvailability: []runpod.GPUAvailabilityInDataCenter{
+			{GPUTypeID: "NVIDIA GeForce RTX 4090", StockStatus: "None"},
+		}, GlobalNetwork: true},
+	})
+	ids := func(dcs []runpod.DataCenter) []string {
+		out := make([]string, len(dcs))
+		for i, dc := range dcs {
+			out[i] = dc.ID
+		}
+		return out
+	}
+
+	all, err := client.SelectDataCenters(t.Context(), nil)
+	if err != nil {
+		t.Fatalf("SelectDataCenters: %v", err)
+	}
+	if len(all) != 4 || all[1].Region != "EU" || all[1].Country != "RO" || all[2].Country != "IS" || all[0].Country != "US" {
+		t.Fatalf("data centers = %+v", all)
+	}
+
+	eu, err := client.SelectDataCenters(t.Context(), &runpod.DataCenterFilter{Regions: []string{"eu"}, NetworkVolumes: true})
+	if err != nil || !reflect.DeepEqual(ids(eu), []string{"EU-RO-1"}) {
+		t.Fatalf("EU with network volumes = %v, %v", ids(eu), err)
+	}
+	global, err := client.SelectDataCenters(t.Context(), &runpod.DataCenterFilter{GlobalNetworking: true, SecureCloud: true})
+	if err != nil || !reflect.DeepEqual(ids(global), []string{"US-GA-1"}) {
+		t.Fatalf("global networking on secure cloud = %v, %v", ids(global), err)
+	}
+	northAmerica, err := client.SelectDataCenters(t.Context(), &runpod.DataCenterFilter{Countries: []string{"US", "CA"}})
+	if err != nil || !reflect.DeepEqual(ids(northAmerica), []string{"US-GA-1", "CA-MTL-1"}) {
+		t.Fatalf("US and CA = %v, %v", ids(northAmerica), err)
+	}
+}
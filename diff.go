@@ -0,0 +1,215 @@
+package runpod
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// FieldDiff is one differing field between two resource configurations.
+// Map fields are compared per key and reported as "env.KEY".
+type FieldDiff struct {
+	Field string
+	// Old and New are the field values; nil when the field (or map key) is
+	// unset on that side.
+	Old interface{}
+	New interface{}
+}
+
+// String renders the diff as `field: old -> new`, or `field: added new` /
+// `field: removed old` for map keys and unset fields.
+func (d FieldDiff) String() string {
+	switch {
+	case d.Old == nil:
+		return fmt.Sprintf("%s: added %s", d.Field, formatDiffValue(d.New))
+	case d.New == nil:
+		return fmt.Sprintf("%s: removed %s", d.Field, formatDiffValue(d.Old))
+	default:
+		return fmt.Sprintf("%s: %s -> %s", d.Field, formatDiffValue(d.Old), formatDiffValue(d.New))
+	}
+}
+
+// Diff is a field-by-field comparison, in struct field order with map keys
+// sorted. An empty Diff means the configurations are equivalent.
+type Diff []FieldDiff
+
+// String renders one FieldDiff per line.
+func (d Diff) String() string {
+	var b strings.Builder
+	for _, fd := range d {
+		b.WriteString(fd.String())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Fields returns the distinct top-level field names that differ ("env" for
+// any "env.KEY" change), in order.
+func (d Diff) Fields() []string {
+	var fields []string
+	seen := map[string]bool{}
+	for _, fd := range d {
+		field, _, _ := strings.Cut(fd.Field, ".")
+		if !seen[field] {
+			seen[field] = true
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// DiffTemplates compares the configuration of two templates. Identity (ID)
+// is ignored, so a template can be compared against a desired state that
+// was never created.
+func DiffTemplates(a, b *Template) Diff {
+	return diffResources(a, b, "id")
+}
+
+// DiffEndpoints compares the configuration of two endpoints. Identity,
+// release and runtime fields (id, userId, version, the embedded template,
+// workers, createdAt) are ignored.
+func DiffEndpoints(a, b *Endpoint) Diff {
+	return diffResources(a, b, "id", "userId", "version", "template", "workers", "createdAt")
+}
+
+// DiffPods compares the configuration of two pods. Status, placement and
+// billing fields reported by the API (machine, runtime, cost, timestamps,
+// public IP, ...) are ignored.
+func DiffPods(a, b *Pod) Diff {
+	return diffResources(a, b, "id", "desiredStatus", "lastStatusChange", "image", "gpu",
+		"memoryInGb", "costPerHr", "machineId", "createdAt", "lastStartedAt", "adjustedCostPerHr",
+		"locked", "publicIp", "runtime", "machine", "networkVolume", "cpuFlavorId")
+}
+
+// DiffValues compares two structs of the same type field by field, naming
+// fields by their tagName struct tag ("json", "yaml"). With skipZeroNew,
+// fields left zero in b are treated as unmanaged and not compared. Used by
+// the manifest package to diff its specs with the same rules as the
+// resource diffs.
+func DiffValues(a, b interface{}, tagName string, skipZeroNew bool) Diff {
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	if av.Kind() == reflect.Pointer {
+		av, bv = av.Elem(), bv.Elem()
+	}
+	return diffStruct(av, bv, tagName, skipZeroNew, nil)
+}
+
+func diffResources[T any](a, b *T, skip ...string) Diff {
+	if a == nil {
+		a = new(T)
+	}
+	if b == nil {
+		b = new(T)
+	}
+	skipped := make(map[string]bool, len(skip))
+	for _, name := range skip {
+		skipped[name] = true
+	}
+	return diffStruct(reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem(), "json", false, skipped)
+}
+
+func diffStruct(a, b reflect.Value, tagName string, skipZeroNew bool, skip map[string]bool) Diff {
+	var diff Diff
+	t := a.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get(tagName), ",")
+		if name == "-" || skip[name] {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		av, bv := a.Field(i), b.Field(i)
+		if skipZeroNew && isEmptyValue(bv) {
+			continue
+		}
+
+		if av.Kind() == reflect.Map && av.Type().Key().Kind() == reflect.String {
+			diff = append(diff, diffMap(name, av, bv)...)
+			continue
+		}
+		if isEmptyValue(av) && isEmptyValue(bv) {
+			continue
+		}
+		if !reflect.DeepEqual(av.Interface(), bv.Interface()) {
+			diff = append(diff, FieldDiff{Field: name, Old: diffValue(av), New: diffValue(bv)})
+		}
+	}
+	return diff
+}
+
+func diffMap(name string, a, b reflect.Value) Diff {
+	keys := map[string]bool{}
+	for _, k := range a.MapKeys() {
+		keys[k.String()] = true
+	}
+	for _, k := range b.MapKeys() {
+		keys[k.String()] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	var diff Diff
+	for _, k := range sorted {
+		key := reflect.ValueOf(k).Convert(a.Type().Key())
+		av, bv := mapValue(a, key), mapValue(b, key)
+		if !reflect.DeepEqual(av, bv) {
+			diff = append(diff, FieldDiff{Field: name + "." + k, Old: av, New: bv})
+		}
+	}
+	return diff
+}
+
+func mapValue(m, key reflect.Value) interface{} {
+	if m.IsNil() {
+		return nil
+	}
+	v := m.MapIndex(key)
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// diffValue returns v's value, or nil when unset.
+func diffValue(v reflect.Value) interface{} {
+	if isEmptyValue(v) {
+		return nil
+	}
+	if v.Kind() == reflect.Pointer {
+		return v.Elem().Interface()
+	}
+	return v.Interface()
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+func formatDiffValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return fmt.Sprintf("%q", v)
+	case []string:
+		quoted := make([]string, len(v))
+		for i, s := range v {
+			quoted[i] = fmt.Sprintf("%q", s)
+		}
+		return "[" + strings.Join(quoted, ", ") + "]"
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,64 @@
+package runpod_test
+
+import (
+	"reflect"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+)
+
+func TestDiffTemplates(t *testing.T) {
+	a := &runpod.Template{
+		ID:                "tpl-1",
+		Name:              "worker",
+		ImageName:         "acme/worker:1.0.0",
+		ContainerDiskInGB: 20,
+		Env:               map[string]string{"MODEL": "sdxl", "DEBUG": "1"},
+		Ports:             []string{},
+	}
+	b := &runpod.Template{
+		ID:                "tpl-2",
+		Name:              "worker",
+		ImageName:         "acme/worker:1.1.0",
+		ContainerDiskInGB: 20,
+		Env:               map[string]string{"MODEL": "flux", "HF_TOKEN": runpod.SecretRef("hf")},
+		IsServerless:      true,
+	}
+
+	diff := runpod.DiffTemplates(a, b)
+	want := "imageName: \"acme/worker:1.0.0\" -> \"acme/worker:1.1.0\"\n" +
+		"env.DEBUG: removed \"1\"\n" +
+		"env.HF_TOKEN: added \"{{ RUNPOD_SECRET_hf }}\"\n" +
+		"env.MODEL: \"sdxl\" -> \"flux\"\n" +
+		"isServerless: added true\n"
+	if diff.String() != want {
+		t.Fatalf("diff =\n%s\nwant\n%s", diff, want)
+	}
+	if got := diff.Fields(); !reflect.DeepEqual(got, []string{"imageName", "env", "isServerless"}) {
+		t.Fatalf("Fields = %v", got)
+	}
+	if d := runpod.DiffTemplates(a, a); len(d) != 0 {
+		t.Fatalf("identical templates must not differ: %s", d)
+	}
+	if d := runpod.DiffTemplates(nil, &runpod.Template{Name: "x"}); len(d) != 1 || d[0].Field != "name" {
+		t.Fatalf("nil side must compare as empty: %s", d)
+	}
+}
+
+func TestDiffEndpointsAndPods(t *testing.T) {
+	a := &runpod.Endpoint{ID: "ep-1", Version: 3, TemplateID: "tpl-1", GPUTypeIDs: []string{"NVIDIA A40"}, WorkersMax: 3}
+	b := &runpod.Endpoint{ID: "ep-1", Version: 4, TemplateID: "tpl-2", GPUTypeIDs: []string{"NVIDIA A40", "NVIDIA L40"}, WorkersMax: 3}
+	diff := runpod.DiffEndpoints(a, b)
+	if got := diff.Fields(); !reflect.DeepEqual(got, []string{"templateId", "gpuTypeIds"}) {
+		t.Fatalf("endpoint diff fields = %v (version must be ignored)\n%s", got, diff)
+	}
+	if diff[1].String() != `gpuTypeIds: ["NVIDIA A40"] -> ["NVIDIA A40", "NVIDIA L40"]` {
+		t.Fatalf("unexpected rendering %q", diff[1].String())
+	}
+
+	p1 := &runpod.Pod{ID: "p1", ImageName: "ubuntu:22.04", DesiredStatus: "RUNNING", CostPerHour: 0.4, GPUCount: 1}
+	p2 := &runpod.Pod{ID: "p2", ImageName: "ubuntu:24.04", DesiredStatus: "EXITED", CostPerHour: 0.5, GPUCount: 1}
+	if got := runpod.DiffPods(p1, p2).Fields(); !reflect.DeepEqual(got, []string{"imageName"}) {
+		t.Fatalf("pod diff fields = %v (status and cost must be ignored)", got)
+	}
+}
@@ -0,0 +1,182 @@
+package runpod
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// DefaultEndpointHeadroom is the WorkersMax over-provisioning factor used by
+// PlanEndpoint when EndpointWorkload.Headroom is unset.
+const DefaultEndpointHeadroom = 1.5
+
+// EndpointWorkload describes the throughput an endpoint must sustain.
+type EndpointWorkload struct {
+	Name       string
+	TemplateID string
+	// JobsPerHour is the expected (peak) request rate.
+	JobsPerHour float64
+	// SecondsPerJob is the execution time of one job on any candidate GPU
+	// type. SecondsPerJobByGPU overrides it per GPU type ID; when only the
+	// map is set, candidates are limited to its keys.
+	SecondsPerJob      float64
+	SecondsPerJobByGPU map[string]float64
+
+	MinVRAMInGB         int
+	GPUCount            int // GPUs per worker, default 1
+	AllowedCudaVersions []string
+	DataCenterID        string
+	// MaxGPUTypes caps how many GPU types the endpoint may fall back to
+	// (default 3).
+	MaxGPUTypes int
+	// Headroom multiplies the busy-worker estimate for WorkersMax (default
+	// DefaultEndpointHeadroom).
+	Headroom float64
+}
+
+// EndpointGPUOption is one GPU type PlanEndpoint considered.
+type EndpointGPUOption struct {
+	GPUTypeID     string
+	SecondsPerJob float64
+	// PricePerHour is the cheapest in-stock on-demand price of one worker.
+	PricePerHour   float64
+	CostPerRequest float64
+}
+
+// EndpointPlan is PlanEndpoint's recommendation.
+type EndpointPlan struct {
+	// Options are the chosen GPU types, cheapest per request first; the
+	// request's GPUTypeIDs follow this order.
+	Options []EndpointGPUOption
+	// BusyWorkers is the average number of concurrently busy workers at
+	// JobsPerHour on the first option.
+	BusyWorkers float64
+	// CostPerRequest and PerHour price the first option.
+	CostPerRequest float64
+	PerHour        float64
+	Request        *CreateEndpointRequest
+}
+
+// PlanEndpoint recommends GPU types and worker counts for an endpoint that
+// must sustain workload, minimizing the cost per request (worker price times
+// execution time). WorkersMin covers the steady base load, which keeps busy
+// workers warm at no extra cost, and WorkersMax the busy-worker estimate
+// times Headroom. Workers are priced at the pod on-demand rate, as in
+// EstimateEndpointCost. The returned request is ready for CreateEndpoint.
+//
+//	plan, err := client.PlanEndpoint(ctx, &runpod.EndpointWorkload{
+//		TemplateID: "tpl_123", JobsPerHour: 600, SecondsPerJob: 12, MinVRAMInGB: 24,
+//	})
+//	endpoint, err := client.CreateEndpoint(ctx, plan.Request)
+func (c *Client) PlanEndpoint(ctx context.Context, workload *EndpointWorkload) (*EndpointPlan, error) {
+	if workload == nil {
+		return nil, NewValidationError("workload", "cannot be nil")
+	}
+	switch {
+	case workload.JobsPerHour <= 0:
+		return nil, NewValidationErrorWithValue("jobsPerHour", "must be positive", workload.JobsPerHour)
+	case workload.SecondsPerJob < 0:
+		return nil, NewValidationErrorWithValue("secondsPerJob", "cannot be negative", workload.SecondsPerJob)
+	case workload.SecondsPerJob == 0 && len(workload.SecondsPerJobByGPU) == 0:
+		return nil, NewValidationError("secondsPerJob", "must be set, directly or per GPU type")
+	case workload.MinVRAMInGB < 0 || workload.GPUCount < 0 || workload.MaxGPUTypes < 0 || workload.Headroom < 0:
+		return nil, NewValidationError("workload", "minVramInGb, gpuCount, maxGpuTypes and headroom cannot be negative")
+	}
+	for id, seconds := range workload.SecondsPerJobByGPU {
+		if seconds <= 0 {
+			return nil, NewValidationErrorWithValue("secondsPerJobByGpu", "must be positive for "+id, seconds)
+		}
+	}
+	count := workload.GPUCount
+	if count == 0 {
+		count = 1
+	}
+	maxTypes := workload.MaxGPUTypes
+	if maxTypes == 0 {
+		maxTypes = 3
+	}
+	headroom := workload.Headroom
+	if headroom == 0 {
+		headroom = DefaultEndpointHeadroom
+	}
+
+	offers, err := c.ListGPUOffers(ctx, &GPUOfferFilter{
+		GPUCount:            count,
+		AllowedCudaVersions: workload.AllowedCudaVersions,
+		DataCenterID:        workload.DataCenterID,
+		InStockOnly:         true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byType := map[string]*EndpointGPUOption{}
+	for _, offer := range offers {
+		if offer.MemoryInGB < workload.MinVRAMInGB || offer.OnDemandPrice <= 0 {
+			continue
+		}
+		seconds, ok := workload.SecondsPerJobByGPU[offer.GPUTypeID]
+		if !ok {
+			seconds = workload.SecondsPerJob
+		}
+		if seconds <= 0 {
+			continue
+		}
+		if option := byType[offer.GPUTypeID]; option == nil || offer.OnDemandPrice < option.PricePerHour {
+			byType[offer.GPUTypeID] = &EndpointGPUOption{
+				GPUTypeID:      offer.GPUTypeID,
+				SecondsPerJob:  seconds,
+				PricePerHour:   offer.OnDemandPrice,
+				CostPerRequest: roundCost(offer.OnDemandPrice * seconds / 3600),
+			}
+		}
+	}
+	if len(byType) == 0 {
+		return nil, fmt.Errorf("%w: no in-stock GPU type fits the workload", ErrNoCapacity)
+	}
+
+	options := make([]EndpointGPUOption, 0, len(byType))
+	for _, option := range byType {
+		options = append(options, *option)
+	}
+	sort.Slice(options, func(i, j int) bool {
+		if options[i].CostPerRequest != options[j].CostPerRequest {
+			return options[i].CostPerRequest < options[j].CostPerRequest
+		}
+		return options[i].GPUTypeID < options[j].GPUTypeID
+	})
+	if len(options) > maxTypes {
+		options = options[:maxTypes]
+	}
+
+	best := options[0]
+	busy := workload.JobsPerHour * best.SecondsPerJob / 3600
+	workersMin := int(math.Floor(busy))
+	workersMax := max(int(math.Ceil(busy*headroom)), workersMin+1)
+
+	ids := make([]string, len(options))
+	for i, option := range options {
+		ids[i] = option.GPUTypeID
+	}
+	req := &CreateEndpointRequest{
+		Name:                strings.TrimSpace(workload.Name),
+		TemplateID:          strings.TrimSpace(workload.TemplateID),
+		GPUTypeIDs:          ids,
+		GPUCount:            count,
+		AllowedCudaVersions: workload.AllowedCudaVersions,
+		WorkersMin:          workersMin,
+		WorkersMax:          workersMax,
+	}
+	if workload.DataCenterID != "" {
+		req.DataCenterIDs = []string{workload.DataCenterID}
+	}
+	return &EndpointPlan{
+		Options:        options,
+		BusyWorkers:    roundCost(busy),
+		CostPerRequest: best.CostPerRequest,
+		PerHour:        roundCost(best.CostPerRequest * workload.JobsPerHour),
+		Request:        req,
+	}, nil
+}
@@ -0,0 +1,61 @@
+package runpod_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestPlanEndpoint(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+	srv.SetGPUTypes([]runpod.GPUType{
+		{ID: "NVIDIA RTX A5000", MemoryInGB: 24, SecureCloud: true,
+			LowestPrice: &runpod.Price{UninterruptablePrice: 0.36, StockStatus: "High"}},
+		{ID: "NVIDIA GeForce RTX 4090", MemoryInGB: 24, SecureCloud: true,
+			LowestPrice: &runpod.Price{UninterruptablePrice: 0.72, StockStatus: "Medium"}},
+		{ID: "NVIDIA L40S", MemoryInGB: 48, SecureCloud: true,
+			LowestPrice: &runpod.Price{UninterruptablePrice: 0.90, StockStatus: "High"}},
+		{ID: "NVIDIA H100 80GB HBM3", MemoryInGB: 80, SecureCloud: true,
+			LowestPrice: &runpod.Price{UninterruptablePrice: 2.70, StockStatus: "None"}},
+	})
+	ctx := t.Context()
+
+	// The 4090 is twice the A5000's price but three times as fast.
+	plan, err := client.PlanEndpoint(ctx, &runpod.EndpointWorkload{
+		TemplateID:         "tpl",
+		JobsPerHour:        900,
+		SecondsPerJobByGPU: map[string]float64{"NVIDIA RTX A5000": 30, "NVIDIA GeForce RTX 4090": 10, "NVIDIA H100 80GB HBM3": 2},
+		MinVRAMInGB:        24,
+	})
+	if err != nil {
+		t.Fatalf("PlanEndpoint: %v", err)
+	}
+	req := plan.Request
+	if !reflect.DeepEqual(req.GPUTypeIDs, []string{"NVIDIA GeForce RTX 4090", "NVIDIA RTX A5000"}) || req.TemplateID != "tpl" || req.GPUCount != 1 {
+		t.Fatalf("request = %+v", req)
+	}
+	// 900 jobs/h at 10s keeps 2.5 workers busy.
+	if plan.BusyWorkers != 2.5 || req.WorkersMin != 2 || req.WorkersMax != 4 || plan.CostPerRequest != 0.002 || plan.PerHour != 1.8 {
+		t.Fatalf("plan = %+v, request = %+v", plan, req)
+	}
+
+	plan, err = client.PlanEndpoint(ctx, &runpod.EndpointWorkload{TemplateID: "tpl", JobsPerHour: 10, SecondsPerJob: 60, MinVRAMInGB: 40, MaxGPUTypes: 1})
+	if err != nil || !reflect.DeepEqual(plan.Request.GPUTypeIDs, []string{"NVIDIA L40S"}) || plan.Request.WorkersMin != 0 || plan.Request.WorkersMax != 1 {
+		t.Fatalf("low-traffic plan = %+v, %v", plan, err)
+	}
+
+	if _, err := client.PlanEndpoint(ctx, &runpod.EndpointWorkload{JobsPerHour: 10, SecondsPerJob: 5, MinVRAMInGB: 80}); !errors.Is(err, runpod.ErrNoCapacity) {
+		t.Fatalf("expected ErrNoCapacity, got %v", err)
+	}
+	var validationErr *runpod.ValidationError
+	for _, w := range []*runpod.EndpointWorkload{nil, {SecondsPerJob: 1}, {JobsPerHour: 1}, {JobsPerHour: 1, SecondsPerJobByGPU: map[string]float64{"x": 0}}} {
+		if _, err := client.PlanEndpoint(ctx, w); !errors.As(err, &validationErr) {
+			t.Errorf("workload %+v: expected validation error, got %v", w, err)
+		}
+	}
+}
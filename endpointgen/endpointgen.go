@@ -0,0 +1,381 @@
+// Package endpointgen generates a typed Go client for a serverless
+// endpoint from its worker's input and output JSON Schemas: an input
+// struct, an output type, and Run, RunSync and Result methods wrapping
+// runpod.Client. cmd/endpointgen runs it from go:generate.
+package endpointgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/cozy-creator/runpod-go-sdk/jsonschema"
+)
+
+// Options configures Generate.
+type Options struct {
+	// Package is the generated file's package name.
+	Package string
+	// Name prefixes the generated types: Name+"Input", Name+"Output" and
+	// Name+"Client". It should be an exported Go identifier.
+	Name string
+	// Input is the worker's input schema; required.
+	Input *jsonschema.Schema
+	// Output is the worker's output schema. Without it the output type is
+	// json.RawMessage.
+	Output *jsonschema.Schema
+	// Source names the schemas in the generated file's header.
+	Source string
+}
+
+// Generate returns the formatted Go source of the client.
+func Generate(opts Options) ([]byte, error) {
+	if opts.Package == "" || opts.Name == "" || opts.Input == nil {
+		return nil, errors.New("endpointgen: Package, Name and Input are required")
+	}
+	if !isExported(opts.Name) {
+		return nil, fmt.Errorf("endpointgen: Name %q is not an exported Go identifier", opts.Name)
+	}
+
+	g := &generator{prefix: opts.Name, names: map[string]bool{}, refs: map[*jsonschema.Schema]string{}}
+	input, output := opts.Name+"Input", opts.Name+"Output"
+	if err := g.root(input, "input", opts.Input); err != nil {
+		return nil, err
+	}
+	if opts.Output == nil {
+		opts.Output = &jsonschema.Schema{}
+	}
+	if err := g.root(output, "output", opts.Output); err != nil {
+		return nil, err
+	}
+
+	var src bytes.Buffer
+	header := "Code generated by endpointgen; DO NOT EDIT."
+	if opts.Source != "" {
+		header = "Code generated by endpointgen from " + opts.Source + "; DO NOT EDIT."
+	}
+	fmt.Fprintf(&src, "// %s\n\npackage %s\n\n", header, opts.Package)
+	src.WriteString("import (\n\t\"context\"\n\t\"encoding/json\"\n\t\"fmt\"\n\n\trunpod \"github.com/cozy-creator/runpod-go-sdk\"\n)\n\n")
+	for _, decl := range g.decls {
+		src.Write(decl.Bytes())
+	}
+	fmt.Fprintf(&src, clientTemplate, opts.Name)
+
+	out, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("endpointgen: generated invalid Go: %w\n%s", err, src.Bytes())
+	}
+	return out, nil
+}
+
+// clientTemplate is formatted with the type prefix as %[1]s.
+const clientTemplate = `// %[1]sClient runs jobs on an endpoint serving this worker.
+type %[1]sClient struct {
+	Client     *runpod.Client
+	EndpointID string
+}
+
+// New%[1]sClient returns a client for the endpoint.
+func New%[1]sClient(client *runpod.Client, endpointID string) *%[1]sClient {
+	return &%[1]sClient{Client: client, EndpointID: endpointID}
+}
+
+// Run submits a job without waiting for it. Pass the job to Result once
+// it has finished.
+func (c *%[1]sClient) Run(ctx context.Context, input *%[1]sInput) (*runpod.Job, error) {
+	return c.Client.RunAsync(ctx, c.EndpointID, input)
+}
+
+// RunSync runs a job and returns its output. It is bounded by the
+// runpod.Client's HTTP timeout; see runpod.Client.RunSync.
+func (c *%[1]sClient) RunSync(ctx context.Context, input *%[1]sInput) (*%[1]sOutput, error) {
+	job, err := c.Client.RunSync(ctx, c.EndpointID, input)
+	if err != nil {
+		return nil, err
+	}
+	return c.Result(ctx, job)
+}
+
+// Result decodes a finished job's output, fetching it first if it was too
+// large to return inline. A job that did not complete is an error.
+func (c *%[1]sClient) Result(ctx context.Context, job *runpod.Job) (*%[1]sOutput, error) {
+	if job.Status != string(runpod.JobStatusCompleted) {
+		return nil, fmt.Errorf("job %%s is %%s: %%s", job.ID, job.Status, job.Error)
+	}
+	raw, err := c.Client.FetchFullOutput(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	var out %[1]sOutput
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, fmt.Errorf("failed to decode output of job %%s: %%w", job.ID, err)
+	}
+	return &out, nil
+}
+`
+
+type generator struct {
+	prefix string
+	decls  []*bytes.Buffer
+	names  map[string]bool
+	// refs names the $defs schemas already generated.
+	refs map[*jsonschema.Schema]string
+	// current is the root schema $refs resolve against.
+	current *jsonschema.Schema
+}
+
+// root generates the named type for a top-level schema; what is "input"
+// or "output".
+func (g *generator) root(name, what string, s *jsonschema.Schema) error {
+	g.current = s
+	g.names[name] = true
+	about := "the job " + what
+	if s.Ref != "" {
+		_, def, err := s.Resolve(s)
+		if err != nil {
+			return fmt.Errorf("%s schema: %w", what, err)
+		}
+		s = def
+	}
+	if isStruct(s) || isEnum(s) {
+		_, err := g.named(name, about, s)
+		return err
+	}
+	decl := g.decl()
+	typ, err := g.goType(name, about, s)
+	if err != nil {
+		return fmt.Errorf("%s schema: %w", what, err)
+	}
+	if typ == "any" {
+		typ = "json.RawMessage"
+	}
+	writeDoc(decl, s, name, about)
+	fmt.Fprintf(decl, "type %s = %s\n\n", name, typ)
+	return nil
+}
+
+// goType returns the Go type for s, generating named types as needed.
+// name is the type name to use if s needs one, and about describes it for
+// the type's doc comment.
+func (g *generator) goType(name, about string, s *jsonschema.Schema) (string, error) {
+	if s.Ref != "" {
+		defName, def, err := s.Resolve(g.current)
+		if err != nil {
+			return "", err
+		}
+		if typ, ok := g.refs[def]; ok {
+			return typ, nil
+		}
+		about := "the schema's " + defName + " definition"
+		if !isStruct(def) && !isEnum(def) {
+			return g.goType(g.prefix+goName(defName), about, def)
+		}
+		// Record the name first so that recursive definitions terminate.
+		typ := g.unique(g.prefix + goName(defName))
+		g.refs[def] = typ
+		return g.named(typ, about, def)
+	}
+	if isStruct(s) || isEnum(s) {
+		return g.named(g.unique(name), about, s)
+	}
+
+	switch primary(s.Type) {
+	case "string":
+		return "string", nil
+	case "integer":
+		return "int64", nil
+	case "number":
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	case "array":
+		if s.Items == nil {
+			return "[]any", nil
+		}
+		elem, err := g.goType(name+"Item", "an element of "+about, s.Items)
+		return "[]" + elem, err
+	case "object":
+		if s.AdditionalProperties == nil || s.AdditionalProperties.Never {
+			return "map[string]any", nil
+		}
+		elem, err := g.goType(name+"Value", "a value of "+about, s.AdditionalProperties)
+		return "map[string]" + elem, err
+	}
+	return "any", nil
+}
+
+// named generates a struct or string enum type called name, which the
+// caller has reserved. Its declaration precedes those of its field types.
+func (g *generator) named(name, about string, s *jsonschema.Schema) (string, error) {
+	decl := g.decl()
+	writeDoc(decl, s, name, about)
+	if isEnum(s) {
+		fmt.Fprintf(decl, "type %s string\n\nconst (\n", name)
+		for _, v := range s.Enum {
+			value := v.(string)
+			fmt.Fprintf(decl, "\t%s %s = %s\n", g.unique(name+goName(value)), name, strconv.Quote(value))
+		}
+		decl.WriteString(")\n\n")
+		return name, nil
+	}
+
+	fmt.Fprintf(decl, "type %s struct {\n", name)
+	props := make([]string, 0, len(s.Properties))
+	for prop := range s.Properties {
+		props = append(props, prop)
+	}
+	sort.Strings(props)
+	for _, prop := range props {
+		ps := s.Properties[prop]
+		field := goName(prop)
+		typ, err := g.goType(name+field, "the "+prop+" field of "+name, ps)
+		if err != nil {
+			return "", fmt.Errorf("%s.%s: %w", name, prop, err)
+		}
+		tag := prop
+		required := s.IsRequired(prop)
+		if (!required || ps.Type.Has("null")) && !isReference(typ) {
+			typ = "*" + typ
+		}
+		if !required {
+			tag += ",omitempty"
+		}
+		var def string
+		if ps.Default != nil {
+			b, _ := json.Marshal(ps.Default)
+			def = "Default: " + string(b) + "."
+		}
+		writeComment(decl, "\t", firstNonEmpty(ps.Description, ps.Title), def)
+		fmt.Fprintf(decl, "\t%s %s `json:%q`\n", field, typ, tag)
+	}
+	decl.WriteString("}\n\n")
+	return name, nil
+}
+
+// decl starts the next top-level declaration.
+func (g *generator) decl() *bytes.Buffer {
+	b := new(bytes.Buffer)
+	g.decls = append(g.decls, b)
+	return b
+}
+
+// writeDoc writes a type's doc comment: the schema's description, or a
+// sentence saying what the type is.
+func writeDoc(buf *bytes.Buffer, s *jsonschema.Schema, name, about string) {
+	text := firstNonEmpty(s.Description, s.Title)
+	if text == "" {
+		text = name + " is " + about + "."
+	}
+	writeComment(buf, "", text)
+}
+
+// unique returns name, suffixed with a number if it is taken.
+func (g *generator) unique(name string) string {
+	candidate := name
+	for i := 2; g.names[candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	g.names[candidate] = true
+	return candidate
+}
+
+func writeComment(buf *bytes.Buffer, indent string, parts ...string) {
+	for _, part := range parts {
+		for _, line := range strings.Split(strings.TrimSpace(part), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				fmt.Fprintf(buf, "%s// %s\n", indent, line)
+			}
+		}
+	}
+}
+
+func isStruct(s *jsonschema.Schema) bool {
+	return primary(s.Type) == "object" && len(s.Properties) > 0
+}
+
+// isEnum reports whether s is a string enum.
+func isEnum(s *jsonschema.Schema) bool {
+	if len(s.Enum) == 0 || (len(s.Type) > 0 && primary(s.Type) != "string") {
+		return false
+	}
+	for _, v := range s.Enum {
+		if _, ok := v.(string); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// isReference reports whether typ already has a nil value, so an optional
+// field of that type needs no pointer.
+func isReference(typ string) bool {
+	return typ == "any" || strings.HasPrefix(typ, "[]") || strings.HasPrefix(typ, "map[")
+}
+
+// primary returns the non-null type of a possibly nullable type list.
+func primary(types jsonschema.Types) string {
+	for _, t := range types {
+		if t != "null" {
+			return t
+		}
+	}
+	return ""
+}
+
+// commonInitialisms are written in upper case in Go names.
+var commonInitialisms = map[string]bool{
+	"API": true, "CPU": true, "GPU": true, "HTTP": true, "ID": true, "JSON": true,
+	"RAM": true, "URI": true, "URL": true, "UUID": true, "VAE": true,
+}
+
+// goName converts a JSON name such as "image_url" or "num-steps" to an
+// exported Go name ("ImageURL", "NumSteps").
+func goName(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+	var b strings.Builder
+	for _, w := range words {
+		// Split camelCase words at case changes.
+		start := 0
+		runes := []rune(w)
+		for i := 1; i <= len(runes); i++ {
+			if i == len(runes) || (unicode.IsUpper(runes[i]) && unicode.IsLower(runes[i-1])) {
+				part := string(runes[start:i])
+				if upper := strings.ToUpper(part); commonInitialisms[upper] {
+					b.WriteString(upper)
+				} else {
+					b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+				}
+				start = i
+			}
+		}
+	}
+	name := b.String()
+	if name == "" || !unicode.IsLetter([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
+
+func isExported(name string) bool {
+	for i, r := range name {
+		if !(unicode.IsLetter(r) || r == '_' || (i > 0 && unicode.IsDigit(r))) {
+			return false
+		}
+	}
+	return name != "" && unicode.IsUpper([]rune(name)[0])
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
+}
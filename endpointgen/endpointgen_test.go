@@ -0,0 +1,85 @@
+package endpointgen_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cozy-creator/runpod-go-sdk/endpointgen"
+	"github.com/cozy-creator/runpod-go-sdk/jsonschema"
+)
+
+// TestGenerateExample regenerates the client in internal/sdxl, which its
+// own tests compile and run against runpodtest.
+func TestGenerateExample(t *testing.T) {
+	input, err := jsonschema.ReadFile("internal/sdxl/input.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	output, err := jsonschema.ReadFile("internal/sdxl/output.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := endpointgen.Generate(endpointgen.Options{
+		Package: "sdxl",
+		Name:    "SDXL",
+		Input:   input,
+		Output:  output,
+		Source:  "input.schema.json and output.schema.json",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile("internal/sdxl/sdxl_client.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Fatalf("internal/sdxl/sdxl_client.go is stale; run go generate ./endpointgen/...\n%s", got)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	schema, err := jsonschema.Parse([]byte(`{
+		"type": "array",
+		"items": {"$ref": "#/definitions/node"},
+		"definitions": {
+			"node": {
+				"type": "object",
+				"required": ["id"],
+				"properties": {
+					"id": {"type": "string"},
+					"2x": {"type": "boolean"},
+					"children": {"type": "array", "items": {"$ref": "#/definitions/node"}}
+				}
+			}
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := endpointgen.Generate(endpointgen.Options{Package: "tree", Name: "Tree", Input: schema})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"type TreeInput = []TreeNode",
+		"type TreeOutput = json.RawMessage",
+		"Children []TreeNode `json:\"children,omitempty\"`",
+		"ID       string     `json:\"id\"`",
+		"X2x      *bool      `json:\"2x,omitempty\"`",
+		"func (c *TreeClient) RunSync(ctx context.Context, input *TreeInput) (*TreeOutput, error)",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Fatalf("generated source missing %q:\n%s", want, src)
+		}
+	}
+
+	if _, err := endpointgen.Generate(endpointgen.Options{Package: "tree", Name: "tree", Input: schema}); err == nil {
+		t.Fatal("unexported Name accepted")
+	}
+	bad := &jsonschema.Schema{Ref: "#/$defs/missing"}
+	if _, err := endpointgen.Generate(endpointgen.Options{Package: "tree", Name: "Tree", Input: bad}); err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Fatalf("unresolved $ref: %v", err)
+	}
+}
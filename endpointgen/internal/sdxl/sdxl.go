@@ -0,0 +1,5 @@
+// Package sdxl is an example endpointgen client, generated from the
+// schemas beside it. endpointgen's tests check that it is up to date.
+package sdxl
+
+//go:generate go run ../../../cmd/endpointgen -name SDXL -input input.schema.json -output output.schema.json
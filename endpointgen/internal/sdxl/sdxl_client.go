@@ -0,0 +1,95 @@
+// Code generated by endpointgen from input.schema.json and output.schema.json; DO NOT EDIT.
+
+package sdxl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+)
+
+// SDXLInput is the job input.
+type SDXLInput struct {
+	Extra    map[string]float64 `json:"extra,omitempty"`
+	ImageURL *string            `json:"image_url,omitempty"`
+	Loras    []SDXLLora         `json:"loras,omitempty"`
+	Meta     any                `json:"meta,omitempty"`
+	// Default: 30.
+	NumInferenceSteps *int64 `json:"num_inference_steps,omitempty"`
+	// What to draw.
+	Prompt    string              `json:"prompt"`
+	Scheduler *SDXLInputScheduler `json:"scheduler,omitempty"`
+	Size      *SDXLInputSize      `json:"size,omitempty"`
+}
+
+// SDXLLora is the schema's lora definition.
+type SDXLLora struct {
+	Name   string   `json:"name"`
+	Weight *float64 `json:"weight,omitempty"`
+}
+
+// SDXLInputScheduler is the scheduler field of SDXLInput.
+type SDXLInputScheduler string
+
+const (
+	SDXLInputSchedulerEuler SDXLInputScheduler = "euler"
+	SDXLInputSchedulerDpm2m SDXLInputScheduler = "dpm++ 2m"
+)
+
+// SDXLInputSize is the size field of SDXLInput.
+type SDXLInputSize struct {
+	Height int64 `json:"height"`
+	Width  int64 `json:"width"`
+}
+
+// SDXLOutput is the job output.
+type SDXLOutput struct {
+	Images []string `json:"images,omitempty"`
+	Seed   *int64   `json:"seed,omitempty"`
+}
+
+// SDXLClient runs jobs on an endpoint serving this worker.
+type SDXLClient struct {
+	Client     *runpod.Client
+	EndpointID string
+}
+
+// NewSDXLClient returns a client for the endpoint.
+func NewSDXLClient(client *runpod.Client, endpointID string) *SDXLClient {
+	return &SDXLClient{Client: client, EndpointID: endpointID}
+}
+
+// Run submits a job without waiting for it. Pass the job to Result once
+// it has finished.
+func (c *SDXLClient) Run(ctx context.Context, input *SDXLInput) (*runpod.Job, error) {
+	return c.Client.RunAsync(ctx, c.EndpointID, input)
+}
+
+// RunSync runs a job and returns its output. It is bounded by the
+// runpod.Client's HTTP timeout; see runpod.Client.RunSync.
+func (c *SDXLClient) RunSync(ctx context.Context, input *SDXLInput) (*SDXLOutput, error) {
+	job, err := c.Client.RunSync(ctx, c.EndpointID, input)
+	if err != nil {
+		return nil, err
+	}
+	return c.Result(ctx, job)
+}
+
+// Result decodes a finished job's output, fetching it first if it was too
+// large to return inline. A job that did not complete is an error.
+func (c *SDXLClient) Result(ctx context.Context, job *runpod.Job) (*SDXLOutput, error) {
+	if job.Status != string(runpod.JobStatusCompleted) {
+		return nil, fmt.Errorf("job %s is %s: %s", job.ID, job.Status, job.Error)
+	}
+	raw, err := c.Client.FetchFullOutput(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	var out SDXLOutput
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, fmt.Errorf("failed to decode output of job %s: %w", job.ID, err)
+	}
+	return &out, nil
+}
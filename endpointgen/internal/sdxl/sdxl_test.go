@@ -0,0 +1,54 @@
+package sdxl_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cozy-creator/runpod-go-sdk/endpointgen/internal/sdxl"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestClient(t *testing.T) {
+	srv := runpodtest.New()
+	t.Cleanup(srv.Close)
+	rp := srv.MustClient()
+	client := sdxl.NewSDXLClient(rp, "ep-1")
+	ctx := t.Context()
+
+	steps := int64(20)
+	scheduler := sdxl.SDXLInputSchedulerEuler
+	job, err := client.Run(ctx, &sdxl.SDXLInput{
+		Prompt:            "a lighthouse",
+		NumInferenceSteps: &steps,
+		Scheduler:         &scheduler,
+		Loras:             []sdxl.SDXLLora{{Name: "film"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sent map[string]any
+	if err := json.Unmarshal(job.Input, &sent); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := sent["image_url"]; ok || sent["scheduler"] != "euler" || sent["num_inference_steps"] != 20.0 {
+		t.Fatalf("input = %v", sent)
+	}
+
+	if _, err := client.Result(ctx, job); err == nil {
+		t.Fatal("Result of an unfinished job succeeded")
+	}
+	if err := srv.CompleteJob("ep-1", job.ID, map[string]any{"images": []string{"https://x/1.png"}, "seed": 7}); err != nil {
+		t.Fatal(err)
+	}
+	job, err = rp.GetJobStatus(ctx, "ep-1", job.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := client.Result(ctx, job)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Images) != 1 || out.Seed == nil || *out.Seed != 7 {
+		t.Fatalf("output = %+v", out)
+	}
+}
@@ -0,0 +1,398 @@
+package runpod
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Endpoint is a serverless endpoint (REST /endpoints). Jobs are submitted to
+// it by ID through RunAsync / RunSync.
+type Endpoint struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	UserID     string    `json:"userId,omitempty"`
+	TemplateID string    `json:"templateId"`
+	Template   *Template `json:"template,omitempty"`
+	// Version increments on every release (endpoint or template update);
+	// workers of older versions are rolled out.
+	Version int `json:"version"`
+
+	ComputeType         ComputeType `json:"computeType,omitempty"`
+	GPUTypeIDs          []string    `json:"gpuTypeIds,omitempty"`
+	GPUCount            int         `json:"gpuCount,omitempty"`
+	CPUFlavorIDs        []string    `json:"cpuFlavorIds,omitempty"`
+	VCPUCount           int         `json:"vcpuCount,omitempty"`
+	AllowedCudaVersions []string    `json:"allowedCudaVersions,omitempty"`
+	DataCenterIDs       []string    `json:"dataCenterIds,omitempty"`
+	NetworkVolumeID     string      `json:"networkVolumeId,omitempty"`
+
+	WorkersMin int `json:"workersMin"`
+	WorkersMax int `json:"workersMax"`
+	// ScalerType is ScalerTypeQueueDelay (scale on seconds of queue wait)
+	// or ScalerTypeRequestCount (scale on queued requests per worker);
+	// ScalerValue is the threshold.
+	ScalerType  ScalerType `json:"scalerType,omitempty"`
+	ScalerValue int        `json:"scalerValue,omitempty"`
+	// IdleTimeout is seconds an idle worker is kept before scale-down.
+	IdleTimeout int `json:"idleTimeout,omitempty"`
+	// ExecutionTimeout fails a job that runs longer.
+	ExecutionTimeout DurationMS `json:"executionTimeoutMs,omitempty"`
+	Flashboot        bool       `json:"flashboot,omitempty"`
+
+	Env       map[string]string `json:"env,omitempty"`
+	Workers   []*Pod            `json:"workers,omitempty"`
+	CreatedAt *JSONTime         `json:"createdAt,omitempty"`
+
+	raw string // see Raw
+}
+
+// CreateEndpointRequest configures endpoint creation (REST POST /endpoints).
+type CreateEndpointRequest struct {
+	Name       string `json:"name,omitempty"`
+	TemplateID string `json:"templateId"`
+
+	// GPU placement (ComputeType="GPU" or empty). Unlike pod creation, an
+	// endpoint does walk GPUTypeIDs in order when scaling up workers.
+	ComputeType         ComputeType `json:"computeType,omitempty"`
+	GPUTypeIDs          []string    `json:"gpuTypeIds,omitempty"`
+	GPUCount            int         `json:"gpuCount,omitempty"`
+	CPUFlavorIDs        []string    `json:"cpuFlavorIds,omitempty"`
+	VCPUCount           int         `json:"vcpuCount,omitempty"`
+	AllowedCudaVersions []string    `json:"allowedCudaVersions,omitempty"`
+	DataCenterIDs       []string    `json:"dataCenterIds,omitempty"`
+	NetworkVolumeID     string      `json:"networkVolumeId,omitempty"`
+
+	WorkersMin       int        `json:"workersMin,omitempty"`
+	WorkersMax       int        `json:"workersMax,omitempty"`
+	ScalerType       ScalerType `json:"scalerType,omitempty"`
+	ScalerValue      int        `json:"scalerValue,omitempty"`
+	IdleTimeout      int        `json:"idleTimeout,omitempty"`
+	ExecutionTimeout DurationMS `json:"executionTimeoutMs,omitempty"`
+	Flashboot        bool       `json:"flashboot,omitempty"`
+}
+
+// UpdateEndpointRequest updates an endpoint (REST PATCH /endpoints/{id}).
+// Nil fields are left unchanged and set fields are sent even when zero, so
+// WorkersMin: runpod.Ptr(0) scales an endpoint to zero. A non-nil empty
+// slice clears the list. Any update is a new release: the endpoint's
+// Version increments and existing workers are rolled.
+type UpdateEndpointRequest struct {
+	Name                *string     `json:"name,omitempty"`
+	TemplateID          *string     `json:"templateId,omitempty"`
+	GPUTypeIDs          []string    `json:"gpuTypeIds,omitzero"`
+	GPUCount            *int        `json:"gpuCount,omitempty"`
+	CPUFlavorIDs        []string    `json:"cpuFlavorIds,omitzero"`
+	VCPUCount           *int        `json:"vcpuCount,omitempty"`
+	AllowedCudaVersions []string    `json:"allowedCudaVersions,omitzero"`
+	DataCenterIDs       []string    `json:"dataCenterIds,omitzero"`
+	NetworkVolumeID     *string     `json:"networkVolumeId,omitempty"`
+	WorkersMin          *int        `json:"workersMin,omitempty"`
+	WorkersMax          *int        `json:"workersMax,omitempty"`
+	ScalerType          *ScalerType `json:"scalerType,omitempty"`
+	ScalerValue         *int        `json:"scalerValue,omitempty"`
+	IdleTimeout         *int        `json:"idleTimeout,omitempty"`
+	ExecutionTimeout    *DurationMS `json:"executionTimeoutMs,omitempty"`
+	Flashboot           *bool       `json:"flashboot,omitempty"`
+}
+
+// GetEndpointOptions toggles the include* query parameters on endpoint reads.
+type GetEndpointOptions struct {
+	IncludeTemplate bool
+	IncludeWorkers  bool
+}
+
+// ListEndpoints lists the account's serverless endpoints.
+func (c *Client) ListEndpoints(ctx context.Context, opts *GetEndpointOptions) ([]Endpoint, error) {
+	// Accept both bare array and object wrapper shapes.
+	var raw json.RawMessage
+	if err := c.Get(ctx, c.buildURLWithParams("/endpoints", endpointIncludeParams(opts)), &raw); err != nil {
+		return nil, fmt.Errorf("failed to list endpoints: %w", err)
+	}
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	if err := c.checkListShape(raw, []Endpoint(nil)); err != nil {
+		return nil, fmt.Errorf("failed to list endpoints: %w", err)
+	}
+
+	var endpoints []Endpoint
+	if err := json.Unmarshal(raw, &endpoints); err == nil {
+		return endpoints, nil
+	}
+
+	var wrapped struct {
+		Endpoints []Endpoint `json:"endpoints"`
+	}
+	if err := json.Unmarshal(raw, &wrapped); err == nil && wrapped.Endpoints != nil {
+		return wrapped.Endpoints, nil
+	}
+
+	return nil, fmt.Errorf("failed to list endpoints: unexpected response shape")
+}
+
+// GetEndpoint retrieves an endpoint by ID.
+func (c *Client) GetEndpoint(ctx context.Context, endpointID string, opts *GetEndpointOptions) (*Endpoint, error) {
+	if err := c.validateRequired("endpointID", endpointID); err != nil {
+		return nil, err
+	}
+
+	key := resourceKey{kind: "endpoint", id: endpointID}
+	if opts != nil {
+		key.opts = *opts
+	}
+	var endpoint Endpoint
+	if err := c.cachedGet(ctx, key, c.buildURLWithParams("/endpoints/"+endpointID, endpointIncludeParams(opts)), &endpoint); err != nil {
+		return nil, fmt.Errorf("failed to get endpoint %s: %w", endpointID, err)
+	}
+	return &endpoint, nil
+}
+
+// CreateEndpoint creates a serverless endpoint from a serverless template.
+func (c *Client) CreateEndpoint(ctx context.Context, req *CreateEndpointRequest) (*Endpoint, error) {
+	if req == nil {
+		return nil, NewValidationError("request", "cannot be nil")
+	}
+	if err := c.validateRequest(req); err != nil {
+		return nil, err
+	}
+	if c.imageCheck != nil {
+		tpl, err := c.GetTemplate(ctx, req.TemplateID)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.checkImage(ctx, "template.imageName", tpl.ImageName); err != nil {
+			return nil, err
+		}
+	}
+
+	var endpoint Endpoint
+	if err := c.Post(ctx, "/endpoints", req, &endpoint); err != nil {
+		return nil, fmt.Errorf("failed to create endpoint: %w", err)
+	}
+	return &endpoint, nil
+}
+
+// GetOrCreateEndpointOptions configures GetOrCreateEndpointWithOptions.
+type GetOrCreateEndpointOptions struct {
+	// Verify compares an existing endpoint with the request and returns an
+	// *EndpointMismatchError when they differ. Fields left zero in the
+	// request are not compared.
+	Verify bool
+}
+
+// GetOrCreateEndpoint returns the endpoint named req.Name, creating it from
+// req when there is none. RunPod does not keep endpoint names unique, so
+// deploys that call CreateEndpoint each run leave duplicate endpoints
+// behind. When several endpoints already share the name it returns a
+// *DuplicateEndpointError instead of picking one. Two concurrent calls can
+// still both create; serialize deploys of the same name.
+func (c *Client) GetOrCreateEndpoint(ctx context.Context, req *CreateEndpointRequest) (*Endpoint, error) {
+	return c.GetOrCreateEndpointWithOptions(ctx, req, nil)
+}
+
+// GetOrCreateEndpointWithOptions is GetOrCreateEndpoint with options.
+func (c *Client) GetOrCreateEndpointWithOptions(ctx context.Context, req *CreateEndpointRequest, opts *GetOrCreateEndpointOptions) (*Endpoint, error) {
+	if req == nil {
+		return nil, NewValidationError("request", "cannot be nil")
+	}
+	if err := c.validateRequired("name", req.Name); err != nil {
+		return nil, err
+	}
+	if err := c.validateRequest(req); err != nil {
+		return nil, err
+	}
+
+	endpoints, err := c.ListEndpoints(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	var matches []Endpoint
+	for _, endpoint := range endpoints {
+		if endpoint.Name == req.Name {
+			matches = append(matches, endpoint)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return c.CreateEndpoint(ctx, req)
+	case 1:
+	default:
+		ids := make([]string, len(matches))
+		for i, endpoint := range matches {
+			ids[i] = endpoint.ID
+		}
+		return nil, &DuplicateEndpointError{Name: req.Name, EndpointIDs: ids}
+	}
+
+	found := matches[0]
+	if opts != nil && opts.Verify {
+		diff, err := endpointRequestDiff(&found, req)
+		if err != nil {
+			return nil, err
+		}
+		if len(diff) > 0 {
+			return nil, &EndpointMismatchError{EndpointID: found.ID, Diff: diff}
+		}
+	}
+	return &found, nil
+}
+
+// endpointRequestDiff compares an endpoint's configuration with the fields
+// set in req. The request's json tags match the endpoint's, so the
+// endpoint is read back as a request.
+func endpointRequestDiff(endpoint *Endpoint, req *CreateEndpointRequest) (Diff, error) {
+	data, err := json.Marshal(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare endpoint %s: %w", endpoint.ID, err)
+	}
+	var current CreateEndpointRequest
+	if err := json.Unmarshal(data, &current); err != nil {
+		return nil, fmt.Errorf("failed to compare endpoint %s: %w", endpoint.ID, err)
+	}
+	return DiffValues(&current, req, "json", true), nil
+}
+
+// UpdateEndpoint updates an endpoint's configuration.
+func (c *Client) UpdateEndpoint(ctx context.Context, endpointID string, req *UpdateEndpointRequest) (*Endpoint, error) {
+	if err := c.validateRequired("endpointID", endpointID); err != nil {
+		return nil, err
+	}
+	if req == nil {
+		return nil, NewValidationError("request", "cannot be nil")
+	}
+	if err := c.validateRequest(req); err != nil {
+		return nil, err
+	}
+
+	if c.resources != nil {
+		defer c.resources.InvalidateEndpoint(endpointID)
+	}
+	var endpoint Endpoint
+	if err := c.Patch(ctx, "/endpoints/"+endpointID, req, &endpoint); err != nil {
+		return nil, fmt.Errorf("failed to update endpoint %s: %w", endpointID, err)
+	}
+	return &endpoint, nil
+}
+
+// DeleteEndpoint deletes an endpoint by ID. Running workers are terminated.
+func (c *Client) DeleteEndpoint(ctx context.Context, endpointID string) error {
+	if err := c.validateRequired("endpointID", endpointID); err != nil {
+		return err
+	}
+	if c.resources != nil {
+		defer c.resources.InvalidateEndpoint(endpointID)
+	}
+	if err := c.Delete(ctx, "/endpoints/"+endpointID); err != nil {
+		return fmt.Errorf("failed to delete endpoint %s: %w", endpointID, err)
+	}
+	return nil
+}
+
+// RefreshEndpointWorkers starts a new release of an endpoint without changing
+// its configuration, so replacement workers re-resolve env values such as
+// secret references. It re-submits the endpoint's current template binding;
+// RunPod treats any endpoint update as a release, bumps Version, and rolls
+// workers as they go idle — in-flight jobs finish on the old workers.
+func (c *Client) RefreshEndpointWorkers(ctx context.Context, endpointID string) (*Endpoint, error) {
+	endpoint, err := c.GetEndpoint(ctx, endpointID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(endpoint.TemplateID) == "" {
+		return nil, fmt.Errorf("failed to refresh endpoint %s: response omitted templateId", endpointID)
+	}
+	return c.UpdateEndpoint(ctx, endpointID, &UpdateEndpointRequest{TemplateID: &endpoint.TemplateID})
+}
+
+// Validate checks the request. It returns a *ValidationError, or a
+// *ValidationErrors when it finds more than one problem.
+func (req *CreateEndpointRequest) Validate() error {
+	if req == nil {
+		return nilRequest()
+	}
+	var p problems
+	p.add(validateRequired("templateId", req.TemplateID))
+
+	p.add(validateEnum("computeType", req.ComputeType, computeTypes))
+	isCPU := req.ComputeType.isCPU()
+	switch {
+	case isCPU && len(req.GPUTypeIDs) > 0:
+		p.add(NewValidationError("gpuTypeIds", "must not be set when computeType is CPU"))
+	case !isCPU && len(req.CPUFlavorIDs) > 0:
+		p.add(NewValidationError("cpuFlavorIds", "must not be set unless computeType is CPU"))
+	case !isCPU && len(req.GPUTypeIDs) == 0:
+		p.add(NewValidationError("gpuTypeIds", "cannot be empty"))
+	}
+
+	validateEndpointSizing(&p, &req.GPUCount, &req.VCPUCount, &req.WorkersMin, &req.WorkersMax, &req.ScalerValue, &req.IdleTimeout, &req.ExecutionTimeout)
+	p.add(validateScalerType(req.ScalerType))
+	validateCudaVersions(&p, "allowedCudaVersions", req.AllowedCudaVersions...)
+	return p.err()
+}
+
+// Validate checks the request. It returns a *ValidationError, or a
+// *ValidationErrors when it finds more than one problem.
+func (req *UpdateEndpointRequest) Validate() error {
+	if req == nil {
+		return nilRequest()
+	}
+	var p problems
+	if req.TemplateID != nil && strings.TrimSpace(*req.TemplateID) == "" {
+		p.add(NewValidationError("templateId", "cannot be empty"))
+	}
+	validateEndpointSizing(&p, req.GPUCount, req.VCPUCount, req.WorkersMin, req.WorkersMax, req.ScalerValue, req.IdleTimeout, req.ExecutionTimeout)
+	if req.ScalerType != nil {
+		if *req.ScalerType == "" {
+			p.add(NewValidationError("scalerType", "cannot be empty"))
+		}
+		p.add(validateScalerType(*req.ScalerType))
+	}
+	validateCudaVersions(&p, "allowedCudaVersions", req.AllowedCudaVersions...)
+	return p.err()
+}
+
+// validateEndpointSizing checks the numeric endpoint fields shared by
+// create and update requests; nil fields are not set.
+func validateEndpointSizing(p *problems, gpuCount, vcpuCount, workersMin, workersMax, scalerValue, idleTimeout *int, executionTimeout *DurationMS) {
+	for _, f := range []struct {
+		name  string
+		value *int
+	}{
+		{"gpuCount", gpuCount},
+		{"vcpuCount", vcpuCount},
+		{"workersMin", workersMin},
+		{"workersMax", workersMax},
+		{"scalerValue", scalerValue},
+		{"idleTimeout", idleTimeout},
+	} {
+		if f.value != nil && *f.value < 0 {
+			p.add(NewValidationErrorWithValue(f.name, "cannot be negative", *f.value))
+		}
+	}
+	if workersMin != nil && workersMax != nil && *workersMax > 0 && *workersMin > *workersMax {
+		p.add(NewValidationErrorWithValue("workersMin", "cannot exceed workersMax", *workersMin))
+	}
+	if executionTimeout != nil && *executionTimeout < 0 {
+		p.add(NewValidationErrorWithValue("executionTimeoutMs", "cannot be negative", executionTimeout.Duration()))
+	}
+}
+
+func validateScalerType(scalerType ScalerType) error {
+	return validateEnum("scalerType", scalerType, scalerTypes)
+}
+
+func endpointIncludeParams(opts *GetEndpointOptions) map[string]string {
+	if opts == nil {
+		return nil
+	}
+	params := map[string]string{}
+	if opts.IncludeTemplate {
+		params["includeTemplate"] = "true"
+	}
+	if opts.IncludeWorkers {
+		params["includeWorkers"] = "true"
+	}
+	return params
+}
@@ -0,0 +1,157 @@
+package runpod_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestEndpointCRUD(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+	ctx := context.Background()
+
+	srv.AddTemplate(&runpod.Template{ID: "tpl1", Name: "worker", ImageName: "acme/worker:1", IsServerless: true})
+
+	created, err := client.CreateEndpoint(ctx, &runpod.CreateEndpointRequest{
+		Name:        "sdxl",
+		TemplateID:  "tpl1",
+		GPUTypeIDs:  []string{"NVIDIA GeForce RTX 4090"},
+		WorkersMax:  3,
+		ScalerType:  "QUEUE_DELAY",
+		ScalerValue: 4,
+
+		ExecutionTimeout: runpod.DurationMS(10 * time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if created.ComputeType != "GPU" || created.Version != 1 || created.ExecutionTimeout.Duration() != 10*time.Minute {
+		t.Fatalf("unexpected create result %+v", created)
+	}
+
+	got, err := client.GetEndpoint(ctx, created.ID, &runpod.GetEndpointOptions{IncludeTemplate: true})
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got.Template == nil || got.Template.ImageName != "acme/worker:1" {
+		t.Fatalf("includeTemplate not honoured: %+v", got)
+	}
+
+	updated, err := client.UpdateEndpoint(ctx, created.ID, &runpod.UpdateEndpointRequest{WorkersMin: runpod.Ptr(1), WorkersMax: runpod.Ptr(5)})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if updated.WorkersMin != 1 || updated.WorkersMax != 5 || updated.ScalerValue != 4 || updated.Version != 2 {
+		t.Fatalf("unexpected update result %+v", updated)
+	}
+
+	// A set zero value is sent, so an endpoint can scale back to zero.
+	updated, err = client.UpdateEndpoint(ctx, created.ID, &runpod.UpdateEndpointRequest{WorkersMin: runpod.Ptr(0), DataCenterIDs: []string{}})
+	if err != nil {
+		t.Fatalf("update to zero: %v", err)
+	}
+	if updated.WorkersMin != 0 || updated.WorkersMax != 5 || updated.Version != 3 {
+		t.Fatalf("unexpected update result %+v", updated)
+	}
+
+	refreshed, err := client.RefreshEndpointWorkers(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if refreshed.Version != 4 || refreshed.TemplateID != "tpl1" {
+		t.Fatalf("refresh must start a release without changing config: %+v", refreshed)
+	}
+
+	endpoints, err := client.ListEndpoints(ctx, nil)
+	if err != nil || len(endpoints) != 1 {
+		t.Fatalf("list = %+v, %v", endpoints, err)
+	}
+
+	if err := client.DeleteEndpoint(ctx, created.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := client.GetEndpoint(ctx, created.ID, nil); !errors.Is(err, runpod.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestGetOrCreateEndpoint(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+	ctx := t.Context()
+
+	srv.AddTemplate(&runpod.Template{ID: "tpl1", Name: "worker", ImageName: "acme/worker:1", IsServerless: true})
+	req := &runpod.CreateEndpointRequest{Name: "sdxl", TemplateID: "tpl1", GPUTypeIDs: []string{"NVIDIA A40"}, WorkersMax: 3}
+
+	created, err := client.GetOrCreateEndpoint(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := client.GetOrCreateEndpointWithOptions(ctx, req, &runpod.GetOrCreateEndpointOptions{Verify: true})
+	if err != nil || again.ID != created.ID {
+		t.Fatalf("second call = %+v, %v; want endpoint %s", again, err, created.ID)
+	}
+	if endpoints, _ := client.ListEndpoints(ctx, nil); len(endpoints) != 1 {
+		t.Fatalf("endpoints = %+v", endpoints)
+	}
+
+	changed := *req
+	changed.WorkersMax = 5
+	if found, err := client.GetOrCreateEndpoint(ctx, &changed); err != nil || found.ID != created.ID {
+		t.Fatalf("unverified call = %+v, %v", found, err)
+	}
+	var mismatch *runpod.EndpointMismatchError
+	_, err = client.GetOrCreateEndpointWithOptions(ctx, &changed, &runpod.GetOrCreateEndpointOptions{Verify: true})
+	if !errors.As(err, &mismatch) || mismatch.EndpointID != created.ID || mismatch.Diff.String() != "workersMax: 3 -> 5\n" {
+		t.Fatalf("verified call with a changed request = %v", err)
+	}
+
+	if _, err := client.CreateEndpoint(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	var duplicate *runpod.DuplicateEndpointError
+	if _, err := client.GetOrCreateEndpoint(ctx, req); !errors.As(err, &duplicate) || len(duplicate.EndpointIDs) != 2 {
+		t.Fatalf("call with duplicates = %v", err)
+	}
+
+	var validationErr *runpod.ValidationError
+	if _, err := client.GetOrCreateEndpoint(ctx, &runpod.CreateEndpointRequest{TemplateID: "tpl1", GPUTypeIDs: []string{"NVIDIA A40"}}); !errors.As(err, &validationErr) {
+		t.Fatalf("call without a name = %v", err)
+	}
+}
+
+func TestEndpointValidation(t *testing.T) {
+	client := mustClient(t, "test_key")
+	ctx := context.Background()
+
+	invalid := []*runpod.CreateEndpointRequest{
+		nil,
+		{GPUTypeIDs: []string{"x"}},
+		{TemplateID: "tpl"},
+		{TemplateID: "tpl", ComputeType: "TPU"},
+		{TemplateID: "tpl", ComputeType: "CPU", GPUTypeIDs: []string{"x"}},
+		{TemplateID: "tpl", GPUTypeIDs: []string{"x"}, WorkersMin: 3, WorkersMax: 1},
+		{TemplateID: "tpl", GPUTypeIDs: []string{"x"}, ScalerType: "CPU_LOAD"},
+	}
+	for i, req := range invalid {
+		if _, err := client.CreateEndpoint(ctx, req); err == nil {
+			t.Errorf("case %d: expected validation error for %+v", i, req)
+		}
+	}
+	if _, err := client.UpdateEndpoint(ctx, "", &runpod.UpdateEndpointRequest{}); err == nil {
+		t.Error("empty endpointID must fail validation")
+	}
+	if _, err := client.UpdateEndpoint(ctx, "ep", &runpod.UpdateEndpointRequest{WorkersMax: runpod.Ptr(-1)}); err == nil {
+		t.Error("negative workersMax must fail validation")
+	}
+	if _, err := client.UpdateEndpoint(ctx, "ep", &runpod.UpdateEndpointRequest{WorkersMin: runpod.Ptr(3), WorkersMax: runpod.Ptr(1)}); err == nil {
+		t.Error("workersMin above workersMax must fail validation")
+	}
+}
@@ -0,0 +1,140 @@
+package runpod
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+// CloudType is the RunPod cloud a pod or GPU offer runs on.
+type CloudType string
+
+const (
+	CloudTypeSecure    CloudType = "SECURE"
+	CloudTypeCommunity CloudType = "COMMUNITY"
+)
+
+// ComputeType selects GPU or CPU workers for pods and endpoints.
+type ComputeType string
+
+const (
+	ComputeTypeGPU ComputeType = "GPU"
+	ComputeTypeCPU ComputeType = "CPU"
+)
+
+// ScalerType is how an endpoint decides to add workers: on seconds of
+// queue wait or on queued requests per worker; ScalerValue is the
+// threshold.
+type ScalerType string
+
+const (
+	ScalerTypeQueueDelay   ScalerType = "QUEUE_DELAY"
+	ScalerTypeRequestCount ScalerType = "REQUEST_COUNT"
+)
+
+// GPUTypePriority is how RunPod picks among a pod's GPUTypeIDs: by current
+// availability, or in the order given.
+type GPUTypePriority string
+
+const (
+	GPUTypePriorityAvailability GPUTypePriority = "availability"
+	GPUTypePriorityCustom       GPUTypePriority = "custom"
+)
+
+// DataCenterPriority is how RunPod picks among a pod's DataCenterIDs: by
+// current availability, or in the order given.
+type DataCenterPriority string
+
+const (
+	DataCenterPriorityAvailability DataCenterPriority = "availability"
+	DataCenterPriorityCustom       DataCenterPriority = "custom"
+)
+
+// Valid reports whether c is a known cloud type; empty is not.
+func (c CloudType) Valid() bool { return slices.Contains(cloudTypes, c) }
+
+// Valid reports whether c is a known compute type; empty is not.
+func (c ComputeType) Valid() bool { return slices.Contains(computeTypes, c) }
+
+// Valid reports whether s is a known scaler type; empty is not.
+func (s ScalerType) Valid() bool { return slices.Contains(scalerTypes, s) }
+
+// Valid reports whether p is a known GPU type priority; empty is not.
+func (p GPUTypePriority) Valid() bool { return slices.Contains(gpuTypePriorities, p) }
+
+// Valid reports whether p is a known data center priority; empty is not.
+func (p DataCenterPriority) Valid() bool { return slices.Contains(dataCenterPriorities, p) }
+
+var (
+	cloudTypes           = []CloudType{CloudTypeSecure, CloudTypeCommunity}
+	computeTypes         = []ComputeType{ComputeTypeGPU, ComputeTypeCPU}
+	scalerTypes          = []ScalerType{ScalerTypeQueueDelay, ScalerTypeRequestCount}
+	gpuTypePriorities    = []GPUTypePriority{GPUTypePriorityAvailability, GPUTypePriorityCustom}
+	dataCenterPriorities = []DataCenterPriority{DataCenterPriorityAvailability, DataCenterPriorityCustom}
+)
+
+// MarshalJSON rejects unknown enum values so that a typo fails before the
+// request is sent. Only requests are checked: models decoded from responses
+// encode whatever the API sent.
+func (r CreatePodRequest) MarshalJSON() ([]byte, error) {
+	type plain CreatePodRequest
+	return marshalChecked(plain(r),
+		validateEnum("cloudType", r.CloudType, cloudTypes),
+		validateEnum("computeType", r.ComputeType, computeTypes),
+		validateEnum("gpuTypePriority", r.GPUTypePriority, gpuTypePriorities),
+		validateEnum("dataCenterPriority", r.DataCenterPriority, dataCenterPriorities))
+}
+
+// MarshalJSON rejects unknown enum values; see CreatePodRequest.MarshalJSON.
+func (r CreateEndpointRequest) MarshalJSON() ([]byte, error) {
+	type plain CreateEndpointRequest
+	return marshalChecked(plain(r),
+		validateEnum("computeType", r.ComputeType, computeTypes),
+		validateEnum("scalerType", r.ScalerType, scalerTypes))
+}
+
+// MarshalJSON rejects unknown enum values; see CreatePodRequest.MarshalJSON.
+func (r UpdateEndpointRequest) MarshalJSON() ([]byte, error) {
+	type plain UpdateEndpointRequest
+	var scalerErr error
+	if r.ScalerType != nil {
+		scalerErr = validateEnum("scalerType", *r.ScalerType, scalerTypes)
+	}
+	return marshalChecked(plain(r), scalerErr)
+}
+
+// validateEnum checks an optional enum field: empty passes.
+func validateEnum[T ~string](field string, value T, valid []T) error {
+	if value == "" || slices.Contains(valid, value) {
+		return nil
+	}
+	quoted := make([]string, len(valid))
+	for i, v := range valid {
+		quoted[i] = "'" + string(v) + "'"
+	}
+	msg := "must be one of " + strings.Join(quoted, ", ")
+	if len(valid) == 2 {
+		msg = "must be either " + quoted[0] + " or " + quoted[1]
+	}
+	return NewValidationErrorWithValue(field, msg, string(value))
+}
+
+// marshalChecked encodes v unless one of the enum checks failed.
+func marshalChecked(v any, checks ...error) ([]byte, error) {
+	for _, err := range checks {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(v)
+}
+
+// normalize trims and upper-cases c, tolerating user input like "secure".
+func (c CloudType) normalize() CloudType {
+	return CloudType(strings.ToUpper(strings.TrimSpace(string(c))))
+}
+
+// isCPU reports whether c selects CPU workers, ignoring case.
+func (c ComputeType) isCPU() bool {
+	return strings.EqualFold(strings.TrimSpace(string(c)), string(ComputeTypeCPU))
+}
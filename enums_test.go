@@ -0,0 +1,70 @@
+package runpod_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestEnumsMarshal(t *testing.T) {
+	data, err := json.Marshal(&runpod.CreatePodRequest{
+		Name:               "p",
+		CloudType:          runpod.CloudTypeSecure,
+		ComputeType:        runpod.ComputeTypeGPU,
+		GPUTypePriority:    runpod.GPUTypePriorityCustom,
+		DataCenterPriority: runpod.DataCenterPriorityAvailability,
+	})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var fields map[string]any
+	_ = json.Unmarshal(data, &fields)
+	if fields["cloudType"] != "SECURE" || fields["gpuTypePriority"] != "custom" || fields["dataCenterPriority"] != "availability" {
+		t.Fatalf("marshalled = %s", data)
+	}
+	if data, err := json.Marshal(&runpod.CreateEndpointRequest{TemplateID: "tpl"}); err != nil || string(data) != `{"templateId":"tpl"}` {
+		t.Fatalf("empty enums = %s, %v", data, err)
+	}
+
+	var validationErr *runpod.ValidationError
+	for _, v := range []any{
+		&runpod.CreatePodRequest{CloudType: "secure"},
+		&runpod.CreatePodRequest{GPUTypePriority: "cheapest"},
+		&runpod.CreateEndpointRequest{ScalerType: "CPU_LOAD"},
+		&runpod.UpdateEndpointRequest{ScalerType: runpod.Ptr[runpod.ScalerType]("queue_delay")},
+	} {
+		if _, err := json.Marshal(v); !errors.As(err, &validationErr) {
+			t.Fatalf("Marshal(%+v) = %v, want a validation error", v, err)
+		}
+	}
+	// Models decoded from responses encode whatever the API sent.
+	for _, v := range []any{
+		&runpod.Endpoint{ID: "e1", ComputeType: "gpu", ScalerType: "CPU_LOAD"},
+		runpod.GPUOffer{CloudType: "ALL"},
+		runpod.CostEstimate{CloudType: "secure"},
+	} {
+		if _, err := json.Marshal(v); err != nil {
+			t.Fatalf("Marshal(%+v) = %v", v, err)
+		}
+	}
+	if runpod.ComputeType("TPU").Valid() || !runpod.ScalerTypeRequestCount.Valid() {
+		t.Fatal("Valid")
+	}
+}
+
+func TestCreatePodRejectsUnknownPriority(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	_, err := srv.MustClient().CreatePod(context.Background(), &runpod.CreatePodRequest{
+		Name: "p", ImageName: "img", GPUTypeIDs: []string{"NVIDIA GeForce RTX 4090"}, GPUCount: 1, ContainerDiskInGB: 20,
+		DataCenterPriority: "nearest",
+	})
+	var validationErr *runpod.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "dataCenterPriority" {
+		t.Fatalf("CreatePod = %v", err)
+	}
+}
@@ -0,0 +1,124 @@
+package runpod
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/joho/godotenv"
+)
+
+// EnvFileOptions tunes LoadEnvFile / ParseEnv.
+type EnvFileOptions struct {
+	// Prefix keeps only keys starting with Prefix.
+	Prefix string
+	// TrimPrefix strips Prefix from the kept keys, so POD_MODEL=x becomes
+	// MODEL=x with Prefix "POD_".
+	TrimPrefix bool
+	// Filter, when set, keeps only keys it returns true for. It sees keys
+	// after prefix trimming.
+	Filter func(key string) bool
+}
+
+// LoadEnvFile parses a .env file into a map suitable for
+// CreatePodRequest.Env / CreateTemplateRequest.Env. See ParseEnv for the
+// accepted syntax.
+func LoadEnvFile(path string, opts *EnvFileOptions) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open env file: %w", err)
+	}
+	defer f.Close()
+
+	return parseEnv(f, path, opts)
+}
+
+// ParseEnv parses .env syntax with github.com/joho/godotenv:
+//
+//	# comment
+//	export KEY=value           # "export " is optional; trailing comments are stripped
+//	QUOTED="line\nbreak $KEY"  # double quotes: escapes and interpolation
+//	LITERAL='no $interp here'  # single quotes: taken verbatim
+//	URL=${HOST}:8080
+//
+// $VAR and ${VAR} interpolate variables defined earlier in the file;
+// anything else expands to "". The process environment is not read. Use \$
+// for a literal dollar sign. Prefix and Filter apply after interpolation,
+// so filtered-out keys can still be referenced.
+//
+// Shell forms godotenv does not expand, such as ${VAR:-default}, are an
+// error rather than being copied into the value half-expanded; quote them
+// in single quotes to keep them literally. So is a line that is not a
+// KEY=value assignment.
+func ParseEnv(r io.Reader, opts *EnvFileOptions) (map[string]string, error) {
+	return parseEnv(r, "env", opts)
+}
+
+func parseEnv(r io.Reader, name string, opts *EnvFileOptions) (map[string]string, error) {
+	if opts == nil {
+		opts = &EnvFileOptions{}
+	}
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	if err := checkEnvExpansions(src, name); err != nil {
+		return nil, err
+	}
+	parsed, err := godotenv.UnmarshalBytes(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	// godotenv files text it cannot attach to a key, such as a line without
+	// "=" or words after a closing quote, under the empty key instead of
+	// failing.
+	if stray, ok := parsed[""]; ok {
+		return nil, fmt.Errorf("failed to parse %s: %q is not a KEY=value assignment", name, stray)
+	}
+
+	env := make(map[string]string, len(parsed))
+	for key, value := range parsed {
+		if opts.Prefix != "" {
+			if !strings.HasPrefix(key, opts.Prefix) {
+				continue
+			}
+			if opts.TrimPrefix {
+				key = strings.TrimPrefix(key, opts.Prefix)
+				if key == "" {
+					continue
+				}
+			}
+		}
+		if opts.Filter != nil && !opts.Filter(key) {
+			continue
+		}
+		env[key] = value
+	}
+	return env, nil
+}
+
+// unsupportedExpansion matches a ${...} reference that is not a plain
+// variable name, unless its dollar sign is escaped.
+var unsupportedExpansion = regexp.MustCompile(`(?:^|[^\\])(\$\{[A-Za-z0-9_]*[^A-Za-z0-9_}])`)
+
+// checkEnvExpansions rejects ${VAR:-default} and similar references outside
+// single-quoted values; godotenv would expand the name and keep the rest
+// of the reference as text.
+func checkEnvExpansions(src []byte, name string) error {
+	for i, line := range bytes.Split(src, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+		if _, value, ok := bytes.Cut(line, []byte("=")); ok && bytes.HasPrefix(bytes.TrimSpace(value), []byte("'")) {
+			continue
+		}
+		if m := unsupportedExpansion.FindSubmatch(line); m != nil {
+			return fmt.Errorf("failed to parse %s: line %d: unsupported expansion %q; only $VAR and ${VAR} are expanded", name, i+1, m[1])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package runpod_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+)
+
+func TestParseEnv(t *testing.T) {
+	t.Setenv("RUNPOD_TEST_HOST", "db.internal")
+
+	// Interpolation reads the file alone, not the process environment.
+	src := `
+# worker settings
+export MODEL=sdxl
+WORKER_MODEL=${MODEL}-turbo   # trailing comment
+WORKER_DB_URL=postgres://${RUNPOD_TEST_HOST}:5432
+WORKER_GREETING="hello\n$MODEL \$5"
+WORKER_LITERAL='no $MODEL here # kept'
+WORKER_PORT=8080
+WORKER_EMPTY=
+WORKER_HF_TOKEN={{ RUNPOD_SECRET_hf }}
+`
+	env, err := runpod.ParseEnv(strings.NewReader(src), nil)
+	if err != nil {
+		t.Fatalf("ParseEnv: %v", err)
+	}
+	want := map[string]string{
+		"MODEL":           "sdxl",
+		"WORKER_MODEL":    "sdxl-turbo",
+		"WORKER_DB_URL":   "postgres://:5432",
+		"WORKER_GREETING": "hello\nsdxl $5",
+		"WORKER_LITERAL":  "no $MODEL here # kept",
+		"WORKER_PORT":     "8080",
+		"WORKER_EMPTY":    "",
+		"WORKER_HF_TOKEN": "{{ RUNPOD_SECRET_hf }}",
+	}
+	if !reflect.DeepEqual(env, want) {
+		t.Fatalf("env = %#v\nwant %#v", env, want)
+	}
+
+	env, err = runpod.ParseEnv(strings.NewReader(src), &runpod.EnvFileOptions{
+		Prefix:     "WORKER_",
+		TrimPrefix: true,
+		Filter:     func(key string) bool { return key != "EMPTY" },
+	})
+	if err != nil {
+		t.Fatalf("ParseEnv with options: %v", err)
+	}
+	if env["MODEL"] != "sdxl-turbo" || env["DB_URL"] != "postgres://:5432" {
+		t.Fatalf("prefixed env = %#v", env)
+	}
+	if _, ok := env["EMPTY"]; ok {
+		t.Fatalf("filtered key kept: %#v", env)
+	}
+	if len(env) != 6 {
+		t.Fatalf("len(env) = %d, want 6: %#v", len(env), env)
+	}
+
+	for _, bad := range []string{"NOVALUE", "A;B=x", `A="open`, "A='open", `A="x" y`} {
+		if _, err := runpod.ParseEnv(strings.NewReader(bad), nil); err == nil {
+			t.Errorf("ParseEnv(%q) succeeded, want error", bad)
+		}
+	}
+	if _, err := runpod.ParseEnv(strings.NewReader(`A="x" y`), nil); err == nil || !strings.Contains(err.Error(), `"y"`) {
+		t.Errorf("stray text not reported: %v", err)
+	}
+
+	// ${VAR:-default} is not expanded by godotenv; it is rejected rather
+	// than loaded half-expanded, unless it is quoted or escaped.
+	for _, bad := range []string{"URL=${HOST:-localhost}:8080", `URL="http://${HOST:=x}"`} {
+		if _, err := runpod.ParseEnv(strings.NewReader("A=1\n"+bad), nil); err == nil || !strings.Contains(err.Error(), "line 2") {
+			t.Errorf("ParseEnv(%q) = %v, want an unsupported expansion error on line 2", bad, err)
+		}
+	}
+	env, err = runpod.ParseEnv(strings.NewReader("A='${HOST:-localhost}'\nB=\\${HOST:-x}\n"), nil)
+	if err != nil || env["A"] != "${HOST:-localhost}" {
+		t.Fatalf("literal expansions = %#v, %v", env, err)
+	}
+}
+
+func TestLoadEnvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte("A=1\nB=${A}2\nbroken line\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, err := runpod.LoadEnvFile(path, nil)
+	if err == nil || !strings.Contains(err.Error(), path) || !strings.Contains(err.Error(), "broken line") {
+		t.Fatalf("err = %v, want the file and the bad line reported", err)
+	}
+
+	if err := os.WriteFile(path, []byte("A=1\nB=${A}2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	env, err := runpod.LoadEnvFile(path, nil)
+	if err != nil {
+		t.Fatalf("LoadEnvFile: %v", err)
+	}
+	if !reflect.DeepEqual(env, map[string]string{"A": "1", "B": "12"}) {
+		t.Fatalf("env = %#v", env)
+	}
+
+	if _, err := runpod.LoadEnvFile(filepath.Join(t.TempDir(), "missing"), nil); err == nil {
+		t.Fatal("missing file: want error")
+	}
+}
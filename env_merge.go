@@ -0,0 +1,74 @@
+package runpod
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Conventional EnvLayer names, lowest precedence first.
+const (
+	EnvLayerTemplate = "template"
+	EnvLayerEndpoint = "endpoint"
+	EnvLayerPod      = "pod"
+	EnvLayerRun      = "run"
+)
+
+// EnvLayer is one named source of environment variables in a MergeEnv call.
+type EnvLayer struct {
+	Name string
+	Env  map[string]string
+}
+
+// EnvConflict records a key whose value from a lower-precedence layer was
+// replaced by a different value from a higher one.
+type EnvConflict struct {
+	Key             string
+	Layer           string // layer whose value won
+	Value           string
+	OverriddenLayer string
+	OverriddenValue string
+}
+
+// String renders the conflict as `KEY: run "b" overrides template "a"`.
+func (c EnvConflict) String() string {
+	return fmt.Sprintf("%s: %s %q overrides %s %q", c.Key, c.Layer, c.Value, c.OverriddenLayer, c.OverriddenValue)
+}
+
+// MergeEnv merges env layers in order, later layers taking precedence:
+//
+//	env, conflicts := runpod.MergeEnv(
+//		runpod.EnvLayer{Name: runpod.EnvLayerTemplate, Env: tpl.Env},
+//		runpod.EnvLayer{Name: runpod.EnvLayerEndpoint, Env: endpointEnv},
+//		runpod.EnvLayer{Name: runpod.EnvLayerRun, Env: runEnv},
+//	)
+//
+// Every override of a different value is reported as an EnvConflict, sorted
+// by key and then precedence; re-setting the same value is not a conflict.
+// Empty values are values, not removals. The result is nil when every layer
+// is empty, and never aliases a layer's map.
+func MergeEnv(layers ...EnvLayer) (map[string]string, []EnvConflict) {
+	var merged map[string]string
+	source := map[string]string{}
+	var conflicts []EnvConflict
+	for _, layer := range layers {
+		for key, value := range layer.Env {
+			if merged == nil {
+				merged = map[string]string{}
+			}
+			if old, ok := merged[key]; ok && old != value {
+				conflicts = append(conflicts, EnvConflict{
+					Key:             key,
+					Layer:           layer.Name,
+					Value:           value,
+					OverriddenLayer: source[key],
+					OverriddenValue: old,
+				})
+			}
+			merged[key] = value
+			source[key] = layer.Name
+		}
+	}
+	// Stable: conflicts for one key stay in precedence order.
+	sort.SliceStable(conflicts, func(i, j int) bool { return conflicts[i].Key < conflicts[j].Key })
+	return merged, conflicts
+}
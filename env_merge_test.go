@@ -0,0 +1,42 @@
+package runpod_test
+
+import (
+	"reflect"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+)
+
+func TestMergeEnv(t *testing.T) {
+	template := map[string]string{"MODEL": "sdxl", "LOG_LEVEL": "info", "PORT": "8000"}
+	endpoint := map[string]string{"MODEL": "flux", "PORT": "8000"}
+	run := map[string]string{"MODEL": "flux-dev", "DEBUG": ""}
+
+	env, conflicts := runpod.MergeEnv(
+		runpod.EnvLayer{Name: runpod.EnvLayerTemplate, Env: template},
+		runpod.EnvLayer{Name: runpod.EnvLayerEndpoint, Env: endpoint},
+		runpod.EnvLayer{Name: runpod.EnvLayerRun, Env: run},
+	)
+	want := map[string]string{"MODEL": "flux-dev", "LOG_LEVEL": "info", "PORT": "8000", "DEBUG": ""}
+	if !reflect.DeepEqual(env, want) {
+		t.Fatalf("env = %v, want %v", env, want)
+	}
+	wantConflicts := []runpod.EnvConflict{
+		{Key: "MODEL", Layer: "endpoint", Value: "flux", OverriddenLayer: "template", OverriddenValue: "sdxl"},
+		{Key: "MODEL", Layer: "run", Value: "flux-dev", OverriddenLayer: "endpoint", OverriddenValue: "flux"},
+	}
+	if !reflect.DeepEqual(conflicts, wantConflicts) {
+		t.Fatalf("conflicts = %v", conflicts)
+	}
+	if got := conflicts[0].String(); got != `MODEL: endpoint "flux" overrides template "sdxl"` {
+		t.Fatalf("String = %q", got)
+	}
+
+	env["MODEL"] = "changed"
+	if template["MODEL"] != "sdxl" || run["MODEL"] != "flux-dev" {
+		t.Fatal("MergeEnv result aliases a layer")
+	}
+	if env, conflicts := runpod.MergeEnv(runpod.EnvLayer{Name: "a"}, runpod.EnvLayer{Name: "b", Env: map[string]string{}}); env != nil || conflicts != nil {
+		t.Fatalf("empty layers = %v, %v", env, conflicts)
+	}
+}
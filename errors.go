@@ -1,8 +1,13 @@
 package runpod
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
 	"time"
 )
 
@@ -19,6 +24,11 @@ var (
 	// signals stock-outs as HTTP 500 with a "no instances available" style
 	// message; the SDK classifies those into *NoCapacityError.
 	ErrNoCapacity = errors.New("runpod: no capacity")
+	// ErrGateway matches *GatewayError: a non-JSON response, such as a
+	// Cloudflare 52x page, from the proxy in front of the API.
+	ErrGateway = errors.New("runpod: gateway error")
+	// ErrClientClosed is returned by calls made after Client.Close.
+	ErrClientClosed = errors.New("runpod: client closed")
 )
 
 // APIError is an error response from the RunPod API. It matches ErrNotFound,
@@ -59,6 +69,75 @@ func (e *APIError) IsServerError() bool {
 	return e.StatusCode >= 500 && e.StatusCode < 600
 }
 
+// Retryable reports whether the same request could succeed later: true for
+// 408, 429 and 5xx (Cloudflare's 520-527 included), false for other 4xx.
+func (e *APIError) Retryable() bool {
+	return e.StatusCode == 408 || e.StatusCode == 429 || e.IsServerError()
+}
+
+// GatewayError is a response that is not JSON where the API would have
+// sent JSON: typically an HTML error page from Cloudflare (520 unknown
+// error, 522 connection timed out, 524 timeout) or another proxy between
+// the client and RunPod. errors.Is(err, ErrGateway) is true.
+type GatewayError struct {
+	StatusCode int
+	// Method is the HTTP method of the request that got the page.
+	Method      string
+	ContentType string
+	// Excerpt is the start of the body as text, with HTML markup removed,
+	// e.g. "rest.runpod.io | 524: A timeout occurred".
+	Excerpt string
+}
+
+func (e *GatewayError) Error() string {
+	if e.Excerpt == "" {
+		return fmt.Sprintf("RunPod gateway error %d: non-JSON response", e.StatusCode)
+	}
+	return fmt.Sprintf("RunPod gateway error %d: %s", e.StatusCode, e.Excerpt)
+}
+
+func (e *GatewayError) Is(target error) bool { return target == ErrGateway }
+
+// Retryable reports whether resending the request is safe and could
+// succeed. Cloudflare's 521-523 mean RunPod was never reached, so they are
+// retryable for any method. Other 5xx pages are transient, but a POST may
+// still have been processed, so they are retryable only for other methods,
+// as in makeRequest. A non-JSON 2xx is not retryable: the request went
+// through.
+func (e *GatewayError) Retryable() bool {
+	switch {
+	case e.StatusCode >= 521 && e.StatusCode <= 523:
+		return true
+	case e.StatusCode < 500:
+		return false
+	default:
+		return e.Method != http.MethodPost
+	}
+}
+
+// IsRetryable reports whether err is a transient failure worth retrying,
+// for callers running their own retry loops. An error with a Retryable()
+// bool method (*APIError, *GatewayError, *NoCapacityError) decides for
+// itself; network errors and dropped connections are retryable, including
+// timeouts, so a caller retrying under its own context should check that
+// it still has time. Validation errors, cancellation and anything
+// unrecognized are not.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var r interface{ Retryable() bool }
+	if errors.As(err, &r) {
+		return r.Retryable()
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
 // NewAPIError creates a new API error.
 func NewAPIError(statusCode int, message string) *APIError {
 	return &APIError{
@@ -109,6 +188,33 @@ func NewValidationErrorWithValue(field, message string, value interface{}) *Vali
 	}
 }
 
+// ValidationErrors lists every problem a request's Validate found. It
+// unwraps to the individual errors, so errors.As with a *ValidationError
+// yields the first one.
+type ValidationErrors struct {
+	Errors []*ValidationError
+}
+
+func (e *ValidationErrors) Error() string {
+	if len(e.Errors) == 1 {
+		return e.Errors[0].Error()
+	}
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d validation errors: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// Unwrap exposes the individual validation errors to errors.Is / errors.As.
+func (e *ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
 // NoCapacityError is returned when RunPod has no stock for the requested
 // GPU type / datacenter combination. errors.Is(err, ErrNoCapacity) is true.
 type NoCapacityError struct {
@@ -133,6 +239,10 @@ func (e *NoCapacityError) Error() string {
 
 func (e *NoCapacityError) Unwrap() error { return e.Cause }
 
+// Retryable reports true: stock comes back, so retry after a delay (or
+// try another GPU type).
+func (e *NoCapacityError) Retryable() bool { return true }
+
 func (e *NoCapacityError) Is(target error) bool { return target == ErrNoCapacity }
 
 // FallbackAttempt records one failed candidate during a pod-create fan-out.
@@ -171,3 +281,84 @@ func (e *FallbackExhaustedError) Unwrap() []error {
 	}
 	return out
 }
+
+// VolumeInUseError is returned by DeleteNetworkVolume when pods or
+// endpoints still reference the volume. Nothing was deleted.
+type VolumeInUseError struct {
+	VolumeID    string
+	PodIDs      []string
+	EndpointIDs []string
+}
+
+func (e *VolumeInUseError) Error() string {
+	var users []string
+	if len(e.PodIDs) > 0 {
+		users = append(users, fmt.Sprintf("pods %v", e.PodIDs))
+	}
+	if len(e.EndpointIDs) > 0 {
+		users = append(users, fmt.Sprintf("endpoints %v", e.EndpointIDs))
+	}
+	return fmt.Sprintf("runpod: network volume %s is attached to %s", e.VolumeID, strings.Join(users, " and "))
+}
+
+// DuplicateEndpointError is returned by GetOrCreateEndpoint when more than
+// one endpoint already has the requested name, so it cannot tell which to
+// use. Nothing was created; delete the strays, or rename all but one.
+type DuplicateEndpointError struct {
+	Name        string
+	EndpointIDs []string
+}
+
+func (e *DuplicateEndpointError) Error() string {
+	return fmt.Sprintf("runpod: %d endpoints are named %q: %v", len(e.EndpointIDs), e.Name, e.EndpointIDs)
+}
+
+// EndpointMismatchError is returned by GetOrCreateEndpointWithOptions with
+// Verify when the endpoint it found is configured differently from the
+// request. Diff runs from the endpoint (Old) to the request (New).
+type EndpointMismatchError struct {
+	EndpointID string
+	Diff       Diff
+}
+
+func (e *EndpointMismatchError) Error() string {
+	return fmt.Sprintf("runpod: endpoint %s differs from the request (%s)", e.EndpointID, strings.Join(e.Diff.Fields(), ", "))
+}
+
+// PurgeRefusedError is returned by PurgeQueue when the endpoint's queue
+// length is outside the range its PurgeQueueOptions expect. Nothing was
+// purged.
+type PurgeRefusedError struct {
+	EndpointID  string
+	Queued      int
+	ExpectedMin int
+	ExpectedMax int // 0 means no upper bound
+}
+
+func (e *PurgeRefusedError) Error() string {
+	expected := fmt.Sprintf("at least %d", e.ExpectedMin)
+	if e.ExpectedMax > 0 {
+		expected = fmt.Sprintf("%d to %d", e.ExpectedMin, e.ExpectedMax)
+	}
+	return fmt.Sprintf("runpod: refusing to purge endpoint %s: %d jobs queued, expected %s", e.EndpointID, e.Queued, expected)
+}
+
+// ShapeError is returned under WithStrictDecoding when a response does not
+// match the SDK's model of it. Paths are JSON paths such as
+// "runtime.ports[0].ip"; the decoded value is discarded.
+type ShapeError struct {
+	Type    string   // Go type the response decodes into, e.g. "runpod.Pod"
+	Unknown []string // fields the SDK does not model
+	Missing []string // required fields that were absent or null
+}
+
+func (e *ShapeError) Error() string {
+	var parts []string
+	if len(e.Unknown) > 0 {
+		parts = append(parts, "unknown fields "+strings.Join(e.Unknown, ", "))
+	}
+	if len(e.Missing) > 0 {
+		parts = append(parts, "missing fields "+strings.Join(e.Missing, ", "))
+	}
+	return fmt.Sprintf("runpod: response does not match %s: %s", e.Type, strings.Join(parts, "; "))
+}
@@ -0,0 +1,119 @@
+package runpod_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestIsRetryable(t *testing.T) {
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{runpod.NewAPIError(429, "slow down"), true},
+		{runpod.NewAPIError(503, "unavailable"), true},
+		{runpod.NewAPIError(522, "connection timed out"), true},
+		{runpod.NewAPIError(408, "request timeout"), true},
+		{runpod.NewAPIError(400, "bad request"), false},
+		{runpod.NewAPIError(401, "unauthorized"), false},
+		{runpod.NewAPIError(404, "not found"), false},
+		{fmt.Errorf("failed to get pod: %w", runpod.NewAPIError(502, "bad gateway")), true},
+		{&runpod.NoCapacityError{GPUTypeID: "NVIDIA A40"}, true},
+		{runpod.NewValidationError("name", "is required"), false},
+		{&net.OpError{Op: "dial", Err: errors.New("connection refused")}, true},
+		{&net.DNSError{Err: "no such host", Name: "rest.runpod.io", IsNotFound: true}, false},
+		{fmt.Errorf("HTTP request failed: %w", io.ErrUnexpectedEOF), true},
+		{context.DeadlineExceeded, true},
+		{context.Canceled, false},
+		{errors.New("something else"), false},
+	}
+	for _, tc := range cases {
+		if got := runpod.IsRetryable(tc.err); got != tc.want {
+			t.Errorf("IsRetryable(%v) = %v, want %v", tc.err, got, tc.want)
+		}
+	}
+
+	// Errors from client calls classify the same way.
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient(runpod.WithMaxRetryAttempts(0))
+	if _, err := client.GetPod(context.Background(), "missing"); err == nil || runpod.IsRetryable(err) {
+		t.Fatalf("GetPod(missing) = %v", err)
+	}
+	srv.FailNext(503, `{"error":"unavailable"}`, "")
+	if _, err := client.GetPod(context.Background(), "missing"); !runpod.IsRetryable(err) {
+		t.Fatalf("GetPod after 503 = %v", err)
+	}
+}
+
+const cloudflare524 = `<!DOCTYPE html>
+<html lang="en-US">
+<head>
+<title>rest.runpod.io | 524: A timeout occurred</title>
+<style>body { margin: 0; }</style>
+<script>window.cf = {};</script>
+</head>
+<body>
+<h1>A timeout occurred <span>Error code 524</span></h1>
+<p>Visit cloudflare.com for more information.</p>
+<p>Cloudflare Ray ID: 8f1e2d3c4b5a6978 &bull; 2026-10-16 12:00:00 UTC</p>
+</body>
+</html>`
+
+func TestGatewayError(t *testing.T) {
+	var calls int
+	status := 524
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "text/html; charset=UTF-8")
+		w.WriteHeader(status)
+		io.WriteString(w, cloudflare524)
+	}))
+	defer srv.Close()
+	client := mustClient(t, "test-key", runpod.WithBaseURL(srv.URL), runpod.WithMaxRetryAttempts(1), runpod.WithRetryDelay(time.Millisecond))
+
+	_, err := client.GetPod(context.Background(), "pod-1")
+	var gwErr *runpod.GatewayError
+	if !errors.As(err, &gwErr) || !errors.Is(err, runpod.ErrGateway) || !runpod.IsRetryable(err) {
+		t.Fatalf("GetPod = %v", err)
+	}
+	want := "rest.runpod.io | 524: A timeout occurred A timeout occurred Error code 524 Visit cloudflare.com for more information. Cloudflare Ray ID: 8f1e2d3c4b5a6978 • 2026-10-16 12:00:00 UTC"
+	if gwErr.StatusCode != 524 || gwErr.Excerpt != want || gwErr.ContentType != "text/html; charset=UTF-8" {
+		t.Fatalf("gateway error = %+v", gwErr)
+	}
+	if calls != 2 {
+		t.Fatalf("GET was sent %d times, want 2", calls)
+	}
+
+	// A 2xx page where JSON was expected is a gateway error too, not a
+	// decode failure.
+	status = http.StatusOK
+	if _, err := client.GetPod(context.Background(), "pod-1"); !errors.Is(err, runpod.ErrGateway) || runpod.IsRetryable(err) {
+		t.Fatalf("GetPod with an HTML 200 = %v", err)
+	}
+
+	// A POST may have been processed behind a 502 or 524, so it is not
+	// retryable, unlike a GET.
+	create := &runpod.CreatePodRequest{Name: "p", ImageName: "img", GPUTypeIDs: []string{"NVIDIA A40"}, GPUCount: 1, ContainerDiskInGB: 10}
+	status = http.StatusBadGateway
+	if _, err := client.CreatePod(context.Background(), create); !errors.Is(err, runpod.ErrGateway) || runpod.IsRetryable(err) {
+		t.Fatalf("CreatePod with an HTML 502 = %v", err)
+	}
+
+	// 522 means Cloudflare never reached RunPod, so even a POST is retried.
+	status, calls = 522, 0
+	if _, err := client.CreatePod(context.Background(), create); !errors.Is(err, runpod.ErrGateway) || !runpod.IsRetryable(err) || calls != 2 {
+		t.Fatalf("CreatePod = %v after %d calls", err, calls)
+	}
+}
@@ -47,8 +47,10 @@ func main() {
 
 	// Step 2: Immediately purge the queue
 	fmt.Println("purging queued jobs...")
-	if err := client.PurgeQueue(ctx, endpointID); err != nil {
+	// Refuse to purge if the queue holds more than the jobs just submitted.
+	result, err := client.PurgeQueue(ctx, endpointID, &runpod.PurgeQueueOptions{ExpectedMax: len(inputs)})
+	if err != nil {
 		log.Fatalf("failed to purge queue: %v", err)
 	}
-	fmt.Println("purge request sent")
+	fmt.Printf("purged %d queued jobs\n", result.Removed)
 }
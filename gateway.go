@@ -0,0 +1,64 @@
+package runpod
+
+import (
+	"bytes"
+	"encoding/json"
+	"html"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// gatewayExcerptLen caps GatewayError.Excerpt, in bytes.
+const gatewayExcerptLen = 200
+
+var (
+	htmlHiddenRE = regexp.MustCompile(`(?is)<(script|style|head|title)\b.*?</(script|style|head|title)>`)
+	htmlTitleRE  = regexp.MustCompile(`(?is)<title\b[^>]*>(.*?)</title>`)
+	htmlTagRE    = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+// gatewayError classifies a response body that should have been JSON. It
+// returns nil for JSON and empty bodies, and for 4xx responses, which keep
+// their *APIError so ErrNotFound and friends still match. The excerpt is
+// redacted.
+func (c *Client) gatewayError(resp *http.Response, body []byte) *GatewayError {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || json.Valid(trimmed) || (resp.StatusCode >= 400 && resp.StatusCode < 500) {
+		return nil
+	}
+	gwErr := &GatewayError{
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Excerpt:     c.Redact(bodyExcerpt(trimmed)),
+	}
+	if resp.Request != nil {
+		gwErr.Method = resp.Request.Method
+	}
+	return gwErr
+}
+
+// bodyExcerpt renders the start of a non-JSON body as one line of text. For
+// HTML the page title leads, since Cloudflare puts the status and reason
+// there, followed by the visible text.
+func bodyExcerpt(body []byte) string {
+	text := string(body)
+	if bytes.HasPrefix(body, []byte("<")) {
+		var title string
+		if m := htmlTitleRE.FindStringSubmatch(text); m != nil {
+			title = m[1]
+		}
+		text = title + " " + htmlTagRE.ReplaceAllString(htmlHiddenRE.ReplaceAllString(text, " "), " ")
+		text = html.UnescapeString(text)
+	}
+	text = strings.Join(strings.Fields(text), " ")
+	if len(text) <= gatewayExcerptLen {
+		return text
+	}
+	cut := gatewayExcerptLen
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut] + "..."
+}
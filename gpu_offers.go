@@ -14,7 +14,7 @@ type GPUOffer struct {
 	GPUTypeID   string
 	DisplayName string
 	MemoryInGB  int
-	CloudType   string // "SECURE" or "COMMUNITY"
+	CloudType   CloudType
 	// GPUCount is the whole-pod count used to obtain both prices below.
 	GPUCount    int
 	StockStatus string
@@ -71,6 +71,12 @@ type graphQLGPUOfferPayload struct {
 // GPUTypeIDs/CloudType/DataCenterIDs (and a per-GPU BidPerGPU for spot) on
 // the CreatePodRequest.
 func (c *Client) ListGPUOffers(ctx context.Context, filter *GPUOfferFilter) ([]GPUOffer, error) {
+	return c.listGPUOffers(ctx, filter, c.catalogGraphQL)
+}
+
+// listGPUOffers runs the offers query through query, letting pollers bypass
+// the catalog cache.
+func (c *Client) listGPUOffers(ctx context.Context, filter *GPUOfferFilter, query func(context.Context, string, map[string]interface{}, interface{}) error) ([]GPUOffer, error) {
 	gpuCount := 1
 	minCUDA := ""
 	inStockOnly := false
@@ -100,7 +106,7 @@ func (c *Client) ListGPUOffers(ctx context.Context, filter *GPUOfferFilter) ([]G
 		}
 	}
 
-	query := `
+	const offersQuery = `
 query($gpuCount: Int!, $minCudaVersion: String, $allowedCudaVersions: [String], $dataCenterId: String, $minMemoryInGb: Int, $minVcpuCount: Int, $minDisk: Int, $totalDisk: Int) {
   gpuTypes {
     id
@@ -137,7 +143,7 @@ query($gpuCount: Int!, $minCudaVersion: String, $allowedCudaVersions: [String],
 	}
 
 	var payload graphQLGPUOfferPayload
-	if err := c.GraphQL(ctx, query, variables, &payload); err != nil {
+	if err := query(ctx, offersQuery, variables, &payload); err != nil {
 		return nil, fmt.Errorf("failed to list GPU offers: %w", err)
 	}
 
@@ -148,7 +154,7 @@ query($gpuCount: Int!, $minCudaVersion: String, $allowedCudaVersions: [String],
 				continue
 			}
 		}
-		add := func(cloud string, price *Price) {
+		add := func(cloud CloudType, price *Price) {
 			if price == nil {
 				return
 			}
@@ -169,10 +175,10 @@ query($gpuCount: Int!, $minCudaVersion: String, $allowedCudaVersions: [String],
 			})
 		}
 		if gpu.SecureCloud {
-			add("SECURE", gpu.Secure)
+			add(CloudTypeSecure, gpu.Secure)
 		}
 		if gpu.CommunityCloud {
-			add("COMMUNITY", gpu.Community)
+			add(CloudTypeCommunity, gpu.Community)
 		}
 	}
 
@@ -185,3 +191,79 @@ query($gpuCount: Int!, $minCudaVersion: String, $allowedCudaVersions: [String],
 
 	return offers, nil
 }
+
+// SpotComparison contrasts on-demand and interruptible (spot) pricing for one
+// GPU type and count. Prices are whole-pod USD/hr.
+type SpotComparison struct {
+	GPUTypeID string
+	GPUCount  int
+	// Price is the raw lowestPrice block the comparison was computed from.
+	Price Price
+	// OnDemandPrice is the uninterruptible price.
+	OnDemandPrice float64
+	// MinimumBidPrice is the spot bid floor; BidPerGPU is the same floor in
+	// the per-GPU form CreatePodRequest.BidPerGPU expects.
+	MinimumBidPrice float64
+	BidPerGPU       float64
+	// SavingsPerHour and SavingsPercent estimate what bidding the floor saves
+	// over on-demand. Actual spot cost depends on the bid and market.
+	SavingsPerHour float64
+	SavingsPercent float64
+	// InStock reports whether the type is currently rentable at all.
+	InStock bool
+}
+
+// SpotAvailable reports whether a spot bid is possible: the type is in stock
+// and RunPod quotes a bid floor below the on-demand price.
+func (s *SpotComparison) SpotAvailable() bool {
+	return s.InStock && s.MinimumBidPrice > 0 && s.SavingsPerHour > 0
+}
+
+// PreferSpot reports whether spot is available and saves at least
+// minSavingsPercent (0-100) over on-demand. Callers weigh this against the
+// workload's tolerance for preemption.
+func (s *SpotComparison) PreferSpot(minSavingsPercent float64) bool {
+	return s.SpotAvailable() && s.SavingsPercent >= minSavingsPercent
+}
+
+// CompareSpotPricing returns current on-demand price, spot bid floor and
+// estimated savings for gpuCount GPUs (default 1) of one GPU type.
+func (c *Client) CompareSpotPricing(ctx context.Context, gpuTypeID string, gpuCount int) (*SpotComparison, error) {
+	id := strings.TrimSpace(gpuTypeID)
+	if id == "" {
+		return nil, NewValidationError("gpuTypeID", "cannot be empty")
+	}
+	if gpuCount < 0 {
+		return nil, NewValidationError("gpuCount", "cannot be negative")
+	}
+	if gpuCount == 0 {
+		gpuCount = 1
+	}
+
+	gpus, err := c.ListGPUTypes(ctx, &GPUTypeFilter{IDs: []string{id}, GPUCount: gpuCount})
+	if err != nil {
+		return nil, err
+	}
+	if len(gpus) == 0 {
+		return nil, NewAPIErrorWithDetails(404, "gpu type not found", id)
+	}
+	if gpus[0].LowestPrice == nil {
+		return nil, fmt.Errorf("%w: no price quoted for %d x %s", ErrNoCapacity, gpuCount, id)
+	}
+
+	price := *gpus[0].LowestPrice
+	cmp := &SpotComparison{
+		GPUTypeID:       id,
+		GPUCount:        gpuCount,
+		Price:           price,
+		OnDemandPrice:   price.UninterruptablePrice,
+		MinimumBidPrice: price.MinimumBidPrice,
+		BidPerGPU:       price.MinimumBidPrice / float64(gpuCount),
+		InStock:         isAvailableStockStatus(price.StockStatus),
+	}
+	if price.MinimumBidPrice > 0 && price.UninterruptablePrice > 0 {
+		cmp.SavingsPerHour = price.UninterruptablePrice - price.MinimumBidPrice
+		cmp.SavingsPercent = cmp.SavingsPerHour / price.UninterruptablePrice * 100
+	}
+	return cmp, nil
+}
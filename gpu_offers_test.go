@@ -140,3 +140,40 @@ func TestCreateSpotPodSendsBid(t *testing.T) {
 		t.Fatalf("unexpected pod %+v", pod)
 	}
 }
+
+func TestCompareSpotPricing(t *testing.T) {
+	server := newGPUTypeGraphQLServer(t, func(req testGraphQLRequest) interface{} {
+		if req.Variables["gpuCount"] != float64(2) {
+			t.Fatalf("gpuCount not passed: %#v", req.Variables)
+		}
+		return map[string]any{"data": map[string]any{"gpuTypes": []map[string]any{
+			{"id": "NVIDIA A100 80GB PCIe", "memoryInGb": 80, "secureCloud": true,
+				"lowestPrice": map[string]any{"uninterruptablePrice": 3.20, "minimumBidPrice": 1.60, "stockStatus": "Medium"}},
+			{"id": "NVIDIA B200", "memoryInGb": 180, "secureCloud": true,
+				"lowestPrice": map[string]any{"uninterruptablePrice": 12.0, "stockStatus": "Low"}},
+		}}}
+	})
+	defer server.Close()
+	client := mustClient(t, "test_key", runpod.WithGraphQLBaseURL(server.URL))
+
+	cmp, err := client.CompareSpotPricing(t.Context(), "NVIDIA A100 80GB PCIe", 2)
+	if err != nil {
+		t.Fatalf("CompareSpotPricing: %v", err)
+	}
+	if cmp.OnDemandPrice != 3.20 || cmp.MinimumBidPrice != 1.60 || cmp.BidPerGPU != 0.80 || cmp.SavingsPerHour != 1.60 || cmp.SavingsPercent != 50 {
+		t.Fatalf("comparison = %+v", cmp)
+	}
+	if !cmp.PreferSpot(40) || cmp.PreferSpot(60) {
+		t.Fatalf("PreferSpot thresholds wrong for %+v", cmp)
+	}
+
+	// No bid floor quoted: spot is not an option.
+	cmp, err = client.CompareSpotPricing(t.Context(), "NVIDIA B200", 2)
+	if err != nil || cmp.SpotAvailable() || cmp.SavingsPerHour != 0 {
+		t.Fatalf("comparison without bid = %+v, %v", cmp, err)
+	}
+
+	if _, err := client.CompareSpotPricing(t.Context(), "NVIDIA H200", 2); !errors.Is(err, runpod.ErrNotFound) {
+		t.Fatalf("unknown type: expected ErrNotFound, got %v", err)
+	}
+}
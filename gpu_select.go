@@ -0,0 +1,121 @@
+package runpod
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// GPUCriteria constrains FindCheapestGPU. Zero values are unconstrained.
+type GPUCriteria struct {
+	// MinVRAMInGB is the minimum GPU memory per GPU.
+	MinVRAMInGB int
+	// CloudType restricts to one cloud (case-insensitive); empty considers
+	// both.
+	CloudType CloudType
+	// MinCudaVersion / AllowedCudaVersions restrict to machines whose CUDA
+	// driver supports the workload.
+	MinCudaVersion      string
+	AllowedCudaVersions []string
+	// GPUCount prices the pod for this many GPUs (default 1).
+	GPUCount int
+	// DataCenterID prices and checks stock in one data center.
+	DataCenterID string
+	// Spot ranks by the interruptible bid floor instead of the on-demand
+	// price, skipping offers without a bid price.
+	Spot bool
+	// MaxPrice drops offers above this whole-pod USD/hr price (the bid floor
+	// when Spot is set).
+	MaxPrice float64
+	// ExcludeIDs skips GPU types, e.g. ones that recently failed to start.
+	ExcludeIDs []string
+}
+
+// GPUSelection is the offer chosen by FindCheapestGPU.
+type GPUSelection struct {
+	GPUOffer
+	// Spot is true when the selection is priced as an interruptible pod.
+	Spot bool
+	// Price is the whole-pod USD/hr price the selection was ranked by.
+	Price float64
+}
+
+// GPUTypeIDs returns the selection as a one-entry CreatePodRequest.GPUTypeIDs
+// list.
+func (s *GPUSelection) GPUTypeIDs() []string {
+	return []string{s.GPUTypeID}
+}
+
+// ApplyTo sets the GPU type, count and cloud of req to the selection. For a
+// spot selection it also marks req interruptible and bids the per-GPU floor.
+func (s *GPUSelection) ApplyTo(req *CreatePodRequest) {
+	req.GPUTypeIDs = s.GPUTypeIDs()
+	req.GPUCount = s.GPUCount
+	req.CloudType = s.CloudType
+	if s.Spot {
+		req.Interruptible = true
+		req.BidPerGPU = s.MinimumBidPrice / float64(s.GPUCount)
+	}
+}
+
+// FindCheapestGPU returns the cheapest in-stock GPU offer matching criteria
+// (which may be nil), ranked by on-demand price or, with Spot, by bid floor.
+// Ties prefer more VRAM, then the GPU type ID. When nothing matches the error
+// wraps ErrNoCapacity.
+func (c *Client) FindCheapestGPU(ctx context.Context, criteria *GPUCriteria) (*GPUSelection, error) {
+	if criteria == nil {
+		criteria = &GPUCriteria{}
+	}
+	switch {
+	case criteria.MinVRAMInGB < 0:
+		return nil, NewValidationError("minVramInGb", "cannot be negative")
+	case criteria.MaxPrice < 0:
+		return nil, NewValidationError("maxPrice", "cannot be negative")
+	}
+	cloud := criteria.CloudType.normalize()
+	if err := validateEnum("cloudType", cloud, cloudTypes); err != nil {
+		return nil, err
+	}
+
+	offers, err := c.ListGPUOffers(ctx, &GPUOfferFilter{
+		GPUCount:            criteria.GPUCount,
+		MinCudaVersion:      criteria.MinCudaVersion,
+		AllowedCudaVersions: criteria.AllowedCudaVersions,
+		DataCenterID:        criteria.DataCenterID,
+		InStockOnly:         true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := make(map[string]bool, len(criteria.ExcludeIDs))
+	for _, id := range criteria.ExcludeIDs {
+		excluded[strings.TrimSpace(id)] = true
+	}
+
+	var best *GPUSelection
+	for _, offer := range offers {
+		if excluded[offer.GPUTypeID] || offer.MemoryInGB < criteria.MinVRAMInGB {
+			continue
+		}
+		if cloud != "" && offer.CloudType != cloud {
+			continue
+		}
+		price := offer.OnDemandPrice
+		if criteria.Spot {
+			price = offer.MinimumBidPrice
+		}
+		if price <= 0 || (criteria.MaxPrice > 0 && price > criteria.MaxPrice) {
+			continue
+		}
+		if best == nil || price < best.Price ||
+			(price == best.Price && (offer.MemoryInGB > best.MemoryInGB ||
+				(offer.MemoryInGB == best.MemoryInGB && offer.GPUTypeID < best.GPUTypeID))) {
+			best = &GPUSelection{GPUOffer: offer, Spot: criteria.Spot, Price: price}
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("%w: no in-stock GPU type matches the criteria", ErrNoCapacity)
+	}
+	return best, nil
+}
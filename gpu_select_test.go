@@ -0,0 +1,70 @@
+package runpod_test
+
+import (
+	"errors"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+)
+
+func TestFindCheapestGPU(t *testing.T) {
+	server := newGPUTypeGraphQLServer(t, func(req testGraphQLRequest) interface{} {
+		if req.Variables["minCudaVersion"] != "12.8" {
+			t.Fatalf("minCudaVersion not passed: %#v", req.Variables)
+		}
+		price := func(bid, onDemand float64, stock string) map[string]any {
+			return map[string]any{"minimumBidPrice": bid, "uninterruptablePrice": onDemand, "stockStatus": stock}
+		}
+		return map[string]any{"data": map[string]any{"gpuTypes": []map[string]any{
+			{"id": "rtx-4090", "memoryInGb": 24, "secureCloud": true, "communityCloud": true,
+				"secure": price(0.30, 0.69, "High"), "community": price(0.20, 0.44, "Low")},
+			{"id": "l40s", "memoryInGb": 48, "secureCloud": true, "communityCloud": true,
+				"secure": price(0.40, 0.86, "Medium"), "community": price(0.35, 0.79, "None")},
+			{"id": "a6000", "memoryInGb": 48, "secureCloud": true, "communityCloud": false,
+				"secure": price(0.25, 0.79, "High")},
+			{"id": "h100", "memoryInGb": 80, "secureCloud": true, "communityCloud": false,
+				"secure": price(1.50, 2.99, "Low")},
+		}}}
+	})
+	defer server.Close()
+	client := mustClient(t, "test_key", runpod.WithGraphQLBaseURL(server.URL))
+	ctx := t.Context()
+
+	got, err := client.FindCheapestGPU(ctx, &runpod.GPUCriteria{MinCudaVersion: "12.8"})
+	if err != nil {
+		t.Fatalf("FindCheapestGPU: %v", err)
+	}
+	if got.GPUTypeID != "rtx-4090" || got.CloudType != "COMMUNITY" || got.Price != 0.44 || got.Spot {
+		t.Fatalf("cheapest = %+v", got)
+	}
+
+	// Out-of-stock community l40s is skipped; a6000 wins the tie on ID.
+	got, err = client.FindCheapestGPU(ctx, &runpod.GPUCriteria{MinCudaVersion: "12.8", MinVRAMInGB: 48})
+	if err != nil || got.GPUTypeID != "a6000" || got.CloudType != "SECURE" {
+		t.Fatalf("cheapest 48GB = %+v, %v", got, err)
+	}
+
+	got, err = client.FindCheapestGPU(ctx, &runpod.GPUCriteria{MinCudaVersion: "12.8", MinVRAMInGB: 48, Spot: true, GPUCount: 2})
+	if err != nil || got.GPUTypeID != "a6000" || got.Price != 0.25 || !got.Spot {
+		t.Fatalf("cheapest 48GB spot = %+v, %v", got, err)
+	}
+	req := &runpod.CreatePodRequest{}
+	got.ApplyTo(req)
+	if len(req.GPUTypeIDs) != 1 || req.GPUTypeIDs[0] != "a6000" || req.GPUCount != 2 || !req.Interruptible || req.BidPerGPU != 0.125 || req.CloudType != "SECURE" {
+		t.Fatalf("ApplyTo = %+v", req)
+	}
+
+	got, err = client.FindCheapestGPU(ctx, &runpod.GPUCriteria{MinCudaVersion: "12.8", CloudType: "secure", ExcludeIDs: []string{"a6000"}})
+	if err != nil || got.GPUTypeID != "rtx-4090" || got.CloudType != "SECURE" {
+		t.Fatalf("cheapest secure excluding a6000 = %+v, %v", got, err)
+	}
+
+	_, err = client.FindCheapestGPU(ctx, &runpod.GPUCriteria{MinCudaVersion: "12.8", MinVRAMInGB: 80, MaxPrice: 2.0})
+	if !errors.Is(err, runpod.ErrNoCapacity) {
+		t.Fatalf("expected ErrNoCapacity, got %v", err)
+	}
+	var validationErr *runpod.ValidationError
+	if _, err := client.FindCheapestGPU(ctx, &runpod.GPUCriteria{CloudType: "SPOT"}); !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
@@ -17,24 +17,51 @@ func (c *Client) ListGPUTypes(ctx context.Context, filter *GPUTypeFilter) ([]GPU
 	gpuCount := 1
 	minCUDA := ""
 	var allowedCUDA []string
+	var secureCloud *bool
+	dataCenterID := ""
+	secure, community := true, false
 
 	if filter != nil {
+		switch {
+		case filter.GPUCount < 0:
+			return nil, NewValidationError("gpuCount", "cannot be negative")
+		case filter.MinVRAMInGB < 0:
+			return nil, NewValidationError("minVramInGb", "cannot be negative")
+		case filter.MaxPrice < 0:
+			return nil, NewValidationError("maxPrice", "cannot be negative")
+		}
+		switch cloud := filter.CloudType.normalize(); cloud {
+		case "":
+		case CloudTypeSecure:
+			if filter.SecureCloud != nil && !*filter.SecureCloud {
+				return nil, NewValidationErrorWithValue("cloudType", "conflicts with secureCloud=false", string(filter.CloudType))
+			}
+			secureCloud = &secure
+		case CloudTypeCommunity:
+			if filter.CommunityCloud != nil && !*filter.CommunityCloud {
+				return nil, NewValidationErrorWithValue("cloudType", "conflicts with communityCloud=false", string(filter.CloudType))
+			}
+			secureCloud = &community
+		default:
+			return nil, validateEnum("cloudType", cloud, cloudTypes)
+		}
 		if filter.GPUCount > 0 {
 			gpuCount = filter.GPUCount
 		}
 		minCUDA = strings.TrimSpace(filter.MinCudaVersion)
 		allowedCUDA = filter.AllowedCudaVersions
+		dataCenterID = strings.TrimSpace(filter.DataCenterID)
 	}
 
 	query := `
-query($gpuCount: Int!, $minCudaVersion: String, $allowedCudaVersions: [String!]) {
+query($gpuCount: Int!, $minCudaVersion: String, $allowedCudaVersions: [String!], $secureCloud: Boolean, $dataCenterId: String) {
   gpuTypes {
     id
     displayName
     memoryInGb
     secureCloud
     communityCloud
-    lowestPrice(input: { gpuCount: $gpuCount, minCudaVersion: $minCudaVersion, allowedCudaVersions: $allowedCudaVersions }) {
+    lowestPrice(input: { gpuCount: $gpuCount, minCudaVersion: $minCudaVersion, allowedCudaVersions: $allowedCudaVersions, secureCloud: $secureCloud, dataCenterId: $dataCenterId }) {
       minimumBidPrice
       uninterruptablePrice
       stockStatus
@@ -48,9 +75,15 @@ query($gpuCount: Int!, $minCudaVersion: String, $allowedCudaVersions: [String!])
 		"minCudaVersion":      minCUDA,
 		"allowedCudaVersions": allowedCUDA,
 	}
+	if secureCloud != nil {
+		variables["secureCloud"] = *secureCloud
+	}
+	if dataCenterID != "" {
+		variables["dataCenterId"] = dataCenterID
+	}
 
 	var payload graphQLGPUTypePayload
-	if err := c.GraphQL(ctx, query, variables, &payload); err != nil {
+	if err := c.catalogGraphQL(ctx, query, variables, &payload); err != nil {
 		return nil, fmt.Errorf("failed to list GPU types: %w", err)
 	}
 
@@ -148,8 +181,27 @@ func filterGPUTypeResults(items []GPUType, filter *GPUTypeFilter) []GPUType {
 		if filter.CommunityCloud != nil && item.CommunityCloud != *filter.CommunityCloud {
 			continue
 		}
-		if (strings.TrimSpace(filter.MinCudaVersion) != "" || len(filter.AllowedCudaVersions) > 0) && item.LowestPrice == nil {
-			// CUDA-constrained query returning no price usually means unsupported/unavailable for that constraint.
+		switch filter.CloudType.normalize() {
+		case CloudTypeSecure:
+			if !item.SecureCloud {
+				continue
+			}
+		case CloudTypeCommunity:
+			if !item.CommunityCloud {
+				continue
+			}
+		}
+		if item.MemoryInGB < filter.MinVRAMInGB {
+			continue
+		}
+		if (strings.TrimSpace(filter.MinCudaVersion) != "" || len(filter.AllowedCudaVersions) > 0 || strings.TrimSpace(filter.DataCenterID) != "") && item.LowestPrice == nil {
+			// A constrained query returning no price usually means unsupported/unavailable for that constraint.
+			continue
+		}
+		if filter.MaxPrice > 0 && (item.LowestPrice == nil || item.LowestPrice.UninterruptablePrice <= 0 || item.LowestPrice.UninterruptablePrice > filter.MaxPrice) {
+			continue
+		}
+		if filter.InStockOnly && (item.LowestPrice == nil || !isAvailableStockStatus(item.LowestPrice.StockStatus)) {
 			continue
 		}
 		out = append(out, item)
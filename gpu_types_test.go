@@ -102,6 +102,57 @@ func TestListGPUTypes_Filtering(t *testing.T) {
 	}
 }
 
+func TestListGPUTypes_VRAMCloudPriceFilters(t *testing.T) {
+	server := newGPUTypeGraphQLServer(t, func(req testGraphQLRequest) interface{} {
+		if req.Variables["secureCloud"] != false || req.Variables["dataCenterId"] != "EU-RO-1" {
+			t.Fatalf("lowestPrice must be scoped to cloud and data center, variables = %#v", req.Variables)
+		}
+		gpu := func(id string, vram int, community bool, price float64, stock string) map[string]interface{} {
+			return map[string]interface{}{
+				"id": id, "displayName": id, "memoryInGb": vram,
+				"secureCloud": true, "communityCloud": community,
+				"lowestPrice": map[string]interface{}{"uninterruptablePrice": price, "stockStatus": stock},
+			}
+		}
+		return map[string]interface{}{"data": map[string]interface{}{"gpuTypes": []map[string]interface{}{
+			gpu("small", 16, true, 0.20, "High"),
+			gpu("secure-only", 48, false, 0.60, "High"),
+			gpu("pricey", 80, true, 2.50, "High"),
+			gpu("sold-out", 48, true, 0.50, "None"),
+			gpu("match", 48, true, 0.70, "Low"),
+		}}}
+	})
+	defer server.Close()
+
+	client := mustClient(t, "test_key", runpod.WithGraphQLBaseURL(server.URL))
+	got, err := client.ListGPUTypes(context.Background(), &runpod.GPUTypeFilter{
+		MinVRAMInGB:  24,
+		CloudType:    "community",
+		DataCenterID: "EU-RO-1",
+		MaxPrice:     1.0,
+		InStockOnly:  true,
+	})
+	if err != nil {
+		t.Fatalf("ListGPUTypes error: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != "match" {
+		t.Fatalf("filtered types = %#v", got)
+	}
+
+	for _, filter := range []*runpod.GPUTypeFilter{
+		{CloudType: "ON_DEMAND"},
+		{MaxPrice: -1},
+		{MinVRAMInGB: -8},
+		{CloudType: runpod.CloudTypeSecure, SecureCloud: runpod.Ptr(false)},
+		{CloudType: "community", CommunityCloud: runpod.Ptr(false)},
+	} {
+		var validationErr *runpod.ValidationError
+		if _, err := client.ListGPUTypes(context.Background(), filter); !errors.As(err, &validationErr) {
+			t.Errorf("filter %+v: expected validation error, got %v", filter, err)
+		}
+	}
+}
+
 func TestListAvailableGPUs_OnlyAvailable(t *testing.T) {
 	server := newGPUTypeGraphQLServer(t, func(req testGraphQLRequest) interface{} {
 		return map[string]interface{}{
@@ -0,0 +1,241 @@
+package runpod
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// DefaultGPUWatchInterval is the WatchGPUAvailability poll interval when
+// GPUWatchOptions.Interval is unset.
+const DefaultGPUWatchInterval = 30 * time.Second
+
+// GPUWatchEvent types.
+const (
+	// GPUWatchAvailable: the target became rentable (in stock and, with
+	// MaxPrice, at or under it).
+	GPUWatchAvailable = "available"
+	// GPUWatchPriceDrop: the target was in stock above MaxPrice and its price
+	// dropped to MaxPrice or below.
+	GPUWatchPriceDrop = "price_drop"
+	// GPUWatchLost: a previously reported target is no longer rentable.
+	GPUWatchLost = "lost"
+	// GPUWatchError: a poll failed; the watcher keeps polling.
+	GPUWatchError = "error"
+)
+
+// GPUWatchTarget is one GPU type (optionally in one data center / cloud) to
+// watch for.
+type GPUWatchTarget struct {
+	GPUTypeID    string
+	DataCenterID string    // empty: any data center
+	CloudType    CloudType // empty for either
+	GPUCount     int       // default 1
+	// MaxPrice is the highest acceptable whole-pod on-demand USD/hr price;
+	// zero accepts any price.
+	MaxPrice float64
+}
+
+// GPUWatchOptions configures WatchGPUAvailability.
+type GPUWatchOptions struct {
+	Targets  []GPUWatchTarget
+	Interval time.Duration
+}
+
+// GPUWatchEvent is one availability transition.
+type GPUWatchEvent struct {
+	Type   string
+	Target GPUWatchTarget
+	// Offer is the cheapest matching offer for available / price_drop
+	// events.
+	Offer *GPUOffer
+	// Err is set for error events.
+	Err error
+	At  time.Time
+}
+
+// gpuWatchState is one target's state at a poll.
+type gpuWatchState struct {
+	matched bool
+	inStock bool
+	// offer is the cheapest matching offer when matched.
+	offer *GPUOffer
+}
+
+// gpuWatchError is a failed poll, for the target whose query failed.
+type gpuWatchError struct {
+	target GPUWatchTarget
+	err    error
+}
+
+func (e *gpuWatchError) Error() string { return e.err.Error() }
+func (e *gpuWatchError) Unwrap() error { return e.err }
+
+// WatchGPUAvailability polls GPU offers for the targets and sends an event
+// whenever a target becomes rentable, drops under its MaxPrice, or stops
+// being rentable. A target already rentable at the first poll is reported
+// immediately. Polls bypass the catalog cache. The returned channel is
+// closed when ctx is done or the client is closed; a slow reader delays the
+// next poll rather than losing events.
+//
+//	events, err := client.WatchGPUAvailability(ctx, &runpod.GPUWatchOptions{
+//		Targets: []runpod.GPUWatchTarget{{GPUTypeID: "NVIDIA H100 80GB HBM3", DataCenterID: "EU-SE-1", MaxPrice: 2.5}},
+//	})
+//	for ev := range events {
+//		if ev.Type == runpod.GPUWatchAvailable || ev.Type == runpod.GPUWatchPriceDrop {
+//			// create the pod now
+//		}
+//	}
+func (c *Client) WatchGPUAvailability(ctx context.Context, opts *GPUWatchOptions) (<-chan GPUWatchEvent, error) {
+	if opts == nil || len(opts.Targets) == 0 {
+		return nil, NewValidationError("targets", "cannot be empty")
+	}
+	targets := make([]GPUWatchTarget, len(opts.Targets))
+	for i, target := range opts.Targets {
+		target.GPUTypeID = strings.TrimSpace(target.GPUTypeID)
+		target.DataCenterID = strings.TrimSpace(target.DataCenterID)
+		target.CloudType = target.CloudType.normalize()
+		if target.GPUTypeID == "" {
+			return nil, NewValidationError("targets.gpuTypeId", "cannot be empty")
+		}
+		if err := validateEnum("targets.cloudType", target.CloudType, cloudTypes); err != nil {
+			return nil, err
+		}
+		if target.GPUCount < 0 || target.MaxPrice < 0 {
+			return nil, NewValidationError("targets", "gpuCount and maxPrice cannot be negative")
+		}
+		if target.GPUCount == 0 {
+			target.GPUCount = 1
+		}
+		targets[i] = target
+	}
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultGPUWatchInterval
+	}
+
+	ctx, release, err := c.lifecycle.start(ctx)
+	if err != nil {
+		return nil, err
+	}
+	w, err := NewWatcher(ctx, WatchOptions[[]gpuWatchState]{
+		Poll:     func(ctx context.Context) ([]gpuWatchState, error) { return c.pollGPUWatch(ctx, targets) },
+		Interval: interval,
+		Changed: func(prev, cur []gpuWatchState) bool {
+			for i := range cur {
+				if cur[i].matched != prev[i].matched || cur[i].inStock != prev[i].inStock {
+					return true
+				}
+			}
+			return false
+		},
+		Clock: c.clock,
+	})
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	events := make(chan GPUWatchEvent)
+	go func() {
+		defer release()
+		defer close(events)
+		defer func() {
+			w.Stop()
+			for range w.Events() {
+			}
+		}()
+		emit := func(ev GPUWatchEvent) bool {
+			select {
+			case events <- ev:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		prev := make([]gpuWatchState, len(targets))
+		for ev := range w.Events() {
+			if ev.Err != nil {
+				var pollErr *gpuWatchError
+				errors.As(ev.Err, &pollErr)
+				if !emit(GPUWatchEvent{Type: GPUWatchError, Target: pollErr.target, Err: pollErr.err, At: ev.At}) {
+					return
+				}
+				continue
+			}
+			for i, cur := range ev.Value {
+				var out *GPUWatchEvent
+				switch {
+				case cur.matched && !prev[i].matched:
+					typ := GPUWatchAvailable
+					if prev[i].inStock {
+						typ = GPUWatchPriceDrop
+					}
+					out = &GPUWatchEvent{Type: typ, Target: targets[i], Offer: cur.offer}
+				case !cur.matched && prev[i].matched:
+					out = &GPUWatchEvent{Type: GPUWatchLost, Target: targets[i]}
+				}
+				if out != nil {
+					out.At = ev.At
+					if !emit(*out) {
+						return
+					}
+				}
+			}
+			prev = ev.Value
+		}
+	}()
+	return events, nil
+}
+
+// pollGPUWatch runs one poll, issuing one offers query per distinct
+// (data center, GPU count), and returns each target's state. A failed
+// query fails the poll with a *gpuWatchError.
+func (c *Client) pollGPUWatch(ctx context.Context, targets []GPUWatchTarget) ([]gpuWatchState, error) {
+	type queryKey struct {
+		dataCenterID string
+		gpuCount     int
+	}
+	results := map[queryKey][]GPUOffer{}
+	state := make([]gpuWatchState, len(targets))
+
+	for i, target := range targets {
+		key := queryKey{target.DataCenterID, target.GPUCount}
+		offers, done := results[key]
+		if !done {
+			var err error
+			offers, err = c.listGPUOffers(ctx, &GPUOfferFilter{GPUCount: target.GPUCount, DataCenterID: target.DataCenterID}, c.GraphQL)
+			if err != nil {
+				return nil, &gpuWatchError{target: target, err: err}
+			}
+			results[key] = offers
+		}
+
+		var best *GPUOffer
+		inStock := false
+		for j := range offers {
+			offer := &offers[j]
+			if offer.GPUTypeID != target.GPUTypeID || (target.CloudType != "" && offer.CloudType != target.CloudType) {
+				continue
+			}
+			if !isAvailableStockStatus(offer.StockStatus) {
+				continue
+			}
+			inStock = true
+			if target.MaxPrice > 0 && (offer.OnDemandPrice <= 0 || offer.OnDemandPrice > target.MaxPrice) {
+				continue
+			}
+			if best == nil || offer.OnDemandPrice < best.OnDemandPrice {
+				best = offer
+			}
+		}
+		state[i] = gpuWatchState{matched: best != nil, inStock: inStock}
+		if best != nil {
+			offer := *best
+			state[i].offer = &offer
+		}
+	}
+	return state, nil
+}
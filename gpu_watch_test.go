@@ -0,0 +1,111 @@
+package runpod_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestWatchGPUAvailability(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+
+	setH100 := func(stock string, price float64) {
+		srv.SetGPUTypes([]runpod.GPUType{{
+			ID: "NVIDIA H100 80GB HBM3", DisplayName: "H100 SXM", MemoryInGB: 80, SecureCloud: true,
+			LowestPrice: &runpod.Price{UninterruptablePrice: price, MinimumBidPrice: price / 2, StockStatus: stock},
+		}})
+	}
+	setH100("None", 2.0)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	events, err := client.WatchGPUAvailability(ctx, &runpod.GPUWatchOptions{
+		Targets:  []runpod.GPUWatchTarget{{GPUTypeID: "NVIDIA H100 80GB HBM3", DataCenterID: "EU-SE-1", MaxPrice: 2.5}},
+		Interval: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("WatchGPUAvailability: %v", err)
+	}
+	next := func() runpod.GPUWatchEvent {
+		t.Helper()
+		select {
+		case ev := <-events:
+			return ev
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for event")
+			return runpod.GPUWatchEvent{}
+		}
+	}
+
+	// waitPoll waits until the watcher has polled at least once more.
+	waitPoll := func() {
+		seen := len(srv.AuthorizationHeaders())
+		for deadline := time.Now().Add(2 * time.Second); len(srv.AuthorizationHeaders()) < seen+2; {
+			if time.Now().After(deadline) {
+				t.Fatal("watcher stopped polling")
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	setH100("High", 3.0) // in stock, over budget: no event yet
+	waitPoll()
+	setH100("High", 2.2)
+	ev := next()
+	if ev.Type != runpod.GPUWatchPriceDrop || ev.Offer == nil || ev.Offer.OnDemandPrice != 2.2 || ev.Target.DataCenterID != "EU-SE-1" {
+		t.Fatalf("event = %+v", ev)
+	}
+
+	setH100("None", 2.2)
+	if ev := next(); ev.Type != runpod.GPUWatchLost || ev.Offer != nil {
+		t.Fatalf("event = %+v", ev)
+	}
+	setH100("Low", 2.0)
+	if ev := next(); ev.Type != runpod.GPUWatchAvailable || ev.Offer.CloudType != "SECURE" {
+		t.Fatalf("event = %+v", ev)
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestWatchGPUAvailabilityErrorsAndValidation(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient(runpod.WithMaxRetryAttempts(0))
+
+	var validationErr *runpod.ValidationError
+	for _, opts := range []*runpod.GPUWatchOptions{nil, {}, {Targets: []runpod.GPUWatchTarget{{}}}, {Targets: []runpod.GPUWatchTarget{{GPUTypeID: "x", CloudType: "SPOT"}}}} {
+		if _, err := client.WatchGPUAvailability(t.Context(), opts); !errors.As(err, &validationErr) {
+			t.Errorf("opts %+v: expected validation error, got %v", opts, err)
+		}
+	}
+
+	srv.FailNext(500, `{"error":"boom"}`, "")
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	events, err := client.WatchGPUAvailability(ctx, &runpod.GPUWatchOptions{
+		Targets:  []runpod.GPUWatchTarget{{GPUTypeID: "NVIDIA GeForce RTX 4090"}},
+		Interval: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev := <-events; ev.Type != runpod.GPUWatchError || ev.Err == nil {
+		t.Fatalf("first event = %+v, want error", ev)
+	}
+	// The watcher keeps polling; the default catalog has the 4090 in stock.
+	if ev := <-events; ev.Type != runpod.GPUWatchAvailable {
+		t.Fatalf("second event = %+v, want available", ev)
+	}
+	cancel()
+	for range events {
+	}
+}
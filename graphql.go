@@ -24,8 +24,17 @@ type graphQLResponse struct {
 
 // GraphQL executes a typed GraphQL request against RunPod's GraphQL API.
 func (c *Client) GraphQL(ctx context.Context, query string, variables map[string]interface{}, result interface{}) error {
+	data, err := c.graphQLData(ctx, query, variables)
+	if err != nil {
+		return err
+	}
+	return decodeGraphQLData(data, result)
+}
+
+// graphQLData executes a GraphQL request and returns its data payload.
+func (c *Client) graphQLData(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
 	if strings.TrimSpace(query) == "" {
-		return NewValidationError("query", "cannot be empty")
+		return nil, NewValidationError("query", "cannot be empty")
 	}
 
 	endpoint := strings.TrimSpace(c.graphqlBaseURL)
@@ -39,26 +48,29 @@ func (c *Client) GraphQL(ctx context.Context, query string, variables map[string
 
 	resp, err := c.makeRequest(ctx, "POST", endpoint, req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read GraphQL response body: %w", err)
+		return nil, fmt.Errorf("failed to read GraphQL response body: %w", err)
 	}
 
 	if c.debug {
-		c.logger.Printf("[DEBUG] GraphQL response status=%d body=%s", resp.StatusCode, string(body))
+		c.logger.Printf("[DEBUG] GraphQL response status=%d body=%s", resp.StatusCode, c.Redact(string(body)))
 	}
 
 	if resp.StatusCode >= 400 {
-		return c.parseErrorResponse(resp.StatusCode, resp.Header, body)
+		return nil, c.parseErrorResponse(resp, body)
 	}
 
+	if gwErr := c.gatewayError(resp, body); gwErr != nil {
+		return nil, gwErr
+	}
 	var envelope graphQLResponse
 	if err := json.Unmarshal(body, &envelope); err != nil {
-		return fmt.Errorf("failed to unmarshal GraphQL response envelope: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal GraphQL response envelope: %w", err)
 	}
 
 	if len(envelope.Errors) > 0 {
@@ -66,14 +78,17 @@ func (c *Client) GraphQL(ctx context.Context, query string, variables map[string
 		if msg == "" {
 			msg = "GraphQL request failed"
 		}
-		return NewAPIErrorWithDetails(400, "graphql error", msg)
+		return nil, NewAPIErrorWithDetails(400, "graphql error", c.Redact(msg))
 	}
+	return envelope.Data, nil
+}
 
-	if result == nil || len(envelope.Data) == 0 || string(envelope.Data) == "null" {
+func decodeGraphQLData(data json.RawMessage, result interface{}) error {
+	if result == nil || len(data) == 0 || string(data) == "null" {
 		return nil
 	}
 
-	if err := json.Unmarshal(envelope.Data, result); err != nil {
+	if err := json.Unmarshal(data, result); err != nil {
 		return fmt.Errorf("failed to unmarshal GraphQL data payload: %w", err)
 	}
 	return nil
@@ -0,0 +1,94 @@
+package runpod
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// WithInputDefaults registers default input fields for endpointID that
+// RunAsync and RunSync (and so RunAndWait, SubmitMultipleJobs and the
+// typed endpoint clients) deep-merge into every job's input, so call sites
+// pass only what varies:
+//
+//	runpod.WithInputDefaults("sdxl-endpoint", map[string]any{
+//		"model":   "sdxl-base-1.0",
+//		"sampler": map[string]any{"name": "euler", "steps": 30},
+//	})
+//
+// defaults is anything that encodes to a JSON object: a map or a struct.
+// Objects merge key by key at every depth; anything else in the call's
+// input, arrays and nulls included, replaces the default. A call with a
+// nil input gets the defaults alone. Given again for the same endpoint,
+// the later defaults replace the earlier ones.
+func WithInputDefaults(endpointID string, defaults interface{}) ClientOption {
+	return func(c *Client) {
+		if c.inputDefaults == nil {
+			c.inputDefaults = map[string]interface{}{}
+		}
+		c.inputDefaults[endpointID] = defaults
+	}
+}
+
+// applyInputDefaults returns input merged over endpointID's defaults, or
+// input unchanged when there are none.
+func (c *Client) applyInputDefaults(endpointID string, input interface{}) (interface{}, error) {
+	defaults, ok := c.inputDefaults[endpointID]
+	if !ok {
+		return input, nil
+	}
+	base, err := jsonObject(defaults)
+	if err != nil || base == nil {
+		return nil, NewValidationErrorWithValue("inputDefaults", "must encode to a JSON object", endpointID)
+	}
+	if input == nil {
+		return base, nil
+	}
+	override, err := jsonObject(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode input: %w", err)
+	}
+	if override == nil {
+		return nil, NewValidationError("input", "must encode to a JSON object to merge with the endpoint's input defaults")
+	}
+	return mergeJSONObjects(base, override), nil
+}
+
+// jsonObject encodes v and decodes it as a JSON object; it returns nil
+// without an error when v encodes to something else.
+func jsonObject(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var obj map[string]interface{}
+	if err := dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// mergeJSONObjects returns base with override merged in: nested objects
+// merge recursively, any other override value wins. Neither map is
+// modified.
+func mergeJSONObjects(base, override map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base)+len(override))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range override {
+		baseObj, baseIsObj := merged[k].(map[string]interface{})
+		overrideObj, overrideIsObj := v.(map[string]interface{})
+		if baseIsObj && overrideIsObj {
+			merged[k] = mergeJSONObjects(baseObj, overrideObj)
+			continue
+		}
+		merged[k] = v
+	}
+	return merged
+}
@@ -0,0 +1,59 @@
+package runpod_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestWithInputDefaults(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	type sampler struct {
+		Name  string `json:"name"`
+		Steps int    `json:"steps"`
+	}
+	client := srv.MustClient(runpod.WithInputDefaults("sdxl", map[string]any{
+		"model":   "sdxl-base-1.0",
+		"sampler": sampler{Name: "euler", Steps: 30},
+		"loras":   []string{"detail"},
+	}))
+	ctx := t.Context()
+
+	// runsync on the fake echoes the input as the output.
+	job, err := client.RunSync(ctx, "sdxl", map[string]any{
+		"prompt":  "a lighthouse",
+		"sampler": map[string]any{"steps": 50},
+		"loras":   []string{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(job.Output, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"loras":[],"model":"sdxl-base-1.0","prompt":"a lighthouse","sampler":{"name":"euler","steps":50}}`
+	if data, _ := json.Marshal(got); string(data) != want {
+		t.Fatalf("input = %s, want %s", data, want)
+	}
+
+	if job, err = client.RunSync(ctx, "sdxl", nil); err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"loras":["detail"],"model":"sdxl-base-1.0","sampler":{"name":"euler","steps":30}}`; string(job.Output) != want {
+		t.Fatalf("defaults alone = %s, want %s", job.Output, want)
+	}
+
+	// Other endpoints are untouched, and a non-object input cannot merge.
+	if job, err = client.RunSync(ctx, "other", []int{1}); err != nil || string(job.Output) != `[1]` {
+		t.Fatalf("other endpoint = %s, %v", job.Output, err)
+	}
+	var validationErr *runpod.ValidationError
+	if _, err := client.RunAsync(ctx, "sdxl", "prompt"); !errors.As(err, &validationErr) {
+		t.Fatalf("string input = %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+// Command clonegen writes clone_gen.go in the runpod package: a Clone
+// method for each type in roots, and for the structs they reach through
+// pointers, that deep-copies slices, maps and pointers. Run it with
+// go generate after changing one of those types; -o writes elsewhere, for
+// checking the committed file is current.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"reflect"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+)
+
+// roots are the types callers copy and mutate: request templates shared
+// across calls, and models they adjust before writing back.
+var roots = []reflect.Type{
+	reflect.TypeFor[runpod.CreatePodRequest](),
+	reflect.TypeFor[runpod.CreateEndpointRequest](),
+	reflect.TypeFor[runpod.UpdateEndpointRequest](),
+	reflect.TypeFor[runpod.CreateTemplateRequest](),
+	reflect.TypeFor[runpod.UpdateTemplateRequest](),
+	reflect.TypeFor[runpod.Pod](),
+	reflect.TypeFor[runpod.Endpoint](),
+	reflect.TypeFor[runpod.Template](),
+	reflect.TypeFor[runpod.Job](),
+}
+
+var pkgPath = reflect.TypeFor[runpod.Pod]().PkgPath()
+
+func main() {
+	output := flag.String("o", "clone_gen.go", "output file")
+	flag.Parse()
+
+	g := &generator{done: map[reflect.Type]bool{}}
+	for _, t := range roots {
+		g.queue(t)
+	}
+	for len(g.pending) > 0 {
+		t := g.pending[0]
+		g.pending = g.pending[1:]
+		g.method(t)
+	}
+
+	src := "// Code generated by clonegen; DO NOT EDIT.\n\npackage runpod\n\nimport (\n\t\"maps\"\n\t\"slices\"\n)\n\n" + g.buf.String()
+	out, err := format.Source([]byte(src))
+	if err != nil {
+		log.Fatalf("clonegen: %v\n%s", err, src)
+	}
+	if err := os.WriteFile(*output, out, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+type generator struct {
+	buf     bytes.Buffer
+	done    map[reflect.Type]bool
+	pending []reflect.Type
+}
+
+func (g *generator) queue(t reflect.Type) {
+	if t.PkgPath() != pkgPath || t.Kind() != reflect.Struct {
+		log.Fatalf("clonegen: %v is not a runpod struct", t)
+	}
+	if !g.done[t] {
+		g.done[t] = true
+		g.pending = append(g.pending, t)
+	}
+}
+
+func (g *generator) method(t reflect.Type) {
+	fmt.Fprintf(&g.buf, "// Clone returns a deep copy of x; nil for nil.\n")
+	fmt.Fprintf(&g.buf, "func (x *%s) Clone() *%[1]s {\n\tif x == nil {\n\t\treturn nil\n\t}\n\tc := *x\n", t.Name())
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if expr := g.copyExpr("x."+f.Name, f.Type); expr != "" {
+			fmt.Fprintf(&g.buf, "\tc.%s = %s\n", f.Name, expr)
+		}
+	}
+	fmt.Fprintf(&g.buf, "\treturn &c\n}\n\n")
+}
+
+// copyExpr returns an expression deep-copying v of type t, or "" when
+// assignment already copies it.
+func (g *generator) copyExpr(v string, t reflect.Type) string {
+	if !hasRefs(t) {
+		return ""
+	}
+	switch t.Kind() {
+	case reflect.Slice:
+		if !hasRefs(t.Elem()) {
+			return "slices.Clone(" + v + ")"
+		}
+		if e := t.Elem(); e.Kind() == reflect.Pointer && e.Elem().Kind() == reflect.Struct {
+			g.queue(e.Elem())
+			return "cloneEach(" + v + ")"
+		}
+		if e := t.Elem(); e.Kind() == reflect.Struct {
+			g.queue(e)
+			return "cloneValues(" + v + ")"
+		}
+	case reflect.Map:
+		if !hasRefs(t.Elem()) {
+			return "maps.Clone(" + v + ")"
+		}
+	case reflect.Pointer:
+		if hasRefs(t.Elem()) {
+			g.queue(t.Elem())
+			return v + ".Clone()"
+		}
+		return "clonePtr(" + v + ")"
+	case reflect.Struct:
+		g.queue(t)
+		return "*" + v + ".Clone()"
+	}
+	log.Fatalf("clonegen: cannot deep-copy %s of type %v", v, t)
+	return ""
+}
+
+// hasRefs reports whether assigning a t shares memory with the original.
+// Interface values are copied shallowly, and time.Time is a value.
+func hasRefs(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Pointer:
+		return true
+	case reflect.Array:
+		return hasRefs(t.Elem())
+	case reflect.Struct:
+		if t == reflect.TypeFor[time.Time]() {
+			return false
+		}
+		for i := range t.NumField() {
+			if hasRefs(t.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
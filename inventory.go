@@ -0,0 +1,220 @@
+package runpod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Inventory is a snapshot of everything on the account, with the key
+// fields of each resource, for status dashboards and drift checks.
+type Inventory struct {
+	At      time.Time   `json:"at"`
+	Account AccountInfo `json:"account"`
+	// SpendPerHr is the account's current USD/hr burn.
+	SpendPerHr float64         `json:"spendPerHr"`
+	Counts     InventoryCounts `json:"counts"`
+
+	Pods           []PodSummary           `json:"pods"`
+	Endpoints      []EndpointSummary      `json:"endpoints"`
+	Templates      []TemplateSummary      `json:"templates"`
+	NetworkVolumes []NetworkVolumeSummary `json:"networkVolumes"`
+	// Secrets are the secret names; values are never returned.
+	Secrets []string `json:"secrets"`
+}
+
+// InventoryCounts totals an Inventory.
+type InventoryCounts struct {
+	Pods        int `json:"pods"`
+	RunningPods int `json:"runningPods"`
+	Endpoints   int `json:"endpoints"`
+	// Workers counts the endpoints' running workers.
+	Workers        int `json:"workers"`
+	Templates      int `json:"templates"`
+	NetworkVolumes int `json:"networkVolumes"`
+	// VolumeGB is the network volumes' total size.
+	VolumeGB int `json:"volumeGb"`
+	Secrets  int `json:"secrets"`
+}
+
+// PodSummary is a pod's key fields.
+type PodSummary struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	DesiredStatus string `json:"desiredStatus"`
+	ImageName     string `json:"imageName,omitempty"`
+	// GPUTypeID is empty for CPU pods, which have CPUFlavorID instead.
+	GPUTypeID    string  `json:"gpuTypeId,omitempty"`
+	GPUCount     int     `json:"gpuCount,omitempty"`
+	CPUFlavorID  string  `json:"cpuFlavorId,omitempty"`
+	DataCenterID string  `json:"dataCenterId,omitempty"`
+	CostPerHr    float64 `json:"costPerHr"`
+}
+
+// EndpointSummary is an endpoint's key fields.
+type EndpointSummary struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	TemplateID string `json:"templateId"`
+	Version    int    `json:"version"`
+	WorkersMin int    `json:"workersMin"`
+	WorkersMax int    `json:"workersMax"`
+	// Workers counts the running workers; CostPerHr is what they cost.
+	Workers   int     `json:"workers"`
+	CostPerHr float64 `json:"costPerHr"`
+}
+
+// TemplateSummary is a template's key fields.
+type TemplateSummary struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	ImageName    string `json:"imageName"`
+	IsServerless bool   `json:"isServerless"`
+}
+
+// NetworkVolumeSummary is a network volume's key fields.
+type NetworkVolumeSummary struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Size         int    `json:"size"` // GB
+	DataCenterID string `json:"dataCenterId"`
+	// Pods counts the pods the volume is attached to.
+	Pods int `json:"pods"`
+}
+
+// GetInventory reads the account, pods, endpoints (with workers),
+// templates, network volumes and secrets concurrently and summarizes them.
+// Each list is sorted by name, then ID. If any read fails, the error joins
+// every failure and no inventory is returned.
+func (c *Client) GetInventory(ctx context.Context) (*Inventory, error) {
+	var (
+		account   *AccountInfo
+		pods      []*Pod
+		endpoints []Endpoint
+		templates []Template
+		volumes   []NetworkVolume
+		secrets   []*Secret
+	)
+	reads := []func() error{
+		func() (err error) { account, err = c.Whoami(ctx); return },
+		func() (err error) { pods, err = c.ListPods(ctx, nil); return },
+		func() (err error) {
+			endpoints, err = c.ListEndpoints(ctx, &GetEndpointOptions{IncludeWorkers: true})
+			return
+		},
+		func() (err error) { templates, err = c.ListTemplates(ctx); return },
+		func() (err error) { volumes, err = c.ListNetworkVolumes(ctx); return },
+		func() (err error) { secrets, err = c.ListSecrets(ctx, nil); return },
+	}
+	errs := make([]error, len(reads))
+	var wg sync.WaitGroup
+	for i, read := range reads {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = read()
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("failed to get inventory: %w", err)
+	}
+
+	inv := &Inventory{
+		At:             c.clock.Now(),
+		Account:        *account,
+		SpendPerHr:     account.CurrentSpendPerHr,
+		Pods:           []PodSummary{},
+		Endpoints:      []EndpointSummary{},
+		Templates:      []TemplateSummary{},
+		NetworkVolumes: []NetworkVolumeSummary{},
+		Secrets:        []string{},
+	}
+	for _, pod := range pods {
+		inv.Pods = append(inv.Pods, summarizePod(pod))
+		if pod.DesiredStatus == "RUNNING" {
+			inv.Counts.RunningPods++
+		}
+	}
+	for _, endpoint := range endpoints {
+		s := EndpointSummary{
+			ID:         endpoint.ID,
+			Name:       endpoint.Name,
+			TemplateID: endpoint.TemplateID,
+			Version:    endpoint.Version,
+			WorkersMin: endpoint.WorkersMin,
+			WorkersMax: endpoint.WorkersMax,
+		}
+		for _, worker := range endpoint.Workers {
+			if worker != nil && worker.DesiredStatus == "RUNNING" {
+				s.Workers++
+				s.CostPerHr = roundCost(s.CostPerHr + runningCostPerHr(worker))
+			}
+		}
+		inv.Counts.Workers += s.Workers
+		inv.Endpoints = append(inv.Endpoints, s)
+	}
+	for _, t := range templates {
+		inv.Templates = append(inv.Templates, TemplateSummary{ID: t.ID, Name: t.Name, ImageName: t.ImageName, IsServerless: t.IsServerless})
+	}
+	for _, v := range volumes {
+		inv.NetworkVolumes = append(inv.NetworkVolumes, NetworkVolumeSummary{ID: v.ID, Name: v.Name, Size: v.Size, DataCenterID: v.DataCenterID, Pods: len(v.PodIds)})
+		inv.Counts.VolumeGB += v.Size
+	}
+	for _, s := range secrets {
+		inv.Secrets = append(inv.Secrets, s.Name)
+	}
+
+	sortByName(inv.Pods, func(p PodSummary) (string, string) { return p.Name, p.ID })
+	sortByName(inv.Endpoints, func(e EndpointSummary) (string, string) { return e.Name, e.ID })
+	sortByName(inv.Templates, func(t TemplateSummary) (string, string) { return t.Name, t.ID })
+	sortByName(inv.NetworkVolumes, func(v NetworkVolumeSummary) (string, string) { return v.Name, v.ID })
+	sort.Strings(inv.Secrets)
+	inv.Counts.Pods = len(inv.Pods)
+	inv.Counts.Endpoints = len(inv.Endpoints)
+	inv.Counts.Templates = len(inv.Templates)
+	inv.Counts.NetworkVolumes = len(inv.NetworkVolumes)
+	inv.Counts.Secrets = len(inv.Secrets)
+	return inv, nil
+}
+
+func summarizePod(pod *Pod) PodSummary {
+	s := PodSummary{
+		ID:            pod.ID,
+		Name:          pod.Name,
+		DesiredStatus: pod.DesiredStatus,
+		ImageName:     pod.ImageName,
+		GPUCount:      pod.GPUCount,
+		CPUFlavorID:   pod.CPUFlavorID,
+		CostPerHr:     pod.CostPerHour,
+	}
+	if pod.AdjustedCostPerHr > 0 {
+		s.CostPerHr = pod.AdjustedCostPerHr
+	}
+	if pod.CPUFlavorID == "" {
+		if pod.GPU != nil {
+			s.GPUTypeID = pod.GPU.ID
+		}
+		if s.GPUTypeID == "" && pod.Machine != nil && pod.Machine.GPUTypeID != "unknown" {
+			s.GPUTypeID = pod.Machine.GPUTypeID
+		}
+	}
+	if pod.Machine != nil {
+		s.DataCenterID = pod.Machine.DataCenterID
+	}
+	return s
+}
+
+func sortByName[T any](items []T, key func(T) (name, id string)) {
+	sort.SliceStable(items, func(i, j int) bool {
+		ni, ii := key(items[i])
+		nj, ij := key(items[j])
+		if ni != nj {
+			return ni < nj
+		}
+		return ii < ij
+	})
+}
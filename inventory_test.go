@@ -0,0 +1,60 @@
+package runpod_test
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestGetInventory(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+	ctx := t.Context()
+
+	srv.SetAccount(runpod.AccountInfo{ID: "acct", CurrentSpendPerHr: 3.25})
+	srv.AddPod(&runpod.Pod{ID: "p2", Name: "train", DesiredStatus: "RUNNING", ImageName: "trainer:1", GPUCount: 2, CostPerHour: 2,
+		GPU: &runpod.PodGPU{ID: "NVIDIA A40", Count: 2}, Machine: &runpod.Machine{DataCenterID: "EU-SE-1"}})
+	srv.AddPod(&runpod.Pod{ID: "p1", Name: "etl", DesiredStatus: "EXITED", CPUFlavorID: "cpu3c", CostPerHour: 0.25})
+	srv.AddEndpoint(&runpod.Endpoint{ID: "e1", Name: "sd", TemplateID: "t1", Version: 3, WorkersMax: 3, Workers: []*runpod.Pod{
+		{ID: "w1", DesiredStatus: "RUNNING", CostPerHour: 0.5},
+		{ID: "w2", DesiredStatus: "RUNNING", CostPerHour: 0.5},
+		{ID: "w3", DesiredStatus: "EXITED", CostPerHour: 0.5},
+	}})
+	srv.AddTemplate(&runpod.Template{ID: "t1", Name: "sd-worker", ImageName: "sd:2", IsServerless: true})
+	srv.AddNetworkVolume(&runpod.NetworkVolume{ID: "v1", Name: "models", Size: 100, DataCenterID: "EU-SE-1", PodIds: []string{"p2"}})
+	srv.AddSecret("hf-token", "secret")
+	srv.AddSecret("api-key", "secret")
+
+	inv, err := client.GetInventory(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantCounts := runpod.InventoryCounts{Pods: 2, RunningPods: 1, Endpoints: 1, Workers: 2, Templates: 1, NetworkVolumes: 1, VolumeGB: 100, Secrets: 2}
+	if inv.Counts != wantCounts || inv.SpendPerHr != 3.25 || inv.Account.ID != "acct" || inv.At.IsZero() {
+		t.Fatalf("inventory = %+v", inv)
+	}
+	wantPods := []runpod.PodSummary{
+		{ID: "p1", Name: "etl", DesiredStatus: "EXITED", CPUFlavorID: "cpu3c", CostPerHr: 0.25},
+		{ID: "p2", Name: "train", DesiredStatus: "RUNNING", ImageName: "trainer:1", GPUTypeID: "NVIDIA A40", GPUCount: 2, DataCenterID: "EU-SE-1", CostPerHr: 2},
+	}
+	if !reflect.DeepEqual(inv.Pods, wantPods) {
+		t.Fatalf("pods = %+v", inv.Pods)
+	}
+	wantEndpoint := runpod.EndpointSummary{ID: "e1", Name: "sd", TemplateID: "t1", Version: 3, WorkersMax: 3, Workers: 2, CostPerHr: 1}
+	if len(inv.Endpoints) != 1 || inv.Endpoints[0] != wantEndpoint {
+		t.Fatalf("endpoints = %+v", inv.Endpoints)
+	}
+	if !reflect.DeepEqual(inv.Secrets, []string{"api-key", "hf-token"}) || inv.NetworkVolumes[0].Pods != 1 || !inv.Templates[0].IsServerless {
+		t.Fatalf("inventory = %+v", inv)
+	}
+
+	srv.FailNext(http.StatusUnauthorized, `{"error":"unauthorized"}`, "")
+	if _, err := client.GetInventory(ctx); !errors.Is(err, runpod.ErrUnauthorized) {
+		t.Fatalf("GetInventory with a failed read: %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package runpod
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// MaxJobStatusWait caps how long one long-poll status request asks RunPod
+// to hold, matching the ~90s RunPod holds a /runsync connection.
+const MaxJobStatusWait = 90 * time.Second
+
+// longPollMargin is kept between a long-poll's wait and the HTTP client's
+// timeout, for the response to arrive before the client gives up on it.
+const longPollMargin = 5 * time.Second
+
+// WithLongPoll makes WaitForJobCompletion (and so RunAndWait) poll with
+// GetJobStatusWait, holding each status request open for up to wait until
+// the job changes state. A short job is then seen finishing as it finishes
+// rather than on the next 5s poll, in one or two requests. wait is capped
+// at MaxJobStatusWait and kept 5s under the client's HTTP timeout (see
+// WithTimeout), so the default 30s timeout allows 25s. A non-positive wait
+// turns long-polling off, the default.
+func WithLongPoll(wait time.Duration) ClientOption {
+	return func(c *Client) {
+		c.longPollWait = wait
+	}
+}
+
+// GetJobStatusWait is GetJobStatus with a server-side wait: RunPod holds the
+// request for up to wait, returning as soon as the job changes state from
+// the one it was in when the request arrived, or reaches a terminal one.
+// Either way the job is returned as it is then. wait is capped as described
+// at WithLongPoll; a non-positive wait makes this a plain GetJobStatus.
+func (c *Client) GetJobStatusWait(ctx context.Context, endpointID, jobID string, wait time.Duration) (*Job, error) {
+	if err := c.validateRequired("endpointID", endpointID); err != nil {
+		return nil, err
+	}
+	if err := c.validateRequired("jobID", jobID); err != nil {
+		return nil, err
+	}
+
+	endpoint := c.serverlessURL(fmt.Sprintf("/v2/%s/status/%s", endpointID, jobID))
+	if wait = c.capLongPoll(wait); wait > 0 {
+		endpoint += "?wait=" + strconv.FormatInt(wait.Milliseconds(), 10)
+	}
+
+	var job Job
+	err := c.Get(ctx, endpoint, &job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status for job %s on endpoint %s: %w", jobID, endpointID, err)
+	}
+	c.observeJob(endpointID, &job)
+
+	return &job, nil
+}
+
+// capLongPoll limits a long-poll wait to MaxJobStatusWait and to what the
+// HTTP client's timeout leaves room for, and to whole milliseconds, the
+// unit RunPod takes.
+func (c *Client) capLongPoll(wait time.Duration) time.Duration {
+	wait = min(wait, MaxJobStatusWait)
+	if timeout := c.httpClient.Timeout; timeout > 0 {
+		wait = min(wait, timeout-longPollMargin)
+	}
+	return wait.Truncate(time.Millisecond)
+}
@@ -0,0 +1,46 @@
+package runpod
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultDeadlineMargin is the WithDeadlinePolicy margin used when it is
+// given a non-positive one.
+const DefaultDeadlineMargin = 5 * time.Second
+
+// WithDeadlinePolicy makes RunAsync and RunSync (and so RunAndWait and
+// SubmitMultipleJobs) derive a JobPolicy from the caller's ctx deadline,
+// so that a job stops when the caller stops waiting for it instead of
+// running on. The time left before the deadline, less margin (which covers
+// the round trip and reading the result), becomes both the job's TTL and
+// its ExecutionTimeout. The derived timeout replaces the endpoint's for
+// that job, so a distant deadline also lengthens it. Calls whose ctx has no
+// deadline are unaffected; a deadline nearer than margin fails the call
+// with an error wrapping context.DeadlineExceeded before anything is
+// submitted.
+func WithDeadlinePolicy(margin time.Duration) ClientOption {
+	return func(c *Client) {
+		if margin <= 0 {
+			margin = DefaultDeadlineMargin
+		}
+		c.deadlineMargin = margin
+	}
+}
+
+// jobPolicy returns the policy WithDeadlinePolicy derives for ctx, or nil.
+func (c *Client) jobPolicy(ctx context.Context) (*JobPolicy, error) {
+	if c.deadlineMargin <= 0 {
+		return nil, nil
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return nil, nil
+	}
+	left := deadline.Sub(c.clock.Now()) - c.deadlineMargin
+	if left < time.Millisecond {
+		return nil, fmt.Errorf("%w: %s left is within the %s deadline margin", context.DeadlineExceeded, deadline.Sub(c.clock.Now()).Round(time.Millisecond), c.deadlineMargin)
+	}
+	return &JobPolicy{ExecutionTimeout: DurationMS(left), TTL: DurationMS(left)}, nil
+}
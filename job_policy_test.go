@@ -0,0 +1,61 @@
+package runpod_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestWithDeadlinePolicy(t *testing.T) {
+	var bodies []map[string]json.RawMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]json.RawMessage
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		json.NewEncoder(w).Encode(runpod.Job{ID: "job-1", Status: "IN_QUEUE"})
+	}))
+	defer srv.Close()
+	now := time.Now()
+	clock := runpodtest.NewClock(now)
+	client := mustClient(t, "test_key", runpod.WithServerlessBaseURL(srv.URL), runpod.WithClock(clock), runpod.WithDeadlinePolicy(10*time.Second))
+
+	ctx, cancel := context.WithDeadline(t.Context(), now.Add(time.Minute))
+	defer cancel()
+	if _, err := client.RunAsync(ctx, "ep1", map[string]int{"n": 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.RunSync(t.Context(), "ep1", map[string]int{"n": 2}); err != nil {
+		t.Fatal(err)
+	}
+	if len(bodies) != 2 {
+		t.Fatalf("requests = %d", len(bodies))
+	}
+	if got := string(bodies[0]["policy"]); got != `{"executionTimeout":50000,"ttl":50000}` {
+		t.Fatalf("policy = %s", got)
+	}
+	if _, ok := bodies[1]["policy"]; ok {
+		t.Fatalf("policy sent without a deadline: %s", bodies[1]["policy"])
+	}
+
+	// Within the margin of the deadline nothing is submitted.
+	clock.Advance(55 * time.Second)
+	if _, err := client.RunAsync(ctx, "ep1", map[string]int{"n": 3}); !errors.Is(err, context.DeadlineExceeded) || len(bodies) != 2 {
+		t.Fatalf("RunAsync near deadline: %v (%d requests)", err, len(bodies))
+	}
+
+	// Without the option the deadline is not sent.
+	plain := mustClient(t, "test_key", runpod.WithServerlessBaseURL(srv.URL))
+	if _, err := plain.RunAsync(ctx, "ep1", map[string]int{"n": 4}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := bodies[2]["policy"]; ok {
+		t.Fatalf("policy sent without WithDeadlinePolicy: %s", bodies[2]["policy"])
+	}
+}
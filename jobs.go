@@ -2,7 +2,11 @@ package runpod
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"strings"
 	"time"
 )
 
@@ -17,12 +21,15 @@ func (c *Client) RunAsync(ctx context.Context, endpointID string, input interfac
 		return nil, err
 	}
 
-	req := &RunJobRequest{Input: input}
+	req, err := c.runJobRequest(ctx, endpointID, input)
+	if err != nil {
+		return nil, err
+	}
 
 	endpoint := c.serverlessURL(fmt.Sprintf("/v2/%s/run", endpointID))
 
 	var job Job
-	err := c.Post(ctx, endpoint, req, &job)
+	err = c.Post(ctx, endpoint, req, &job)
 	if err != nil {
 		return nil, fmt.Errorf("failed to submit async job to endpoint %s: %w", endpointID, err)
 	}
@@ -30,6 +37,20 @@ func (c *Client) RunAsync(ctx context.Context, endpointID string, input interfac
 	return &job, nil
 }
 
+// runJobRequest builds the request RunAsync and RunSync send: input over
+// the endpoint's WithInputDefaults, under WithDeadlinePolicy's policy.
+func (c *Client) runJobRequest(ctx context.Context, endpointID string, input interface{}) (*RunJobRequest, error) {
+	input, err := c.applyInputDefaults(endpointID, input)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := c.jobPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &RunJobRequest{Input: input, Policy: policy}, nil
+}
+
 // RunSync submits a synchronous job and waits for completion.
 // Blocks until the job completes or times out.
 //
@@ -42,37 +63,26 @@ func (c *Client) RunSync(ctx context.Context, endpointID string, input interface
 		return nil, err
 	}
 
-	req := &RunJobRequest{Input: input}
+	req, err := c.runJobRequest(ctx, endpointID, input)
+	if err != nil {
+		return nil, err
+	}
 
 	endpoint := c.serverlessURL(fmt.Sprintf("/v2/%s/runsync", endpointID))
 
 	var job Job
-	err := c.Post(ctx, endpoint, req, &job)
+	err = c.Post(ctx, endpoint, req, &job)
 	if err != nil {
 		return nil, fmt.Errorf("failed to submit sync job to endpoint %s: %w", endpointID, err)
 	}
+	c.observeJob(endpointID, &job)
 
 	return &job, nil
 }
 
 // GetJobStatus retrieves the status and results of a job
 func (c *Client) GetJobStatus(ctx context.Context, endpointID, jobID string) (*Job, error) {
-	if err := c.validateRequired("endpointID", endpointID); err != nil {
-		return nil, err
-	}
-	if err := c.validateRequired("jobID", jobID); err != nil {
-		return nil, err
-	}
-
-	endpoint := c.serverlessURL(fmt.Sprintf("/v2/%s/status/%s", endpointID, jobID))
-
-	var job Job
-	err := c.Get(ctx, endpoint, &job)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get status for job %s on endpoint %s: %w", jobID, endpointID, err)
-	}
-
-	return &job, nil
+	return c.GetJobStatusWait(ctx, endpointID, jobID, 0)
 }
 
 // CancelJob cancels a running or queued job
@@ -114,20 +124,51 @@ func (c *Client) RetryJob(ctx context.Context, endpointID, jobID string) (*Job,
 	return &job, nil
 }
 
-// PurgeQueue clears all pending jobs from the endpoint queue
-// Does not affect jobs that are already running
-func (c *Client) PurgeQueue(ctx context.Context, endpointID string) error {
+// PurgeQueue removes every job still waiting in the endpoint's queue;
+// jobs already running are not affected. A purge cannot be undone, so
+// opts must either set Confirm or bound the queue length expected, which
+// is checked against GetHealth first. The result reports how many jobs
+// the API removed.
+func (c *Client) PurgeQueue(ctx context.Context, endpointID string, opts *PurgeQueueOptions) (*PurgeQueueResult, error) {
 	if err := c.validateRequired("endpointID", endpointID); err != nil {
-		return err
+		return nil, err
+	}
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	if opts.ExpectedMin > 0 || opts.ExpectedMax > 0 {
+		health, err := c.GetHealth(ctx, endpointID)
+		if err != nil {
+			return nil, err
+		}
+		queued := health.JobsInQueue
+		if queued < opts.ExpectedMin || (opts.ExpectedMax > 0 && queued > opts.ExpectedMax) {
+			return nil, &PurgeRefusedError{EndpointID: endpointID, Queued: queued, ExpectedMin: opts.ExpectedMin, ExpectedMax: opts.ExpectedMax}
+		}
 	}
 
 	endpoint := c.serverlessURL(fmt.Sprintf("/v2/%s/purge-queue", endpointID))
 
-	err := c.Post(ctx, endpoint, nil, nil)
+	var result PurgeQueueResult
+	err := c.Post(ctx, endpoint, nil, &result)
 	if err != nil {
-		return fmt.Errorf("failed to purge queue for endpoint %s: %w", endpointID, err)
+		return nil, fmt.Errorf("failed to purge queue for endpoint %s: %w", endpointID, err)
 	}
 
+	return &result, nil
+}
+
+func (o *PurgeQueueOptions) validate() error {
+	if o == nil || (!o.Confirm && o.ExpectedMin == 0 && o.ExpectedMax == 0) {
+		return NewValidationError("opts", "purging a queue requires Confirm or an expected job count")
+	}
+	if o.ExpectedMin < 0 {
+		return NewValidationErrorWithValue("expectedMin", "cannot be negative", o.ExpectedMin)
+	}
+	if o.ExpectedMax < 0 || (o.ExpectedMax > 0 && o.ExpectedMax < o.ExpectedMin) {
+		return NewValidationErrorWithValue("expectedMax", "must be 0 or at least expectedMin", o.ExpectedMax)
+	}
 	return nil
 }
 
@@ -154,15 +195,21 @@ func (c *Client) GetHealth(ctx context.Context, endpointID string) (*EndpointHea
 
 // WaitForJobCompletion waits for a job to complete or fail
 // Returns the final job state or an error if timeout is reached
+//
+// Polls are 5s apart. With WithLongPoll each poll waits server-side for
+// the job to change state, and a poll that sees a change is followed
+// immediately by the next.
 func (c *Client) WaitForJobCompletion(ctx context.Context, endpointID, jobID string, maxWaitTime time.Duration) (*Job, error) {
 	if maxWaitTime <= 0 {
 		maxWaitTime = 10 * time.Minute // Default timeout
 	}
 
-	deadline := time.Now().Add(maxWaitTime)
+	deadline := c.clock.Now().Add(maxWaitTime)
+	var lastStatus string
 
-	for time.Now().Before(deadline) {
-		job, err := c.GetJobStatus(ctx, endpointID, jobID)
+	for c.clock.Now().Before(deadline) {
+		polled := c.clock.Now()
+		job, err := c.GetJobStatusWait(ctx, endpointID, jobID, min(c.longPollWait, deadline.Sub(polled)))
 		if err != nil {
 			return nil, err
 		}
@@ -179,11 +226,18 @@ func (c *Client) WaitForJobCompletion(ctx context.Context, endpointID, jobID str
 			return job, fmt.Errorf("job %s timed out", jobID)
 		}
 
-		// Wait before next check
+		changed := lastStatus != "" && job.Status != lastStatus
+		lastStatus = job.Status
+		if c.longPollWait > 0 && changed {
+			continue
+		}
+
+		// Wait before next check; a long poll that was held counts
+		// toward the interval, and one held 5s or more needs no wait.
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
-		case <-time.After(5 * time.Second):
+		case <-c.clock.After(5*time.Second - c.clock.Now().Sub(polled)):
 			// Continue polling
 		}
 	}
@@ -210,6 +264,66 @@ func (c *Client) IsJobTerminal(status string) bool {
 	return false
 }
 
+// FetchFullOutput returns the job's complete output. When the output was
+// too large to return inline (see Job.OutputOversized) it is downloaded from
+// the referenced URL and stored in job.Output; otherwise job.Output is
+// returned as is. The API key is sent only to RunPod's own hosts, never to
+// the presigned storage URLs outputs are usually uploaded to.
+func (c *Client) FetchFullOutput(ctx context.Context, job *Job) (json.RawMessage, error) {
+	if job == nil {
+		return nil, NewValidationError("job", "is required")
+	}
+	outputURL, oversized := job.OutputOversized()
+	if !oversized {
+		return job.Output, nil
+	}
+	if outputURL == "" {
+		return nil, fmt.Errorf("job %s output was truncated and no URL to the full output was given", job.ID)
+	}
+	release, err := c.lifecycle.begin()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, outputURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch output of job %s: %w", job.ID, err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	if c.isRunPodURL(outputURL) {
+		c.setRequestHeaders(req, c.apiKey(), false)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch output of job %s: %w", job.ID, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch output of job %s: %w", job.ID, err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("failed to fetch output of job %s: %w", job.ID, c.parseErrorResponse(resp, body))
+	}
+	if !json.Valid(body) {
+		return nil, fmt.Errorf("failed to fetch output of job %s: response from %s is not JSON", job.ID, outputURL)
+	}
+
+	job.Output = body
+	return job.Output, nil
+}
+
+// isRunPodURL reports whether u is under one of the client's API base URLs.
+func (c *Client) isRunPodURL(u string) bool {
+	for _, base := range []string{c.baseURL, c.serverlessBaseURL, c.graphqlBaseURL} {
+		if base != "" && strings.HasPrefix(u, strings.TrimRight(base, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
 // ================================
 // STREAMING SUPPORT
 // ================================
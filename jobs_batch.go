@@ -0,0 +1,162 @@
+package runpod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Defaults for SubmitJobsOptions.
+const (
+	DefaultSubmitChunkSize   = 100
+	DefaultSubmitConcurrency = 10
+)
+
+// ErrSubmissionSkipped is the error of an input SubmitMultipleJobs did not
+// submit because the batch was aborted.
+var ErrSubmissionSkipped = errors.New("runpod: job not submitted, batch aborted")
+
+// SubmitJobsOptions tunes SubmitMultipleJobs. The zero value is usable.
+type SubmitJobsOptions struct {
+	// ChunkSize is how many inputs are worked through before the next
+	// chunk starts (default DefaultSubmitChunkSize), bounding how far a
+	// large batch gets ahead of an abort.
+	ChunkSize int
+	// Concurrency caps the submissions in flight at once (default
+	// DefaultSubmitConcurrency).
+	Concurrency int
+	// AbortOnError stops the batch at the first failed submission.
+	// Submissions already in flight finish; the inputs not yet started
+	// are left with ErrSubmissionSkipped.
+	AbortOnError bool
+}
+
+// JobSubmission is the outcome of submitting one input: the queued Job,
+// or the error that kept it from being queued.
+type JobSubmission struct {
+	// Index is the input's position in the batch.
+	Index int
+	Job   *Job
+	Err   error
+}
+
+// JobSubmissions are SubmitMultipleJobs' results, one per input in input
+// order.
+type JobSubmissions []JobSubmission
+
+// Jobs returns the jobs that were queued, in input order.
+func (s JobSubmissions) Jobs() []*Job {
+	var jobs []*Job
+	for _, r := range s {
+		if r.Job != nil {
+			jobs = append(jobs, r.Job)
+		}
+	}
+	return jobs
+}
+
+// Err joins the errors of the inputs that were not queued, each prefixed
+// with its index, or returns nil if every input was.
+func (s JobSubmissions) Err() error {
+	var errs []error
+	for _, r := range s {
+		if r.Err != nil {
+			errs = append(errs, fmt.Errorf("input %d: %w", r.Index, r.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SubmitMultipleJobs queues one job per input on an endpoint, as RunAsync
+// does, working through the inputs in chunks with bounded concurrency. It
+// returns a result per input rather than failing the whole batch when
+// some submissions fail; see JobSubmissions.Err.
+//
+// The returned error is non-nil only when the batch stopped early: with
+// AbortOnError after a failed submission, or when ctx is done. The results
+// are returned either way, so the jobs already queued can be tracked or
+// cancelled. Failed submissions are not retried beyond the client's usual
+// policy, which never retries POSTs on server errors, so that no input is
+// queued twice.
+func (c *Client) SubmitMultipleJobs(ctx context.Context, endpointID string, inputs []interface{}, opts *SubmitJobsOptions) (JobSubmissions, error) {
+	if err := c.validateRequired("endpointID", endpointID); err != nil {
+		return nil, err
+	}
+	o := SubmitJobsOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.ChunkSize < 0 {
+		return nil, NewValidationErrorWithValue("chunkSize", "cannot be negative", o.ChunkSize)
+	}
+	if o.Concurrency < 0 {
+		return nil, NewValidationErrorWithValue("concurrency", "cannot be negative", o.Concurrency)
+	}
+	if o.ChunkSize == 0 {
+		o.ChunkSize = DefaultSubmitChunkSize
+	}
+	if o.Concurrency == 0 {
+		o.Concurrency = DefaultSubmitConcurrency
+	}
+
+	results := make(JobSubmissions, len(inputs))
+	for i := range results {
+		results[i] = JobSubmission{Index: i, Err: ErrSubmissionSkipped}
+	}
+
+	var (
+		mu      sync.Mutex
+		failure error // first failure, under AbortOnError
+	)
+	stopped := func() error {
+		mu.Lock()
+		defer mu.Unlock()
+		if failure != nil {
+			return failure
+		}
+		return ctx.Err()
+	}
+
+	slots := make(chan struct{}, o.Concurrency)
+	for start := 0; start < len(inputs); start += o.ChunkSize {
+		end := min(start+o.ChunkSize, len(inputs))
+		var wg sync.WaitGroup
+		for i := start; i < end && stopped() == nil; i++ {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				continue
+			}
+			if stopped() != nil {
+				<-slots
+				break
+			}
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				defer func() { <-slots }()
+				job, err := c.RunAsync(ctx, endpointID, inputs[i])
+				mu.Lock()
+				defer mu.Unlock()
+				results[i] = JobSubmission{Index: i, Job: job, Err: err}
+				if err != nil && o.AbortOnError && failure == nil {
+					failure = fmt.Errorf("batch aborted at input %d: %w", i, err)
+				}
+			}(i)
+		}
+		wg.Wait()
+		if err := stopped(); err != nil {
+			if failure == nil {
+				// ctx is done: the inputs never started were cancelled.
+				for i := range results {
+					if results[i].Err == ErrSubmissionSkipped {
+						results[i].Err = err
+					}
+				}
+			}
+			return results, err
+		}
+	}
+	return results, nil
+}
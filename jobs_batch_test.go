@@ -0,0 +1,100 @@
+package runpod_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-sdk"
+)
+
+func TestSubmitMultipleJobs(t *testing.T) {
+	var (
+		mu             sync.Mutex
+		inFlight, peak int
+		submitted      []int
+		onSubmit       func()
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Input struct{ N int } `json:"input"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		submitted = append(submitted, req.Input.N)
+		if onSubmit != nil {
+			onSubmit()
+		}
+		mu.Unlock()
+		time.Sleep(2 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		if req.Input.N == 3 {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"bad input"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"id":"job-%d","status":"IN_QUEUE"}`, req.Input.N)
+	}))
+	defer srv.Close()
+	client := mustClient(t, "test_key", runpod.WithServerlessBaseURL(srv.URL))
+	inputs := make([]interface{}, 10)
+	for i := range inputs {
+		inputs[i] = map[string]int{"n": i}
+	}
+	ctx := context.Background()
+
+	results, err := client.SubmitMultipleJobs(ctx, "ep", inputs, &runpod.SubmitJobsOptions{ChunkSize: 4, Concurrency: 3})
+	if err != nil {
+		t.Fatalf("SubmitMultipleJobs: %v", err)
+	}
+	if len(results) != 10 || len(results.Jobs()) != 9 || peak > 3 || len(submitted) != 10 {
+		t.Fatalf("results = %+v, peak = %d, submitted = %v", results, peak, submitted)
+	}
+	for i, r := range results {
+		if r.Index != i || (i == 3) != (r.Err != nil) || (r.Job != nil && r.Job.ID != fmt.Sprintf("job-%d", i)) {
+			t.Fatalf("result %d = %+v", i, r)
+		}
+	}
+	var apiErr *runpod.APIError
+	if err := results.Err(); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("Err = %v", err)
+	}
+
+	// Abort on the first failure: nothing after it starts.
+	submitted = nil
+	results, err = client.SubmitMultipleJobs(ctx, "ep", inputs, &runpod.SubmitJobsOptions{ChunkSize: 2, Concurrency: 1, AbortOnError: true})
+	if !errors.As(err, &apiErr) || len(submitted) != 4 {
+		t.Fatalf("abort: err = %v, submitted = %v", err, submitted)
+	}
+	if len(results.Jobs()) != 3 || !errors.Is(results[4].Err, runpod.ErrSubmissionSkipped) || !errors.Is(results[9].Err, runpod.ErrSubmissionSkipped) {
+		t.Fatalf("abort results = %+v", results)
+	}
+
+	// A cancelled context stops the batch; unstarted inputs report it.
+	submitted = nil
+	cctx, cancel := context.WithCancel(ctx)
+	onSubmit = func() {
+		if len(submitted) == 2 {
+			cancel()
+		}
+	}
+	results, err = client.SubmitMultipleJobs(cctx, "ep", inputs, &runpod.SubmitJobsOptions{Concurrency: 1})
+	if !errors.Is(err, context.Canceled) || !errors.Is(results[9].Err, context.Canceled) || len(submitted) != 2 {
+		t.Fatalf("cancel: err = %v, results = %+v, submitted = %v", err, results, submitted)
+	}
+
+	var validationErr *runpod.ValidationError
+	if _, err := client.SubmitMultipleJobs(ctx, "ep", inputs, &runpod.SubmitJobsOptions{Concurrency: -1}); !errors.As(err, &validationErr) {
+		t.Fatalf("negative concurrency: %v", err)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
 )
 
 // ================================
@@ -98,7 +99,7 @@ func createJobTestServer() *httptest.Server {
 		// Purge queue: POST /v2/{endpoint_id}/purge-queue
 		case method == "POST" && strings.Contains(path, "/purge-queue"):
 			w.WriteHeader(http.StatusOK)
-			fmt.Fprintf(w, `{"message": "queue purged"}`)
+			fmt.Fprintf(w, `{"removed": 2, "status": "completed"}`)
 
 		// Get health: GET /v2/{endpoint_id}/health
 		case method == "GET" && strings.Contains(path, "/health"):
@@ -378,10 +379,29 @@ func TestPurgeQueue(t *testing.T) {
 	client := mustClient(t, "test_key", runpod.WithServerlessBaseURL(server.URL))
 	ctx := context.Background()
 
-	err := client.PurgeQueue(ctx, "endpoint-123")
+	result, err := client.PurgeQueue(ctx, "endpoint-123", &runpod.PurgeQueueOptions{Confirm: true})
 	if err != nil {
 		t.Errorf("PurgeQueue() error = %v", err)
 	}
+	if result.Removed != 2 || result.Status != "completed" {
+		t.Errorf("PurgeQueue() = %+v", result)
+	}
+
+	// The mock queue holds 5 jobs.
+	var validationErr *runpod.ValidationError
+	if _, err := client.PurgeQueue(ctx, "endpoint-123", nil); !errors.As(err, &validationErr) {
+		t.Errorf("unconfirmed PurgeQueue() error = %v", err)
+	}
+	var refused *runpod.PurgeRefusedError
+	if _, err := client.PurgeQueue(ctx, "endpoint-123", &runpod.PurgeQueueOptions{ExpectedMax: 3}); !errors.As(err, &refused) || refused.Queued != 5 {
+		t.Errorf("PurgeQueue() over ExpectedMax error = %v", err)
+	}
+	if _, err := client.PurgeQueue(ctx, "endpoint-123", &runpod.PurgeQueueOptions{ExpectedMin: 1, ExpectedMax: 5}); err != nil {
+		t.Errorf("PurgeQueue() in range error = %v", err)
+	}
+	if _, err := client.PurgeQueue(ctx, "endpoint-123", &runpod.PurgeQueueOptions{ExpectedMin: 4, ExpectedMax: 2}); !errors.As(err, &validationErr) {
+		t.Errorf("inverted range error = %v", err)
+	}
 }
 
 func TestGetHealth(t *testing.T) {
@@ -461,6 +481,38 @@ func TestWaitForJobCompletion(t *testing.T) {
 	}
 }
 
+func TestWaitForJobCompletionLongPoll(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient(runpod.WithLongPoll(10 * time.Second))
+	ctx := t.Context()
+
+	job, err := client.RunAsync(ctx, "ep1", map[string]int{"n": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A poll on a job that does not change is held for the whole wait.
+	start := time.Now()
+	if j, err := client.GetJobStatusWait(ctx, "ep1", job.ID, 50*time.Millisecond); err != nil || j.Status != "IN_QUEUE" || time.Since(start) < 50*time.Millisecond {
+		t.Fatalf("GetJobStatusWait = %+v, %v after %v", j, err, time.Since(start))
+	}
+
+	// The job finishes mid-poll and the waiter sees it then, not after
+	// the 5s poll interval.
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		srv.CompleteJob("ep1", job.ID, "ok")
+	}()
+	start = time.Now()
+	j, err := client.WaitForJobCompletion(ctx, "ep1", job.ID, time.Minute)
+	if err != nil || j.Status != "COMPLETED" {
+		t.Fatalf("WaitForJobCompletion = %+v, %v", j, err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("WaitForJobCompletion took %v", elapsed)
+	}
+}
+
 func TestRunAndWait(t *testing.T) {
 	server := createJobTestServer()
 	defer server.Close()
@@ -517,6 +569,64 @@ func TestStreamResults(t *testing.T) {
 	}
 }
 
+func TestFetchFullOutput(t *testing.T) {
+	var bucketAuth []string
+	bucket := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bucketAuth = append(bucketAuth, r.Header.Get("Authorization"))
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"error":"expired"}`)
+			return
+		}
+		fmt.Fprint(w, `{"images":["a","b"]}`)
+	}))
+	defer bucket.Close()
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test_key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"images":["c"]}`)
+	}))
+	defer api.Close()
+
+	client := mustClient(t, "test_key", runpod.WithServerlessBaseURL(api.URL))
+	ctx := context.Background()
+
+	inline := &runpod.Job{ID: "j0", Output: json.RawMessage(`{"ok":true}`)}
+	if out, err := client.FetchFullOutput(ctx, inline); err != nil || string(out) != `{"ok":true}` {
+		t.Fatalf("inline output = %s, %v", out, err)
+	}
+
+	var job runpod.Job
+	if err := json.Unmarshal([]byte(`{"id":"j1","status":"COMPLETED","output":{"output_url":"`+bucket.URL+`/out.json"}}`), &job); err != nil {
+		t.Fatal(err)
+	}
+	if url, ok := job.OutputOversized(); !ok || url != bucket.URL+"/out.json" {
+		t.Fatalf("OutputOversized = %q, %v", url, ok)
+	}
+	out, err := client.FetchFullOutput(ctx, &job)
+	if err != nil || string(out) != `{"images":["a","b"]}` || string(job.Output) != string(out) {
+		t.Fatalf("FetchFullOutput = %s, %v", out, err)
+	}
+	if bucketAuth[0] != "" {
+		t.Fatalf("API key sent to storage host: %q", bucketAuth[0])
+	}
+
+	// RunPod-hosted references get the API key.
+	hosted := &runpod.Job{ID: "j2", OutputURL: api.URL + "/v2/ep/output/j2"}
+	if out, err := client.FetchFullOutput(ctx, hosted); err != nil || string(out) != `{"images":["c"]}` {
+		t.Fatalf("hosted output = %s, %v", out, err)
+	}
+
+	if _, err := client.FetchFullOutput(ctx, &runpod.Job{ID: "j3", OutputURL: bucket.URL + "/missing"}); !errors.Is(err, runpod.ErrUnauthorized) {
+		t.Fatalf("expired URL err = %v", err)
+	}
+	if _, err := client.FetchFullOutput(ctx, &runpod.Job{ID: "j4", OutputTruncated: true}); err == nil {
+		t.Fatal("truncated output without a URL should fail")
+	}
+}
+
 // ================================
 // ERROR HANDLING TESTS
 // ================================
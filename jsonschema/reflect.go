@@ -0,0 +1,320 @@
+package jsonschema
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// For returns the schema of T, which must be a struct or a pointer to one;
+// see Reflect.
+func For[T any]() (*Schema, error) {
+	return Reflect(reflect.TypeFor[T]())
+}
+
+// Reflect derives the schema of a Go struct type as encoding/json sees it:
+// properties are named by json tags, "-" fields are skipped and embedded
+// structs are flattened. Other struct types used by the fields become
+// $defs. Objects reject unknown properties.
+//
+// A field is required unless its json tag has omitempty, it is a pointer,
+// slice, map or interface, or it has a default tag; validate:"required"
+// makes any field required. These tags add keywords:
+//
+//	description:"Steps to run."         description
+//	default:"30"                        default (JSON, or a bare string)
+//	format:"uri"                        format
+//	validate:"min=1,max=150"            minimum/maximum, or the length or item
+//	                                    count bounds of strings and slices
+//	validate:"gt=0,lt=1"                exclusiveMinimum/exclusiveMaximum
+//	validate:"len=8"                    exact length or item count
+//	validate:"oneof=euler ddim"         enum
+//	validate:"url" (or uri, email, uuid) format
+//
+// so the same struct tags serve go-playground/validator style validation
+// in the worker and the published contract.
+func Reflect(t reflect.Type) (*Schema, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("jsonschema: %s is not a struct", t)
+	}
+	r := &reflector{root: t, defs: map[string]*Schema{}, names: map[reflect.Type]string{}}
+	s, err := r.object(t)
+	if err != nil {
+		return nil, err
+	}
+	s.Schema = Draft
+	if len(r.defs) > 0 {
+		s.Defs = r.defs
+	}
+	return s, nil
+}
+
+type reflector struct {
+	root  reflect.Type
+	defs  map[string]*Schema
+	names map[reflect.Type]string
+}
+
+var (
+	timeType      = reflect.TypeFor[time.Time]()
+	rawType       = reflect.TypeFor[json.RawMessage]()
+	marshalerType = reflect.TypeFor[json.Marshaler]()
+	textType      = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// schema returns the schema of a field or element type.
+func (r *reflector) schema(t reflect.Type) (*Schema, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t == timeType:
+		return &Schema{Type: Types{"string"}, Format: "date-time"}, nil
+	case t == rawType:
+		return &Schema{}, nil
+	case t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType):
+		// A custom encoding could be anything, except for string kinds,
+		// which are typically enums that validate on the way out.
+		if t.Kind() == reflect.String {
+			return &Schema{Type: Types{"string"}}, nil
+		}
+		return &Schema{}, nil
+	case t.Implements(textType) || reflect.PointerTo(t).Implements(textType):
+		return &Schema{Type: Types{"string"}}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: Types{"boolean"}}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Schema{Type: Types{"integer"}}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		zero := 0.0
+		return &Schema{Type: Types{"integer"}, Minimum: &zero}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: Types{"number"}}, nil
+	case reflect.String:
+		return &Schema{Type: Types{"string"}}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			return &Schema{Type: Types{"string"}, Format: "byte"}, nil
+		}
+		items, err := r.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		s := &Schema{Type: Types{"array"}, Items: items}
+		if t.Kind() == reflect.Array {
+			n := t.Len()
+			s.MinItems, s.MaxItems = &n, &n
+		}
+		return s, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String && !t.Key().Implements(textType) {
+			return nil, fmt.Errorf("jsonschema: map key type %s cannot be encoded as JSON", t.Key())
+		}
+		values, err := r.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: Types{"object"}, AdditionalProperties: values}, nil
+	case reflect.Interface:
+		return &Schema{}, nil
+	case reflect.Struct:
+		return r.ref(t)
+	}
+	return nil, fmt.Errorf("jsonschema: type %s cannot be encoded as JSON", t)
+}
+
+// ref returns a $ref to t's definition, adding it on first use. Anonymous
+// structs are inlined instead.
+func (r *reflector) ref(t reflect.Type) (*Schema, error) {
+	if t.Name() == "" {
+		return r.object(t)
+	}
+	if t == r.root {
+		return &Schema{Ref: "#"}, nil
+	}
+	name, ok := r.names[t]
+	if !ok {
+		name = t.Name()
+		for i := 2; r.defs[name] != nil; i++ {
+			name = t.Name() + strconv.Itoa(i)
+		}
+		r.names[t] = name
+		r.defs[name] = &Schema{} // placeholder for recursive references
+		def, err := r.object(t)
+		if err != nil {
+			return nil, err
+		}
+		r.defs[name] = def
+	}
+	return &Schema{Ref: "#/$defs/" + name}, nil
+}
+
+// object returns the inline schema of struct type t.
+func (r *reflector) object(t reflect.Type) (*Schema, error) {
+	s := &Schema{Type: Types{"object"}, Properties: map[string]*Schema{}, AdditionalProperties: &Schema{Never: true}}
+	if err := r.fields(s, t); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// fields adds t's fields to s, flattening embedded structs the way
+// encoding/json does.
+func (r *reflector) fields(s *Schema, t reflect.Type) error {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if err := r.fields(s, ft); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		prop, err := r.schema(f.Type)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", t, f.Name, err)
+		}
+		if hasOption(opts, "string") && prop.Ref == "" && (prop.Type.Has("integer") || prop.Type.Has("number") || prop.Type.Has("boolean")) {
+			prop = &Schema{Type: Types{"string"}}
+		}
+		if err := annotate(prop, f.Tag); err != nil {
+			return fmt.Errorf("%s.%s: %w", t, f.Name, err)
+		}
+
+		required := !hasOption(opts, "omitempty") && prop.Default == nil
+		switch f.Type.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+			required = false
+		}
+		if hasOption(f.Tag.Get("validate"), "required") {
+			required = true
+		}
+		if required {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = prop
+	}
+	return nil
+}
+
+// annotate applies a field's description, default, format and validate
+// tags to its schema. On a $ref they become sibling keywords, which draft
+// 2020-12 allows.
+func annotate(s *Schema, tag reflect.StructTag) error {
+	s.Description = tag.Get("description")
+	if format := tag.Get("format"); format != "" {
+		s.Format = format
+	}
+	if def, ok := tag.Lookup("default"); ok {
+		// Strings may be written bare; anything else is JSON.
+		var v any
+		if err := json.Unmarshal([]byte(def), &v); err == nil && (!s.Type.Has("string") || strings.HasPrefix(def, `"`)) {
+			s.Default = v
+		} else if s.Type.Has("string") {
+			s.Default = def
+		} else {
+			return fmt.Errorf("default %q: not a JSON value", def)
+		}
+	}
+
+	validate := tag.Get("validate")
+	if validate == "" {
+		return nil
+	}
+	// Length bounds apply to strings, item counts to arrays, and value
+	// bounds to numbers.
+	isString, isArray := s.Type.Has("string"), s.Type.Has("array")
+	for _, rule := range strings.Split(validate, ",") {
+		key, value, _ := strings.Cut(rule, "=")
+		switch key {
+		case "min", "max", "gt", "gte", "lt", "lte", "len":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("validate %q: %w", rule, err)
+			}
+			switch {
+			case isString || isArray:
+				count := int(n)
+				minField, maxField := &s.MinLength, &s.MaxLength
+				if isArray {
+					minField, maxField = &s.MinItems, &s.MaxItems
+				}
+				switch key {
+				case "min", "gte":
+					*minField = &count
+				case "max", "lte":
+					*maxField = &count
+				case "gt":
+					count++
+					*minField = &count
+				case "lt":
+					count--
+					*maxField = &count
+				case "len":
+					*minField, *maxField = &count, &count
+				}
+			default:
+				switch key {
+				case "min", "gte":
+					s.Minimum = &n
+				case "max", "lte":
+					s.Maximum = &n
+				case "gt":
+					s.ExclusiveMinimum = &n
+				case "lt":
+					s.ExclusiveMaximum = &n
+				case "len":
+					s.Minimum, s.Maximum = &n, &n
+				}
+			}
+		case "oneof":
+			s.Enum = nil
+			for _, v := range strings.Fields(value) {
+				if isString {
+					s.Enum = append(s.Enum, v)
+				} else if n, err := strconv.ParseFloat(v, 64); err == nil {
+					s.Enum = append(s.Enum, n)
+				} else {
+					return fmt.Errorf("validate %q: %q is not a number", rule, v)
+				}
+			}
+		case "url", "uri":
+			s.Format = "uri"
+		case "email", "uuid":
+			s.Format = key
+		}
+	}
+	return nil
+}
+
+func hasOption(opts, name string) bool {
+	return slices.Contains(strings.Split(opts, ","), name)
+}
@@ -0,0 +1,91 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-sdk/jsonschema"
+)
+
+type lora struct {
+	Name   string  `json:"name" validate:"required,min=1"`
+	Weight float64 `json:"weight,omitempty" validate:"gte=0,lte=2"`
+}
+
+type common struct {
+	Seed *int64 `json:"seed,omitempty" description:"Random seed."`
+}
+
+type generateInput struct {
+	common
+	Prompt    string            `json:"prompt" validate:"required,max=500"`
+	Steps     int               `json:"steps" default:"30" validate:"min=1,max=150"`
+	Scheduler string            `json:"scheduler,omitempty" default:"euler" validate:"oneof=euler ddim"`
+	Image     string            `json:"image_url,omitempty" validate:"url"`
+	Loras     []lora            `json:"loras" validate:"max=4"`
+	Tags      map[string]string `json:"tags,omitempty"`
+	Strength  float32           `json:"strength,string,omitempty"`
+	Deadline  time.Time         `json:"deadline,omitempty"`
+	Extra     json.RawMessage   `json:"extra,omitempty"`
+	internal  bool
+	Ignored   bool `json:"-"`
+}
+
+func TestReflect(t *testing.T) {
+	s, err := jsonschema.For[*generateInput]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Schema != jsonschema.Draft || !s.AdditionalProperties.Never {
+		t.Fatalf("root = %+v", s)
+	}
+	if want := []string{"prompt"}; !reflect.DeepEqual(s.Required, want) {
+		t.Fatalf("required = %v, want %v", s.Required, want)
+	}
+	var names []string
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	if len(names) != 10 {
+		t.Fatalf("properties = %v", names)
+	}
+
+	p := s.Properties
+	if p["seed"].Description != "Random seed." || !p["seed"].Type.Has("integer") {
+		t.Fatalf("seed = %+v", p["seed"])
+	}
+	if *p["prompt"].MaxLength != 500 {
+		t.Fatalf("prompt = %+v", p["prompt"])
+	}
+	if p["steps"].Default != 30.0 || *p["steps"].Minimum != 1 || *p["steps"].Maximum != 150 {
+		t.Fatalf("steps = %+v", p["steps"])
+	}
+	if p["scheduler"].Default != "euler" || !reflect.DeepEqual(p["scheduler"].Enum, []any{"euler", "ddim"}) {
+		t.Fatalf("scheduler = %+v", p["scheduler"])
+	}
+	if p["image_url"].Format != "uri" || p["deadline"].Format != "date-time" || !p["strength"].Type.Has("string") {
+		t.Fatalf("formats = %+v %+v %+v", p["image_url"], p["deadline"], p["strength"])
+	}
+	if *p["loras"].MaxItems != 4 || p["loras"].Items.Ref != "#/$defs/lora" || !p["tags"].AdditionalProperties.Type.Has("string") {
+		t.Fatalf("collections = %+v %+v", p["loras"], p["tags"])
+	}
+	if len(p["extra"].Type) != 0 {
+		t.Fatalf("extra = %+v", p["extra"])
+	}
+	def := s.Defs["lora"]
+	if def == nil || !def.IsRequired("name") || def.IsRequired("weight") || *def.Properties["weight"].Maximum != 2 {
+		t.Fatalf("lora def = %+v", def)
+	}
+
+	if _, err := jsonschema.For[[]string](); err == nil {
+		t.Fatal("non-struct accepted")
+	}
+	type badDefault struct {
+		N int `json:"n" default:"many"`
+	}
+	if _, err := jsonschema.For[badDefault](); err == nil {
+		t.Fatal("non-JSON default accepted for an integer")
+	}
+}
@@ -0,0 +1,150 @@
+// Package jsonschema models the subset of JSON Schema the SDK reads and
+// writes: worker input and output contracts. It covers object, array and
+// scalar types, required properties, enums, defaults, local $refs and the
+// common validation keywords; it is not a general validator. For derives
+// a schema from a Go struct.
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Draft is the $schema URI of the JSON Schema version this package writes.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is one JSON Schema. Boolean schemas decode as well: true as the
+// empty schema, which accepts anything, and false as a schema with
+// Never set.
+type Schema struct {
+	Schema      string `json:"$schema,omitempty"`
+	ID          string `json:"$id,omitempty"`
+	Ref         string `json:"$ref,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	Type   Types  `json:"type,omitempty"`
+	Format string `json:"format,omitempty"`
+	Enum   []any  `json:"enum,omitempty"`
+	// Default is the value a worker fills in for an absent property.
+	Default any `json:"default,omitempty"`
+
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+
+	Minimum          *float64 `json:"minimum,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty"`
+	ExclusiveMinimum *float64 `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum *float64 `json:"exclusiveMaximum,omitempty"`
+	MinLength        *int     `json:"minLength,omitempty"`
+	MaxLength        *int     `json:"maxLength,omitempty"`
+	Pattern          string   `json:"pattern,omitempty"`
+	MinItems         *int     `json:"minItems,omitempty"`
+	MaxItems         *int     `json:"maxItems,omitempty"`
+
+	// Defs holds the schemas $refs point at; Definitions is the pre-2019
+	// spelling of the same keyword.
+	Defs        map[string]*Schema `json:"$defs,omitempty"`
+	Definitions map[string]*Schema `json:"definitions,omitempty"`
+
+	// Never marks the false schema, which accepts nothing.
+	Never bool `json:"-"`
+}
+
+// Types is the "type" keyword: one JSON type name, or several.
+type Types []string
+
+// UnmarshalJSON accepts a string or an array of strings.
+func (t *Types) UnmarshalJSON(b []byte) error {
+	var one string
+	if err := json.Unmarshal(b, &one); err == nil {
+		*t = Types{one}
+		return nil
+	}
+	return json.Unmarshal(b, (*[]string)(t))
+}
+
+// MarshalJSON writes a single type as a string.
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// Has reports whether typ is one of t.
+func (t Types) Has(typ string) bool {
+	return slices.Contains(t, typ)
+}
+
+// UnmarshalJSON decodes a schema object or a boolean schema.
+func (s *Schema) UnmarshalJSON(b []byte) error {
+	switch strings.TrimSpace(string(b)) {
+	case "true":
+		*s = Schema{}
+		return nil
+	case "false":
+		*s = Schema{Never: true}
+		return nil
+	}
+	type plain Schema
+	return json.Unmarshal(b, (*plain)(s))
+}
+
+// MarshalJSON writes the false schema as false.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	if s.Never {
+		return []byte("false"), nil
+	}
+	type plain Schema
+	return json.Marshal((*plain)(s))
+}
+
+// Parse decodes a schema document.
+func Parse(data []byte) (*Schema, error) {
+	var s Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("jsonschema: %w", err)
+	}
+	return &s, nil
+}
+
+// ReadFile reads and parses a schema file.
+func ReadFile(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Resolve returns the definition a local $ref such as "#/$defs/lora" or
+// "#/definitions/lora" names in root, and its name.
+func (s *Schema) Resolve(root *Schema) (name string, def *Schema, err error) {
+	for _, prefix := range []string{"#/$defs/", "#/definitions/"} {
+		if name, ok := strings.CutPrefix(s.Ref, prefix); ok {
+			defs := root.Defs
+			if prefix == "#/definitions/" {
+				defs = root.Definitions
+			}
+			if def := defs[name]; def != nil {
+				return name, def, nil
+			}
+		}
+	}
+	return "", nil, fmt.Errorf("jsonschema: cannot resolve $ref %q", s.Ref)
+}
+
+// IsRequired reports whether name is in s.Required.
+func (s *Schema) IsRequired(name string) bool {
+	return slices.Contains(s.Required, name)
+}
@@ -0,0 +1,49 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cozy-creator/runpod-go-sdk/jsonschema"
+)
+
+func TestParse(t *testing.T) {
+	s, err := jsonschema.Parse([]byte(`{
+		"type": "object",
+		"required": ["image"],
+		"properties": {
+			"image": {"$ref": "#/$defs/image"},
+			"seed": {"type": ["integer", "null"]}
+		},
+		"additionalProperties": false,
+		"$defs": {"image": {"type": "string", "format": "uri"}}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.Type.Has("object") || !s.IsRequired("image") || s.IsRequired("seed") {
+		t.Fatalf("schema = %+v", s)
+	}
+	if seed := s.Properties["seed"]; !seed.Type.Has("null") || !seed.Type.Has("integer") {
+		t.Fatalf("seed type = %v", seed.Type)
+	}
+	if !s.AdditionalProperties.Never {
+		t.Fatal("additionalProperties: false did not decode as Never")
+	}
+	name, def, err := s.Properties["image"].Resolve(s)
+	if err != nil || name != "image" || def.Format != "uri" {
+		t.Fatalf("Resolve = %q, %+v, %v", name, def, err)
+	}
+	if _, _, err := (&jsonschema.Schema{Ref: "#/$defs/video"}).Resolve(s); err == nil {
+		t.Fatal("unknown $ref resolved")
+	}
+
+	out, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := jsonschema.Parse(out)
+	if err != nil || !again.AdditionalProperties.Never || len(again.Properties["seed"].Type) != 2 {
+		t.Fatalf("round trip %s: %v", out, err)
+	}
+}
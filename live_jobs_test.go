@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cozy-creator/runpod-go-sdk"
 	"github.com/joho/godotenv"
 )
 
@@ -126,11 +127,11 @@ func TestSubmitAndPurgeLive(t *testing.T) {
 	}
 
 	time.Sleep(3 * time.Second)
-	err := client.PurgeQueue(ctx, endpointID)
+	result, err := client.PurgeQueue(ctx, endpointID, &runpod.PurgeQueueOptions{ExpectedMax: len(inputs)})
 	if err != nil {
 		t.Errorf("PurgeQueue failed: %v", err)
 	} else {
-		t.Log("🧹 Queue purged")
+		t.Logf("🧹 Queue purged: %d jobs removed", result.Removed)
 	}
 }
 
@@ -0,0 +1,387 @@
+// Package loadtest drives a serverless endpoint with generated jobs at a
+// configured rate and concurrency and reports queue delay, execution
+// time, cold starts and failures — the numbers to check before changing
+// an endpoint's capacity.
+//
+// Usage:
+//
+//	report, err := loadtest.Run(ctx, rp, loadtest.Options{
+//		EndpointID:  "abc123",
+//		Requests:    200,
+//		Rate:        5,
+//		Concurrency: 20,
+//		Input:       func(i int) any { return map[string]any{"prompt": prompts[i%len(prompts)]} },
+//	})
+//	fmt.Println(report)
+package loadtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+)
+
+// Defaults for Options.
+const (
+	DefaultRequests           = 100
+	DefaultConcurrency        = 10
+	DefaultPollInterval       = time.Second
+	DefaultJobTimeout         = 10 * time.Minute
+	DefaultColdStartThreshold = 10 * time.Second
+)
+
+// Options configures a load test.
+type Options struct {
+	EndpointID string
+	// Requests is how many jobs to submit. It defaults to DefaultRequests
+	// unless Duration is set, in which case submission is unbounded.
+	Requests int
+	// Duration, if set, stops submitting new jobs after this long; jobs
+	// already submitted still run to completion.
+	Duration time.Duration
+	// Rate is the submission rate in jobs per second; zero submits as fast
+	// as Concurrency allows.
+	Rate float64
+	// Concurrency caps the jobs in flight at once.
+	Concurrency int
+	// Input returns the input for the i-th job (default an empty object).
+	Input func(i int) any
+	// PollInterval is how often a job's status is polled.
+	PollInterval time.Duration
+	// JobTimeout bounds each job from submission to a final status.
+	JobTimeout time.Duration
+	// ColdStartThreshold is the queue delay above which a job counts as a
+	// cold start. RunPod does not report cold starts, so this is the
+	// heuristic: a job that waited this long most likely waited for a
+	// worker to boot.
+	ColdStartThreshold time.Duration
+	// OnResult, if set, is called as each job finishes; calls are
+	// serialized.
+	OnResult func(Result)
+}
+
+// Result is one job's outcome.
+type Result struct {
+	Index     int
+	JobID     string
+	Status    string
+	Err       error
+	Submitted time.Time
+	// QueueDelay is the time from submission to a worker picking the job
+	// up, ExecutionTime the handler's run time, and Latency the time from
+	// submission to the final status as observed by the client.
+	QueueDelay    time.Duration
+	ExecutionTime time.Duration
+	Latency       time.Duration
+	ColdStart     bool
+}
+
+// Failed reports whether the job did not complete successfully.
+func (r Result) Failed() bool {
+	return r.Err != nil || r.Status != string(runpod.JobStatusCompleted)
+}
+
+// Stats summarizes a duration distribution.
+type Stats struct {
+	Min, Mean, P50, P90, P99, Max time.Duration
+}
+
+// Report summarizes a load test.
+type Report struct {
+	EndpointID string
+	Requests   int
+	Completed  int
+	Failed     int
+	ColdStarts int
+	// Duration is the wall time from the first submission to the last
+	// final status.
+	Duration time.Duration
+	// Throughput is completed jobs per second over Duration.
+	Throughput    float64
+	FailureRate   float64
+	QueueDelay    Stats
+	ExecutionTime Stats
+	Latency       Stats
+	// Errors counts failures by message.
+	Errors  map[string]int
+	Results []Result
+}
+
+// Run drives the endpoint as configured and returns the report once every
+// submitted job has a final status or timed out. If ctx is cancelled it
+// stops submitting, abandons the jobs in flight and returns the partial
+// report with ctx's error.
+func Run(ctx context.Context, rp *runpod.Client, opts Options) (*Report, error) {
+	if rp == nil {
+		return nil, runpod.NewValidationError("client", "cannot be nil")
+	}
+	if strings.TrimSpace(opts.EndpointID) == "" {
+		return nil, runpod.NewValidationError("endpointID", "cannot be empty")
+	}
+	if opts.Rate < 0 || math.IsNaN(opts.Rate) || math.IsInf(opts.Rate, 0) {
+		return nil, runpod.NewValidationErrorWithValue("rate", "must be a finite non-negative number", opts.Rate)
+	}
+	if opts.Requests < 0 || opts.Concurrency < 0 || opts.Duration < 0 {
+		return nil, runpod.NewValidationError("options", "requests, concurrency and duration cannot be negative")
+	}
+	if opts.Requests == 0 && opts.Duration == 0 {
+		opts.Requests = DefaultRequests
+	}
+	if opts.Concurrency == 0 {
+		opts.Concurrency = DefaultConcurrency
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+	if opts.JobTimeout <= 0 {
+		opts.JobTimeout = DefaultJobTimeout
+	}
+	if opts.ColdStartThreshold <= 0 {
+		opts.ColdStartThreshold = DefaultColdStartThreshold
+	}
+	if opts.Input == nil {
+		opts.Input = func(int) any { return map[string]any{} }
+	}
+
+	submitCtx := ctx
+	if opts.Duration > 0 {
+		var cancel context.CancelFunc
+		submitCtx, cancel = context.WithTimeout(ctx, opts.Duration)
+		defer cancel()
+	}
+	var tick <-chan time.Time
+	if opts.Rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / opts.Rate))
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	start := time.Now()
+	slots := make(chan struct{}, opts.Concurrency)
+	var (
+		mu      sync.Mutex
+		results []Result
+		wg      sync.WaitGroup
+	)
+submit:
+	for i := 0; opts.Requests == 0 || i < opts.Requests; i++ {
+		if i > 0 && tick != nil {
+			select {
+			case <-tick:
+			case <-submitCtx.Done():
+				break submit
+			}
+		}
+		select {
+		case slots <- struct{}{}:
+		case <-submitCtx.Done():
+			break submit
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			result := runJob(ctx, rp, opts, i)
+			mu.Lock()
+			defer mu.Unlock()
+			results = append(results, result)
+			if opts.OnResult != nil {
+				opts.OnResult(result)
+			}
+		}()
+	}
+	wg.Wait()
+
+	report := summarize(opts.EndpointID, results, time.Since(start))
+	if err := ctx.Err(); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+// runJob submits one job and polls it to a final status.
+func runJob(ctx context.Context, rp *runpod.Client, opts Options, i int) Result {
+	result := Result{Index: i, Submitted: time.Now()}
+	jobCtx, cancel := context.WithTimeout(ctx, opts.JobTimeout)
+	defer cancel()
+
+	job, err := rp.RunAsync(jobCtx, opts.EndpointID, opts.Input(i))
+	if err != nil {
+		result.Err = err
+		result.Latency = time.Since(result.Submitted)
+		return result
+	}
+	result.JobID = job.ID
+	var pickedUp time.Time
+	for !rp.IsJobTerminal(job.Status) {
+		select {
+		case <-jobCtx.Done():
+			result.Status = job.Status
+			result.Err = fmt.Errorf("job %s did not finish: %w", job.ID, jobCtx.Err())
+			result.Latency = time.Since(result.Submitted)
+			return result
+		case <-time.After(opts.PollInterval):
+		}
+		next, err := rp.GetJobStatus(jobCtx, opts.EndpointID, result.JobID)
+		if err != nil {
+			if jobCtx.Err() == nil {
+				// A failed poll is retried on the next tick.
+				continue
+			}
+			result.Status = job.Status
+			result.Err = err
+			result.Latency = time.Since(result.Submitted)
+			return result
+		}
+		job = next
+		if pickedUp.IsZero() && job.Status != string(runpod.JobStatusInQueue) {
+			pickedUp = time.Now()
+		}
+	}
+	result.Latency = time.Since(result.Submitted)
+	result.Status = job.Status
+	if job.Status != string(runpod.JobStatusCompleted) && job.Error != "" {
+		result.Err = errors.New(errorMessage(job.Error))
+	}
+	result.ExecutionTime, result.QueueDelay = timings(job, result, pickedUp)
+	result.ColdStart = result.QueueDelay >= opts.ColdStartThreshold
+	return result
+}
+
+// errorMessage extracts the message from the JSON error payload workers
+// report, so that failures group by cause rather than by worker.
+func errorMessage(text string) string {
+	var payload struct {
+		Message string `json:"error_message"`
+	}
+	if json.Unmarshal([]byte(text), &payload) == nil && payload.Message != "" {
+		return payload.Message
+	}
+	return text
+}
+
+// timings prefers RunPod's reported times and falls back to what the
+// client observed while polling.
+func timings(job *runpod.Job, result Result, pickedUp time.Time) (execution, delay time.Duration) {
+	switch {
+	case job.ExecutionTime > 0:
+		execution = time.Duration(job.ExecutionTime) * time.Millisecond
+	case job.StartedAt != nil && job.CompletedAt != nil && !job.StartedAt.IsZero():
+		execution = job.CompletedAt.Sub(job.StartedAt.Time)
+	}
+	switch {
+	case job.DelayTime > 0:
+		delay = time.Duration(job.DelayTime) * time.Millisecond
+	case !pickedUp.IsZero():
+		delay = pickedUp.Sub(result.Submitted)
+	default:
+		delay = result.Latency - execution
+	}
+	return max(execution, 0), max(delay, 0)
+}
+
+func summarize(endpointID string, results []Result, elapsed time.Duration) *Report {
+	sort.Slice(results, func(a, b int) bool { return results[a].Index < results[b].Index })
+	report := &Report{
+		EndpointID: endpointID,
+		Requests:   len(results),
+		Duration:   elapsed,
+		Errors:     map[string]int{},
+		Results:    results,
+	}
+	var delays, executions, latencies []time.Duration
+	for _, r := range results {
+		if r.Failed() {
+			report.Failed++
+			msg := r.Status
+			if r.Err != nil {
+				msg = r.Err.Error()
+			}
+			report.Errors[msg]++
+		} else {
+			report.Completed++
+			executions = append(executions, r.ExecutionTime)
+		}
+		if r.JobID != "" && r.Status != "" {
+			delays = append(delays, r.QueueDelay)
+		}
+		if r.ColdStart {
+			report.ColdStarts++
+		}
+		latencies = append(latencies, r.Latency)
+	}
+	if report.Requests > 0 {
+		report.FailureRate = float64(report.Failed) / float64(report.Requests)
+	}
+	if elapsed > 0 {
+		report.Throughput = float64(report.Completed) / elapsed.Seconds()
+	}
+	report.QueueDelay = newStats(delays)
+	report.ExecutionTime = newStats(executions)
+	report.Latency = newStats(latencies)
+	return report
+}
+
+func newStats(values []time.Duration) Stats {
+	if len(values) == 0 {
+		return Stats{}
+	}
+	sorted := append([]time.Duration(nil), values...)
+	sort.Slice(sorted, func(a, b int) bool { return sorted[a] < sorted[b] })
+	var total time.Duration
+	for _, v := range sorted {
+		total += v
+	}
+	return Stats{
+		Min:  sorted[0],
+		Mean: total / time.Duration(len(sorted)),
+		P50:  percentile(sorted, 0.50),
+		P90:  percentile(sorted, 0.90),
+		P99:  percentile(sorted, 0.99),
+		Max:  sorted[len(sorted)-1],
+	}
+}
+
+// percentile uses the nearest-rank method on sorted values.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
+
+// String renders the report as a plain-text summary.
+func (r *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "endpoint %s: %d requests in %s\n", r.EndpointID, r.Requests, r.Duration.Round(time.Millisecond))
+	fmt.Fprintf(&b, "  completed %d, failed %d (%.1f%%), cold starts %d, throughput %.2f jobs/s\n",
+		r.Completed, r.Failed, r.FailureRate*100, r.ColdStarts, r.Throughput)
+	fmt.Fprintf(&b, "  %-15s %9s %9s %9s %9s %9s %9s\n", "", "min", "mean", "p50", "p90", "p99", "max")
+	for _, row := range []struct {
+		name  string
+		stats Stats
+	}{{"queue delay", r.QueueDelay}, {"execution time", r.ExecutionTime}, {"latency", r.Latency}} {
+		s := row.stats
+		fmt.Fprintf(&b, "  %-15s %9s %9s %9s %9s %9s %9s\n", row.name,
+			round(s.Min), round(s.Mean), round(s.P50), round(s.P90), round(s.P99), round(s.Max))
+	}
+	msgs := make([]string, 0, len(r.Errors))
+	for msg := range r.Errors {
+		msgs = append(msgs, msg)
+	}
+	sort.Strings(msgs)
+	for _, msg := range msgs {
+		fmt.Fprintf(&b, "  error x%d: %s\n", r.Errors[msg], msg)
+	}
+	return b.String()
+}
+
+func round(d time.Duration) string {
+	return d.Round(time.Millisecond).String()
+}
@@ -0,0 +1,88 @@
+package loadtest_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/loadtest"
+	"github.com/cozy-creator/runpod-go-sdk/serverless"
+)
+
+type quietLogger struct{}
+
+func (quietLogger) Printf(string, ...any) {}
+
+func TestRun(t *testing.T) {
+	handler := func(ctx context.Context, job *serverless.Job) (any, error) {
+		var in struct {
+			N int `json:"n"`
+		}
+		if err := job.DecodeInput(&in); err != nil {
+			return nil, err
+		}
+		time.Sleep(20 * time.Millisecond)
+		if in.N%5 == 4 {
+			return nil, errors.New("out of memory")
+		}
+		return in.N, nil
+	}
+	// Two workers behind five requests in flight make jobs queue.
+	dev, err := serverless.NewDevServer(handler, serverless.DevServerOptions{Concurrency: 2, Logger: quietLogger{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(dev)
+	t.Cleanup(func() {
+		srv.Close()
+		dev.Close()
+	})
+	rp, err := runpod.NewClient("test-key", runpod.WithServerlessBaseURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	finished := 0
+	report, err := loadtest.Run(t.Context(), rp, loadtest.Options{
+		EndpointID:         "local",
+		Requests:           10,
+		Rate:               500,
+		Concurrency:        5,
+		Input:              func(i int) any { return map[string]int{"n": i} },
+		PollInterval:       2 * time.Millisecond,
+		ColdStartThreshold: 15 * time.Millisecond,
+		OnResult:           func(loadtest.Result) { finished++ },
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if report.Requests != 10 || report.Completed != 8 || report.Failed != 2 || report.FailureRate != 0.2 || finished != 10 {
+		t.Fatalf("report = %+v", report)
+	}
+	if report.ExecutionTime.P50 < 20*time.Millisecond || report.Latency.Min < report.ExecutionTime.Min {
+		t.Fatalf("execution = %+v, latency = %+v", report.ExecutionTime, report.Latency)
+	}
+	if report.QueueDelay.Max < 15*time.Millisecond || report.ColdStarts == 0 {
+		t.Fatalf("queue delay = %+v, cold starts = %d", report.QueueDelay, report.ColdStarts)
+	}
+	for i, r := range report.Results {
+		if r.Index != i || r.JobID == "" {
+			t.Fatalf("result %d = %+v", i, r)
+		}
+	}
+	summary := report.String()
+	for _, want := range []string{"10 requests", "failed 2 (20.0%)", "queue delay", "error x2: out of memory"} {
+		if !strings.Contains(summary, want) {
+			t.Fatalf("summary missing %q:\n%s", want, summary)
+		}
+	}
+
+	var validationErr *runpod.ValidationError
+	if _, err := loadtest.Run(t.Context(), rp, loadtest.Options{}); !errors.As(err, &validationErr) {
+		t.Fatalf("missing endpoint: %v", err)
+	}
+}
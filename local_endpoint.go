@@ -0,0 +1,81 @@
+package runpod
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// WithLocalEndpoint routes the serverless calls for endpointID (RunAsync,
+// RunSync, GetJobStatus, StreamResults, CancelJob, GetHealth, ...) to
+// handler in-process instead of the network, so application code can be
+// developed end to end without deploying a worker or spending GPU credits.
+// handler serves the endpoint's job API under /v2/{endpointID}/ as RunPod
+// does; serverless.DevServer runs a Go worker handler that way (see its
+// Route method). Calls for other endpoints, and all REST and GraphQL
+// calls, are unaffected. It may be given several times, once per endpoint.
+func WithLocalEndpoint(endpointID string, handler http.Handler) ClientOption {
+	return func(c *Client) {
+		if c.localEndpoints == nil {
+			c.localEndpoints = map[string]http.Handler{}
+		}
+		c.localEndpoints[endpointID] = handler
+	}
+}
+
+// localEndpoint returns the handler registered for the endpoint a
+// serverless URL addresses, if any.
+func (c *Client) localEndpoint(u string) http.Handler {
+	if len(c.localEndpoints) == 0 {
+		return nil
+	}
+	rest, ok := strings.CutPrefix(u, strings.TrimRight(c.serverlessBaseURL, "/")+"/v2/")
+	if !ok {
+		return nil
+	}
+	endpointID, _, _ := strings.Cut(rest, "/")
+	return c.localEndpoints[endpointID]
+}
+
+// serveLocal answers req with handler, as a transport would.
+func serveLocal(handler http.Handler, req *http.Request) *http.Response {
+	w := &localResponse{header: http.Header{}}
+	handler.ServeHTTP(w, req)
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", w.status, http.StatusText(w.status)),
+		StatusCode:    w.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        w.header,
+		Body:          io.NopCloser(&w.body),
+		ContentLength: int64(w.body.Len()),
+		Request:       req,
+	}
+}
+
+// localResponse is the http.ResponseWriter serveLocal buffers a response
+// in.
+type localResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *localResponse) Header() http.Header { return w.header }
+
+func (w *localResponse) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *localResponse) Write(b []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	return w.body.Write(b)
+}
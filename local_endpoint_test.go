@@ -0,0 +1,53 @@
+package runpod_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestWithLocalEndpoint(t *testing.T) {
+	var paths []string
+	local := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		if r.URL.Path == "/v2/sd/health" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"error":"no workers"}`)
+			return
+		}
+		fmt.Fprint(w, `{"id":"local-1","status":"COMPLETED","output":"ok"}`)
+	})
+	srv := runpodtest.New()
+	t.Cleanup(srv.Close)
+	client := srv.MustClient(runpod.WithLocalEndpoint("sd", local), runpod.WithMaxRetryAttempts(0))
+	ctx := t.Context()
+
+	job, err := client.RunSync(ctx, "sd", map[string]string{"prompt": "x"})
+	if err != nil || job.ID != "local-1" || string(job.Output) != `"ok"` {
+		t.Fatalf("RunSync = %+v, %v", job, err)
+	}
+	if _, err := client.GetJobStatus(ctx, "sd", "local-1"); err != nil {
+		t.Fatal(err)
+	}
+	var apiErr *runpod.APIError
+	if _, err := client.GetHealth(ctx, "sd"); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("GetHealth = %v", err)
+	}
+
+	// Only the registered endpoint is local: "sdxl" and the REST API reach
+	// the server.
+	if job, err := client.RunAsync(ctx, "sdxl", map[string]string{"prompt": "x"}); err != nil || job.ID == "local-1" {
+		t.Fatalf("RunAsync sdxl = %+v, %v", job, err)
+	}
+	if _, err := client.ListPods(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"POST /v2/sd/runsync", "GET /v2/sd/status/local-1", "GET /v2/sd/health"}
+	if fmt.Sprint(paths) != fmt.Sprint(want) {
+		t.Fatalf("local requests = %v, want %v", paths, want)
+	}
+}
@@ -0,0 +1,684 @@
+package manifest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+)
+
+// Actions a Change can carry.
+const (
+	ActionCreate    = "create"
+	ActionUpdate    = "update"
+	ActionReplace   = "replace" // pods only: terminate, then create
+	ActionDelete    = "delete"
+	ActionUnchanged = "unchanged"
+)
+
+// ApplyOptions tunes BuildPlan and Apply.
+type ApplyOptions struct {
+	// Prune deletes live resources of every kind present in the manifest
+	// that the manifest does not declare. Kinds absent from the manifest are
+	// never touched.
+	Prune bool
+	// AllowReplace lets Apply terminate and recreate pods whose spec has
+	// drifted. Without it a planned replace makes Apply fail before any
+	// change is made.
+	AllowReplace bool
+	// UpdateSecrets overwrites existing secrets with the current value of
+	// their valueFromEnv variable. Secret values cannot be read back, so
+	// without it existing secrets are reported unchanged.
+	UpdateSecrets bool
+	// Env holds per-run overrides merged over every pod's env. Pod env is
+	// merged with precedence template < pod spec < Env, and the effective
+	// result is what is compared and created.
+	Env map[string]string
+}
+
+// Change is one planned operation.
+type Change struct {
+	Kind   string
+	Name   string
+	Action string
+	// ID is the live resource ID; empty for creates.
+	ID string
+	// Diff lists the drifted spec fields of an update or replace, named as
+	// in the manifest.
+	Diff runpod.Diff
+	// EnvConflicts lists pod env keys whose template or pod spec value was
+	// overridden by a higher-precedence layer.
+	EnvConflicts []runpod.EnvConflict
+	// Done is set by Apply once the change has been made.
+	Done bool
+
+	doc interface{}
+	env map[string]string // effective pod env
+}
+
+// Plan is the ordered set of changes that converges live state on a
+// manifest: creates and updates in dependency order (secrets, network
+// volumes, templates, endpoints, pods), then deletes in reverse order.
+type Plan struct {
+	Changes []*Change
+}
+
+// HasChanges reports whether applying the plan would change anything.
+func (p *Plan) HasChanges() bool {
+	for _, c := range p.Changes {
+		if c.Action != ActionUnchanged {
+			return true
+		}
+	}
+	return false
+}
+
+// String renders the plan for review: one line per change naming the
+// drifted fields, followed by the field-by-field diff, plus a summary.
+// Unchanged resources are omitted.
+func (p *Plan) String() string {
+	var b strings.Builder
+	counts := map[string]int{}
+	for _, c := range p.Changes {
+		if c.Action == ActionUnchanged {
+			continue
+		}
+		counts[c.Action]++
+		symbol := map[string]string{ActionCreate: "+", ActionUpdate: "~", ActionReplace: "-/+", ActionDelete: "-"}[c.Action]
+		fmt.Fprintf(&b, "%-3s %s %s", symbol, c.Kind, c.Name)
+		if len(c.Diff) > 0 {
+			fmt.Fprintf(&b, " (%s)", strings.Join(c.Diff.Fields(), ", "))
+		}
+		b.WriteString("\n")
+		for _, fd := range c.Diff {
+			fmt.Fprintf(&b, "      %s\n", fd)
+		}
+		for _, conflict := range c.EnvConflicts {
+			fmt.Fprintf(&b, "      ! env %s\n", conflict)
+		}
+	}
+	if b.Len() == 0 {
+		return "No changes.\n"
+	}
+	fmt.Fprintf(&b, "Plan: %d to create, %d to update, %d to replace, %d to delete.\n",
+		counts[ActionCreate], counts[ActionUpdate], counts[ActionReplace], counts[ActionDelete])
+	return b.String()
+}
+
+// liveState is the subset of account state a manifest refers to, indexed by
+// name.
+type liveState struct {
+	templates   map[string]runpod.Template
+	endpoints   map[string]runpod.Endpoint
+	pods        map[string]*runpod.Pod
+	volumes     map[string]runpod.NetworkVolume
+	secrets     map[string]*runpod.Secret
+	templateIDs map[string]string // name -> ID, including resources created during apply
+	volumeIDs   map[string]string
+}
+
+// BuildPlan computes the changes Apply would make, without making any.
+func BuildPlan(ctx context.Context, client *runpod.Client, m *Manifest, opts *ApplyOptions) (*Plan, error) {
+	p, _, err := buildPlan(ctx, client, m, opts)
+	return p, err
+}
+
+// Apply converges the account on the manifest and returns the executed plan.
+// It stops at the first failed change; changes already made are marked Done.
+//
+// Zero-valued endpoint and pod spec fields are left to the API's defaults and
+// not compared, so omitting e.g. idleTimeout does not cause perpetual drift.
+func Apply(ctx context.Context, client *runpod.Client, m *Manifest, opts *ApplyOptions) (*Plan, error) {
+	if opts == nil {
+		opts = &ApplyOptions{}
+	}
+	p, live, err := buildPlan(ctx, client, m, opts)
+	if err != nil {
+		return nil, err
+	}
+	if !opts.AllowReplace {
+		for _, c := range p.Changes {
+			if c.Action == ActionReplace {
+				return p, fmt.Errorf("pod %s has drifted (%s) and must be replaced; set AllowReplace", c.Name, strings.Join(c.Diff.Fields(), ", "))
+			}
+		}
+	}
+
+	for _, c := range p.Changes {
+		if c.Action == ActionUnchanged {
+			continue
+		}
+		if err := execute(ctx, client, live, c); err != nil {
+			return p, fmt.Errorf("failed to %s %s %s: %w", c.Action, c.Kind, c.Name, err)
+		}
+		c.Done = true
+	}
+	return p, nil
+}
+
+func buildPlan(ctx context.Context, client *runpod.Client, m *Manifest, opts *ApplyOptions) (*Plan, *liveState, error) {
+	if opts == nil {
+		opts = &ApplyOptions{}
+	}
+	live, err := loadLiveState(ctx, client, m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p := &Plan{}
+	var errs []error
+	add := func(c *Change, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", c.Kind, c.Name, err))
+			return
+		}
+		p.Changes = append(p.Changes, c)
+	}
+
+	for _, d := range m.Secrets {
+		add(planSecret(live, d, opts))
+	}
+	for _, d := range m.NetworkVolumes {
+		add(planVolume(live, d))
+	}
+	for _, d := range m.Templates {
+		add(planTemplate(live, d))
+	}
+	declaredTemplates := names(m.Templates, func(d *TemplateDocument) string { return d.Metadata.Name })
+	declaredVolumes := names(m.NetworkVolumes, func(d *NetworkVolumeDocument) string { return d.Metadata.Name })
+	for _, d := range m.Endpoints {
+		add(planEndpoint(live, d, declaredTemplates, declaredVolumes))
+	}
+	templateEnv := map[string]map[string]string{}
+	for name, t := range live.templates {
+		templateEnv[name] = t.Env
+	}
+	for _, d := range m.Templates {
+		templateEnv[d.Metadata.Name] = d.Spec.Env // applied before pods
+	}
+	for _, d := range m.Pods {
+		add(planPod(live, d, declaredTemplates, declaredVolumes, templateEnv, opts))
+	}
+
+	if opts.Prune {
+		if len(m.Pods) > 0 {
+			p.Changes = append(p.Changes, prune(KindPod, live.pods, names(m.Pods, func(d *PodDocument) string { return d.Metadata.Name }), func(p *runpod.Pod) string { return p.ID })...)
+		}
+		if len(m.Endpoints) > 0 {
+			p.Changes = append(p.Changes, prune(KindEndpoint, live.endpoints, names(m.Endpoints, func(d *EndpointDocument) string { return d.Metadata.Name }), func(e runpod.Endpoint) string { return e.ID })...)
+		}
+		if len(m.Templates) > 0 {
+			p.Changes = append(p.Changes, prune(KindTemplate, live.templates, declaredTemplates, func(t runpod.Template) string { return t.ID })...)
+		}
+		if len(m.NetworkVolumes) > 0 {
+			p.Changes = append(p.Changes, prune(KindNetworkVolume, live.volumes, declaredVolumes, func(v runpod.NetworkVolume) string { return v.ID })...)
+		}
+		if len(m.Secrets) > 0 {
+			p.Changes = append(p.Changes, prune(KindSecret, live.secrets, names(m.Secrets, func(d *SecretDocument) string { return d.Metadata.Name }), func(s *runpod.Secret) string { return s.ID })...)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, nil, err
+	}
+	return p, live, nil
+}
+
+func loadLiveState(ctx context.Context, client *runpod.Client, m *Manifest) (*liveState, error) {
+	live := &liveState{
+		templates:   map[string]runpod.Template{},
+		endpoints:   map[string]runpod.Endpoint{},
+		pods:        map[string]*runpod.Pod{},
+		volumes:     map[string]runpod.NetworkVolume{},
+		secrets:     map[string]*runpod.Secret{},
+		templateIDs: map[string]string{},
+		volumeIDs:   map[string]string{},
+	}
+	referencing := len(m.Endpoints) > 0 || len(m.Pods) > 0
+
+	if len(m.Templates) > 0 || referencing {
+		templates, err := client.ListTemplates(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range templates {
+			if err := index(live.templates, t.Name, t, KindTemplate); err != nil {
+				return nil, err
+			}
+			live.templateIDs[t.Name] = t.ID
+		}
+	}
+	if len(m.NetworkVolumes) > 0 || referencing {
+		volumes, err := client.ListNetworkVolumes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range volumes {
+			if err := index(live.volumes, v.Name, v, KindNetworkVolume); err != nil {
+				return nil, err
+			}
+			live.volumeIDs[v.Name] = v.ID
+		}
+	}
+	if len(m.Endpoints) > 0 {
+		endpoints, err := client.ListEndpoints(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range endpoints {
+			if err := index(live.endpoints, e.Name, e, KindEndpoint); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(m.Pods) > 0 {
+		pods, err := client.ListPods(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range pods {
+			if err := index(live.pods, p.Name, p, KindPod); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(m.Secrets) > 0 {
+		secrets, err := client.ListSecrets(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range secrets {
+			live.secrets[s.Name] = s
+		}
+	}
+	return live, nil
+}
+
+// index adds v under name, failing on duplicates: a manifest addresses
+// resources by name, so an ambiguous name cannot be managed safely.
+func index[T any](m map[string]T, name string, v T, kind string) error {
+	if _, dup := m[name]; dup {
+		return fmt.Errorf("%s name %q is ambiguous: several live resources share it", kind, name)
+	}
+	m[name] = v
+	return nil
+}
+
+func names[D any](docs []D, name func(D) string) map[string]bool {
+	out := make(map[string]bool, len(docs))
+	for _, d := range docs {
+		out[name(d)] = true
+	}
+	return out
+}
+
+// prune plans deletes for live resources not declared, sorted by name.
+func prune[T any](kind string, live map[string]T, declared map[string]bool, id func(T) string) []*Change {
+	var changes []*Change
+	for name, v := range live {
+		if !declared[name] {
+			changes = append(changes, &Change{Kind: kind, Name: name, Action: ActionDelete, ID: id(v)})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	return changes
+}
+
+func planSecret(live *liveState, d *SecretDocument, opts *ApplyOptions) (*Change, error) {
+	c := &Change{Kind: KindSecret, Name: d.Metadata.Name, Action: ActionUnchanged, doc: d}
+	existing, ok := live.secrets[d.Metadata.Name]
+	switch {
+	case !ok:
+		c.Action = ActionCreate
+	case opts.UpdateSecrets:
+		c.Action, c.ID = ActionUpdate, existing.ID
+		// Values cannot be read back, so the old side is unknown.
+		c.Diff = runpod.Diff{{Field: "value", Old: "(unknown)", New: "$" + d.Spec.ValueFromEnv}}
+	default:
+		c.ID = existing.ID
+	}
+	if c.Action != ActionUnchanged && os.Getenv(d.Spec.ValueFromEnv) == "" {
+		return c, fmt.Errorf("environment variable %s is not set", d.Spec.ValueFromEnv)
+	}
+	return c, nil
+}
+
+func planVolume(live *liveState, d *NetworkVolumeDocument) (*Change, error) {
+	c := &Change{Kind: KindNetworkVolume, Name: d.Metadata.Name, Action: ActionCreate, doc: d}
+	v, ok := live.volumes[d.Metadata.Name]
+	if !ok {
+		return c, nil
+	}
+	c.ID, c.Action = v.ID, ActionUnchanged
+	if v.DataCenterID != d.Spec.DataCenterID {
+		return c, fmt.Errorf("dataCenterId cannot be changed (live %s, declared %s); volumes cannot move without losing data", v.DataCenterID, d.Spec.DataCenterID)
+	}
+	switch {
+	case d.Spec.Size < v.Size:
+		return c, fmt.Errorf("size cannot shrink (live %d GB, declared %d GB)", v.Size, d.Spec.Size)
+	case d.Spec.Size > v.Size:
+		c.Action = ActionUpdate
+		c.Diff = runpod.Diff{{Field: "size", Old: v.Size, New: d.Spec.Size}}
+	}
+	return c, nil
+}
+
+func planTemplate(live *liveState, d *TemplateDocument) (*Change, error) {
+	c := &Change{Kind: KindTemplate, Name: d.Metadata.Name, Action: ActionCreate, doc: d}
+	t, ok := live.templates[d.Metadata.Name]
+	if !ok {
+		return c, nil
+	}
+	c.ID, c.Action = t.ID, ActionUnchanged
+	current := FromTemplate(&t).Spec
+	diff := templateSpecDiff(current, d.Spec)
+	if len(diff) == 0 {
+		return c, nil
+	}
+	if err := checkUpdatable(current, d.Spec); err != nil {
+		return c, err
+	}
+	c.Action = ActionUpdate
+	c.Diff = diff
+	return c, nil
+}
+
+func planEndpoint(live *liveState, d *EndpointDocument, templates, volumes map[string]bool) (*Change, error) {
+	c := &Change{Kind: KindEndpoint, Name: d.Metadata.Name, Action: ActionCreate, doc: d}
+	if err := checkRefs(live, d.Spec.Template, d.Spec.NetworkVolume, templates, volumes); err != nil {
+		return c, err
+	}
+	e, ok := live.endpoints[d.Metadata.Name]
+	if !ok {
+		return c, nil
+	}
+	c.ID, c.Action = e.ID, ActionUnchanged
+
+	current := endpointSpec(live, &e)
+	desired := d.Spec
+	if desired.ComputeType == "" {
+		desired.ComputeType = "GPU"
+	}
+	if current.ComputeType == "" {
+		current.ComputeType = "GPU"
+	}
+	if desired.ComputeType != current.ComputeType {
+		return c, fmt.Errorf("computeType cannot be changed after creation; recreate the endpoint")
+	}
+	if diff := endpointSpecDiff(current, desired); len(diff) > 0 {
+		c.Action, c.Diff = ActionUpdate, diff
+	}
+	return c, nil
+}
+
+// endpointSpecDiff reports the drift an update converges: fields the spec
+// leaves unset keep RunPod's value, but worker counts, the network volume
+// and flashboot are always sent, so they are compared even when zero.
+func endpointSpecDiff(current, desired EndpointSpec) runpod.Diff {
+	diff := runpod.DiffValues(current, desired, "yaml", true)
+	always := func(field string, old, new interface{}) {
+		if old == new || slices.ContainsFunc(diff, func(d runpod.FieldDiff) bool { return d.Field == field }) {
+			return
+		}
+		if new == "" {
+			new = nil // shown as removed
+		}
+		diff = append(diff, runpod.FieldDiff{Field: field, Old: old, New: new})
+	}
+	always("networkVolume", current.NetworkVolume, desired.NetworkVolume)
+	always("workersMin", current.WorkersMin, desired.WorkersMin)
+	always("workersMax", current.WorkersMax, desired.WorkersMax)
+	always("flashboot", current.Flashboot, desired.Flashboot)
+	return diff
+}
+
+// endpointSpec expresses a live endpoint as a spec, mapping IDs back to the
+// names manifests reference.
+func endpointSpec(live *liveState, e *runpod.Endpoint) EndpointSpec {
+	return EndpointSpec{
+		Template:            nameForID(live.templateIDs, e.TemplateID),
+		ComputeType:         e.ComputeType,
+		GPUTypeIDs:          e.GPUTypeIDs,
+		GPUCount:            e.GPUCount,
+		CPUFlavorIDs:        e.CPUFlavorIDs,
+		VCPUCount:           e.VCPUCount,
+		AllowedCudaVersions: e.AllowedCudaVersions,
+		DataCenterIDs:       e.DataCenterIDs,
+		NetworkVolume:       nameForID(live.volumeIDs, e.NetworkVolumeID),
+		WorkersMin:          e.WorkersMin,
+		WorkersMax:          e.WorkersMax,
+		ScalerType:          e.ScalerType,
+		ScalerValue:         e.ScalerValue,
+		IdleTimeout:         e.IdleTimeout,
+		ExecutionTimeoutMs:  int(e.ExecutionTimeout.Duration().Milliseconds()),
+		Flashboot:           e.Flashboot,
+	}
+}
+
+func planPod(live *liveState, d *PodDocument, templates, volumes map[string]bool, templateEnv map[string]map[string]string, opts *ApplyOptions) (*Change, error) {
+	c := &Change{Kind: KindPod, Name: d.Metadata.Name, Action: ActionCreate, doc: d}
+	if err := checkRefs(live, d.Spec.Template, d.Spec.NetworkVolume, templates, volumes); err != nil {
+		return c, err
+	}
+	var tplEnv map[string]string
+	if d.Spec.Template != "" {
+		tplEnv = templateEnv[d.Spec.Template]
+	}
+	c.env, c.EnvConflicts = runpod.MergeEnv(
+		runpod.EnvLayer{Name: runpod.EnvLayerTemplate, Env: tplEnv},
+		runpod.EnvLayer{Name: runpod.EnvLayerPod, Env: d.Spec.Env},
+		runpod.EnvLayer{Name: runpod.EnvLayerRun, Env: opts.Env},
+	)
+	desired := d.Spec
+	desired.Env = c.env
+	pod, ok := live.pods[d.Metadata.Name]
+	if !ok {
+		return c, nil
+	}
+	c.ID, c.Action = pod.ID, ActionUnchanged
+	if diff := runpod.DiffValues(podSpec(live, pod), observablePodSpec(desired), "yaml", true); len(diff) > 0 {
+		c.Action, c.Diff = ActionReplace, diff
+	}
+	return c, nil
+}
+
+// podSpec expresses the fields of a live pod that the API reports back.
+func podSpec(live *liveState, pod *runpod.Pod) PodSpec {
+	return PodSpec{
+		ImageName:         pod.ImageName,
+		GPUCount:          pod.GPUCount,
+		ContainerDiskInGB: pod.ContainerDiskInGB,
+		VolumeInGB:        pod.VolumeInGB,
+		VolumeMountPath:   pod.VolumeMountPath,
+		NetworkVolume:     nameForID(live.volumeIDs, pod.NetworkVolumeID),
+		Interruptible:     pod.Interruptible,
+		Env:               pod.Env,
+		Ports:             pod.Ports,
+	}
+}
+
+// observablePodSpec drops placement inputs (GPU type, datacenter, cloud
+// type, ...) a live pod does not echo back, so they never register as drift.
+func observablePodSpec(s PodSpec) PodSpec {
+	return PodSpec{
+		ImageName:         s.ImageName,
+		GPUCount:          s.GPUCount,
+		ContainerDiskInGB: s.ContainerDiskInGB,
+		VolumeInGB:        s.VolumeInGB,
+		VolumeMountPath:   s.VolumeMountPath,
+		NetworkVolume:     s.NetworkVolume,
+		Interruptible:     s.Interruptible,
+		Env:               s.Env,
+		Ports:             s.Ports,
+	}
+}
+
+// checkRefs verifies that referenced templates / volumes are declared in the
+// manifest or already exist.
+func checkRefs(live *liveState, template, volume string, templates, volumes map[string]bool) error {
+	if _, ok := live.templateIDs[template]; template != "" && !ok && !templates[template] {
+		return fmt.Errorf("references unknown template %q", template)
+	}
+	if _, ok := live.volumeIDs[volume]; volume != "" && !ok && !volumes[volume] {
+		return fmt.Errorf("references unknown network volume %q", volume)
+	}
+	return nil
+}
+
+func nameForID(ids map[string]string, id string) string {
+	if id == "" {
+		return ""
+	}
+	for name, candidate := range ids {
+		if candidate == id {
+			return name
+		}
+	}
+	return id // not managed by name; compare by ID
+}
+
+// optional returns nil for a zero v, leaving the field out of an update.
+func optional[T comparable](v T) *T {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return &v
+}
+
+// execute makes one planned change, recording created IDs so later
+// references resolve.
+func execute(ctx context.Context, client *runpod.Client, live *liveState, c *Change) error {
+	if c.Action == ActionDelete {
+		switch c.Kind {
+		case KindPod:
+			return client.TerminatePod(ctx, c.ID)
+		case KindEndpoint:
+			return client.DeleteEndpoint(ctx, c.ID)
+		case KindTemplate:
+			return client.DeleteTemplate(ctx, c.ID)
+		case KindNetworkVolume:
+			return client.DeleteNetworkVolume(ctx, c.ID)
+		case KindSecret:
+			return client.DeleteSecret(ctx, c.Name)
+		}
+	}
+
+	switch d := c.doc.(type) {
+	case *SecretDocument:
+		value := os.Getenv(d.Spec.ValueFromEnv)
+		if c.Action == ActionCreate {
+			_, err := client.CreateSecret(ctx, &runpod.CreateSecretRequest{Name: d.Metadata.Name, Value: value})
+			return err
+		}
+		_, err := client.UpdateSecret(ctx, d.Metadata.Name, &runpod.UpdateSecretRequest{Value: value})
+		return err
+
+	case *NetworkVolumeDocument:
+		if c.Action == ActionCreate {
+			v, err := client.CreateNetworkVolume(ctx, &runpod.CreateNetworkVolumeRequest{
+				Name: d.Metadata.Name, Size: d.Spec.Size, DataCenterID: d.Spec.DataCenterID,
+			})
+			if err != nil {
+				return err
+			}
+			live.volumeIDs[d.Metadata.Name] = v.ID
+			return nil
+		}
+		_, err := client.UpdateNetworkVolume(ctx, c.ID, &runpod.UpdateNetworkVolumeRequest{Size: d.Spec.Size})
+		return err
+
+	case *TemplateDocument:
+		if c.Action == ActionCreate {
+			t, err := client.CreateTemplate(ctx, d.CreateRequest())
+			if err != nil {
+				return err
+			}
+			live.templateIDs[d.Metadata.Name] = t.ID
+			return nil
+		}
+		_, err := client.UpdateTemplate(ctx, c.ID, d.UpdateRequest())
+		return err
+
+	case *EndpointDocument:
+		s := d.Spec
+		if c.Action == ActionCreate {
+			_, err := client.CreateEndpoint(ctx, &runpod.CreateEndpointRequest{
+				Name:                d.Metadata.Name,
+				TemplateID:          live.templateIDs[s.Template],
+				ComputeType:         s.ComputeType,
+				GPUTypeIDs:          s.GPUTypeIDs,
+				GPUCount:            s.GPUCount,
+				CPUFlavorIDs:        s.CPUFlavorIDs,
+				VCPUCount:           s.VCPUCount,
+				AllowedCudaVersions: s.AllowedCudaVersions,
+				DataCenterIDs:       s.DataCenterIDs,
+				NetworkVolumeID:     live.volumeIDs[s.NetworkVolume],
+				WorkersMin:          s.WorkersMin,
+				WorkersMax:          s.WorkersMax,
+				ScalerType:          s.ScalerType,
+				ScalerValue:         s.ScalerValue,
+				IdleTimeout:         s.IdleTimeout,
+				ExecutionTimeout:    runpod.DurationMS(time.Duration(s.ExecutionTimeoutMs) * time.Millisecond),
+				Flashboot:           s.Flashboot,
+			})
+			return err
+		}
+		// Worker counts, flashboot and the volume converge even to zero;
+		// the rest keep RunPod's defaults when the spec leaves them out.
+		// endpointSpecDiff plans the same fields.
+		_, err := client.UpdateEndpoint(ctx, c.ID, &runpod.UpdateEndpointRequest{
+			TemplateID:          runpod.Ptr(live.templateIDs[s.Template]),
+			GPUTypeIDs:          s.GPUTypeIDs,
+			GPUCount:            optional(s.GPUCount),
+			CPUFlavorIDs:        s.CPUFlavorIDs,
+			VCPUCount:           optional(s.VCPUCount),
+			AllowedCudaVersions: s.AllowedCudaVersions,
+			DataCenterIDs:       s.DataCenterIDs,
+			NetworkVolumeID:     runpod.Ptr(live.volumeIDs[s.NetworkVolume]),
+			WorkersMin:          runpod.Ptr(s.WorkersMin),
+			WorkersMax:          runpod.Ptr(s.WorkersMax),
+			ScalerType:          optional(s.ScalerType),
+			ScalerValue:         optional(s.ScalerValue),
+			IdleTimeout:         optional(s.IdleTimeout),
+			ExecutionTimeout:    optional(runpod.DurationMS(time.Duration(s.ExecutionTimeoutMs) * time.Millisecond)),
+			Flashboot:           runpod.Ptr(s.Flashboot),
+		})
+		return err
+
+	case *PodDocument:
+		if c.Action == ActionReplace {
+			if err := client.TerminatePod(ctx, c.ID); err != nil {
+				return err
+			}
+		}
+		s := d.Spec
+		_, err := client.CreatePod(ctx, &runpod.CreatePodRequest{
+			Name:              d.Metadata.Name,
+			ImageName:         s.ImageName,
+			TemplateID:        live.templateIDs[s.Template],
+			ComputeType:       s.ComputeType,
+			GPUTypeIDs:        s.GPUTypeIDs,
+			GPUCount:          s.GPUCount,
+			CPUFlavorIDs:      s.CPUFlavorIDs,
+			VCPUCount:         s.VCPUCount,
+			ContainerDiskInGB: s.ContainerDiskInGB,
+			VolumeInGB:        s.VolumeInGB,
+			VolumeMountPath:   s.VolumeMountPath,
+			DataCenterIDs:     s.DataCenterIDs,
+			NetworkVolumeID:   live.volumeIDs[s.NetworkVolume],
+			CloudType:         s.CloudType,
+			Interruptible:     s.Interruptible,
+			Env:               c.env,
+			Ports:             s.Ports,
+		})
+		return err
+	}
+	return fmt.Errorf("unsupported change")
+}
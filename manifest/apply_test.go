@@ -0,0 +1,255 @@
+package manifest_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/manifest"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+const stack = `apiVersion: runpod-go-sdk/v1
+kind: Secret
+metadata:
+  name: hf_token
+spec:
+  valueFromEnv: TEST_HF_TOKEN
+---
+apiVersion: runpod-go-sdk/v1
+kind: NetworkVolume
+metadata:
+  name: models
+spec:
+  size: 50
+  dataCenterId: US-KS-2
+---
+apiVersion: runpod-go-sdk/v1
+kind: Template
+metadata:
+  name: sd-worker
+spec:
+  imageName: acme/sd-worker:1
+  isServerless: true
+  env:
+    HF_TOKEN: '{{ RUNPOD_SECRET_hf_token }}'
+---
+apiVersion: runpod-go-sdk/v1
+kind: Endpoint
+metadata:
+  name: sd
+spec:
+  template: sd-worker
+  networkVolume: models
+  gpuTypeIds: [NVIDIA GeForce RTX 4090]
+  workersMax: WORKERS_MAX
+`
+
+func TestParseManifest(t *testing.T) {
+	m, err := manifest.Parse([]byte(strings.Replace(stack, "WORKERS_MAX", "3", 1)))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(m.Secrets) != 1 || len(m.NetworkVolumes) != 1 || len(m.Templates) != 1 || len(m.Endpoints) != 1 {
+		t.Fatalf("unexpected manifest %+v", m)
+	}
+
+	jsonManifest := `[{"apiVersion":"runpod-go-sdk/v1","kind":"Pod","metadata":{"name":"dev"},"spec":{"imageName":"ubuntu","gpuCount":1}},
+		{"apiVersion":"runpod-go-sdk/v1","kind":"Secret","metadata":{"name":"s"},"spec":{"valueFromEnv":"S"}}]`
+	m, err = manifest.Parse([]byte(jsonManifest))
+	if err != nil || len(m.Pods) != 1 || m.Pods[0].Spec.GPUCount != 1 || len(m.Secrets) != 1 {
+		t.Fatalf("json manifest = %+v, %v", m, err)
+	}
+
+	for name, doc := range map[string]string{
+		"unknown kind": "apiVersion: runpod-go-sdk/v1\nkind: Cluster\nmetadata: {name: x}\n",
+		"unknown key":  "apiVersion: runpod-go-sdk/v1\nkind: Secret\nmetadata: {name: x}\nspec: {value: plaintext}\n",
+		"duplicate":    "apiVersion: runpod-go-sdk/v1\nkind: Secret\nmetadata: {name: x}\nspec: {valueFromEnv: A}\n---\napiVersion: runpod-go-sdk/v1\nkind: Secret\nmetadata: {name: x}\nspec: {valueFromEnv: B}\n",
+	} {
+		if _, err := manifest.Parse([]byte(doc)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestApplyManifest(t *testing.T) {
+	t.Setenv("TEST_HF_TOKEN", "hf_secret")
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+	ctx := context.Background()
+
+	m, err := manifest.Parse([]byte(strings.Replace(stack, "WORKERS_MAX", "3", 1)))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	plan, err := manifest.BuildPlan(ctx, client, m, nil)
+	if err != nil {
+		t.Fatalf("plan: %v", err)
+	}
+	want := "+   Secret hf_token\n+   NetworkVolume models\n+   Template sd-worker\n+   Endpoint sd\nPlan: 4 to create, 0 to update, 0 to replace, 0 to delete.\n"
+	if plan.String() != want {
+		t.Fatalf("plan =\n%s\nwant\n%s", plan, want)
+	}
+
+	if _, err := manifest.Apply(ctx, client, m, nil); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if value, _ := srv.SecretValue("hf_token"); value != "hf_secret" {
+		t.Fatalf("secret value = %q", value)
+	}
+	plan, err = manifest.BuildPlan(ctx, client, m, nil)
+	if err != nil || plan.HasChanges() {
+		t.Fatalf("re-plan must be empty: %v\n%s", err, plan)
+	}
+
+	m, _ = manifest.Parse([]byte(strings.Replace(stack, "WORKERS_MAX", "5", 1)))
+	plan, err = manifest.Apply(ctx, client, m, nil)
+	if err != nil {
+		t.Fatalf("apply drift: %v", err)
+	}
+	if !strings.Contains(plan.String(), "~   Endpoint sd (workersMax)\n      workersMax: 3 -> 5\n") {
+		t.Fatalf("unexpected drift plan\n%s", plan)
+	}
+
+	// Zero is a value too: scaling down to no workers converges.
+	m, _ = manifest.Parse([]byte(strings.Replace(stack, "WORKERS_MAX", "0", 1)))
+	plan, err = manifest.Apply(ctx, client, m, nil)
+	if err != nil {
+		t.Fatalf("apply zero: %v", err)
+	}
+	if !strings.Contains(plan.String(), "workersMax: 5 -> 0\n") {
+		t.Fatalf("scaling to zero not planned\n%s", plan)
+	}
+	if plan, err := manifest.BuildPlan(ctx, client, m, nil); err != nil || plan.HasChanges() {
+		t.Fatalf("zero workersMax did not converge: %v\n%s", err, plan)
+	}
+
+	// Prune removes undeclared endpoints only when asked, and only for kinds
+	// the manifest declares.
+	srv.AddEndpoint(&runpod.Endpoint{ID: "stray", Name: "stray", TemplateID: "x"})
+	srv.AddPod(&runpod.Pod{ID: "pod-1", Name: "unmanaged"})
+	plan, err = manifest.Apply(ctx, client, m, &manifest.ApplyOptions{Prune: true})
+	if err != nil {
+		t.Fatalf("apply prune: %v", err)
+	}
+	if !strings.Contains(plan.String(), "-   Endpoint stray") || srv.Endpoint("stray") != nil {
+		t.Fatalf("stray endpoint not pruned\n%s", plan)
+	}
+	if srv.Pod("pod-1") == nil {
+		t.Fatal("prune must not touch kinds absent from the manifest")
+	}
+}
+
+func TestApplyManifestPods(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+	ctx := context.Background()
+
+	pod := func(image string) *manifest.Manifest {
+		m, err := manifest.Parse([]byte("apiVersion: runpod-go-sdk/v1\nkind: Pod\nmetadata: {name: dev}\nspec:\n  imageName: " + image + "\n  gpuTypeIds: [NVIDIA GeForce RTX 4090]\n  gpuCount: 1\n  containerDiskInGb: 20\n"))
+		if err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+		return m
+	}
+
+	if _, err := manifest.Apply(ctx, client, pod("ubuntu:22.04"), nil); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	plan, err := manifest.Apply(ctx, client, pod("ubuntu:24.04"), nil)
+	if err == nil || plan == nil || plan.Changes[0].Action != manifest.ActionReplace || plan.Changes[0].Done {
+		t.Fatalf("replace without AllowReplace must fail before changing anything: %+v, %v", plan, err)
+	}
+	if _, err := manifest.Apply(ctx, client, pod("ubuntu:24.04"), &manifest.ApplyOptions{AllowReplace: true}); err != nil {
+		t.Fatalf("apply replace: %v", err)
+	}
+	plan, err = manifest.BuildPlan(ctx, client, pod("ubuntu:24.04"), nil)
+	if err != nil || plan.HasChanges() {
+		t.Fatalf("re-plan after replace must be empty: %v\n%s", err, plan)
+	}
+}
+
+func TestApplyManifestPodEnv(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+	ctx := context.Background()
+
+	m, err := manifest.Parse([]byte(`
+apiVersion: runpod-go-sdk/v1
+kind: Template
+metadata: {name: worker}
+spec:
+  imageName: acme/worker:1.0
+  containerDiskInGb: 20
+  env: {MODEL: sdxl, LOG_LEVEL: info}
+---
+apiVersion: runpod-go-sdk/v1
+kind: Pod
+metadata: {name: dev}
+spec:
+  imageName: acme/worker:1.0
+  template: worker
+  gpuTypeIds: [NVIDIA GeForce RTX 4090]
+  gpuCount: 1
+  containerDiskInGb: 20
+  env: {MODEL: flux}
+`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	opts := &manifest.ApplyOptions{Env: map[string]string{"LOG_LEVEL": "debug", "GIT_SHA": "abc123"}}
+	plan, err := manifest.Apply(ctx, client, m, opts)
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	podChange := plan.Changes[len(plan.Changes)-1]
+	if len(podChange.EnvConflicts) != 2 ||
+		podChange.EnvConflicts[0].String() != `LOG_LEVEL: run "debug" overrides template "info"` ||
+		podChange.EnvConflicts[1].String() != `MODEL: pod "flux" overrides template "sdxl"` {
+		t.Fatalf("EnvConflicts = %v", podChange.EnvConflicts)
+	}
+	if !strings.Contains(plan.String(), `! env MODEL: pod "flux" overrides template "sdxl"`) {
+		t.Fatalf("plan does not report conflicts:\n%s", plan)
+	}
+
+	pods, _ := client.ListPods(ctx, nil)
+	want := map[string]string{"MODEL": "flux", "LOG_LEVEL": "debug", "GIT_SHA": "abc123"}
+	if len(pods) != 1 || !reflect.DeepEqual(pods[0].Env, want) {
+		t.Fatalf("pod env = %v, want %v", pods[0].Env, want)
+	}
+	if plan, err := manifest.BuildPlan(ctx, client, m, opts); err != nil || plan.HasChanges() {
+		t.Fatalf("re-plan must be empty: %v\n%s", err, plan)
+	}
+	plan, err = manifest.BuildPlan(ctx, client, m, nil)
+	if err != nil || plan.Changes[len(plan.Changes)-1].Action != manifest.ActionReplace {
+		t.Fatalf("dropping run overrides must replace the pod: %v\n%s", err, plan)
+	}
+}
+
+func TestBuildPlanErrors(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+	ctx := context.Background()
+
+	srv.AddNetworkVolume(&runpod.NetworkVolume{ID: "vol-1", Name: "models", Size: 100, DataCenterID: "US-KS-2"})
+	for name, doc := range map[string]string{
+		"volume shrink":    "apiVersion: runpod-go-sdk/v1\nkind: NetworkVolume\nmetadata: {name: models}\nspec: {size: 50, dataCenterId: US-KS-2}\n",
+		"volume move":      "apiVersion: runpod-go-sdk/v1\nkind: NetworkVolume\nmetadata: {name: models}\nspec: {size: 100, dataCenterId: EU-RO-1}\n",
+		"unknown template": "apiVersion: runpod-go-sdk/v1\nkind: Endpoint\nmetadata: {name: sd}\nspec: {template: missing, gpuTypeIds: [x]}\n",
+		"unset secret env": "apiVersion: runpod-go-sdk/v1\nkind: Secret\nmetadata: {name: s}\nspec: {valueFromEnv: TEST_UNSET_SECRET_VALUE}\n",
+	} {
+		m, err := manifest.Parse([]byte(doc))
+		if err != nil {
+			t.Fatalf("%s: parse: %v", name, err)
+		}
+		if _, err := manifest.BuildPlan(ctx, client, m, nil); err == nil {
+			t.Errorf("%s: expected plan error", name)
+		}
+	}
+}
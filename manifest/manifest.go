@@ -0,0 +1,298 @@
+package manifest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"gopkg.in/yaml.v3"
+)
+
+// Document kinds accepted in a manifest besides KindTemplate.
+const (
+	KindEndpoint      = "Endpoint"
+	KindPod           = "Pod"
+	KindNetworkVolume = "NetworkVolume"
+	KindSecret        = "Secret"
+)
+
+// EndpointSpec is the desired configuration of a serverless endpoint.
+// Template and NetworkVolume reference other resources by metadata.name, so
+// a manifest is portable between accounts.
+type EndpointSpec struct {
+	Template            string             `yaml:"template"`
+	ComputeType         runpod.ComputeType `yaml:"computeType,omitempty"`
+	GPUTypeIDs          []string           `yaml:"gpuTypeIds,omitempty"`
+	GPUCount            int                `yaml:"gpuCount,omitempty"`
+	CPUFlavorIDs        []string           `yaml:"cpuFlavorIds,omitempty"`
+	VCPUCount           int                `yaml:"vcpuCount,omitempty"`
+	AllowedCudaVersions []string           `yaml:"allowedCudaVersions,omitempty"`
+	DataCenterIDs       []string           `yaml:"dataCenterIds,omitempty"`
+	NetworkVolume       string             `yaml:"networkVolume,omitempty"`
+	WorkersMin          int                `yaml:"workersMin,omitempty"`
+	WorkersMax          int                `yaml:"workersMax,omitempty"`
+	ScalerType          runpod.ScalerType  `yaml:"scalerType,omitempty"`
+	ScalerValue         int                `yaml:"scalerValue,omitempty"`
+	IdleTimeout         int                `yaml:"idleTimeout,omitempty"`
+	ExecutionTimeoutMs  int                `yaml:"executionTimeoutMs,omitempty"`
+	Flashboot           bool               `yaml:"flashboot,omitempty"`
+}
+
+// EndpointDocument is the YAML document form of an endpoint.
+type EndpointDocument struct {
+	APIVersion string       `yaml:"apiVersion"`
+	Kind       string       `yaml:"kind"`
+	Metadata   Metadata     `yaml:"metadata"`
+	Spec       EndpointSpec `yaml:"spec"`
+}
+
+// PodSpec is the desired configuration of a pod. Pods cannot be updated in
+// place; drift is converged by replacing the pod (see ApplyOptions).
+type PodSpec struct {
+	ImageName         string             `yaml:"imageName"`
+	Template          string             `yaml:"template,omitempty"`
+	ComputeType       runpod.ComputeType `yaml:"computeType,omitempty"`
+	GPUTypeIDs        []string           `yaml:"gpuTypeIds,omitempty"`
+	GPUCount          int                `yaml:"gpuCount,omitempty"`
+	CPUFlavorIDs      []string           `yaml:"cpuFlavorIds,omitempty"`
+	VCPUCount         int                `yaml:"vcpuCount,omitempty"`
+	ContainerDiskInGB int                `yaml:"containerDiskInGb,omitempty"`
+	VolumeInGB        int                `yaml:"volumeInGb,omitempty"`
+	VolumeMountPath   string             `yaml:"volumeMountPath,omitempty"`
+	DataCenterIDs     []string           `yaml:"dataCenterIds,omitempty"`
+	NetworkVolume     string             `yaml:"networkVolume,omitempty"`
+	CloudType         runpod.CloudType   `yaml:"cloudType,omitempty"`
+	Interruptible     bool               `yaml:"interruptible,omitempty"`
+	Env               map[string]string  `yaml:"env,omitempty"`
+	Ports             []string           `yaml:"ports,omitempty"`
+}
+
+// PodDocument is the YAML document form of a pod.
+type PodDocument struct {
+	APIVersion string   `yaml:"apiVersion"`
+	Kind       string   `yaml:"kind"`
+	Metadata   Metadata `yaml:"metadata"`
+	Spec       PodSpec  `yaml:"spec"`
+}
+
+// NetworkVolumeSpec is the desired configuration of a network volume.
+type NetworkVolumeSpec struct {
+	Size         int    `yaml:"size"` // GB; can only grow
+	DataCenterID string `yaml:"dataCenterId"`
+}
+
+// NetworkVolumeDocument is the YAML document form of a network volume.
+type NetworkVolumeDocument struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   Metadata          `yaml:"metadata"`
+	Spec       NetworkVolumeSpec `yaml:"spec"`
+}
+
+// SecretSpec declares a secret. Values never appear in a manifest: the value
+// is read from the named environment variable at apply time (typically a CI
+// secret).
+type SecretSpec struct {
+	ValueFromEnv string `yaml:"valueFromEnv"`
+}
+
+// SecretDocument is the YAML document form of a secret.
+type SecretDocument struct {
+	APIVersion string     `yaml:"apiVersion"`
+	Kind       string     `yaml:"kind"`
+	Metadata   Metadata   `yaml:"metadata"`
+	Spec       SecretSpec `yaml:"spec"`
+}
+
+// Manifest is a set of resource documents applied together.
+type Manifest struct {
+	Templates      []*TemplateDocument
+	Endpoints      []*EndpointDocument
+	Pods           []*PodDocument
+	NetworkVolumes []*NetworkVolumeDocument
+	Secrets        []*SecretDocument
+}
+
+// ReadFile parses a manifest file (see Parse).
+func ReadFile(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	m, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return m, nil
+}
+
+// Parse decodes a manifest: a YAML stream of documents separated by "---",
+// or JSON — a single document object or an array of them. Every document is
+// validated; unknown keys and duplicate kind/name pairs are rejected.
+func Parse(data []byte) (*Manifest, error) {
+	m := &Manifest{}
+	seen := map[string]struct{}{}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for i := 1; ; i++ {
+		var node yaml.Node
+		err := dec.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode manifest: %w", err)
+		}
+		if len(node.Content) == 0 {
+			continue // empty document, e.g. a trailing "---"
+		}
+		root := node.Content[0]
+		items := []*yaml.Node{root}
+		if root.Kind == yaml.SequenceNode {
+			items = root.Content
+		}
+		for _, item := range items {
+			kind, name, err := m.add(item)
+			if err != nil {
+				return nil, fmt.Errorf("document %d: %w", i, err)
+			}
+			key := kind + "/" + name
+			if _, dup := seen[key]; dup {
+				return nil, fmt.Errorf("document %d: duplicate %s %q", i, kind, name)
+			}
+			seen[key] = struct{}{}
+		}
+	}
+	return m, nil
+}
+
+// add strictly decodes one document node into its typed form.
+func (m *Manifest) add(node *yaml.Node) (kind, name string, err error) {
+	var header struct {
+		Kind     string   `yaml:"kind"`
+		Metadata Metadata `yaml:"metadata"`
+	}
+	if err := node.Decode(&header); err != nil {
+		return "", "", err
+	}
+
+	switch header.Kind {
+	case KindTemplate:
+		doc := &TemplateDocument{}
+		if err = decodeStrict(node, doc); err == nil {
+			err = doc.Validate()
+		}
+		m.Templates = append(m.Templates, doc)
+	case KindEndpoint:
+		doc := &EndpointDocument{}
+		if err = decodeStrict(node, doc); err == nil {
+			err = doc.Validate()
+		}
+		m.Endpoints = append(m.Endpoints, doc)
+	case KindPod:
+		doc := &PodDocument{}
+		if err = decodeStrict(node, doc); err == nil {
+			err = doc.Validate()
+		}
+		m.Pods = append(m.Pods, doc)
+	case KindNetworkVolume:
+		doc := &NetworkVolumeDocument{}
+		if err = decodeStrict(node, doc); err == nil {
+			err = doc.Validate()
+		}
+		m.NetworkVolumes = append(m.NetworkVolumes, doc)
+	case KindSecret:
+		doc := &SecretDocument{}
+		if err = decodeStrict(node, doc); err == nil {
+			err = doc.Validate()
+		}
+		m.Secrets = append(m.Secrets, doc)
+	default:
+		return "", "", runpod.NewValidationErrorWithValue("kind", "unsupported document kind", header.Kind)
+	}
+	return header.Kind, header.Metadata.Name, err
+}
+
+// decodeStrict decodes node into out rejecting unknown keys. yaml.Node has
+// no strict Decode, so the node is re-encoded and run through a Decoder.
+func decodeStrict(node *yaml.Node, out interface{}) error {
+	data, err := yaml.Marshal(node)
+	if err != nil {
+		return err
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	return dec.Decode(out)
+}
+
+// validateHeader checks the fields every document shares.
+func validateHeader(apiVersion, kind, wantKind string, meta Metadata) error {
+	if apiVersion != APIVersion {
+		return runpod.NewValidationErrorWithValue("apiVersion", "must be "+APIVersion, apiVersion)
+	}
+	if kind != wantKind {
+		return runpod.NewValidationErrorWithValue("kind", "must be "+wantKind, kind)
+	}
+	if strings.TrimSpace(meta.Name) == "" {
+		return runpod.NewValidationError("metadata.name", "cannot be empty")
+	}
+	return nil
+}
+
+// Validate checks the document header and required fields.
+func (d *EndpointDocument) Validate() error {
+	if err := validateHeader(d.APIVersion, d.Kind, KindEndpoint, d.Metadata); err != nil {
+		return err
+	}
+	if strings.TrimSpace(d.Spec.Template) == "" {
+		return runpod.NewValidationError("spec.template", "cannot be empty")
+	}
+	switch d.Spec.ComputeType {
+	case "", "GPU", "CPU":
+	default:
+		return runpod.NewValidationErrorWithValue("spec.computeType", "must be either 'GPU' or 'CPU'", d.Spec.ComputeType)
+	}
+	if d.Spec.ComputeType != "CPU" && len(d.Spec.GPUTypeIDs) == 0 {
+		return runpod.NewValidationError("spec.gpuTypeIds", "cannot be empty")
+	}
+	return nil
+}
+
+// Validate checks the document header and required fields.
+func (d *PodDocument) Validate() error {
+	if err := validateHeader(d.APIVersion, d.Kind, KindPod, d.Metadata); err != nil {
+		return err
+	}
+	if strings.TrimSpace(d.Spec.ImageName) == "" && strings.TrimSpace(d.Spec.Template) == "" {
+		return runpod.NewValidationError("spec.imageName", "cannot be empty without spec.template")
+	}
+	return nil
+}
+
+// Validate checks the document header and required fields.
+func (d *NetworkVolumeDocument) Validate() error {
+	if err := validateHeader(d.APIVersion, d.Kind, KindNetworkVolume, d.Metadata); err != nil {
+		return err
+	}
+	if d.Spec.Size <= 0 {
+		return runpod.NewValidationErrorWithValue("spec.size", "must be positive", d.Spec.Size)
+	}
+	if strings.TrimSpace(d.Spec.DataCenterID) == "" {
+		return runpod.NewValidationError("spec.dataCenterId", "cannot be empty")
+	}
+	return nil
+}
+
+// Validate checks the document header and required fields.
+func (d *SecretDocument) Validate() error {
+	if err := validateHeader(d.APIVersion, d.Kind, KindSecret, d.Metadata); err != nil {
+		return err
+	}
+	if strings.TrimSpace(d.Spec.ValueFromEnv) == "" {
+		return runpod.NewValidationError("spec.valueFromEnv", "cannot be empty")
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package manifest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"gopkg.in/yaml.v3"
+)
+
+// Snapshot reads the account's templates, network volumes, endpoints, pods
+// and secrets into a manifest that would leave the account unchanged if
+// applied to it. References between resources are expressed by name, so
+// the manifest can be applied to another account.
+//
+// Secret values cannot be read back: each secret's valueFromEnv names
+// SecretEnvVar(name), which must be set when the snapshot is applied.
+// Template env values are copied verbatim, and registry auth IDs are kept
+// as they are; both are account-specific if they hold credentials.
+// Resources that share a name cannot be addressed by a manifest, so a name
+// used twice within a kind is an error.
+func Snapshot(ctx context.Context, client *runpod.Client) (*Manifest, error) {
+	live := &liveState{templateIDs: map[string]string{}, volumeIDs: map[string]string{}}
+	m := &Manifest{}
+
+	templates, err := client.ListTemplates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	for i := range templates {
+		t := &templates[i]
+		if err := index(live.templateIDs, t.Name, t.ID, KindTemplate); err != nil {
+			return nil, err
+		}
+		m.Templates = append(m.Templates, FromTemplate(t))
+	}
+
+	volumes, err := client.ListNetworkVolumes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list network volumes: %w", err)
+	}
+	for _, v := range volumes {
+		if err := index(live.volumeIDs, v.Name, v.ID, KindNetworkVolume); err != nil {
+			return nil, err
+		}
+		m.NetworkVolumes = append(m.NetworkVolumes, &NetworkVolumeDocument{
+			APIVersion: APIVersion,
+			Kind:       KindNetworkVolume,
+			Metadata:   Metadata{Name: v.Name, ID: v.ID},
+			Spec:       NetworkVolumeSpec{Size: v.Size, DataCenterID: v.DataCenterID},
+		})
+	}
+
+	endpoints, err := client.ListEndpoints(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list endpoints: %w", err)
+	}
+	endpointNames := map[string]bool{}
+	for i := range endpoints {
+		e := &endpoints[i]
+		if err := index(endpointNames, e.Name, true, KindEndpoint); err != nil {
+			return nil, err
+		}
+		m.Endpoints = append(m.Endpoints, &EndpointDocument{
+			APIVersion: APIVersion,
+			Kind:       KindEndpoint,
+			Metadata:   Metadata{Name: e.Name, ID: e.ID},
+			Spec:       endpointSpec(live, e),
+		})
+	}
+
+	pods, err := client.ListPods(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	podNames := map[string]bool{}
+	for _, pod := range pods {
+		if err := index(podNames, pod.Name, true, KindPod); err != nil {
+			return nil, err
+		}
+		m.Pods = append(m.Pods, &PodDocument{
+			APIVersion: APIVersion,
+			Kind:       KindPod,
+			Metadata:   Metadata{Name: pod.Name, ID: pod.ID},
+			Spec:       placedPodSpec(live, pod),
+		})
+	}
+
+	secrets, err := client.ListSecrets(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %w", err)
+	}
+	for _, s := range secrets {
+		m.Secrets = append(m.Secrets, &SecretDocument{
+			APIVersion: APIVersion,
+			Kind:       KindSecret,
+			Metadata:   Metadata{Name: s.Name, ID: s.ID},
+			Spec:       SecretSpec{ValueFromEnv: SecretEnvVar(s.Name)},
+		})
+	}
+	return m, nil
+}
+
+// placedPodSpec is podSpec plus the placement a live pod reports: its GPU
+// type or CPU flavor and its data center, so that a recreated pod lands
+// on the same hardware.
+func placedPodSpec(live *liveState, pod *runpod.Pod) PodSpec {
+	s := podSpec(live, pod)
+	if pod.CPUFlavorID != "" {
+		s.ComputeType = runpod.ComputeTypeCPU
+		s.CPUFlavorIDs = []string{pod.CPUFlavorID}
+		s.VCPUCount = pod.VCPUCount
+	} else {
+		gpuType := ""
+		if pod.GPU != nil {
+			gpuType = pod.GPU.ID
+		}
+		if gpuType == "" && pod.Machine != nil && pod.Machine.GPUTypeID != "unknown" {
+			gpuType = pod.Machine.GPUTypeID
+		}
+		if gpuType != "" {
+			s.GPUTypeIDs = []string{gpuType}
+		}
+	}
+	if pod.Machine != nil && pod.Machine.DataCenterID != "" && s.NetworkVolume == "" {
+		s.DataCenterIDs = []string{pod.Machine.DataCenterID}
+	}
+	return s
+}
+
+var nonEnvChars = regexp.MustCompile(`[^A-Z0-9_]+`)
+
+// SecretEnvVar is the environment variable a snapshot reads a secret's
+// value from on import: RUNPOD_SECRET_ followed by the name upper-cased,
+// with other characters replaced by underscores ("hf-token" becomes
+// RUNPOD_SECRET_HF_TOKEN).
+func SecretEnvVar(name string) string {
+	return "RUNPOD_SECRET_" + nonEnvChars.ReplaceAllString(strings.ToUpper(name), "_")
+}
+
+// MarshalState encodes a manifest as a JSON array of documents, the form
+// ExportState writes. Documents are ordered by kind as Apply creates them
+// (secrets, network volumes, templates, endpoints, pods) and by name
+// within a kind, and object keys are sorted, so exporting an unchanged
+// account twice produces identical bytes. Parse reads it back.
+func MarshalState(m *Manifest) ([]byte, error) {
+	var docs []interface{}
+	docs = appendSorted(docs, m.Secrets, func(d *SecretDocument) string { return d.Metadata.Name })
+	docs = appendSorted(docs, m.NetworkVolumes, func(d *NetworkVolumeDocument) string { return d.Metadata.Name })
+	docs = appendSorted(docs, m.Templates, func(d *TemplateDocument) string { return d.Metadata.Name })
+	docs = appendSorted(docs, m.Endpoints, func(d *EndpointDocument) string { return d.Metadata.Name })
+	docs = appendSorted(docs, m.Pods, func(d *PodDocument) string { return d.Metadata.Name })
+
+	// Documents carry yaml tags only; a YAML round trip yields plain maps
+	// with the manifest's key names, which encoding/json writes sorted.
+	data, err := yaml.Marshal(docs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode state: %w", err)
+	}
+	var plain []interface{}
+	if err := yaml.Unmarshal(data, &plain); err != nil {
+		return nil, fmt.Errorf("failed to encode state: %w", err)
+	}
+	if plain == nil {
+		plain = []interface{}{}
+	}
+	out, err := json.MarshalIndent(plain, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode state: %w", err)
+	}
+	return append(out, '\n'), nil
+}
+
+func appendSorted[D any](out []interface{}, docs []D, name func(D) string) []interface{} {
+	sorted := append([]D(nil), docs...)
+	sort.SliceStable(sorted, func(i, j int) bool { return name(sorted[i]) < name(sorted[j]) })
+	for _, d := range sorted {
+		out = append(out, d)
+	}
+	return out
+}
+
+// ExportState snapshots the account (see Snapshot) and encodes it with
+// MarshalState: the document ImportState applies to promote an
+// environment, or a provider compares against.
+func ExportState(ctx context.Context, client *runpod.Client) ([]byte, error) {
+	m, err := Snapshot(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	return MarshalState(m)
+}
+
+// ImportState reconciles the account client addresses onto exported state
+// with Apply, creating, updating and (with opts.Prune) deleting resources
+// until it matches. Resources are matched by name, never by the exported
+// IDs, so state exported from one account can be imported into another.
+// Use Parse and BuildPlan to review the changes first.
+func ImportState(ctx context.Context, client *runpod.Client, data []byte, opts *ApplyOptions) (*Plan, error) {
+	m, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	return Apply(ctx, client, m, opts)
+}
@@ -0,0 +1,88 @@
+package manifest_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cozy-creator/runpod-go-sdk/manifest"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestExportImportState(t *testing.T) {
+	t.Setenv("TEST_HF_TOKEN", "hf_secret")
+	ctx := context.Background()
+	staging := runpodtest.New()
+	defer staging.Close()
+	src := staging.MustClient()
+
+	pod := "---\napiVersion: runpod-go-sdk/v1\nkind: Pod\nmetadata: {name: dev}\nspec:\n  imageName: ubuntu:22.04\n  gpuTypeIds: [NVIDIA GeForce RTX 4090]\n  gpuCount: 1\n  containerDiskInGb: 20\n"
+	m, err := manifest.Parse([]byte(strings.Replace(stack, "WORKERS_MAX", "3", 1) + pod))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manifest.Apply(ctx, src, m, nil); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	state, err := manifest.ExportState(ctx, src)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	again, err := manifest.ExportState(ctx, src)
+	if err != nil || string(again) != string(state) {
+		t.Fatalf("export is not stable: %v\n%s\n%s", err, state, again)
+	}
+	for _, want := range []string{`"kind": "Secret"`, `"valueFromEnv": "RUNPOD_SECRET_HF_TOKEN"`, `"networkVolume": "models"`, `"template": "sd-worker"`, `"kind": "Pod"`} {
+		if !strings.Contains(string(state), want) {
+			t.Fatalf("state missing %s:\n%s", want, state)
+		}
+	}
+	if strings.Index(string(state), `"kind": "Secret"`) > strings.Index(string(state), `"kind": "Endpoint"`) {
+		t.Fatalf("documents out of dependency order:\n%s", state)
+	}
+
+	// The exported state describes the source account exactly.
+	parsed, err := manifest.Parse(state)
+	if err != nil {
+		t.Fatalf("parse state: %v", err)
+	}
+	if plan, err := manifest.BuildPlan(ctx, src, parsed, nil); err != nil || plan.HasChanges() {
+		t.Fatalf("state does not match its own account: %v\n%s", err, plan)
+	}
+
+	// Importing needs the secret values, then recreates everything by name.
+	prod := runpodtest.New()
+	defer prod.Close()
+	dst := prod.MustClient()
+	if _, err := manifest.ImportState(ctx, dst, state, nil); err == nil || !strings.Contains(err.Error(), "RUNPOD_SECRET_HF_TOKEN") {
+		t.Fatalf("import without secret values: %v", err)
+	}
+	t.Setenv("RUNPOD_SECRET_HF_TOKEN", "hf_prod")
+	plan, err := manifest.ImportState(ctx, dst, state, nil)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if want := "Plan: 5 to create, 0 to update, 0 to replace, 0 to delete.\n"; !strings.HasSuffix(plan.String(), want) {
+		t.Fatalf("import plan =\n%s", plan)
+	}
+	if value, _ := prod.SecretValue("hf_token"); value != "hf_prod" {
+		t.Fatalf("imported secret = %q", value)
+	}
+	if plan, err := manifest.BuildPlan(ctx, dst, parsed, nil); err != nil || plan.HasChanges() {
+		t.Fatalf("import did not converge: %v\n%s", err, plan)
+	}
+	imported, err := manifest.ExportState(ctx, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(string(imported), `"kind"`) != 5 {
+		t.Fatalf("imported state:\n%s", imported)
+	}
+}
+
+func TestSecretEnvVar(t *testing.T) {
+	if got := manifest.SecretEnvVar("hf-token.v2"); got != "RUNPOD_SECRET_HF_TOKEN_V2" {
+		t.Fatalf("SecretEnvVar = %q", got)
+	}
+}
@@ -0,0 +1,377 @@
+// Package manifest reads and writes RunPod resources as reviewable YAML
+// documents, so configuration can live in git and be applied by CI.
+// ApplyTemplateFile handles a single template; Parse, BuildPlan and Apply
+// converge a whole manifest of templates, endpoints, pods, network volumes
+// and secrets. ExportState and ImportState move a whole account's state
+// between accounts in the same format.
+//
+// A template document looks like:
+//
+//	apiVersion: runpod-go-sdk/v1
+//	kind: Template
+//	metadata:
+//	  name: sd-worker
+//	spec:
+//	  imageName: ghcr.io/acme/sd-worker:1.4.0
+//	  containerDiskInGb: 20
+//	  isServerless: true
+//	  env:
+//	    HF_TOKEN: '{{ RUNPOD_SECRET_hf_token }}'
+//
+// Documents are matched to live resources by metadata.name; metadata.id is
+// informational (written by export, ignored on apply). Output is stable: keys
+// are emitted in a fixed order and env keys are sorted, so re-exporting an
+// unchanged template produces an identical file.
+//
+// YAML support lives in this subpackage so the root runpod package stays
+// dependency-free.
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"gopkg.in/yaml.v3"
+)
+
+// APIVersion is the document format version written by Marshal and accepted
+// by the Unmarshal functions.
+const APIVersion = "runpod-go-sdk/v1"
+
+// KindTemplate is the document kind for templates.
+const KindTemplate = "Template"
+
+// Metadata identifies the resource a document describes.
+type Metadata struct {
+	Name string `yaml:"name"`
+	// ID is the live resource ID at export time. Informational only.
+	ID string `yaml:"id,omitempty"`
+}
+
+// TemplateSpec is the desired configuration of a template. Fields mirror
+// runpod.CreateTemplateRequest.
+type TemplateSpec struct {
+	ImageName               string            `yaml:"imageName"`
+	Category                string            `yaml:"category,omitempty"`
+	ContainerDiskInGB       int               `yaml:"containerDiskInGb,omitempty"`
+	VolumeInGB              int               `yaml:"volumeInGb,omitempty"`
+	VolumeMountPath         string            `yaml:"volumeMountPath,omitempty"`
+	ContainerRegistryAuthID string            `yaml:"containerRegistryAuthId,omitempty"`
+	DockerEntrypoint        []string          `yaml:"dockerEntrypoint,omitempty"`
+	DockerStartCmd          []string          `yaml:"dockerStartCmd,omitempty"`
+	Env                     map[string]string `yaml:"env,omitempty"`
+	Ports                   []string          `yaml:"ports,omitempty"`
+	IsPublic                bool              `yaml:"isPublic,omitempty"`
+	IsServerless            bool              `yaml:"isServerless,omitempty"`
+	Readme                  string            `yaml:"readme,omitempty"`
+}
+
+// TemplateDocument is the YAML document form of a template.
+type TemplateDocument struct {
+	APIVersion string       `yaml:"apiVersion"`
+	Kind       string       `yaml:"kind"`
+	Metadata   Metadata     `yaml:"metadata"`
+	Spec       TemplateSpec `yaml:"spec"`
+}
+
+// FromTemplate builds a document from a live template. Env values are
+// exported verbatim: store credentials as RunPod secrets and reference them
+// with runpod.SecretRef rather than committing plaintext values.
+func FromTemplate(t *runpod.Template) *TemplateDocument {
+	return &TemplateDocument{
+		APIVersion: APIVersion,
+		Kind:       KindTemplate,
+		Metadata:   Metadata{Name: t.Name, ID: t.ID},
+		Spec: TemplateSpec{
+			ImageName:               t.ImageName,
+			Category:                t.Category,
+			ContainerDiskInGB:       t.ContainerDiskInGB,
+			VolumeInGB:              t.VolumeInGB,
+			VolumeMountPath:         t.VolumeMountPath,
+			ContainerRegistryAuthID: t.ContainerRegistryAuthID,
+			DockerEntrypoint:        cloneStrings(t.DockerEntrypoint),
+			DockerStartCmd:          cloneStrings(t.DockerStartCmd),
+			Env:                     cloneEnv(t.Env),
+			Ports:                   cloneStrings(t.Ports),
+			IsPublic:                t.IsPublic,
+			IsServerless:            t.IsServerless,
+			Readme:                  t.Readme,
+		},
+	}
+}
+
+// FromCreateTemplateRequest builds a document from a creation request.
+func FromCreateTemplateRequest(req *runpod.CreateTemplateRequest) *TemplateDocument {
+	return FromTemplate(&runpod.Template{
+		Name:                    req.Name,
+		ImageName:               req.ImageName,
+		Category:                req.Category,
+		ContainerDiskInGB:       req.ContainerDiskInGB,
+		VolumeInGB:              req.VolumeInGB,
+		VolumeMountPath:         req.VolumeMountPath,
+		ContainerRegistryAuthID: req.ContainerRegistryAuthID,
+		DockerEntrypoint:        req.DockerEntrypoint,
+		DockerStartCmd:          req.DockerStartCmd,
+		Env:                     req.Env,
+		Ports:                   req.Ports,
+		IsPublic:                req.IsPublic,
+		IsServerless:            req.IsServerless,
+		Readme:                  req.Readme,
+	})
+}
+
+// Template returns the document as a runpod.Template (ID from metadata).
+func (d *TemplateDocument) Template() *runpod.Template {
+	return &runpod.Template{
+		ID:                      d.Metadata.ID,
+		Name:                    d.Metadata.Name,
+		ImageName:               d.Spec.ImageName,
+		Category:                d.Spec.Category,
+		ContainerDiskInGB:       d.Spec.ContainerDiskInGB,
+		VolumeInGB:              d.Spec.VolumeInGB,
+		VolumeMountPath:         d.Spec.VolumeMountPath,
+		ContainerRegistryAuthID: d.Spec.ContainerRegistryAuthID,
+		DockerEntrypoint:        cloneStrings(d.Spec.DockerEntrypoint),
+		DockerStartCmd:          cloneStrings(d.Spec.DockerStartCmd),
+		Env:                     cloneEnv(d.Spec.Env),
+		Ports:                   cloneStrings(d.Spec.Ports),
+		IsPublic:                d.Spec.IsPublic,
+		IsServerless:            d.Spec.IsServerless,
+		Readme:                  d.Spec.Readme,
+	}
+}
+
+// CreateRequest returns the request that creates the described template.
+func (d *TemplateDocument) CreateRequest() *runpod.CreateTemplateRequest {
+	t := d.Template()
+	return &runpod.CreateTemplateRequest{
+		Name:                    t.Name,
+		ImageName:               t.ImageName,
+		Category:                t.Category,
+		ContainerDiskInGB:       t.ContainerDiskInGB,
+		VolumeInGB:              t.VolumeInGB,
+		VolumeMountPath:         t.VolumeMountPath,
+		ContainerRegistryAuthID: t.ContainerRegistryAuthID,
+		DockerEntrypoint:        t.DockerEntrypoint,
+		DockerStartCmd:          t.DockerStartCmd,
+		Env:                     t.Env,
+		Ports:                   t.Ports,
+		IsPublic:                t.IsPublic,
+		IsServerless:            t.IsServerless,
+		Readme:                  t.Readme,
+	}
+}
+
+// UpdateRequest returns the request that converges an existing template on
+// the document. Every updatable field is sent, so one the document leaves
+// empty is cleared, except an unset container disk, which keeps its current
+// size. Category and IsServerless are fixed at creation and not included.
+func (d *TemplateDocument) UpdateRequest() *runpod.UpdateTemplateRequest {
+	t := d.Template()
+	return &runpod.UpdateTemplateRequest{
+		Name:                    &t.Name,
+		ImageName:               &t.ImageName,
+		ContainerDiskInGB:       optional(t.ContainerDiskInGB),
+		VolumeInGB:              &t.VolumeInGB,
+		VolumeMountPath:         &t.VolumeMountPath,
+		ContainerRegistryAuthID: &t.ContainerRegistryAuthID,
+		DockerEntrypoint:        nonNil(t.DockerEntrypoint),
+		DockerStartCmd:          nonNil(t.DockerStartCmd),
+		Env:                     nonNilEnv(t.Env),
+		Ports:                   nonNil(t.Ports),
+		IsPublic:                &t.IsPublic,
+		Readme:                  &t.Readme,
+	}
+}
+
+// Validate checks the document header and required fields.
+func (d *TemplateDocument) Validate() error {
+	if err := validateHeader(d.APIVersion, d.Kind, KindTemplate, d.Metadata); err != nil {
+		return err
+	}
+	if strings.TrimSpace(d.Spec.ImageName) == "" {
+		return runpod.NewValidationError("spec.imageName", "cannot be empty")
+	}
+	return nil
+}
+
+// Marshal encodes a document as YAML with two-space indentation.
+func Marshal(doc interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalTemplate encodes a live template as a YAML document.
+func MarshalTemplate(t *runpod.Template) ([]byte, error) {
+	return Marshal(FromTemplate(t))
+}
+
+// UnmarshalTemplate decodes and validates a template document. Unknown keys
+// are rejected so typos fail review instead of being silently dropped.
+func UnmarshalTemplate(data []byte) (*TemplateDocument, error) {
+	var doc TemplateDocument
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode template document: %w", err)
+	}
+	if err := doc.Validate(); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// ExportTemplate fetches a template and returns it as a YAML document.
+func ExportTemplate(ctx context.Context, client *runpod.Client, templateID string) ([]byte, error) {
+	t, err := client.GetTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	return MarshalTemplate(t)
+}
+
+// ApplyResult reports the outcome of applying one document.
+type ApplyResult struct {
+	Action   string // ActionCreate, ActionUpdate or ActionUnchanged
+	Template *runpod.Template
+}
+
+// ApplyTemplateFile reads a template document from path and applies it (see
+// ApplyTemplate).
+func ApplyTemplateFile(ctx context.Context, client *runpod.Client, path string) (*ApplyResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	doc, err := UnmarshalTemplate(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return ApplyTemplate(ctx, client, doc)
+}
+
+// ApplyTemplate converges the account on doc: it creates the template named
+// metadata.name when absent, updates it when its spec has drifted, and
+// otherwise leaves it alone. A name matching several templates is an error.
+//
+// An update sends every field (see UpdateRequest), so one the document
+// leaves empty is cleared on the template. Category and isServerless are
+// fixed at creation; drift in either is reported as an error rather than
+// silently ignored.
+func ApplyTemplate(ctx context.Context, client *runpod.Client, doc *TemplateDocument) (*ApplyResult, error) {
+	if err := doc.Validate(); err != nil {
+		return nil, err
+	}
+
+	templates, err := client.ListTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var live *runpod.Template
+	for i := range templates {
+		if templates[i].Name != doc.Metadata.Name {
+			continue
+		}
+		if live != nil {
+			return nil, fmt.Errorf("template name %q is ambiguous: matches %s and %s", doc.Metadata.Name, live.ID, templates[i].ID)
+		}
+		live = &templates[i]
+	}
+
+	if live == nil {
+		created, err := client.CreateTemplate(ctx, doc.CreateRequest())
+		if err != nil {
+			return nil, err
+		}
+		return &ApplyResult{Action: ActionCreate, Template: created}, nil
+	}
+
+	current := FromTemplate(live).Spec
+	if len(templateSpecDiff(current, doc.Spec)) == 0 {
+		return &ApplyResult{Action: ActionUnchanged, Template: live}, nil
+	}
+	if err := checkUpdatable(current, doc.Spec); err != nil {
+		return nil, fmt.Errorf("template %s: %w", live.ID, err)
+	}
+	updated, err := client.UpdateTemplate(ctx, live.ID, doc.UpdateRequest())
+	if err != nil {
+		return nil, err
+	}
+	return &ApplyResult{Action: ActionUpdate, Template: updated}, nil
+}
+
+// checkUpdatable reports drift UpdateTemplate cannot converge.
+func checkUpdatable(current, desired TemplateSpec) error {
+	var errs []error
+	if !strings.EqualFold(current.Category, desired.Category) && desired.Category != "" {
+		errs = append(errs, errors.New("category cannot be changed after creation; recreate the template"))
+	}
+	if current.IsServerless != desired.IsServerless {
+		errs = append(errs, errors.New("isServerless cannot be changed after creation; recreate the template"))
+	}
+	return errors.Join(errs...)
+}
+
+// templateSpecDiff compares specs the way the API echoes them back: nil and
+// empty collections are equal, category is case-insensitive, and an unset
+// desired category or container disk accepts whatever the API defaulted to
+// (UpdateRequest does not send either).
+func templateSpecDiff(current, desired TemplateSpec) runpod.Diff {
+	if desired.Category == "" {
+		current.Category = ""
+	}
+	if desired.ContainerDiskInGB == 0 {
+		current.ContainerDiskInGB = 0
+	}
+	current.Category = strings.ToUpper(current.Category)
+	desired.Category = strings.ToUpper(desired.Category)
+	return runpod.DiffValues(current, desired, "yaml", false)
+}
+
+func cloneStrings(in []string) []string {
+	if in == nil {
+		return nil
+	}
+	return append([]string(nil), in...)
+}
+
+// nonNil returns an empty slice for a nil one, so an update clears the
+// field rather than leaving it out.
+func nonNil(in []string) []string {
+	if in == nil {
+		return []string{}
+	}
+	return in
+}
+
+// nonNilEnv is nonNil for env maps.
+func nonNilEnv(in map[string]string) map[string]string {
+	if in == nil {
+		return map[string]string{}
+	}
+	return in
+}
+
+func cloneEnv(in map[string]string) map[string]string {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,136 @@
+package manifest_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/manifest"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestTemplateRoundTrip(t *testing.T) {
+	tpl := &runpod.Template{
+		ID:                "tpl-1",
+		Name:              "sd-worker",
+		ImageName:         "ghcr.io/acme/sd-worker:1.4.0",
+		Category:          "NVIDIA",
+		ContainerDiskInGB: 20,
+		Env:               map[string]string{"Z": "last", "HF_TOKEN": runpod.SecretRef("hf_token"), "A": "first"},
+		Ports:             []string{"8888/http"},
+		IsServerless:      true,
+		Readme:            "# SD worker\n\nServes SDXL.\n",
+	}
+	data, err := manifest.MarshalTemplate(tpl)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	out := string(data)
+	if !strings.HasPrefix(out, "apiVersion: "+manifest.APIVersion+"\nkind: Template\n") {
+		t.Fatalf("unexpected header:\n%s", out)
+	}
+	if strings.Index(out, "A: first") > strings.Index(out, "Z: last") {
+		t.Fatalf("env keys must be sorted:\n%s", out)
+	}
+	again, _ := manifest.MarshalTemplate(tpl)
+	if string(again) != out {
+		t.Fatal("output must be stable across runs")
+	}
+
+	doc, err := manifest.UnmarshalTemplate(data)
+	if err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	got := doc.Template()
+	if got.ID != "tpl-1" || got.Readme != tpl.Readme || got.Env["HF_TOKEN"] != tpl.Env["HF_TOKEN"] || !got.IsServerless {
+		t.Fatalf("round trip lost data: %+v", got)
+	}
+	if req := doc.CreateRequest(); req.Name != "sd-worker" || req.ContainerDiskInGB != 20 {
+		t.Fatalf("unexpected create request %+v", req)
+	}
+	if req := manifest.FromCreateTemplateRequest(doc.CreateRequest()); req.Spec.ImageName != tpl.ImageName {
+		t.Fatalf("unexpected document from create request %+v", req)
+	}
+}
+
+func TestUnmarshalTemplateRejectsInvalid(t *testing.T) {
+	cases := map[string]string{
+		"unknown key":   "apiVersion: runpod-go-sdk/v1\nkind: Template\nmetadata: {name: x}\nspec: {imageName: img, imageTag: oops}\n",
+		"wrong kind":    "apiVersion: runpod-go-sdk/v1\nkind: Endpoint\nmetadata: {name: x}\nspec: {imageName: img}\n",
+		"wrong version": "apiVersion: v0\nkind: Template\nmetadata: {name: x}\nspec: {imageName: img}\n",
+		"missing name":  "apiVersion: runpod-go-sdk/v1\nkind: Template\nspec: {imageName: img}\n",
+		"missing image": "apiVersion: runpod-go-sdk/v1\nkind: Template\nmetadata: {name: x}\n",
+	}
+	for name, doc := range cases {
+		if _, err := manifest.UnmarshalTemplate([]byte(doc)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestApplyTemplateFile(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "worker.yaml")
+	write := func(image string) {
+		doc := "apiVersion: runpod-go-sdk/v1\nkind: Template\nmetadata:\n  name: worker\nspec:\n  imageName: " + image + "\n  containerDiskInGb: 10\n  env:\n    MODEL: sdxl\n"
+		if err := os.WriteFile(path, []byte(doc), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("acme/worker:1")
+	res, err := manifest.ApplyTemplateFile(ctx, client, path)
+	if err != nil || res.Action != manifest.ActionCreate {
+		t.Fatalf("first apply = %+v, %v", res, err)
+	}
+	id := res.Template.ID
+
+	res, err = manifest.ApplyTemplateFile(ctx, client, path)
+	if err != nil || res.Action != manifest.ActionUnchanged {
+		t.Fatalf("re-apply = %+v, %v", res, err)
+	}
+
+	write("acme/worker:2")
+	res, err = manifest.ApplyTemplateFile(ctx, client, path)
+	if err != nil || res.Action != manifest.ActionUpdate || res.Template.ID != id {
+		t.Fatalf("drift apply = %+v, %v", res, err)
+	}
+	if srv.Template(id).ImageName != "acme/worker:2" {
+		t.Fatal("update not applied")
+	}
+
+	exported, err := manifest.ExportTemplate(ctx, client, id)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	doc, err := manifest.UnmarshalTemplate(exported)
+	if err != nil || doc.Metadata.ID != id || doc.Spec.Env["MODEL"] != "sdxl" {
+		t.Fatalf("export = %s, %v", exported, err)
+	}
+
+	// Fields the document leaves empty are cleared by the update.
+	doc.Spec.Env = nil
+	res, err = manifest.ApplyTemplate(ctx, client, doc)
+	if err != nil || res.Action != manifest.ActionUpdate || len(srv.Template(id).Env) != 0 {
+		t.Fatalf("clearing env = %+v, %v; env %v", res, err, srv.Template(id).Env)
+	}
+	if res, err = manifest.ApplyTemplate(ctx, client, doc); err != nil || res.Action != manifest.ActionUnchanged {
+		t.Fatalf("re-apply after clearing = %+v, %v", res, err)
+	}
+
+	// A document without containerDiskInGb accepts the size the API chose.
+	doc.Spec.ContainerDiskInGB = 0
+	if _, err := client.UpdateTemplate(ctx, id, &runpod.UpdateTemplateRequest{ContainerDiskInGB: runpod.Ptr(50)}); err != nil {
+		t.Fatal(err)
+	}
+	if res, err = manifest.ApplyTemplate(ctx, client, doc); err != nil || res.Action != manifest.ActionUnchanged {
+		t.Fatalf("apply without a disk size = %+v, %v", res, err)
+	}
+}
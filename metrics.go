@@ -0,0 +1,99 @@
+package runpod
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Metric names a MetricsSink receives.
+const (
+	// MetricRequests counts API calls, once per call however many attempts
+	// it took.
+	MetricRequests = "runpod.requests"
+	// MetricRequestErrors counts calls that failed in transport or got an
+	// HTTP status of 400 or above.
+	MetricRequestErrors = "runpod.request.errors"
+	// MetricRequestDuration is a call's latency, retries and backoff
+	// included.
+	MetricRequestDuration = "runpod.request.duration"
+)
+
+// Tag is a metric dimension.
+type Tag struct {
+	Key, Value string
+}
+
+// MetricsSink receives the client's per-call metrics. Every metric carries
+// the tags api (rest, serverless or graphql), method, route (the request
+// path with IDs replaced by {id}, such as /pods/{id}/stop or
+// /v2/{id}/status/{id}) and status (the HTTP status code, or "error" when no
+// response arrived). The statsd package provides a StatsD/DogStatsD sink;
+// other backends need only these two methods. Calls must not block, and a
+// sink must be safe for concurrent use.
+type MetricsSink interface {
+	Count(name string, value int64, tags []Tag)
+	Timing(name string, d time.Duration, tags []Tag)
+}
+
+// WithMetrics reports every API call's count, errors and latency to sink.
+func WithMetrics(sink MetricsSink) ClientOption {
+	return func(c *Client) {
+		c.metrics = sink
+	}
+}
+
+// recordRequest reports one call made by makeRequest.
+func (c *Client) recordRequest(method, endpoint string, start time.Time, statusCode int, err error) {
+	if c.metrics == nil {
+		return
+	}
+	api, route := c.requestRoute(endpoint)
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(statusCode)
+	}
+	tags := []Tag{{"api", api}, {"method", method}, {"route", route}, {"status", status}}
+
+	c.metrics.Count(MetricRequests, 1, tags)
+	if err != nil || statusCode >= 400 {
+		c.metrics.Count(MetricRequestErrors, 1, tags)
+	}
+	c.metrics.Timing(MetricRequestDuration, time.Since(start), tags)
+}
+
+// requestRoute classifies an endpoint by API and reduces its path to a
+// low-cardinality route. RunPod paths alternate between collection names
+// and IDs (/pods/{id}/stop, /v2/{id}/cancel/{id}), so every second segment
+// is an ID.
+func (c *Client) requestRoute(endpoint string) (api, route string) {
+	full := c.buildURL(endpoint)
+	graphqlBase := c.graphqlBaseURL
+	if strings.TrimSpace(graphqlBase) == "" {
+		graphqlBase = DefaultGraphQLBaseURL
+	}
+	var path string
+	switch {
+	case full == strings.TrimSpace(graphqlBase):
+		return "graphql", "/graphql"
+	case strings.HasPrefix(full, strings.TrimRight(c.serverlessBaseURL, "/")+"/v2/"):
+		api, path = "serverless", strings.TrimPrefix(full, strings.TrimRight(c.serverlessBaseURL, "/"))
+	case strings.HasPrefix(full, c.baseURL):
+		api, path = "rest", strings.TrimPrefix(full, c.baseURL)
+	default:
+		api = "other"
+		if u, err := url.Parse(full); err == nil {
+			path = u.Path
+		}
+	}
+	path, _, _ = strings.Cut(path, "?")
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := range segments {
+		if i%2 == 1 {
+			segments[i] = "{id}"
+		}
+	}
+	return api, "/" + strings.Join(segments, "/")
+}
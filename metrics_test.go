@@ -0,0 +1,68 @@
+package runpod_test
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+type recordingSink struct {
+	mu      sync.Mutex
+	metrics []string
+}
+
+func (s *recordingSink) Count(name string, value int64, tags []runpod.Tag) {
+	s.add(fmt.Sprintf("%s %d %v", name, value, tags))
+}
+
+func (s *recordingSink) Timing(name string, d time.Duration, tags []runpod.Tag) {
+	s.add(fmt.Sprintf("%s %v", name, tags))
+}
+
+func (s *recordingSink) add(m string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.metrics = append(s.metrics, m)
+}
+
+func TestWithMetrics(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	srv.AddPod(&runpod.Pod{ID: "pod-1", Name: "api"})
+	sink := &recordingSink{}
+	client := srv.MustClient(runpod.WithMetrics(sink))
+	ctx := t.Context()
+
+	if _, err := client.GetPod(ctx, "pod-1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetPod(ctx, "missing"); err == nil {
+		t.Fatal("GetPod(missing) succeeded")
+	}
+	if _, err := client.GetHealth(ctx, "ep1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ListGPUTypes(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"runpod.requests 1 [{api rest} {method GET} {route /pods/{id}} {status 200}]",
+		"runpod.request.duration [{api rest} {method GET} {route /pods/{id}} {status 200}]",
+		"runpod.requests 1 [{api rest} {method GET} {route /pods/{id}} {status 404}]",
+		"runpod.request.errors 1 [{api rest} {method GET} {route /pods/{id}} {status 404}]",
+		"runpod.request.duration [{api rest} {method GET} {route /pods/{id}} {status 404}]",
+		"runpod.requests 1 [{api serverless} {method GET} {route /v2/{id}/health} {status 200}]",
+		"runpod.request.duration [{api serverless} {method GET} {route /v2/{id}/health} {status 200}]",
+		"runpod.requests 1 [{api graphql} {method POST} {route /graphql} {status 200}]",
+		"runpod.request.duration [{api graphql} {method POST} {route /graphql} {status 200}]",
+	}
+	if !slices.Equal(sink.metrics, want) {
+		t.Fatalf("metrics:\n%v\nwant:\n%v", sink.metrics, want)
+	}
+}
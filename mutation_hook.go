@@ -0,0 +1,189 @@
+package runpod
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Mutation describes one change the client asked RunPod to make, for
+// WithMutationHook.
+type Mutation struct {
+	// ResourceType is "pod", "endpoint", "template", "networkVolume",
+	// "containerRegistryAuth", "secret", "job", "account" or "teamMember".
+	ResourceType string
+	// ResourceID is the ID in the request, or for a create the ID in the
+	// response; empty when a create failed.
+	ResourceID string
+	// Action is "create", "update", "delete" or "terminate", or the verb of
+	// the call: "stop", "resume", "run", "runsync", "cancel", "retry",
+	// "purge-queue", "invite", "update-role", "remove", "update-settings",
+	// "update-notification-settings".
+	Action string
+	// Request is the HTTP method and path, e.g. "POST /pods/abc/stop", or
+	// the GraphQL operation, e.g. "mutation UpdateTeamMemberRole".
+	Request string
+	// Requester is the value ContextWithRequester attached to the call's
+	// context, if any.
+	Requester string
+	At        time.Time
+	Duration  time.Duration
+	// Err is the call's error; nil when RunPod accepted the change.
+	Err error
+}
+
+// MutationHook receives every Mutation. It runs synchronously on the
+// calling goroutine before the SDK method returns, so keep it quick; ctx is
+// the call's context.
+type MutationHook func(ctx context.Context, m Mutation)
+
+// WithMutationHook calls hook for every mutating request the client sends
+// (creates, updates, deletes, pod and job actions, account and team
+// changes), whether it succeeds or fails, so platforms can keep their own
+// audit trail of everything changed through the SDK. Calls rejected by
+// client-side validation send nothing and are not reported; reads never
+// are.
+func WithMutationHook(hook MutationHook) ClientOption {
+	return func(c *Client) {
+		c.mutationHook = hook
+	}
+}
+
+type requesterKey struct{}
+
+// ContextWithRequester attaches the identity of whoever a call is made on
+// behalf of, such as a user or service name, to ctx. It is reported as
+// Mutation.Requester.
+func ContextWithRequester(ctx context.Context, requester string) context.Context {
+	return context.WithValue(ctx, requesterKey{}, requester)
+}
+
+// RequesterFromContext returns the requester ContextWithRequester attached
+// to ctx, or "".
+func RequesterFromContext(ctx context.Context) string {
+	requester, _ := ctx.Value(requesterKey{}).(string)
+	return requester
+}
+
+// restResourceTypes maps REST collections to Mutation.ResourceType.
+var restResourceTypes = map[string]string{
+	"pods":                  "pod",
+	"endpoints":             "endpoint",
+	"templates":             "template",
+	"networkvolumes":        "networkVolume",
+	"containerregistryauth": "containerRegistryAuth",
+	"secrets":               "secret",
+}
+
+// send performs a request and decodes the response into result, reporting
+// it to the mutation hook; Post, Put, Patch and Delete use it.
+func (c *Client) send(ctx context.Context, method, endpoint string, body interface{}, result interface{}) error {
+	start := c.clock.Now()
+	resp, err := c.makeRequest(ctx, method, endpoint, body)
+	if err == nil {
+		err = c.handleResponse(resp, result)
+	}
+	if c.mutationHook != nil {
+		m := c.describeMutation(method, endpoint, result, err)
+		c.recordMutation(ctx, m, start, err)
+	}
+	return err
+}
+
+// graphQLMutation runs a GraphQL mutation and reports it to the mutation
+// hook as m.
+func (c *Client) graphQLMutation(ctx context.Context, m Mutation, query string, variables map[string]interface{}, result interface{}) error {
+	start := c.clock.Now()
+	err := c.GraphQL(ctx, query, variables, result)
+	if c.mutationHook != nil {
+		m.Request = strings.Join(strings.Fields(strings.SplitN(query, "(", 2)[0]), " ")
+		c.recordMutation(ctx, m, start, err)
+	}
+	return err
+}
+
+func (c *Client) recordMutation(ctx context.Context, m Mutation, start time.Time, err error) {
+	m.Requester = RequesterFromContext(ctx)
+	m.At = start
+	m.Duration = c.clock.Now().Sub(start)
+	m.Err = err
+	c.mutationHook(ctx, m)
+}
+
+// describeMutation names the resource and action of a REST or serverless
+// request from its path, taking a created resource's ID from result.
+func (c *Client) describeMutation(method, endpoint string, result interface{}, err error) Mutation {
+	full := c.buildURL(endpoint)
+	var path string
+	serverlessBase := strings.TrimRight(c.serverlessBaseURL, "/")
+	switch {
+	case strings.HasPrefix(full, serverlessBase+"/v2/"):
+		path = strings.TrimPrefix(full, serverlessBase)
+	case strings.HasPrefix(full, c.baseURL):
+		path = strings.TrimPrefix(full, c.baseURL)
+	default:
+		if u, err := url.Parse(full); err == nil {
+			path = u.Path
+		}
+	}
+	path, _, _ = strings.Cut(path, "?")
+	m := Mutation{Request: method + " " + path}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, s := range segments {
+		if unescaped, err := url.PathUnescape(s); err == nil {
+			segments[i] = unescaped
+		}
+	}
+	createdID := func() string {
+		if err != nil || result == nil {
+			return ""
+		}
+		var created struct {
+			ID string `json:"id"`
+		}
+		data, _ := json.Marshal(result)
+		_ = json.Unmarshal(data, &created)
+		return created.ID
+	}
+
+	if segments[0] == "v2" && len(segments) >= 3 {
+		// /v2/{endpointID}/{action}[/{jobID}]
+		m.Action = segments[2]
+		switch {
+		case len(segments) >= 4:
+			m.ResourceType, m.ResourceID = "job", segments[3]
+		case m.Action == "run" || m.Action == "runsync":
+			m.ResourceType, m.ResourceID = "job", createdID()
+		default:
+			m.ResourceType, m.ResourceID = "endpoint", segments[1]
+		}
+		return m
+	}
+
+	// /{collection}[/{id}[/{action}]]
+	m.ResourceType = segments[0]
+	if t, ok := restResourceTypes[segments[0]]; ok {
+		m.ResourceType = t
+	}
+	switch {
+	case len(segments) == 1:
+		m.Action, m.ResourceID = "create", createdID()
+	case len(segments) >= 3:
+		m.Action, m.ResourceID = segments[2], segments[1]
+	default:
+		m.ResourceID = segments[1]
+		switch {
+		case method == http.MethodDelete && m.ResourceType == "pod":
+			m.Action = "terminate"
+		case method == http.MethodDelete:
+			m.Action = "delete"
+		default:
+			m.Action = "update"
+		}
+	}
+	return m
+}
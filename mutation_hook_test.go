@@ -0,0 +1,74 @@
+package runpod_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestWithMutationHook(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	var got []runpod.Mutation
+	client := srv.MustClient(runpod.WithMutationHook(func(ctx context.Context, m runpod.Mutation) {
+		got = append(got, m)
+	}))
+	ctx := runpod.ContextWithRequester(t.Context(), "alice@example.com")
+
+	pod, err := client.CreatePod(ctx, &runpod.CreatePodRequest{Name: "p", ImageName: "img", GPUTypeIDs: []string{"NVIDIA A40"}, GPUCount: 1, ContainerDiskInGB: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ListPods(ctx, nil); err != nil { // reads are not reported
+		t.Fatal(err)
+	}
+	if err := client.StopPod(ctx, pod.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.TerminatePod(ctx, pod.ID); err != nil {
+		t.Fatal(err)
+	}
+	job, err := client.RunAsync(ctx, "ep1", map[string]int{"n": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.CancelJob(ctx, "ep1", job.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.UpdateAccountSettings(ctx, &runpod.AccountSettings{SpendLimit: runpod.Ptr(40.0)}); err != nil {
+		t.Fatal(err)
+	}
+	deleteErr := client.DeleteTemplate(ctx, "missing")
+	if !errors.Is(deleteErr, runpod.ErrNotFound) {
+		t.Fatalf("DeleteTemplate(missing) = %v", deleteErr)
+	}
+	if _, err := client.CreatePod(ctx, &runpod.CreatePodRequest{}); err == nil { // rejected before sending
+		t.Fatal("expected validation error")
+	}
+
+	want := []runpod.Mutation{
+		{ResourceType: "pod", ResourceID: pod.ID, Action: "create", Request: "POST /pods"},
+		{ResourceType: "pod", ResourceID: pod.ID, Action: "stop", Request: "POST /pods/" + pod.ID + "/stop"},
+		{ResourceType: "pod", ResourceID: pod.ID, Action: "terminate", Request: "DELETE /pods/" + pod.ID},
+		{ResourceType: "job", ResourceID: job.ID, Action: "run", Request: "POST /v2/ep1/run"},
+		{ResourceType: "job", ResourceID: job.ID, Action: "cancel", Request: "POST /v2/ep1/cancel/" + job.ID},
+		{ResourceType: "account", Action: "update-settings", Request: "mutation UpdateAccountSettings"},
+		{ResourceType: "template", ResourceID: "missing", Action: "delete", Request: "DELETE /templates/missing"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("mutations = %+v", got)
+	}
+	for i, w := range want {
+		m := got[i]
+		if m.ResourceType != w.ResourceType || m.ResourceID != w.ResourceID || m.Action != w.Action || m.Request != w.Request ||
+			m.Requester != "alice@example.com" || m.At.IsZero() {
+			t.Errorf("mutation %d = %+v, want %+v", i, m, w)
+		}
+		if (i == len(want)-1) != (m.Err != nil) {
+			t.Errorf("mutation %d error = %v", i, m.Err)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 // ListNetworkVolumes lists all network volumes on the account.
@@ -16,6 +17,9 @@ func (c *Client) ListNetworkVolumes(ctx context.Context) ([]NetworkVolume, error
 	if len(raw) == 0 {
 		return nil, nil
 	}
+	if err := c.checkListShape(raw, []NetworkVolume(nil)); err != nil {
+		return nil, fmt.Errorf("failed to list network volumes: %w", err)
+	}
 
 	var volumes []NetworkVolume
 	if err := json.Unmarshal(raw, &volumes); err == nil {
@@ -50,13 +54,7 @@ func (c *Client) CreateNetworkVolume(ctx context.Context, req *CreateNetworkVolu
 	if req == nil {
 		return nil, NewValidationError("request", "cannot be nil")
 	}
-	if err := c.validateRequired("name", req.Name); err != nil {
-		return nil, err
-	}
-	if err := c.validatePositive("size", req.Size); err != nil {
-		return nil, err
-	}
-	if err := c.validateRequired("dataCenterId", req.DataCenterID); err != nil {
+	if err := c.validateRequest(req); err != nil {
 		return nil, err
 	}
 
@@ -73,13 +71,11 @@ func (c *Client) UpdateNetworkVolume(ctx context.Context, volumeID string, req *
 	if err := c.validateRequired("volumeID", volumeID); err != nil {
 		return nil, err
 	}
-	if req == nil || (req.Name == "" && req.Size == 0) {
-		return nil, NewValidationError("request", "must set name and/or size")
+	if req == nil {
+		return nil, NewValidationError("request", "cannot be nil")
 	}
-	if req.Size != 0 {
-		if err := c.validatePositive("size", req.Size); err != nil {
-			return nil, err
-		}
+	if err := c.validateRequest(req); err != nil {
+		return nil, err
 	}
 
 	var volume NetworkVolume
@@ -89,13 +85,93 @@ func (c *Client) UpdateNetworkVolume(ctx context.Context, volumeID string, req *
 	return &volume, nil
 }
 
-// DeleteNetworkVolume deletes a network volume by ID.
+// NetworkVolumeUsers returns the pods (running or stopped) and endpoints
+// that reference a network volume.
+func (c *Client) NetworkVolumeUsers(ctx context.Context, volumeID string) (podIDs, endpointIDs []string, err error) {
+	if err := c.validateRequired("volumeID", volumeID); err != nil {
+		return nil, nil, err
+	}
+	for pod, err := range c.Pods(ctx, nil) {
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to check network volume %s users: %w", volumeID, err)
+		}
+		if strings.TrimSpace(pod.NetworkVolumeID) == volumeID || (pod.NetworkVolume != nil && pod.NetworkVolume.ID == volumeID) {
+			podIDs = append(podIDs, pod.ID)
+		}
+	}
+	endpoints, err := c.ListEndpoints(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to check network volume %s users: %w", volumeID, err)
+	}
+	for _, endpoint := range endpoints {
+		if strings.TrimSpace(endpoint.NetworkVolumeID) == volumeID {
+			endpointIDs = append(endpointIDs, endpoint.ID)
+		}
+	}
+	return podIDs, endpointIDs, nil
+}
+
+// DeleteNetworkVolumeOptions tunes DeleteNetworkVolumeWithOptions.
+type DeleteNetworkVolumeOptions struct {
+	// Force deletes without first checking for pods and endpoints that
+	// reference the volume, saving the requests the check makes.
+	Force bool
+}
+
+// DeleteNetworkVolume deletes a network volume by ID. It first checks that
+// no pod or endpoint references the volume and returns a
+// *VolumeInUseError, without deleting, if any does. The check lists every
+// pod and endpoint; see DeleteNetworkVolumeWithOptions to skip it.
 func (c *Client) DeleteNetworkVolume(ctx context.Context, volumeID string) error {
+	return c.DeleteNetworkVolumeWithOptions(ctx, volumeID, nil)
+}
+
+// DeleteNetworkVolumeWithOptions deletes a network volume by ID, checking
+// for users as DeleteNetworkVolume does unless opts.Force is set.
+func (c *Client) DeleteNetworkVolumeWithOptions(ctx context.Context, volumeID string, opts *DeleteNetworkVolumeOptions) error {
 	if err := c.validateRequired("volumeID", volumeID); err != nil {
 		return err
 	}
+	if opts == nil || !opts.Force {
+		podIDs, endpointIDs, err := c.NetworkVolumeUsers(ctx, volumeID)
+		if err != nil {
+			return err
+		}
+		if len(podIDs) > 0 || len(endpointIDs) > 0 {
+			return &VolumeInUseError{VolumeID: volumeID, PodIDs: podIDs, EndpointIDs: endpointIDs}
+		}
+	}
 	if err := c.Delete(ctx, "/networkvolumes/"+volumeID); err != nil {
 		return fmt.Errorf("failed to delete network volume %s: %w", volumeID, err)
 	}
 	return nil
 }
+
+// Validate checks the request. It returns a *ValidationError, or a
+// *ValidationErrors when it finds more than one problem.
+func (req *CreateNetworkVolumeRequest) Validate() error {
+	if req == nil {
+		return nilRequest()
+	}
+	var p problems
+	p.add(validateRequired("name", req.Name))
+	p.add(validatePositive("size", req.Size))
+	p.add(validateRequired("dataCenterId", req.DataCenterID))
+	return p.err()
+}
+
+// Validate checks the request. It returns a *ValidationError, or a
+// *ValidationErrors when it finds more than one problem.
+func (req *UpdateNetworkVolumeRequest) Validate() error {
+	if req == nil {
+		return nilRequest()
+	}
+	var p problems
+	if req.Name == "" && req.Size == 0 {
+		p.add(NewValidationError("request", "must set name and/or size"))
+	}
+	if req.Size != 0 {
+		p.add(validatePositive("size", req.Size))
+	}
+	return p.err()
+}
@@ -3,11 +3,13 @@ package runpod_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
 )
 
 func newVolumeServer(t *testing.T) *httptest.Server {
@@ -39,6 +41,9 @@ func newVolumeServer(t *testing.T) *httptest.Server {
 				t.Fatalf("decode: %v", err)
 			}
 			json.NewEncoder(w).Encode(runpod.NetworkVolume{ID: "vol1", Name: "models", Size: req.Size, DataCenterID: "EU-RO-1"})
+		case r.Method == "GET" && (r.URL.Path == "/pods" || r.URL.Path == "/endpoints"):
+			// DeleteNetworkVolume checks for attached pods and endpoints.
+			w.Write([]byte(`[{"id":"other","networkVolumeId":"vol2"}]`))
 		case r.Method == "DELETE" && r.URL.Path == "/networkvolumes/vol1":
 			w.WriteHeader(http.StatusNoContent)
 		default:
@@ -110,3 +115,43 @@ func TestNetworkVolumeValidation(t *testing.T) {
 		t.Fatal("empty volumeID must fail validation")
 	}
 }
+
+func TestDeleteNetworkVolumeInUse(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+	ctx := t.Context()
+
+	srv.AddNetworkVolume(&runpod.NetworkVolume{ID: "vol-1", Name: "models", Size: 100, DataCenterID: "EU-RO-1"})
+	srv.AddPod(&runpod.Pod{ID: "pod-1", DesiredStatus: "EXITED", NetworkVolumeID: "vol-1"})
+	srv.AddEndpoint(&runpod.Endpoint{ID: "ep-1", NetworkVolumeID: "vol-1"})
+
+	err := client.DeleteNetworkVolume(ctx, "vol-1")
+	var inUse *runpod.VolumeInUseError
+	if !errors.As(err, &inUse) || len(inUse.PodIDs) != 1 || inUse.PodIDs[0] != "pod-1" || len(inUse.EndpointIDs) != 1 || inUse.EndpointIDs[0] != "ep-1" {
+		t.Fatalf("error = %v", err)
+	}
+	if _, err := client.GetNetworkVolume(ctx, "vol-1"); err != nil {
+		t.Fatalf("volume must survive a refused delete: %v", err)
+	}
+
+	// Force skips the check.
+	srv.AddNetworkVolume(&runpod.NetworkVolume{ID: "vol-2", Name: "scratch", Size: 10, DataCenterID: "EU-RO-1"})
+	srv.AddEndpoint(&runpod.Endpoint{ID: "ep-2", NetworkVolumeID: "vol-2"})
+	if err := client.DeleteNetworkVolumeWithOptions(ctx, "vol-2", &runpod.DeleteNetworkVolumeOptions{Force: true}); err != nil {
+		t.Fatalf("forced delete: %v", err)
+	}
+	if _, err := client.GetNetworkVolume(ctx, "vol-2"); !errors.Is(err, runpod.ErrNotFound) {
+		t.Fatalf("volume after forced delete: %v", err)
+	}
+
+	if err := client.TerminatePod(ctx, "pod-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.DeleteEndpoint(ctx, "ep-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.DeleteNetworkVolume(ctx, "vol-1"); err != nil {
+		t.Fatalf("DeleteNetworkVolume after detaching: %v", err)
+	}
+}
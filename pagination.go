@@ -0,0 +1,181 @@
+package runpod
+
+import (
+	"context"
+	"iter"
+)
+
+// DefaultPageSize is the page size the list iterators request when
+// ListOptions.Limit is unset.
+const DefaultPageSize = 100
+
+// Page is one page of a list response.
+type Page[T any] struct {
+	Items []T
+	PageInfo
+}
+
+// PageInfo describes where a page sits in a cursor-paginated list.
+// NextCursor is empty on the last page, and when the endpoint paginates
+// by offset only.
+type PageInfo struct {
+	NextCursor string `json:"nextCursor,omitempty"`
+	HasMore    bool   `json:"hasMore,omitempty"`
+	Total      int    `json:"total,omitempty"`
+}
+
+// pageEnvelope decodes the cursor fields of a wrapped list response, either
+// nested under "pageInfo" or at the top level.
+type pageEnvelope struct {
+	PageInfo   *PageInfo `json:"pageInfo"`
+	NextCursor string    `json:"nextCursor"`
+	HasMore    bool      `json:"hasMore"`
+}
+
+func (e pageEnvelope) info() PageInfo {
+	if e.PageInfo != nil {
+		return *e.PageInfo
+	}
+	return PageInfo{NextCursor: e.NextCursor, HasMore: e.HasMore}
+}
+
+// Pods iterates over the account's pods a page at a time (opts.Limit, or
+// DefaultPageSize, starting at opts.Cursor or opts.Offset), following the
+// API's cursors when it returns them, so a large account is never held in
+// memory at once:
+//
+//	for pod, err := range client.Pods(ctx, nil) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// Like every list iterator, it yields at most one error, with a nil value,
+// and then stops. Breaking out of the loop stops further requests.
+func (c *Client) Pods(ctx context.Context, opts *ListOptions) iter.Seq2[*Pod, error] {
+	return paginate(ctx, opts, c.ListPodsPage, func(p *Pod) string { return p.ID })
+}
+
+// Secrets iterates over the account's secrets a page at a time; see Pods.
+func (c *Client) Secrets(ctx context.Context, opts *ListOptions) iter.Seq2[*Secret, error] {
+	return paginate(ctx, opts, c.ListSecretsPage, func(s *Secret) string { return s.ID + "/" + s.Name })
+}
+
+// Endpoints iterates over the account's endpoints. RunPod returns them in
+// one response, so this is ListEndpoints in iterator form.
+func (c *Client) Endpoints(ctx context.Context, opts *GetEndpointOptions) iter.Seq2[Endpoint, error] {
+	return each(func() ([]Endpoint, error) { return c.ListEndpoints(ctx, opts) })
+}
+
+// Templates iterates over the account's templates; see Endpoints.
+func (c *Client) Templates(ctx context.Context) iter.Seq2[Template, error] {
+	return each(func() ([]Template, error) { return c.ListTemplates(ctx) })
+}
+
+// NetworkVolumes iterates over the account's network volumes; see Endpoints.
+func (c *Client) NetworkVolumes(ctx context.Context) iter.Seq2[NetworkVolume, error] {
+	return each(func() ([]NetworkVolume, error) { return c.ListNetworkVolumes(ctx) })
+}
+
+// ContainerRegistryAuths iterates over the stored registry credentials; see
+// Endpoints.
+func (c *Client) ContainerRegistryAuths(ctx context.Context) iter.Seq2[ContainerRegistryAuth, error] {
+	return each(func() ([]ContainerRegistryAuth, error) { return c.ListContainerRegistryAuths(ctx) })
+}
+
+// TeamMembers iterates over the team's members and pending invitations;
+// see Endpoints.
+func (c *Client) TeamMembers(ctx context.Context) iter.Seq2[TeamMember, error] {
+	return each(func() ([]TeamMember, error) { return c.ListTeamMembers(ctx) })
+}
+
+// AuditLogs iterates over the audit events matching filter, newest first,
+// following NextCursor one page at a time; see Pods.
+func (c *Client) AuditLogs(ctx context.Context, filter *AuditLogFilter) iter.Seq2[AuditLogEntry, error] {
+	return func(yield func(AuditLogEntry, error) bool) {
+		page := AuditLogFilter{}
+		if filter != nil {
+			page = *filter
+		}
+		for {
+			result, err := c.ListAuditLogs(ctx, &page)
+			if err != nil {
+				yield(AuditLogEntry{}, err)
+				return
+			}
+			for _, entry := range result.Entries {
+				if !yield(entry, nil) {
+					return
+				}
+			}
+			if result.NextCursor == "" || result.NextCursor == page.Cursor {
+				return
+			}
+			page.Cursor = result.NextCursor
+		}
+	}
+}
+
+// paginate walks a paginated list, by cursor once the API returns one and
+// by offset otherwise. A short offset page ends the walk, and so does a
+// page that starts with the previous page's first item, which means the
+// API ignored the offset.
+func paginate[T any](ctx context.Context, opts *ListOptions, list func(context.Context, *ListOptions) (*Page[T], error), key func(T) string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		page := ListOptions{}
+		if opts != nil {
+			page = *opts
+		}
+		if page.Limit <= 0 {
+			page.Limit = DefaultPageSize
+		}
+		var first string
+		for {
+			result, err := list(ctx, &page)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			items := result.Items
+			if len(items) == 0 || (page.Cursor == "" && page.Offset > 0 && key(items[0]) == first) {
+				return
+			}
+			first = key(items[0])
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			switch {
+			case result.NextCursor != "":
+				if result.NextCursor == page.Cursor {
+					return
+				}
+				page.Cursor, page.Offset = result.NextCursor, 0
+			case page.Cursor != "" || len(items) < page.Limit:
+				return
+			default:
+				page.Offset += len(items)
+			}
+		}
+	}
+}
+
+// each is the iterator form of a list RunPod returns in one response.
+func each[T any](list func() ([]T, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		items, err := list()
+		if err != nil {
+			var zero T
+			yield(zero, err)
+			return
+		}
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
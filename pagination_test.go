@@ -0,0 +1,191 @@
+package runpod_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestListIterators(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+	ctx := context.Background()
+
+	for i := range 5 {
+		srv.AddPod(&runpod.Pod{ID: fmt.Sprintf("pod-%d", i), Name: "p"})
+		srv.AddSecret(fmt.Sprintf("secret-%d", i), "v")
+	}
+	srv.AddTemplate(&runpod.Template{ID: "tpl1", Name: "worker", ImageName: "acme/worker:1"})
+
+	var ids []string
+	for pod, err := range client.Pods(ctx, &runpod.ListOptions{Limit: 2}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, pod.ID)
+	}
+	if fmt.Sprint(ids) != "[pod-0 pod-1 pod-2 pod-3 pod-4]" {
+		t.Fatalf("pods = %v", ids)
+	}
+
+	// Breaking out early stops paging.
+	n := 0
+	for _, err := range client.Secrets(ctx, &runpod.ListOptions{Limit: 2, Offset: 1}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n++; n == 3 {
+			break
+		}
+	}
+	if n != 3 {
+		t.Fatalf("secrets yielded %d", n)
+	}
+
+	for tpl, err := range client.Templates(ctx) {
+		if err != nil || tpl.ID != "tpl1" {
+			t.Fatalf("template = %+v, %v", tpl, err)
+		}
+	}
+}
+
+func TestListIteratorStopsWhenOffsetIgnored(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/endpoints" {
+			http.Error(w, `{"error":"not found"}`, http.StatusNotFound)
+			return
+		}
+		requests.Add(1)
+		w.Write([]byte(`[{"id":"a"},{"id":"b"}]`))
+	}))
+	defer server.Close()
+	client := mustClient(t, "test_key", runpod.WithBaseURL(server.URL))
+
+	n := 0
+	for _, err := range client.Pods(context.Background(), &runpod.ListOptions{Limit: 2}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		n++
+	}
+	if n != 2 || requests.Load() != 2 {
+		t.Fatalf("yielded %d pods in %d requests", n, requests.Load())
+	}
+
+	var failed error
+	for pod, err := range client.Endpoints(context.Background(), nil) {
+		if err == nil {
+			t.Fatalf("unexpected endpoint %+v", pod)
+		}
+		failed = err
+	}
+	if !errors.Is(failed, runpod.ErrNotFound) {
+		t.Fatalf("want ErrNotFound, got %v", failed)
+	}
+}
+
+func TestListIteratorFollowsCursors(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+	ctx := context.Background()
+
+	for i := range 6 {
+		srv.AddPod(&runpod.Pod{ID: fmt.Sprintf("pod-%d", i), Name: "p"})
+	}
+
+	page, err := client.ListPodsPage(ctx, &runpod.ListOptions{Limit: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Items) != 4 || page.NextCursor != "pod-3" || !page.HasMore || page.Total != 6 {
+		t.Fatalf("page = %+v", page)
+	}
+
+	// Terminating a pod already seen would shift every later offset by one;
+	// the cursor keeps the walk in place.
+	var ids []string
+	for pod, err := range client.Pods(ctx, &runpod.ListOptions{Limit: 2}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, pod.ID)
+		if pod.ID == "pod-1" {
+			if err := client.TerminatePod(ctx, "pod-0"); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if fmt.Sprint(ids) != "[pod-0 pod-1 pod-2 pod-3 pod-4 pod-5]" {
+		t.Fatalf("pods = %v", ids)
+	}
+}
+
+func TestListPodsPageTopLevelCursor(t *testing.T) {
+	var cursors []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cursors = append(cursors, r.URL.Query().Get("cursor"))
+		if r.URL.Query().Get("cursor") == "" {
+			w.Write([]byte(`{"pods":[{"id":"a"}],"nextCursor":"c1"}`))
+			return
+		}
+		w.Write([]byte(`{"pods":[{"id":"b"}]}`))
+	}))
+	defer server.Close()
+	client := mustClient(t, "test_key", runpod.WithBaseURL(server.URL))
+
+	var ids []string
+	for pod, err := range client.Pods(context.Background(), nil) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, pod.ID)
+	}
+	if fmt.Sprint(ids) != "[a b]" || fmt.Sprint(cursors) != "[ c1]" {
+		t.Fatalf("pods = %v, cursors = %q", ids, cursors)
+	}
+}
+
+func TestListOptionsQuery(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+	client := mustClient(t, "test_key", runpod.WithBaseURL(server.URL))
+
+	_, err := client.ListPods(context.Background(), &runpod.ListOptions{
+		Limit:         10,
+		Filter:        runpod.ListFilter{DesiredStatus: "RUNNING", ComputeType: runpod.ComputeTypeGPU},
+		SortBy:        "createdAt",
+		SortOrder:     runpod.SortDescending,
+		IncludeFields: []string{"id", "desiredStatus"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "computeType=GPU&desiredStatus=RUNNING&includeFields=id%2CdesiredStatus&limit=10&sortBy=createdAt&sortOrder=desc"
+	if got := query.Encode(); got != want {
+		t.Fatalf("query = %s, want %s", got, want)
+	}
+
+	srv := runpodtest.New()
+	defer srv.Close()
+	srv.AddPod(&runpod.Pod{ID: "a", Name: "web", DesiredStatus: "RUNNING"})
+	srv.AddPod(&runpod.Pod{ID: "b", Name: "batch", DesiredStatus: "EXITED"})
+	pods, err := srv.MustClient().ListPods(context.Background(), &runpod.ListOptions{Filter: runpod.ListFilter{DesiredStatus: "RUNNING"}})
+	if err != nil || len(pods) != 1 || pods[0].ID != "a" {
+		t.Fatalf("filtered pods = %v, %v", pods, err)
+	}
+}
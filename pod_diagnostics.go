@@ -117,10 +117,9 @@ func derivePortMappings(pod *Pod) map[string]int {
 		return out
 	}
 
-	for key, val := range pod.Runtime.Ports {
-		port := coercePortValue(val)
-		if port > 0 {
-			out[key] = port
+	for _, port := range pod.Runtime.Ports {
+		if port.PublicPort > 0 {
+			out[port.Key()] = port.PublicPort
 		}
 	}
 	return out
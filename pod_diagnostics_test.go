@@ -43,6 +43,36 @@ func TestGetPodWithOptions_QueryParams(t *testing.T) {
 	}
 }
 
+func TestListPodsInclude(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("includeSavingsPlans") != "true" || q.Get("includeTemplate") != "true" || q.Get("includeMachine") != "" || q.Get("limit") != "100" {
+			t.Errorf("unexpected query params: %v", q)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{
+			"id": "pod-1",
+			"desiredStatus": "RUNNING",
+			"savingsPlans": [{"id": "sp-1", "gpuTypeId": "NVIDIA A40", "costPerHr": 0.25, "endTime": "2026-12-01T00:00:00Z"}],
+			"template": {"id": "tpl-1", "name": "comfy"}
+		}]`))
+	}))
+	defer server.Close()
+
+	client := mustClient(t, "test_key", runpod.WithBaseURL(server.URL+"/v1"))
+	opts := &runpod.ListOptions{Include: runpod.GetPodOptions{IncludeSavingsPlans: true, IncludeTemplate: true}}
+	for pod, err := range client.Pods(context.Background(), opts) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(pod.SavingsPlans) != 1 || pod.SavingsPlans[0].CostPerHour != 0.25 || pod.SavingsPlans[0].EndTime.Month() != 12 ||
+			pod.Template == nil || pod.Template.ID != "tpl-1" {
+			t.Fatalf("pod = %+v", pod)
+		}
+	}
+}
+
 func TestGetPodDiagnostics_StatusMatrix(t *testing.T) {
 	tests := []struct {
 		name             string
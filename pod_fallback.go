@@ -87,6 +87,9 @@ func (c *Client) CreatePodWithFallback(ctx context.Context, req *CreatePodReques
 	if len(candidates) == 0 {
 		return nil, NewValidationError("candidates", "cannot be empty")
 	}
+	if err := c.checkImage(ctx, "imageName", req.ImageName); err != nil {
+		return nil, err
+	}
 	if opts != nil && opts.CandidateFilter != nil {
 		if filtered := opts.CandidateFilter(candidates); len(filtered) > 0 {
 			candidates = filtered
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/url"
 	"path"
 	"strings"
 )
@@ -19,6 +18,11 @@ func (c *Client) CreatePod(ctx context.Context, req *CreatePodRequest) (*Pod, er
 	if req != nil && len(req.GPUTypeIDs) > 1 {
 		return c.CreatePodWithFallback(ctx, req, req.GPUTypeIDs, nil)
 	}
+	if req != nil {
+		if err := c.checkImage(ctx, "imageName", req.ImageName); err != nil {
+			return nil, err
+		}
+	}
 	return c.createPod(ctx, req)
 }
 
@@ -64,33 +68,13 @@ func (c *Client) GetPod(ctx context.Context, podID string) (*Pod, error) {
 }
 
 // GetPodWithOptions retrieves a pod by ID with include* query options.
+// ListOptions.Include does the same for pod lists.
 func (c *Client) GetPodWithOptions(ctx context.Context, podID string, opts *GetPodOptions) (*Pod, error) {
 	if err := c.validateRequired("podID", podID); err != nil {
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf("/pods/%s", podID)
-	if opts != nil {
-		q := url.Values{}
-		if opts.IncludeMachine {
-			q.Set("includeMachine", "true")
-		}
-		if opts.IncludeNetworkVolume {
-			q.Set("includeNetworkVolume", "true")
-		}
-		if opts.IncludeSavingsPlans {
-			q.Set("includeSavingsPlans", "true")
-		}
-		if opts.IncludeTemplate {
-			q.Set("includeTemplate", "true")
-		}
-		if opts.IncludeWorkers {
-			q.Set("includeWorkers", "true")
-		}
-		if encoded := q.Encode(); encoded != "" {
-			endpoint += "?" + encoded
-		}
-	}
+	endpoint := c.buildURLWithParams(fmt.Sprintf("/pods/%s", podID), podIncludeParams(opts))
 
 	var pod Pod
 	err := c.Get(ctx, endpoint, &pod)
@@ -104,11 +88,25 @@ func (c *Client) GetPodWithOptions(ctx context.Context, podID string, opts *GetP
 
 // ListPods lists all pods with optional filtering
 func (c *Client) ListPods(ctx context.Context, opts *ListOptions) ([]*Pod, error) {
+	page, err := c.ListPodsPage(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return page.Items, nil
+}
+
+// ListPodsPage returns one page of pods and its PageInfo; pass
+// PageInfo.NextCursor back as opts.Cursor for the next page.
+func (c *Client) ListPodsPage(ctx context.Context, opts *ListOptions) (*Page[*Pod], error) {
 	endpoint := c.buildListURL("/pods", opts)
+	if opts != nil {
+		endpoint = c.buildURLWithParams(endpoint, podIncludeParams(&opts.Include))
+	}
 
 	// RunPod has returned multiple shapes for this endpoint over time:
 	// - [...] (current documented shape)
 	// - {"pods":[...]} (legacy compatibility shape)
+	// - {"pods":[...],"pageInfo":{"nextCursor":...}} (cursor envelope)
 	//
 	// Be permissive so higher-level schedulers can reliably enforce max_workers / pod counts.
 	var raw json.RawMessage
@@ -116,28 +114,55 @@ func (c *Client) ListPods(ctx context.Context, opts *ListOptions) ([]*Pod, error
 		return nil, fmt.Errorf("failed to list pods: %w", err)
 	}
 	if len(raw) == 0 {
-		return nil, nil
+		return &Page[*Pod]{}, nil
+	}
+	if err := c.checkListShape(raw, []*Pod(nil)); err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
 	}
 
 	// Retain the legacy object wrapper before decoding the current bare array.
 	var wrapped struct {
 		Pods []*Pod `json:"pods"`
+		pageEnvelope
 	}
 	if err := json.Unmarshal(raw, &wrapped); err == nil && wrapped.Pods != nil {
 		normalizePods(wrapped.Pods)
-		return wrapped.Pods, nil
+		return &Page[*Pod]{Items: wrapped.Pods, PageInfo: wrapped.info()}, nil
 	}
 
 	// Fallback: bare array.
 	var pods []*Pod
 	if err := json.Unmarshal(raw, &pods); err == nil {
 		normalizePods(pods)
-		return pods, nil
+		return &Page[*Pod]{Items: pods}, nil
 	}
 
 	return nil, fmt.Errorf("failed to list pods: unexpected response shape")
 }
 
+func podIncludeParams(opts *GetPodOptions) map[string]string {
+	if opts == nil {
+		return nil
+	}
+	params := map[string]string{}
+	if opts.IncludeMachine {
+		params["includeMachine"] = "true"
+	}
+	if opts.IncludeNetworkVolume {
+		params["includeNetworkVolume"] = "true"
+	}
+	if opts.IncludeSavingsPlans {
+		params["includeSavingsPlans"] = "true"
+	}
+	if opts.IncludeTemplate {
+		params["includeTemplate"] = "true"
+	}
+	if opts.IncludeWorkers {
+		params["includeWorkers"] = "true"
+	}
+	return params
+}
+
 // StopPod stops a running pod
 func (c *Client) StopPod(ctx context.Context, podID string) error {
 	if err := c.validateRequired("podID", podID); err != nil {
@@ -185,145 +210,119 @@ func (c *Client) TerminatePod(ctx context.Context, podID string) error {
 	return nil
 }
 
-// validateCreatePodRequest validates a pod creation request. The GPU-only
-// fields (gpuTypeIds, gpuCount) are required when ComputeType="GPU" or empty
-// (the SDK historically defaulted to GPU); for CPU pods (ComputeType="CPU")
-// they are forbidden — RunPod's REST API rejects unknown fields outright, so
-// sending a zeroed gpuCount on a CPU request would fail. CPU placement allows
-// an optional cpuFlavorIds list to constrain which CPU family to land on, but
-// the list is not required — RunPod auto-picks the cheapest available flavor
-// when omitted.
+// validateCreatePodRequest rejects a nil request, then runs Validate unless
+// request validation is disabled.
 func (c *Client) validateCreatePodRequest(req *CreatePodRequest) error {
 	if req == nil {
 		return NewValidationError("request", "cannot be nil")
 	}
+	return c.validateRequest(req)
+}
 
-	// Required fields
-	if err := c.validateRequired("name", req.Name); err != nil {
-		return err
-	}
-	if err := c.validateRequired("imageName", req.ImageName); err != nil {
-		return err
+// Validate checks the request. It returns a *ValidationError, or a
+// *ValidationErrors when it finds more than one problem. The GPU-only
+// fields (gpuTypeIds, gpuCount) are required when ComputeType="GPU" or
+// empty (the SDK historically defaulted to GPU); for CPU pods
+// (ComputeType="CPU") they are forbidden — RunPod's REST API rejects
+// unknown fields outright, so sending a zeroed gpuCount on a CPU request
+// would fail. CPU placement allows an optional cpuFlavorIds list to
+// constrain which CPU family to land on, but the list is not required —
+// RunPod auto-picks the cheapest available flavor when omitted.
+func (req *CreatePodRequest) Validate() error {
+	if req == nil {
+		return nilRequest()
 	}
+	var p problems
+
+	// Required fields
+	p.add(validateRequired("name", req.Name))
+	p.add(validateRequired("imageName", req.ImageName))
 
 	// Compute-class-specific selector validation.
-	isCPU := strings.EqualFold(strings.TrimSpace(req.ComputeType), "CPU")
+	isCPU := req.ComputeType.isCPU()
 	if isCPU {
 		if len(req.GPUTypeIDs) > 0 {
-			return NewValidationError("gpuTypeIds", "must not be set when computeType is CPU")
+			p.add(NewValidationError("gpuTypeIds", "must not be set when computeType is CPU"))
 		}
 		if req.GPUCount > 0 {
-			return NewValidationError("gpuCount", "must not be set when computeType is CPU")
+			p.add(NewValidationError("gpuCount", "must not be set when computeType is CPU"))
 		}
 		if req.MinRAMPerGPU != 0 {
-			return NewValidationError("minRAMPerGPU", "must not be set when computeType is CPU")
+			p.add(NewValidationError("minRAMPerGPU", "must not be set when computeType is CPU"))
 		}
 		if req.MinVCPUPerGPU != 0 {
-			return NewValidationError("minVCPUPerGPU", "must not be set when computeType is CPU")
+			p.add(NewValidationError("minVCPUPerGPU", "must not be set when computeType is CPU"))
 		}
 	} else {
 		// GPU is the historical default; require the GPU selector + count.
-		if err := c.validateRequired("gpuTypeId", req.GPUTypeIDs); err != nil {
-			return err
-		}
-		if err := c.validatePositive("gpuCount", req.GPUCount); err != nil {
-			return err
-		}
+		p.add(validateRequired("gpuTypeId", req.GPUTypeIDs))
+		p.add(validatePositive("gpuCount", req.GPUCount))
 		if len(req.CPUFlavorIDs) > 0 {
-			return NewValidationError("cpuFlavorIds", "must not be set unless computeType is CPU")
+			p.add(NewValidationError("cpuFlavorIds", "must not be set unless computeType is CPU"))
 		}
 		if req.MinRAMPerGPU != 0 {
-			if err := c.validatePositive("minRAMPerGPU", req.MinRAMPerGPU); err != nil {
-				return err
-			}
+			p.add(validatePositive("minRAMPerGPU", req.MinRAMPerGPU))
 		}
 		if req.MinVCPUPerGPU != 0 {
-			if err := c.validatePositive("minVCPUPerGPU", req.MinVCPUPerGPU); err != nil {
-				return err
-			}
+			p.add(validatePositive("minVCPUPerGPU", req.MinVCPUPerGPU))
 		}
 	}
 
-	if err := c.validatePositive("containerDiskInGb", req.ContainerDiskInGB); err != nil {
-		return err
-	}
+	p.add(validatePositive("containerDiskInGb", req.ContainerDiskInGB))
 
-	// Optional positive values
-	if req.VCPUCount > 0 {
-		if err := c.validatePositive("vcpuCount", req.VCPUCount); err != nil {
-			return err
-		}
+	// Optional non-negative values
+	if req.VCPUCount < 0 {
+		p.add(validatePositive("vcpuCount", req.VCPUCount))
 	}
-	if req.VolumeInGB > 0 {
-		if err := c.validatePositive("volumeInGb", req.VolumeInGB); err != nil {
-			return err
-		}
+	if req.VolumeInGB < 0 {
+		p.add(validatePositive("volumeInGb", req.VolumeInGB))
 	}
+	validatePorts(&p, "ports", req.Ports)
 
 	// Bid prices only make sense on interruptible (spot) pods.
 	if req.BidPerGPU != 0 {
-		if err := c.validatePositiveFloat("bidPerGpu", req.BidPerGPU); err != nil {
-			return err
-		}
+		p.add(validatePositiveFloat("bidPerGpu", req.BidPerGPU))
 		if !req.Interruptible {
-			return NewValidationError("bidPerGpu", "requires interruptible=true (spot pods)")
+			p.add(NewValidationError("bidPerGpu", "requires interruptible=true (spot pods)"))
 		}
 	}
 
 	// Validate cloud type
-	if req.CloudType != "" {
-		validCloudTypes := []string{"SECURE", "COMMUNITY"}
-		isValid := false
-		for _, validType := range validCloudTypes {
-			if req.CloudType == validType {
-				isValid = true
-				break
-			}
-		}
-		if !isValid {
-			return NewValidationErrorWithValue("cloudType", "must be either 'SECURE' or 'COMMUNITY'", req.CloudType)
-		}
-	}
+	p.add(validateEnum("cloudType", req.CloudType, cloudTypes))
 
 	// Network volumes are a create-time, datacenter-local Secure Cloud
 	// attachment. Keep the three provider constraints inseparable so callers
 	// cannot accidentally ask RunPod to attach a volume while still allowing
 	// placement in another datacenter or in Community Cloud.
 	if strings.TrimSpace(req.NetworkVolumeID) != "" {
-		if strings.EqualFold(strings.TrimSpace(req.CloudType), "COMMUNITY") {
-			return NewValidationError("cloudType", "must be SECURE when networkVolumeId is set")
+		if req.CloudType.normalize() == CloudTypeCommunity {
+			p.add(NewValidationError("cloudType", "must be SECURE when networkVolumeId is set"))
 		}
 		if len(req.DataCenterIDs) != 1 || strings.TrimSpace(req.DataCenterIDs[0]) == "" {
-			return NewValidationError("dataCenterIds", "must contain exactly the network volume datacenter")
+			p.add(NewValidationError("dataCenterIds", "must contain exactly the network volume datacenter"))
 		}
 		if req.VolumeInGB != 0 {
-			return NewValidationError("volumeInGb", "must be omitted when networkVolumeId is set")
+			p.add(NewValidationError("volumeInGb", "must be omitted when networkVolumeId is set"))
 		}
 		if mount := strings.TrimSpace(req.VolumeMountPath); mount != "" && !path.IsAbs(mount) {
-			return NewValidationError("volumeMountPath", "must be an absolute POSIX container path")
+			p.add(NewValidationError("volumeMountPath", "must be an absolute POSIX container path"))
 		}
 	}
 
-	// Validate compute type
-	if req.ComputeType != "" {
-		validComputeTypes := []string{"GPU", "CPU"}
-		isValid := false
-		for _, validType := range validComputeTypes {
-			if req.ComputeType == validType {
-				isValid = true
-				break
-			}
-		}
-		if !isValid {
-			return NewValidationErrorWithValue("computeType", "must be either 'GPU' or 'CPU'", req.ComputeType)
-		}
-	}
+	// Validate compute type and placement priorities
+	p.add(validateEnum("computeType", req.ComputeType, computeTypes))
+	p.add(validateEnum("gpuTypePriority", req.GPUTypePriority, gpuTypePriorities))
+	p.add(validateEnum("dataCenterPriority", req.DataCenterPriority, dataCenterPriorities))
 
-	if strings.TrimSpace(req.MinCudaVersion) != "" && len(req.AllowedCudaVersions) > 0 {
-		return NewValidationError("minCudaVersion", "cannot be set together with allowedCudaVersions")
+	if strings.TrimSpace(req.MinCudaVersion) != "" {
+		if len(req.AllowedCudaVersions) > 0 {
+			p.add(NewValidationError("minCudaVersion", "cannot be set together with allowedCudaVersions"))
+		}
+		validateCudaVersions(&p, "minCudaVersion", req.MinCudaVersion)
 	}
+	validateCudaVersions(&p, "allowedCudaVersions", req.AllowedCudaVersions...)
 
-	return nil
+	return p.err()
 }
 
 // isPodInErrorState checks if a pod is in a terminal error state
@@ -25,16 +25,8 @@ type SSHTarget struct {
 	User string
 }
 
-type podRuntimePortGQL struct {
-	IP          string `json:"ip"`
-	IsIPPublic  bool   `json:"isIpPublic"`
-	PrivatePort int    `json:"privatePort"`
-	PublicPort  int    `json:"publicPort"`
-	Type        string `json:"type"`
-}
-
 type podRuntimeForSSHGQL struct {
-	Ports []podRuntimePortGQL `json:"ports"`
+	Ports PodPorts `json:"ports"`
 }
 
 type podForSSHGQL struct {
@@ -183,7 +175,7 @@ func (c *Client) StreamPodCommand(ctx context.Context, podID, cmd string, stdout
 	sshCmd.Stderr = stdout
 
 	if c.debug {
-		c.logger.Printf("[DEBUG] StreamPodCommand pod=%s cmd=%q ssh-args=%v", podID, cmd, args)
+		c.logger.Printf("[DEBUG] StreamPodCommand pod=%s cmd=%q ssh-args=%v", podID, c.Redact(cmd), args)
 	}
 
 	if err := sshCmd.Run(); err != nil {
@@ -110,7 +110,7 @@ func (c *Client) WaitForPodReady(ctx context.Context, podID string, opts *WaitFo
 
 	deadline := time.Time{}
 	if timeout > 0 {
-		deadline = time.Now().Add(timeout)
+		deadline = c.clock.Now().Add(timeout)
 	}
 
 	var lastTiming *PodReadyTiming
@@ -132,7 +132,7 @@ func (c *Client) WaitForPodReady(ctx context.Context, podID string, opts *WaitFo
 			// the actual transition timestamp, so the orchestrator clock is the
 			// best signal we have.
 			if timing.FirstRuntimeAt.IsZero() {
-				timing.FirstRuntimeAt = time.Now().UTC()
+				timing.FirstRuntimeAt = c.clock.Now().UTC()
 			}
 			if !timing.LastStartedAt.IsZero() && timing.FirstRuntimeAt.After(timing.LastStartedAt) {
 				timing.PullAndStartDuration = timing.FirstRuntimeAt.Sub(timing.LastStartedAt)
@@ -144,14 +144,14 @@ func (c *Client) WaitForPodReady(ctx context.Context, podID string, opts *WaitFo
 			return timing, PodReadyStateTerminal, fmt.Errorf("pod %s entered terminal state %q before runtime came up", podID, pod.Status())
 		}
 
-		if !deadline.IsZero() && time.Now().After(deadline) {
+		if !deadline.IsZero() && c.clock.Now().After(deadline) {
 			return timing, PodReadyStateTimeout, fmt.Errorf("pod %s did not reach runtime-ready within %s", podID, timeout)
 		}
 
 		select {
 		case <-ctx.Done():
 			return timing, PodReadyStateUnknown, ctx.Err()
-		case <-time.After(interval):
+		case <-c.clock.After(interval):
 		}
 	}
 }
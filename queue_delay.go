@@ -0,0 +1,119 @@
+package runpod
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+)
+
+// executionSampleWindow is how many recent execution times the client keeps
+// per endpoint for EstimateQueueDelay.
+const executionSampleWindow = 50
+
+// ErrNoExecutionTimes is returned by EstimateQueueDelay when a new job would
+// have to wait but the client has seen no execution times for the endpoint
+// to size the wait with.
+var ErrNoExecutionTimes = errors.New("runpod: no recent execution times for endpoint")
+
+// QueueDelayEstimate is EstimateQueueDelay's result, with the inputs it was
+// computed from.
+type QueueDelayEstimate struct {
+	// Delay is how long a job submitted now is expected to wait in the
+	// queue before a worker picks it up.
+	Delay time.Duration
+	// JobsInQueue, WorkersIdle and Workers are from the endpoint's health;
+	// Workers counts idle and running workers.
+	JobsInQueue int
+	WorkersIdle int
+	Workers     int
+	// ExecutionTime is the median of the Samples recent execution times.
+	ExecutionTime time.Duration
+	Samples       int
+}
+
+// EstimateQueueDelay estimates how long a job submitted to endpointID now
+// would wait before starting. Idle workers take queued jobs first; the jobs
+// left over are shared among all workers, each taking the median recent
+// execution time. With no workers up the estimate assumes one, and cold
+// start time is not included.
+//
+// Execution times come from jobs this client has read through RunSync and
+// GetJobStatus (and so WaitForJobCompletion), plus any passed to
+// RecordExecutionTime. When a job would wait and none are known, the error
+// is ErrNoExecutionTimes; a job that would start at once gets a zero Delay
+// either way.
+func (c *Client) EstimateQueueDelay(ctx context.Context, endpointID string) (*QueueDelayEstimate, error) {
+	health, err := c.GetHealth(ctx, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	est := &QueueDelayEstimate{
+		JobsInQueue: health.JobsInQueue,
+		WorkersIdle: health.WorkersIdle,
+		Workers:     max(health.WorkersIdle+health.WorkersActive, health.WorkersTotal),
+	}
+	est.ExecutionTime, est.Samples = c.executionTimes.median(endpointID)
+
+	// The new job is the (JobsInQueue+1)th in line; idle workers start the
+	// first WorkersIdle of those immediately.
+	waiting := est.JobsInQueue + 1 - est.WorkersIdle
+	if waiting <= 0 {
+		return est, nil
+	}
+	if est.Samples == 0 {
+		return est, ErrNoExecutionTimes
+	}
+	est.Delay = time.Duration(float64(est.ExecutionTime) * float64(waiting) / float64(max(est.Workers, 1)))
+	return est, nil
+}
+
+// RecordExecutionTime adds an execution time for endpointID to the samples
+// EstimateQueueDelay uses, for jobs whose results reach the caller some
+// other way, such as a webhook. Non-positive durations are ignored.
+func (c *Client) RecordExecutionTime(endpointID string, d time.Duration) {
+	c.executionTimes.record(endpointID, d)
+}
+
+// observeJob records a finished job's execution time.
+func (c *Client) observeJob(endpointID string, job *Job) {
+	if c.IsJobTerminal(job.Status) && job.ExecutionTime > 0 {
+		c.executionTimes.record(endpointID, time.Duration(job.ExecutionTime)*time.Millisecond)
+	}
+}
+
+// executionTimes keeps the most recent execution times per endpoint. The
+// zero value is ready to use.
+type executionTimes struct {
+	mu      sync.Mutex
+	samples map[string][]time.Duration
+}
+
+func (e *executionTimes) record(endpointID string, d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.samples == nil {
+		e.samples = map[string][]time.Duration{}
+	}
+	s := append(e.samples[endpointID], d)
+	if len(s) > executionSampleWindow {
+		s = s[len(s)-executionSampleWindow:]
+	}
+	e.samples[endpointID] = s
+}
+
+func (e *executionTimes) median(endpointID string) (time.Duration, int) {
+	e.mu.Lock()
+	s := slices.Clone(e.samples[endpointID])
+	e.mu.Unlock()
+	if len(s) == 0 {
+		return 0, 0
+	}
+	slices.Sort(s)
+	return s[len(s)/2], len(s)
+}
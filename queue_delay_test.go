@@ -0,0 +1,63 @@
+package runpod_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-sdk"
+)
+
+func TestEstimateQueueDelay(t *testing.T) {
+	health := runpod.EndpointHealth{Status: "healthy"}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/ep1/health":
+			json.NewEncoder(w).Encode(health)
+		case "/v2/ep1/status/job-1":
+			json.NewEncoder(w).Encode(runpod.Job{ID: "job-1", Status: "COMPLETED", ExecutionTime: 4000})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	client := mustClient(t, "test_key", runpod.WithServerlessBaseURL(srv.URL))
+	ctx := t.Context()
+
+	// An idle worker takes the job at once, samples or not.
+	health.WorkersIdle, health.WorkersTotal = 1, 1
+	est, err := client.EstimateQueueDelay(ctx, "ep1")
+	if err != nil || est.Delay != 0 || est.Samples != 0 {
+		t.Fatalf("idle worker: %+v, %v", est, err)
+	}
+
+	health.JobsInQueue, health.WorkersIdle, health.WorkersActive, health.WorkersTotal = 3, 0, 2, 2
+	if _, err := client.EstimateQueueDelay(ctx, "ep1"); !errors.Is(err, runpod.ErrNoExecutionTimes) {
+		t.Fatalf("no samples: %v", err)
+	}
+
+	// Medians of 2s, 4s (observed through GetJobStatus) and 10s is 4s;
+	// four jobs in line over two workers wait two executions.
+	client.RecordExecutionTime("ep1", 2*time.Second)
+	if _, err := client.GetJobStatus(ctx, "ep1", "job-1"); err != nil {
+		t.Fatal(err)
+	}
+	client.RecordExecutionTime("ep1", 10*time.Second)
+	client.RecordExecutionTime("other", time.Minute)
+	est, err = client.EstimateQueueDelay(ctx, "ep1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if est.Delay != 8*time.Second || est.ExecutionTime != 4*time.Second || est.Samples != 3 || est.Workers != 2 || est.JobsInQueue != 3 {
+		t.Fatalf("estimate = %+v", est)
+	}
+
+	// With no workers up, one is assumed.
+	health.WorkersActive, health.WorkersTotal = 0, 0
+	if est, err := client.EstimateQueueDelay(ctx, "ep1"); err != nil || est.Delay != 16*time.Second {
+		t.Fatalf("no workers: %+v, %v", est, err)
+	}
+}
@@ -0,0 +1,72 @@
+package runpod
+
+import "encoding/json"
+
+// Decoded Pods, Endpoints, Jobs and Templates keep the JSON object they were
+// decoded from, so callers can read fields the SDK does not model yet and
+// log the exact API payload:
+//
+//	var extra struct {
+//		UptimeSeconds int `json:"uptimeSeconds"`
+//	}
+//	err := json.Unmarshal(pod.Raw(), &extra)
+//
+// Raw returns nil for a value built in code rather than decoded. The raw
+// JSON is kept as a string so the models stay comparable, and it is never
+// marshalled back.
+
+// Raw returns the JSON object p was decoded from, or nil.
+func (p *Pod) Raw() json.RawMessage { return rawJSON(p.raw) }
+
+// Raw returns the JSON object e was decoded from, or nil.
+func (e *Endpoint) Raw() json.RawMessage { return rawJSON(e.raw) }
+
+// Raw returns the JSON object j was decoded from, or nil.
+func (j *Job) Raw() json.RawMessage { return rawJSON(j.raw) }
+
+// Raw returns the JSON object t was decoded from, or nil.
+func (t *Template) Raw() json.RawMessage { return rawJSON(t.raw) }
+
+// UnmarshalJSON decodes p and keeps b for Raw.
+func (p *Pod) UnmarshalJSON(b []byte) error {
+	type plain Pod
+	return decodeRaw(b, (*plain)(p), &p.raw)
+}
+
+// UnmarshalJSON decodes e and keeps b for Raw.
+func (e *Endpoint) UnmarshalJSON(b []byte) error {
+	type plain Endpoint
+	return decodeRaw(b, (*plain)(e), &e.raw)
+}
+
+// UnmarshalJSON decodes j and keeps b for Raw.
+func (j *Job) UnmarshalJSON(b []byte) error {
+	type plain Job
+	return decodeRaw(b, (*plain)(j), &j.raw)
+}
+
+// UnmarshalJSON decodes t and keeps b for Raw.
+func (t *Template) UnmarshalJSON(b []byte) error {
+	type plain Template
+	return decodeRaw(b, (*plain)(t), &t.raw)
+}
+
+// decodeRaw decodes b into v, a method-less alias of the model, and copies
+// b into raw; null leaves both untouched.
+func decodeRaw(b []byte, v any, raw *string) error {
+	if string(b) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return err
+	}
+	*raw = string(b)
+	return nil
+}
+
+func rawJSON(raw string) json.RawMessage {
+	if raw == "" {
+		return nil
+	}
+	return json.RawMessage(raw)
+}
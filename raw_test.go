@@ -0,0 +1,106 @@
+package runpod_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+)
+
+func TestRawJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/pods/p1":
+			w.Write([]byte(`{"id":"p1","imageName":"acme/app:1","uptimeSeconds":42}`))
+		case "/pods":
+			w.Write([]byte(`[{"id":"p1","slsVersion":3},{"id":"p2"}]`))
+		case "/endpoints/e1":
+			w.Write([]byte(`{"id":"e1","templateId":"t1","template":{"id":"t1","boot":"fast"},"instanceIds":["i1"]}`))
+		case "/v2/e1/status/j1":
+			w.Write([]byte(`{"id":"j1","status":"COMPLETED","workerId":"w1"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	client := mustClient(t, "test_key", runpod.WithBaseURL(server.URL), runpod.WithServerlessBaseURL(server.URL))
+	ctx := context.Background()
+
+	field := func(raw json.RawMessage, key string) any {
+		t.Helper()
+		var m map[string]any
+		if err := json.Unmarshal(raw, &m); err != nil {
+			t.Fatalf("raw %q: %v", raw, err)
+		}
+		return m[key]
+	}
+
+	pod, err := client.GetPod(ctx, "p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pod.ImageName != "acme/app:1" || field(pod.Raw(), "uptimeSeconds") != 42.0 {
+		t.Fatalf("pod raw = %s", pod.Raw())
+	}
+	pods, err := client.ListPods(ctx, nil)
+	if err != nil || len(pods) != 2 || field(pods[0].Raw(), "slsVersion") != 3.0 || field(pods[1].Raw(), "id") != "p2" {
+		t.Fatalf("list raw: %v", err)
+	}
+
+	endpoint, err := client.GetEndpoint(ctx, "e1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if field(endpoint.Raw(), "instanceIds") == nil || field(endpoint.Template.Raw(), "boot") != "fast" {
+		t.Fatalf("endpoint raw = %s", endpoint.Raw())
+	}
+
+	job, err := client.GetJobStatus(ctx, "e1", "j1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if field(job.Raw(), "workerId") != "w1" {
+		t.Fatalf("job raw = %s", job.Raw())
+	}
+
+	// Raw is input only: it is neither marshalled nor set on built values.
+	data, err := json.Marshal(pod)
+	if err != nil || field(data, "uptimeSeconds") != nil {
+		t.Fatalf("marshal = %s, %v", data, err)
+	}
+	if (&runpod.Pod{ID: "p3"}).Raw() != nil {
+		t.Fatal("built pod has raw JSON")
+	}
+}
+
+func TestRawJSONCached(t *testing.T) {
+	const body = `{"id":"e1","templateId":"t1","futureField":"x"}`
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+	client := mustClient(t, "test_key", runpod.WithBaseURL(server.URL), runpod.WithResourceCache(runpod.NewResourceCache(time.Minute)))
+
+	// A cache hit returns the response as RunPod sent it, not a
+	// re-encoding of the decoded endpoint.
+	for i := range 2 {
+		endpoint, err := client.GetEndpoint(t.Context(), "e1", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(endpoint.Raw()) != body {
+			t.Fatalf("call %d: raw = %s, want %s", i, endpoint.Raw(), body)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("calls = %d, want 1", calls)
+	}
+}
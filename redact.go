@@ -0,0 +1,148 @@
+package runpod
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Redacted replaces each sensitive value the client masks.
+const Redacted = "[REDACTED]"
+
+// minRedactedLength is the shortest known value masked by its text alone;
+// shorter ones would mask unrelated digits and words.
+const minRedactedLength = 4
+
+var (
+	// sensitiveFieldRE matches a JSON string field whose name suggests a
+	// credential, e.g. "password", "apiKey", "webhookSecret" or an env
+	// entry such as "HF_TOKEN".
+	sensitiveFieldRE = regexp.MustCompile(`("[^"]*(?i:password|passwd|secret|token|api_?key|authorization|credential|private_?key)[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	// sensitiveParamRE matches a URL query parameter whose name suggests a
+	// credential, e.g. a webhook's ?token= or a presigned X-Amz-Signature.
+	sensitiveParamRE = regexp.MustCompile(`([?&][^=&\s"']*(?i:password|secret|token|signature|key|credential)[^=&\s"']*=)[^&\s"'#]+`)
+	bearerRE         = regexp.MustCompile(`(?i)(bearer\s+)[^\s"',]+`)
+)
+
+// WithRedactedValues adds values the client masks wherever it writes
+// request or response text: debug logs, error messages and Redact. Use it
+// for secrets the client cannot learn on its own, such as webhook signing
+// secrets or storage keys. The client's API keys, and the secret values and
+// registry passwords it sends, are masked without it.
+func WithRedactedValues(values ...string) ClientOption {
+	return func(c *Client) {
+		c.redactions.pin(values...)
+	}
+}
+
+// Redact masks the sensitive values in s with Redacted: the client's API
+// keys, the last 256 secret values and registry passwords it has sent,
+// values added with WithRedactedValues, string fields of JSON objects named
+// like credentials ("password", "token", "apiKey", ...), credential-like URL
+// query parameters and bearer tokens. The client applies it to its own debug
+// logs and error messages; pass HTTP dumps, generated curl commands and any
+// other request text through it before logging them. Known values shorter
+// than four characters are masked only by their field or parameter name.
+func (c *Client) Redact(s string) string {
+	return c.redactions.redact(s, c.apiKeys)
+}
+
+// maxLearnedRedactions caps the secret values and registry passwords a
+// client remembers from its own requests. Past it the oldest are dropped,
+// so a long-running client that rotates secrets does not keep every value
+// it ever sent.
+const maxLearnedRedactions = 256
+
+// redactions holds the values the client masks. The replacer built from
+// them is cached and rebuilt only when they change.
+type redactions struct {
+	mu sync.Mutex
+	// pinned are the WithRedactedValues values, kept for the client's
+	// lifetime; learned are values the client sent, oldest first.
+	pinned   map[string]struct{}
+	learned  []string
+	replacer *strings.Replacer
+	// keys is how many API keys replacer was built with.
+	keys int
+}
+
+// pin adds values that are never dropped.
+func (r *redactions) pin(values ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, v := range values {
+		if len(v) < minRedactedLength {
+			continue
+		}
+		if r.pinned == nil {
+			r.pinned = map[string]struct{}{}
+		}
+		r.pinned[v] = struct{}{}
+		r.replacer = nil
+	}
+}
+
+// add remembers values the client sent, dropping the oldest past
+// maxLearnedRedactions. A value added again becomes the newest.
+func (r *redactions) add(values ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, v := range values {
+		if len(v) < minRedactedLength {
+			continue
+		}
+		if i := slices.Index(r.learned, v); i >= 0 {
+			r.learned = slices.Delete(r.learned, i, i+1)
+		}
+		r.learned = append(r.learned, v)
+		if len(r.learned) > maxLearnedRedactions {
+			r.learned = slices.Delete(r.learned, 0, len(r.learned)-maxLearnedRedactions)
+		}
+		r.replacer = nil
+	}
+}
+
+// known returns the replacer masking every known value, or nil when there
+// are none.
+func (r *redactions) known(apiKeys []string) *strings.Replacer {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.replacer != nil && r.keys == len(apiKeys) {
+		return r.replacer
+	}
+	known := make([]string, 0, len(r.pinned)+len(r.learned)+len(apiKeys))
+	for v := range r.pinned {
+		known = append(known, v)
+	}
+	known = append(known, r.learned...)
+	for _, key := range apiKeys {
+		if len(key) >= minRedactedLength {
+			known = append(known, key)
+		}
+	}
+	if len(known) == 0 {
+		return nil
+	}
+	// Longest first, so a value containing another is masked whole.
+	sort.Slice(known, func(i, j int) bool { return len(known[i]) > len(known[j]) })
+	pairs := make([]string, 0, 2*len(known))
+	for _, v := range known {
+		pairs = append(pairs, v, Redacted)
+	}
+	r.replacer, r.keys = strings.NewReplacer(pairs...), len(apiKeys)
+	return r.replacer
+}
+
+func (r *redactions) redact(s string, apiKeys []string) string {
+	if s == "" {
+		return s
+	}
+	if replacer := r.known(apiKeys); replacer != nil {
+		s = replacer.Replace(s)
+	}
+	s = sensitiveFieldRE.ReplaceAllString(s, `${1}"`+Redacted+`"`)
+	s = sensitiveParamRE.ReplaceAllString(s, "${1}"+Redacted)
+	return bearerRE.ReplaceAllString(s, "${1}"+Redacted)
+}
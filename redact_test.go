@@ -0,0 +1,94 @@
+package runpod_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestRedaction(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	var logs bytes.Buffer
+	client, err := srv.ClientWithAPIKey("rpa_live_key",
+		runpod.WithDebug(true),
+		runpod.WithLogger(log.New(&logs, "", 0)),
+		runpod.WithRedactedValues("whsec_signing"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := t.Context()
+
+	if _, err := client.CreateSecret(ctx, &runpod.CreateSecretRequest{Name: "hf", Value: "hf_s3cr3t"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CreateContainerRegistryAuth(ctx, &runpod.CreateContainerRegistryAuthRequest{Name: "ghcr", Username: "bot", Password: "hunter22"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.RunAsync(ctx, "ep1", map[string]string{"prompt": "echo hf_s3cr3t", "HF_TOKEN": "plain"}); err != nil {
+		t.Fatal(err)
+	}
+	srv.FailNext(400, `{"message": "rejected rpa_live_key", "details": "signed with whsec_signing"}`, "")
+	_, err = client.GetPod(ctx, "p1")
+	var apiErr *runpod.APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != "rejected [REDACTED]" || apiErr.Details != "signed with [REDACTED]" {
+		t.Fatalf("GetPod error = %v", err)
+	}
+
+	out := logs.String()
+	for _, leaked := range []string{"rpa_live_key", "hf_s3cr3t", "hunter22", "whsec_signing", "plain"} {
+		if strings.Contains(out, leaked) {
+			t.Errorf("debug log leaks %q:\n%s", leaked, out)
+		}
+	}
+	if !strings.Contains(out, `"HF_TOKEN": "[REDACTED]"`) || !strings.Contains(out, `"prompt": "echo [REDACTED]"`) {
+		t.Errorf("debug log does not show the masked fields:\n%s", out)
+	}
+
+	for in, want := range map[string]string{
+		`curl -H "Authorization: Bearer rpa_other" https://hooks.example.com/x?id=1&token=abc#f`: `curl -H "Authorization: Bearer [REDACTED]" https://hooks.example.com/x?id=1&token=[REDACTED]#f`,
+		`{"apiKey":"k","name":"n"}`: `{"apiKey":"[REDACTED]","name":"n"}`,
+		"rpa_live_key-2":            "[REDACTED]-2",
+	} {
+		if got := client.Redact(in); got != want {
+			t.Errorf("Redact(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestRedactionForgetsOldValues(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient(runpod.WithRedactedValues("whsec_signing"))
+	ctx := t.Context()
+
+	create := func(name, value string) {
+		t.Helper()
+		if _, err := client.CreateSecret(ctx, &runpod.CreateSecretRequest{Name: name, Value: value}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	create("first", "first_value")
+	// Values sent after a Redact call are masked too.
+	if got := client.Redact("first_value second_value"); got != "[REDACTED] second_value" {
+		t.Fatalf("Redact = %q", got)
+	}
+	create("second", "second_value")
+	if got := client.Redact("first_value second_value"); got != "[REDACTED] [REDACTED]" {
+		t.Fatalf("Redact = %q", got)
+	}
+
+	for i := 0; i < 256; i++ {
+		create(fmt.Sprintf("s%d", i), fmt.Sprintf("rotated_%03d", i))
+	}
+	if got := client.Redact("first_value rotated_255 whsec_signing"); got != "first_value [REDACTED] [REDACTED]" {
+		t.Fatalf("Redact after 256 more values = %q", got)
+	}
+}
@@ -18,6 +18,9 @@ func (c *Client) ListContainerRegistryAuths(ctx context.Context) ([]ContainerReg
 	if len(raw) == 0 {
 		return nil, nil
 	}
+	if err := c.checkListShape(raw, []ContainerRegistryAuth(nil)); err != nil {
+		return nil, fmt.Errorf("failed to list container registry auths: %w", err)
+	}
 
 	var auths []ContainerRegistryAuth
 	if err := json.Unmarshal(raw, &auths); err == nil {
@@ -34,21 +37,56 @@ func (c *Client) ListContainerRegistryAuths(ctx context.Context) ([]ContainerReg
 	return nil, fmt.Errorf("failed to list container registry auths: unexpected response shape")
 }
 
-// CreateContainerRegistryAuth stores a Docker registry credential. Names must
-// be unique per account.
-func (c *Client) CreateContainerRegistryAuth(ctx context.Context, req *CreateContainerRegistryAuthRequest) (*ContainerRegistryAuth, error) {
+// GetContainerRegistryAuth retrieves a stored registry credential by ID. The
+// password is never returned.
+func (c *Client) GetContainerRegistryAuth(ctx context.Context, authID string) (*ContainerRegistryAuth, error) {
+	if err := c.validateRequired("authID", authID); err != nil {
+		return nil, err
+	}
+
+	var auth ContainerRegistryAuth
+	if err := c.Get(ctx, "/containerregistryauth/"+authID, &auth); err != nil {
+		return nil, fmt.Errorf("failed to get container registry auth %s: %w", authID, err)
+	}
+	return &auth, nil
+}
+
+// EnsureContainerRegistryAuth returns the stored credential named req.Name,
+// creating it when absent, so callers can idempotently obtain an ID to set
+// on CreateTemplateRequest.ContainerRegistryAuthID or
+// CreatePodRequest.ContainerRegistryAuthId. RunPod cannot update a stored
+// password: rotate by deleting the old credential and ensuring a new name.
+func (c *Client) EnsureContainerRegistryAuth(ctx context.Context, req *CreateContainerRegistryAuthRequest) (*ContainerRegistryAuth, error) {
 	if req == nil {
 		return nil, NewValidationError("request", "cannot be nil")
 	}
 	if err := c.validateRequired("name", req.Name); err != nil {
 		return nil, err
 	}
-	if err := c.validateRequired("username", req.Username); err != nil {
+
+	auths, err := c.ListContainerRegistryAuths(ctx)
+	if err != nil {
 		return nil, err
 	}
-	if err := c.validateRequired("password", req.Password); err != nil {
+	for _, auth := range auths {
+		if auth.Name == req.Name {
+			found := auth
+			return &found, nil
+		}
+	}
+	return c.CreateContainerRegistryAuth(ctx, req)
+}
+
+// CreateContainerRegistryAuth stores a Docker registry credential. Names must
+// be unique per account.
+func (c *Client) CreateContainerRegistryAuth(ctx context.Context, req *CreateContainerRegistryAuthRequest) (*ContainerRegistryAuth, error) {
+	if req == nil {
+		return nil, NewValidationError("request", "cannot be nil")
+	}
+	if err := c.validateRequest(req); err != nil {
 		return nil, err
 	}
+	c.redactions.add(req.Password)
 
 	var auth ContainerRegistryAuth
 	if err := c.Post(ctx, "/containerregistryauth", req, &auth); err != nil {
@@ -112,3 +150,16 @@ func IsRegistryAuthError(err error) bool {
 	}
 	return false
 }
+
+// Validate checks the request. It returns a *ValidationError, or a
+// *ValidationErrors when it finds more than one problem.
+func (req *CreateContainerRegistryAuthRequest) Validate() error {
+	if req == nil {
+		return nilRequest()
+	}
+	var p problems
+	p.add(validateRequired("name", req.Name))
+	p.add(validateRequired("username", req.Username))
+	p.add(validateRequired("password", req.Password))
+	return p.err()
+}
@@ -28,6 +28,8 @@ func newRegistryAuthServer(t *testing.T) *httptest.Server {
 				t.Fatalf("credentials not marshalled: %+v", req)
 			}
 			json.NewEncoder(w).Encode(runpod.ContainerRegistryAuth{ID: "auth2", Name: req.Name})
+		case r.Method == "GET" && r.URL.Path == "/containerregistryauth/auth1":
+			w.Write([]byte(`{"id":"auth1","name":"gen-orchestrator:docker.io:bob"}`))
 		case r.Method == "DELETE" && r.URL.Path == "/containerregistryauth/auth1":
 			w.WriteHeader(http.StatusNoContent)
 		default:
@@ -61,6 +63,34 @@ func TestContainerRegistryAuthCRUD(t *testing.T) {
 		t.Fatalf("unexpected create result %+v", created)
 	}
 
+	got, err := client.GetContainerRegistryAuth(ctx, "auth1")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got.Name != "gen-orchestrator:docker.io:bob" {
+		t.Fatalf("unexpected get result %+v", got)
+	}
+
+	// Ensure returns the existing credential by name without creating.
+	existing, err := client.EnsureContainerRegistryAuth(ctx, &runpod.CreateContainerRegistryAuthRequest{
+		Name: "gen-orchestrator:docker.io:bob", Username: "bob", Password: "tok",
+	})
+	if err != nil {
+		t.Fatalf("ensure existing: %v", err)
+	}
+	if existing.ID != "auth1" {
+		t.Fatalf("ensure must reuse the existing auth, got %+v", existing)
+	}
+	ensured, err := client.EnsureContainerRegistryAuth(ctx, &runpod.CreateContainerRegistryAuthRequest{
+		Name: "gen-orchestrator:docker.io:carol", Username: "carol", Password: "tok",
+	})
+	if err != nil {
+		t.Fatalf("ensure new: %v", err)
+	}
+	if ensured.ID != "auth2" {
+		t.Fatalf("ensure must create a missing auth, got %+v", ensured)
+	}
+
 	if err := client.DeleteContainerRegistryAuth(ctx, "auth1"); err != nil {
 		t.Fatalf("delete: %v", err)
 	}
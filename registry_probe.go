@@ -0,0 +1,295 @@
+package runpod
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// dockerHubRegistry is the registry API host behind "docker.io" refs.
+const dockerHubRegistry = "registry-1.docker.io"
+
+// manifestAcceptTypes covers single-platform and multi-platform manifests in
+// both Docker and OCI formats; registries answer 404 for a tag whose only
+// manifest type was not accepted.
+var manifestAcceptTypes = strings.Join([]string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}, ", ")
+
+// RegistryCredentials are pull credentials for one registry. RunPod never
+// returns stored registry auth passwords, so the caller supplies the same
+// username / token it registered with CreateContainerRegistryAuth.
+type RegistryCredentials struct {
+	Username string
+	Password string
+}
+
+// RegistryProbeOptions configures NewRegistryProbe.
+type RegistryProbeOptions struct {
+	// Credentials are keyed by registry host as written in image refs
+	// ("ghcr.io", "docker.io", "registry.example.com:5000"). Images on
+	// registries without an entry are probed anonymously.
+	Credentials map[string]RegistryCredentials
+	// HTTPClient overrides the HTTP client (default: 15s timeout).
+	HTTPClient *http.Client
+}
+
+// imageRef is a parsed image reference.
+type imageRef struct {
+	Registry   string // API host, e.g. "registry-1.docker.io"
+	Host       string // host as written (credentials key), e.g. "docker.io"
+	Repository string // e.g. "library/ubuntu"
+	Reference  string // tag or digest
+}
+
+// parseImageRef parses "ubuntu", "acme/worker:1.0", "ghcr.io/acme/worker@sha256:..."
+// and registry-with-port refs using Docker's rules: the first path component
+// is a registry host only if it contains "." or ":" or is "localhost".
+func parseImageRef(ref string) (*imageRef, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || strings.ContainsAny(ref, " \t") {
+		return nil, fmt.Errorf("invalid image reference %q", ref)
+	}
+
+	out := &imageRef{Host: "docker.io", Registry: dockerHubRegistry}
+	if i := strings.Index(ref, "/"); i > 0 {
+		first := ref[:i]
+		if strings.ContainsAny(first, ".:") || first == "localhost" {
+			out.Host, out.Registry = first, first
+			if first == "docker.io" || first == "index.docker.io" {
+				out.Registry = dockerHubRegistry
+			}
+			ref = ref[i+1:]
+		}
+	}
+
+	if i := strings.Index(ref, "@"); i >= 0 {
+		out.Repository, out.Reference = ref[:i], ref[i+1:]
+	} else {
+		repo, tag := splitImageTag(ref)
+		if tag == "" {
+			tag = "latest"
+		}
+		out.Repository, out.Reference = repo, tag
+	}
+	if out.Repository == "" || out.Reference == "" || strings.HasSuffix(out.Repository, "/") {
+		return nil, fmt.Errorf("invalid image reference %q", ref)
+	}
+	if out.Registry == dockerHubRegistry && !strings.Contains(out.Repository, "/") {
+		out.Repository = "library/" + out.Repository
+	}
+	return out, nil
+}
+
+// NewRegistryProbe returns a RegistryProbeFunc that checks an image
+// reference against its registry's manifests API (Docker Registry HTTP API
+// v2, as served by Docker Hub, GHCR, ECR, GCR and self-hosted registries),
+// handling bearer-token and basic auth challenges.
+//
+// Registries commonly answer "unauthorized" rather than "not found" for a
+// repository that does not exist, so without credentials a missing image
+// may be reported as RegistryProbeUnauthorized.
+func NewRegistryProbe(opts *RegistryProbeOptions) RegistryProbeFunc {
+	httpClient := &http.Client{Timeout: 15 * time.Second}
+	var creds map[string]RegistryCredentials
+	if opts != nil {
+		if opts.HTTPClient != nil {
+			httpClient = opts.HTTPClient
+		}
+		creds = opts.Credentials
+	}
+
+	return func(ctx context.Context, ref string) (string, string) {
+		image, err := parseImageRef(ref)
+		if err != nil {
+			return RegistryProbeInvalidRef, err.Error()
+		}
+		var cred *RegistryCredentials
+		if c, ok := creds[image.Host]; ok {
+			cred = &c
+		}
+
+		manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", image.Registry, image.Repository, image.Reference)
+		resp, err := probeManifest(ctx, httpClient, manifestURL, "")
+		if err != nil {
+			return RegistryProbeUnreachable, err.Error()
+		}
+		if resp.StatusCode == http.StatusUnauthorized {
+			authorization, err := registryAuthorization(ctx, httpClient, resp.Header.Get("WWW-Authenticate"), cred)
+			if err != nil {
+				return RegistryProbeUnreachable, err.Error()
+			}
+			if authorization != "" {
+				if resp, err = probeManifest(ctx, httpClient, manifestURL, authorization); err != nil {
+					return RegistryProbeUnreachable, err.Error()
+				}
+			}
+		}
+
+		switch resp.StatusCode {
+		case http.StatusOK:
+			return RegistryProbeOK, ""
+		case http.StatusNotFound:
+			return RegistryProbeNotFound, fmt.Sprintf("%s:%s not found on %s", image.Repository, image.Reference, image.Host)
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return RegistryProbeUnauthorized, fmt.Sprintf("%s denied access to %s (HTTP %d)", image.Host, image.Repository, resp.StatusCode)
+		default:
+			return RegistryProbeUnreachable, fmt.Sprintf("%s returned HTTP %d", image.Host, resp.StatusCode)
+		}
+	}
+}
+
+// probeManifest issues HEAD for the manifest and closes the body.
+func probeManifest(ctx context.Context, httpClient *http.Client, manifestURL, authorization string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", manifestAcceptTypes)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// registryAuthorization answers a WWW-Authenticate challenge. Bearer
+// challenges fetch a token from the realm (with basic credentials when
+// present); Basic challenges use the credentials directly. Returns "" when
+// the challenge cannot be answered.
+func registryAuthorization(ctx context.Context, httpClient *http.Client, challenge string, cred *RegistryCredentials) (string, error) {
+	scheme, params := parseAuthChallenge(challenge)
+	switch scheme {
+	case "basic":
+		if cred == nil {
+			return "", nil
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(cred.Username+":"+cred.Password)), nil
+
+	case "bearer":
+		realm := params["realm"]
+		if realm == "" {
+			return "", nil
+		}
+		tokenURL, err := url.Parse(realm)
+		if err != nil {
+			return "", fmt.Errorf("invalid token realm %q: %w", realm, err)
+		}
+		q := tokenURL.Query()
+		for _, key := range []string{"service", "scope"} {
+			if v := params[key]; v != "" {
+				q.Set(key, v)
+			}
+		}
+		tokenURL.RawQuery = q.Encode()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL.String(), nil)
+		if err != nil {
+			return "", err
+		}
+		if cred != nil {
+			req.SetBasicAuth(cred.Username, cred.Password)
+		}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+			return "", nil // credentials rejected; the manifest request stays 401
+		}
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("token endpoint returned HTTP %d", resp.StatusCode)
+		}
+		var token struct {
+			Token       string `json:"token"`
+			AccessToken string `json:"access_token"`
+		}
+		if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&token); err != nil {
+			return "", fmt.Errorf("failed to decode registry token: %w", err)
+		}
+		if token.Token == "" {
+			token.Token = token.AccessToken
+		}
+		if token.Token == "" {
+			return "", nil
+		}
+		return "Bearer " + token.Token, nil
+	}
+	return "", nil
+}
+
+// parseAuthChallenge parses `Bearer realm="...",service="...",scope="..."`.
+func parseAuthChallenge(challenge string) (scheme string, params map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params = map[string]string{}
+	for rest != "" {
+		var pair string
+		rest = strings.TrimLeft(rest, ", ")
+		key, value, ok := strings.Cut(rest, "=")
+		if !ok {
+			break
+		}
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				break
+			}
+			pair, rest = value[1:end+1], value[end+2:]
+		} else {
+			pair, rest, _ = strings.Cut(value, ",")
+		}
+		params[strings.ToLower(strings.TrimSpace(key))] = pair
+	}
+	return strings.ToLower(scheme), params
+}
+
+// WithImageCheck makes CreateTemplate, UpdateTemplate, CreatePod and
+// CreateEndpoint verify the referenced image exists before calling RunPod,
+// turning a worker stuck pulling a bad tag into an immediate
+// *ValidationError. Use NewRegistryProbe for a registry-backed probe. An
+// unreachable registry does not block the request.
+func WithImageCheck(probe RegistryProbeFunc) ClientOption {
+	return func(c *Client) {
+		c.imageCheck = probe
+	}
+}
+
+// checkImage runs the configured image check for field's image ref.
+func (c *Client) checkImage(ctx context.Context, field, ref string) error {
+	if c.imageCheck == nil || strings.TrimSpace(ref) == "" {
+		return nil
+	}
+	outcome, detail := c.imageCheck(ctx, ref)
+	var msg string
+	switch outcome {
+	case RegistryProbeNotFound:
+		msg = "image not found in registry"
+	case RegistryProbeUnauthorized:
+		msg = "registry denied access to image (missing or wrong credentials, or the repository does not exist)"
+	case RegistryProbeInvalidRef:
+		msg = "invalid image reference"
+	default:
+		if outcome == RegistryProbeUnreachable && c.debug {
+			c.logger.Printf("[DEBUG] Image check for %s skipped: %s", ref, detail)
+		}
+		return nil
+	}
+	if detail != "" {
+		msg += ": " + detail
+	}
+	return NewValidationErrorWithValue(field, msg, ref)
+}
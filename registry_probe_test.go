@@ -0,0 +1,111 @@
+package runpod_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+)
+
+// newFakeRegistry serves acme/worker:1.0 behind bearer-token auth; only
+// user:secret gets a token for the private acme/private repository.
+func newFakeRegistry(t *testing.T) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			scope := r.URL.Query().Get("scope")
+			user, pass, ok := r.BasicAuth()
+			if strings.Contains(scope, "acme/private") && !(ok && user == "user" && pass == "secret") {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"token":"tok:` + scope + `"}`))
+		case strings.HasPrefix(r.URL.Path, "/v2/"):
+			if r.Method != http.MethodHead || !strings.Contains(r.Header.Get("Accept"), "manifest.list.v2+json") {
+				t.Errorf("unexpected manifest request %s accept=%q", r.Method, r.Header.Get("Accept"))
+			}
+			repo, ref, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/v2/"), "/manifests/")
+			scope := "repository:" + repo + ":pull"
+			if r.Header.Get("Authorization") != "Bearer tok:"+scope {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="`+server.URL+`/token",service="fake",scope="`+scope+`"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if ref != "1.0" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server
+}
+
+func TestRegistryProbe(t *testing.T) {
+	registry := newFakeRegistry(t)
+	defer registry.Close()
+	host := strings.TrimPrefix(registry.URL, "https://")
+	ctx := context.Background()
+
+	anonymous := runpod.NewRegistryProbe(&runpod.RegistryProbeOptions{HTTPClient: registry.Client()})
+	authed := runpod.NewRegistryProbe(&runpod.RegistryProbeOptions{
+		HTTPClient:  registry.Client(),
+		Credentials: map[string]runpod.RegistryCredentials{host: {Username: "user", Password: "secret"}},
+	})
+
+	cases := []struct {
+		probe runpod.RegistryProbeFunc
+		ref   string
+		want  string
+	}{
+		{anonymous, host + "/acme/worker:1.0", runpod.RegistryProbeOK},
+		{anonymous, host + "/acme/worker:2.0", runpod.RegistryProbeNotFound},
+		{anonymous, host + "/acme/private:1.0", runpod.RegistryProbeUnauthorized},
+		{authed, host + "/acme/private:1.0", runpod.RegistryProbeOK},
+		{anonymous, "bad ref", runpod.RegistryProbeInvalidRef},
+		{anonymous, "127.0.0.1:1/acme/worker:1.0", runpod.RegistryProbeUnreachable},
+	}
+	for _, tc := range cases {
+		if got, detail := tc.probe(ctx, tc.ref); got != tc.want {
+			t.Errorf("probe(%q) = %s (%s), want %s", tc.ref, got, detail, tc.want)
+		}
+	}
+}
+
+func TestWithImageCheck(t *testing.T) {
+	registry := newFakeRegistry(t)
+	defer registry.Close()
+	host := strings.TrimPrefix(registry.URL, "https://")
+
+	var created int
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		created++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"tpl1","name":"worker"}`))
+	}))
+	defer api.Close()
+
+	probe := runpod.NewRegistryProbe(&runpod.RegistryProbeOptions{HTTPClient: registry.Client()})
+	client := mustClient(t, "test_key", runpod.WithBaseURL(api.URL), runpod.WithImageCheck(probe))
+	ctx := context.Background()
+
+	_, err := client.CreateTemplate(ctx, &runpod.CreateTemplateRequest{Name: "worker", ImageName: host + "/acme/worker:9.9"})
+	var valErr *runpod.ValidationError
+	if !errors.As(err, &valErr) || valErr.Field != "imageName" || created != 0 {
+		t.Fatalf("missing tag must fail before calling RunPod: err=%v created=%d", err, created)
+	}
+	if _, err := client.CreateTemplate(ctx, &runpod.CreateTemplateRequest{Name: "worker", ImageName: host + "/acme/worker:1.0"}); err != nil || created != 1 {
+		t.Fatalf("existing image: err=%v created=%d", err, created)
+	}
+	if _, err := client.CreatePod(ctx, &runpod.CreatePodRequest{Name: "p", ImageName: host + "/acme/private:1.0", GPUTypeIDs: []string{"NVIDIA A40"}, GPUCount: 1, ContainerDiskInGB: 10}); !errors.As(err, &valErr) || created != 1 {
+		t.Fatalf("unauthorized image must fail before calling RunPod: err=%v created=%d", err, created)
+	}
+}
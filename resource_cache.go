@@ -0,0 +1,115 @@
+package runpod
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DefaultResourceCacheTTL is the ResourceCache TTL used when
+// NewResourceCache is given a non-positive duration.
+const DefaultResourceCacheTTL = time.Minute
+
+// ResourceCache caches GetTemplate and GetEndpoint responses, for request
+// paths that read the same template or endpoint over and over. Updates and
+// deletes made through a client using the cache invalidate the affected
+// entries; changes made any other way (the console, another process) show
+// up once the TTL expires, or after Invalidate. Endpoints read with
+// IncludeWorkers are cached too, so their worker lists are as old as the
+// TTL allows.
+//
+// A ResourceCache is safe for concurrent use and may be shared by clients
+// talking to the same account.
+type ResourceCache struct {
+	cache *ttlCache[resourceKey]
+}
+
+type resourceKey struct {
+	kind string // "template" or "endpoint"
+	id   string
+	opts GetEndpointOptions
+}
+
+// NewResourceCache returns an empty cache whose entries live for ttl
+// (DefaultResourceCacheTTL when ttl <= 0).
+func NewResourceCache(ttl time.Duration) *ResourceCache {
+	if ttl <= 0 {
+		ttl = DefaultResourceCacheTTL
+	}
+	return &ResourceCache{cache: newTTLCache[resourceKey](ttl)}
+}
+
+// TTL returns how long a template or endpoint is served from the cache.
+func (rc *ResourceCache) TTL() time.Duration {
+	return rc.cache.ttl
+}
+
+// Invalidate forgets every template and endpoint.
+func (rc *ResourceCache) Invalidate() {
+	rc.cache.invalidate(nil)
+}
+
+// InvalidateTemplate drops the cached template, and every endpoint read
+// with IncludeTemplate, which may embed it.
+func (rc *ResourceCache) InvalidateTemplate(templateID string) {
+	rc.cache.invalidate(func(key resourceKey) bool {
+		return (key.kind == "template" && key.id == templateID) || (key.kind == "endpoint" && key.opts.IncludeTemplate)
+	})
+}
+
+// InvalidateEndpoint drops the cached endpoint, however it was read.
+func (rc *ResourceCache) InvalidateEndpoint(endpointID string) {
+	rc.cache.invalidate(func(key resourceKey) bool {
+		return key.kind == "endpoint" && key.id == endpointID
+	})
+}
+
+// WithResourceCache caches GetTemplate and GetEndpoint (and the helpers
+// built on them) in cache. Without it every call queries RunPod.
+func WithResourceCache(cache *ResourceCache) ClientOption {
+	return func(c *Client) {
+		c.resources = cache
+	}
+}
+
+// ResourceCache returns the client's template and endpoint cache, or nil
+// when caching is disabled.
+func (c *Client) ResourceCache() *ResourceCache {
+	return c.resources
+}
+
+// cachedGet reads endpoint into result through the resource cache when one
+// is configured. Response bodies are cached as received and decoded per
+// call, so callers never share a cached value and Raw keeps the fields the
+// SDK does not model; failed reads are not cached, and neither is a read
+// that an update through the client invalidated while it was in flight.
+func (c *Client) cachedGet(ctx context.Context, key resourceKey, endpoint string, result interface{}) error {
+	if c.resources == nil {
+		return c.Get(ctx, endpoint, result)
+	}
+	if data, ok := c.resources.cache.get(key, c.clock.Now()); ok {
+		if c.debug {
+			c.logger.Printf("[DEBUG] Resource cache hit: %s %s", key.kind, key.id)
+		}
+		return json.Unmarshal(data, result)
+	}
+
+	gen := c.resources.cache.begin(key)
+	defer c.resources.cache.done(key)
+	var body json.RawMessage
+	if err := c.Get(ctx, endpoint, &body); err != nil {
+		return err
+	}
+	if len(body) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if err := c.checkShape(body, result); err != nil {
+		return err
+	}
+	c.resources.cache.put(key, gen, body, c.clock.Now())
+	return nil
+}
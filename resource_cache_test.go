@@ -0,0 +1,70 @@
+package runpod_test
+
+import (
+	"testing"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestResourceCache(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	clock := runpodtest.NewClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	cache := runpod.NewResourceCache(time.Minute)
+	client := srv.MustClient(runpod.WithClock(clock), runpod.WithResourceCache(cache))
+	ctx := t.Context()
+
+	srv.AddTemplate(&runpod.Template{ID: "t1", Name: "worker", ImageName: "worker:1", IsServerless: true})
+	srv.AddEndpoint(&runpod.Endpoint{ID: "e1", Name: "sd", TemplateID: "t1", WorkersMax: 1})
+
+	tpl, err := client.GetTemplate(ctx, "t1")
+	if err != nil || tpl.ImageName != "worker:1" {
+		t.Fatalf("GetTemplate = %+v, %v", tpl, err)
+	}
+	tpl.ImageName = "mutated" // results must not share cached state
+	if _, err := client.GetEndpoint(ctx, "e1", &runpod.GetEndpointOptions{IncludeTemplate: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Changes made behind the client's back are not seen until the TTL
+	// expires.
+	srv.AddTemplate(&runpod.Template{ID: "t1", Name: "worker", ImageName: "worker:2", IsServerless: true})
+	if tpl, err := client.GetTemplate(ctx, "t1"); err != nil || tpl.ImageName != "worker:1" {
+		t.Fatalf("cached GetTemplate = %+v, %v", tpl, err)
+	}
+	clock.Advance(61 * time.Second)
+	if tpl, err := client.GetTemplate(ctx, "t1"); err != nil || tpl.ImageName != "worker:2" {
+		t.Fatalf("GetTemplate after TTL = %+v, %v", tpl, err)
+	}
+
+	// Updates through the client invalidate at once, including endpoints
+	// that embed the template.
+	if _, err := client.GetEndpoint(ctx, "e1", &runpod.GetEndpointOptions{IncludeTemplate: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.UpdateTemplate(ctx, "t1", &runpod.UpdateTemplateRequest{ImageName: runpod.Ptr("worker:3")}); err != nil {
+		t.Fatal(err)
+	}
+	if tpl, err := client.GetTemplate(ctx, "t1"); err != nil || tpl.ImageName != "worker:3" {
+		t.Fatalf("GetTemplate after update = %+v, %v", tpl, err)
+	}
+	ep, err := client.GetEndpoint(ctx, "e1", &runpod.GetEndpointOptions{IncludeTemplate: true})
+	if err != nil || ep.Template == nil || ep.Template.ImageName != "worker:3" {
+		t.Fatalf("GetEndpoint after template update = %+v, %v", ep, err)
+	}
+
+	if _, err := client.GetEndpoint(ctx, "e1", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.UpdateEndpoint(ctx, "e1", &runpod.UpdateEndpointRequest{WorkersMax: runpod.Ptr(4)}); err != nil {
+		t.Fatal(err)
+	}
+	if ep, err := client.GetEndpoint(ctx, "e1", nil); err != nil || ep.WorkersMax != 4 {
+		t.Fatalf("GetEndpoint after update = %+v, %v", ep, err)
+	}
+	if client.ResourceCache() != cache {
+		t.Fatal("ResourceCache() did not return the configured cache")
+	}
+}
@@ -0,0 +1,97 @@
+package runpodtest
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+)
+
+// Clock is a fake runpod.Clock whose time moves only when Advance is
+// called, so tests of polling helpers run without real sleeps:
+//
+//	clock := runpodtest.NewClock(time.Now())
+//	client := srv.MustClient(runpod.WithClock(clock))
+//	go func() { done <- client.WaitForJobCompletion(ctx, ep, id, time.Minute) }()
+//	clock.BlockUntil(1)           // the helper is waiting for its next poll
+//	clock.Advance(5 * time.Second) // ... which happens now
+//
+// A Clock is safe for concurrent use.
+type Clock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []clockWaiter
+}
+
+type clockWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+var _ runpod.Clock = (*Clock)(nil)
+
+// NewClock returns a Clock reading start.
+func NewClock(start time.Time) *Clock {
+	c := &Clock{now: start}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now returns the clock's current time.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel that receives the clock's time once Advance has
+// moved it d past now. A non-positive d fires at once.
+func (c *Clock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, clockWaiter{at: c.now.Add(d), ch: ch})
+	c.cond.Broadcast()
+	return ch
+}
+
+// Advance moves the clock forward by d and fires every After whose time
+// has come, earliest first.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	sort.SliceStable(c.waiters, func(i, j int) bool { return c.waiters[i].at.Before(c.waiters[j].at) })
+	n := 0
+	for _, w := range c.waiters {
+		if w.at.After(c.now) {
+			break
+		}
+		w.ch <- c.now
+		n++
+	}
+	c.waiters = c.waiters[n:]
+}
+
+// Waiters reports how many After channels have not fired yet.
+func (c *Clock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+// BlockUntil blocks until at least n After channels are waiting to fire,
+// so a test advances the clock only once the code under test is asleep.
+func (c *Clock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
@@ -1,9 +1,10 @@
 // Package runpodtest provides an in-process fake RunPod API server for
 // consumer tests. It implements the endpoints the SDK covers — pods CRUD
 // with per-GPU-type stock-out injection, network volumes, container registry
-// auths, the serverless job lifecycle, and GraphQL gpuTypes/pod lifecycle
-// queries — plus
-// one-shot fault injection (429/500) for retry-path testing.
+// auths, templates, secrets, serverless endpoints, the serverless job
+// lifecycle (including long-poll status), and GraphQL gpuTypes/dataCenters/cpuFlavors/pod
+// lifecycle queries — plus one-shot fault injection (429/500) for
+// retry-path testing.
 //
 // Usage:
 //
@@ -19,9 +20,13 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	runpod "github.com/cozy-creator/runpod-go-sdk"
 )
@@ -47,15 +52,30 @@ type Server struct {
 	pods      map[string]*runpod.Pod
 	volumes   map[string]*runpod.NetworkVolume
 	auths     map[string]*runpod.ContainerRegistryAuth
-	jobs      map[string]*fakeJob // key: endpointID + "/" + jobID
-	stockOut  map[string]bool     // GPU type ID -> out of stock
+	templates map[string]*runpod.Template
+	endpoints map[string]*runpod.Endpoint
+	secrets   map[string]*fakeSecret // key: secret name
+	jobs      map[string]*fakeJob    // key: endpointID + "/" + jobID
+	stockOut  map[string]bool        // GPU type ID -> out of stock
 	gpuTypes  []runpod.GPUType
+	dcs       []runpod.DataCenter
+	cpus      []runpod.CPUFlavor
 	lifecycle map[string]*runpod.PodLifecycleObservation
-	accountID string
+	account   runpod.AccountInfo
+	notify    runpod.NotificationSettings
+	team      []runpod.TeamMember
+	audit     []runpod.AuditLogEntry
+	revoked   map[string]bool // API key -> 401 everywhere
+	scoped    map[string]bool // API key -> 403 on GraphQL
 	authz     []string
 	faults    []fault // queued one-shot injected responses
 }
 
+type fakeSecret struct {
+	secret runpod.Secret
+	value  string
+}
+
 type fakeJob struct {
 	ID         string          `json:"id"`
 	Status     string          `json:"status"`
@@ -71,10 +91,16 @@ func New() *Server {
 		pods:      map[string]*runpod.Pod{},
 		volumes:   map[string]*runpod.NetworkVolume{},
 		auths:     map[string]*runpod.ContainerRegistryAuth{},
+		templates: map[string]*runpod.Template{},
+		endpoints: map[string]*runpod.Endpoint{},
+		secrets:   map[string]*fakeSecret{},
 		jobs:      map[string]*fakeJob{},
 		stockOut:  map[string]bool{},
 		lifecycle: map[string]*runpod.PodLifecycleObservation{},
-		accountID: "runpodtest-account",
+		account:   runpod.AccountInfo{ID: "runpodtest-account", Email: "runpodtest@example.com", ClientBalance: 100},
+		notify:    runpod.NotificationSettings{LowBalance: true, LowBalanceThreshold: 10, PodEvents: true},
+		revoked:   map[string]bool{},
+		scoped:    map[string]bool{},
 	}
 	// Default GPU catalog for gpuTypes queries; override with SetGPUTypes.
 	for _, spec := range runpod.GPUCatalog() {
@@ -91,6 +117,17 @@ func New() *Server {
 			},
 		})
 	}
+	// Default CPU flavors mirror the static family catalog.
+	for _, family := range runpod.CPUFamilies() {
+		s.cpus = append(s.cpus, runpod.CPUFlavor{
+			ID:          family.ID,
+			GroupID:     family.ID,
+			GroupName:   family.Family,
+			DisplayName: family.Description,
+			MinVCPU:     2,
+			MaxVCPU:     32,
+		})
+	}
 	s.httpServer = httptest.NewServer(http.HandlerFunc(s.handle))
 	return s
 }
@@ -154,12 +191,82 @@ func (s *Server) SetGPUTypes(types []runpod.GPUType) {
 	s.gpuTypes = append([]runpod.GPUType(nil), types...)
 }
 
+// SetDataCenters replaces the inventory served to GraphQL dataCenters
+// queries (empty by default). The availability input is not applied.
+func (s *Server) SetDataCenters(dataCenters []runpod.DataCenter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dcs = append([]runpod.DataCenter(nil), dataCenters...)
+}
+
+// SetCPUFlavors replaces the catalog served to GraphQL cpuFlavors queries.
+func (s *Server) SetCPUFlavors(flavors []runpod.CPUFlavor) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cpus = append([]runpod.CPUFlavor(nil), flavors...)
+}
+
 // SetAccountID replaces the stable ID returned by the authenticated
 // `myself` GraphQL query.
 func (s *Server) SetAccountID(accountID string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.accountID = accountID
+	s.account.ID = accountID
+}
+
+// SetAccount replaces the account returned by the `myself` GraphQL query.
+func (s *Server) SetAccount(account runpod.AccountInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.account = account
+}
+
+// NotificationSettings returns the fake account's notification settings.
+func (s *Server) NotificationSettings() runpod.NotificationSettings {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.notify
+}
+
+// userLocked renders the `myself` object: account fields plus
+// notification settings. Callers hold s.mu.
+func (s *Server) userLocked() map[string]interface{} {
+	user := map[string]interface{}{}
+	for _, v := range []interface{}{s.account, s.notify} {
+		raw, _ := json.Marshal(v)
+		_ = json.Unmarshal(raw, &user)
+	}
+	return user
+}
+
+// AddTeamMember seeds a team member or pending invitation.
+func (s *Server) AddTeamMember(member runpod.TeamMember) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.team = append(s.team, member)
+}
+
+// AddAuditLogEntries seeds audit log events. ListAuditLogs serves them
+// newest first.
+func (s *Server) AddAuditLogEntries(entries ...runpod.AuditLogEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.audit = append(s.audit, entries...)
+}
+
+// RevokeAPIKey makes every request authenticated with apiKey fail with 401.
+func (s *Server) RevokeAPIKey(apiKey string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.revoked[apiKey] = true
+}
+
+// RestrictAPIKey makes GraphQL requests authenticated with apiKey fail with
+// 403, like a restricted-scope key; REST and serverless routes still work.
+func (s *Server) RestrictAPIKey(apiKey string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scoped[apiKey] = true
 }
 
 // AuthorizationHeaders returns the credentials observed by the fake in
@@ -213,6 +320,66 @@ func (s *Server) SetPodLifecycleObservation(observation *runpod.PodLifecycleObse
 	s.lifecycle[observation.PodID] = &copy
 }
 
+// AddTemplate seeds a template into the fake state.
+func (s *Server) AddTemplate(template *runpod.Template) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	copy := *template
+	s.templates[copy.ID] = &copy
+}
+
+// Template returns a copy of a seeded/created template, or nil.
+func (s *Server) Template(id string) *runpod.Template {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	template := s.templates[id]
+	if template == nil {
+		return nil
+	}
+	copy := *template
+	return &copy
+}
+
+// AddEndpoint seeds a serverless endpoint into the fake state.
+func (s *Server) AddEndpoint(endpoint *runpod.Endpoint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	copy := *endpoint
+	s.endpoints[copy.ID] = &copy
+}
+
+// Endpoint returns a copy of a seeded/created endpoint, or nil. Version
+// increments on every update, so tests can assert a release was started.
+func (s *Server) Endpoint(id string) *runpod.Endpoint {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	endpoint := s.endpoints[id]
+	if endpoint == nil {
+		return nil
+	}
+	copy := *endpoint
+	return &copy
+}
+
+// AddSecret seeds a secret into the fake state.
+func (s *Server) AddSecret(name, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.secrets[name] = &fakeSecret{secret: runpod.Secret{ID: s.newID("secret"), Name: name}, value: value}
+}
+
+// SecretValue returns the stored value of a secret. The API never returns
+// values; this accessor exists so tests can assert on writes.
+func (s *Server) SecretValue(name string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	secret := s.secrets[name]
+	if secret == nil {
+		return "", false
+	}
+	return secret.value, true
+}
+
 // Pod returns a seeded/created pod by ID, or nil.
 func (s *Server) Pod(id string) *runpod.Pod {
 	s.mu.Lock()
@@ -265,6 +432,36 @@ func writeErr(w http.ResponseWriter, status int, msg string) {
 	writeJSON(w, status, map[string]string{"error": msg})
 }
 
+// paged applies the cursor, limit and offset query parameters of a list
+// request to items, which are sorted by id. The cursor is the id of the last
+// item already returned, so deleting items between pages skips nothing.
+func paged[T any](r *http.Request, items []T, id func(T) string) ([]T, runpod.PageInfo) {
+	query := r.URL.Query()
+	info := runpod.PageInfo{Total: len(items)}
+	if cursor := query.Get("cursor"); cursor != "" {
+		start, _ := sort.Find(len(items), func(i int) int { return strings.Compare(cursor, id(items[i])) })
+		for start < len(items) && id(items[start]) == cursor {
+			start++
+		}
+		items = items[start:]
+	} else {
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		items = items[min(max(offset, 0), len(items)):]
+	}
+	if limit, _ := strconv.Atoi(query.Get("limit")); limit > 0 && limit < len(items) {
+		items = items[:limit]
+		info.NextCursor, info.HasMore = id(items[limit-1]), true
+	}
+	return items, info
+}
+
+// matches reports whether value passes the equality filter named key in
+// query; an absent filter passes everything.
+func matches(query url.Values, key, value string) bool {
+	filter := query.Get(key)
+	return filter == "" || filter == value
+}
+
 func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
 	// Injected faults take priority (retry-path testing).
 	s.mu.Lock()
@@ -285,13 +482,19 @@ func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
 	authorization := r.Header.Get("Authorization")
 	s.mu.Lock()
 	s.authz = append(s.authz, authorization)
+	apiKey := strings.TrimPrefix(authorization, "Bearer ")
+	revoked, scoped := s.revoked[apiKey], s.scoped[apiKey]
 	s.mu.Unlock()
-	if authorization == "" {
+	if authorization == "" || revoked {
 		writeErr(w, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	path := strings.TrimSuffix(r.URL.Path, "/")
+	if path == "/graphql" && scoped {
+		writeErr(w, http.StatusForbidden, "API key does not have GraphQL access")
+		return
+	}
 	switch {
 	case path == "/graphql":
 		s.handleGraphQL(w, r)
@@ -303,6 +506,12 @@ func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
 		s.handleVolumes(w, r, path)
 	case strings.HasPrefix(path, "/containerregistryauth"):
 		s.handleRegistryAuths(w, r, path)
+	case strings.HasPrefix(path, "/templates"):
+		s.handleTemplates(w, r, path)
+	case strings.HasPrefix(path, "/endpoints"):
+		s.handleEndpoints(w, r, path)
+	case strings.HasPrefix(path, "/secrets"):
+		s.handleSecrets(w, r, path)
 	default:
 		writeErr(w, http.StatusNotFound, "route not found")
 	}
@@ -337,7 +546,7 @@ func (s *Server) handlePods(w http.ResponseWriter, r *http.Request, path string)
 				writeErr(w, http.StatusBadRequest, "network volume not found")
 				return
 			}
-			cloudType := strings.TrimSpace(req.CloudType)
+			cloudType := strings.TrimSpace(string(req.CloudType))
 			if cloudType != "" && !strings.EqualFold(cloudType, "SECURE") {
 				s.mu.Unlock()
 				writeErr(w, http.StatusBadRequest, "network volumes require Secure Cloud")
@@ -377,11 +586,16 @@ func (s *Server) handlePods(w http.ResponseWriter, r *http.Request, path string)
 	case r.Method == http.MethodGet && len(parts) == 1:
 		s.mu.Lock()
 		pods := make([]*runpod.Pod, 0, len(s.pods))
+		query := r.URL.Query()
 		for _, p := range s.pods {
-			pods = append(pods, p)
+			if matches(query, "name", p.Name) && matches(query, "desiredStatus", p.DesiredStatus) && matches(query, "imageName", p.ImageName) {
+				pods = append(pods, p)
+			}
 		}
 		s.mu.Unlock()
-		writeJSON(w, http.StatusOK, map[string]interface{}{"pods": pods})
+		sort.Slice(pods, func(i, j int) bool { return pods[i].ID < pods[j].ID })
+		pods, info := paged(r, pods, func(p *runpod.Pod) string { return p.ID })
+		writeJSON(w, http.StatusOK, map[string]interface{}{"pods": pods, "pageInfo": info})
 
 	case len(parts) >= 2:
 		podID := parts[1]
@@ -538,6 +752,16 @@ func (s *Server) handleRegistryAuths(w http.ResponseWriter, r *http.Request, pat
 		s.mu.Unlock()
 		writeJSON(w, http.StatusCreated, auth)
 
+	case r.Method == http.MethodGet && len(parts) == 2:
+		s.mu.Lock()
+		auth := s.auths[parts[1]]
+		s.mu.Unlock()
+		if auth == nil {
+			writeErr(w, http.StatusNotFound, "registry auth not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, auth)
+
 	case r.Method == http.MethodDelete && len(parts) == 2:
 		s.mu.Lock()
 		_, ok := s.auths[parts[1]]
@@ -554,6 +778,338 @@ func (s *Server) handleRegistryAuths(w http.ResponseWriter, r *http.Request, pat
 	}
 }
 
+// --- templates ---
+
+func (s *Server) handleTemplates(w http.ResponseWriter, r *http.Request, path string) {
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+
+	switch {
+	case r.Method == http.MethodGet && len(parts) == 1:
+		// Public and RunPod-official templates are only listed when asked
+		// for, like the live API's include* parameters.
+		q := r.URL.Query()
+		includePublic := q.Get("includePublicTemplates") == "true"
+		includeRunpod := q.Get("includeRunpodTemplates") == "true"
+		s.mu.Lock()
+		templates := make([]*runpod.Template, 0, len(s.templates))
+		for _, t := range s.templates {
+			if (t.IsRunpod && !includeRunpod) || (t.IsPublic && !t.IsRunpod && !includePublic) {
+				continue
+			}
+			templates = append(templates, t)
+		}
+		s.mu.Unlock()
+		writeJSON(w, http.StatusOK, templates)
+
+	case r.Method == http.MethodPost && len(parts) == 1:
+		var req runpod.CreateTemplateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeErr(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+		s.mu.Lock()
+		if id := strings.TrimSpace(req.ContainerRegistryAuthID); id != "" && s.auths[id] == nil {
+			s.mu.Unlock()
+			writeErr(w, http.StatusBadRequest, "container registry auth not found")
+			return
+		}
+		tpl := &runpod.Template{
+			ID:                      s.newID("tpl"),
+			Name:                    req.Name,
+			ImageName:               req.ImageName,
+			Category:                req.Category,
+			ContainerDiskInGB:       req.ContainerDiskInGB,
+			VolumeInGB:              req.VolumeInGB,
+			VolumeMountPath:         req.VolumeMountPath,
+			ContainerRegistryAuthID: req.ContainerRegistryAuthID,
+			DockerEntrypoint:        req.DockerEntrypoint,
+			DockerStartCmd:          req.DockerStartCmd,
+			Env:                     req.Env,
+			Ports:                   req.Ports,
+			IsPublic:                req.IsPublic,
+			IsServerless:            req.IsServerless,
+			Readme:                  req.Readme,
+		}
+		s.templates[tpl.ID] = tpl
+		s.mu.Unlock()
+		writeJSON(w, http.StatusCreated, tpl)
+
+	case len(parts) == 2:
+		tplID := parts[1]
+		s.mu.Lock()
+		tpl := s.templates[tplID]
+		s.mu.Unlock()
+		if tpl == nil {
+			writeErr(w, http.StatusNotFound, "template not found")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, tpl)
+		case http.MethodPatch:
+			var req runpod.UpdateTemplateRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeErr(w, http.StatusBadRequest, "invalid body")
+				return
+			}
+			s.mu.Lock()
+			set(&tpl.Name, req.Name)
+			set(&tpl.ImageName, req.ImageName)
+			set(&tpl.ContainerDiskInGB, req.ContainerDiskInGB)
+			set(&tpl.VolumeInGB, req.VolumeInGB)
+			set(&tpl.VolumeMountPath, req.VolumeMountPath)
+			set(&tpl.ContainerRegistryAuthID, req.ContainerRegistryAuthID)
+			if req.DockerEntrypoint != nil {
+				tpl.DockerEntrypoint = req.DockerEntrypoint
+			}
+			if req.DockerStartCmd != nil {
+				tpl.DockerStartCmd = req.DockerStartCmd
+			}
+			if req.Env != nil {
+				tpl.Env = req.Env
+			}
+			if req.Ports != nil {
+				tpl.Ports = req.Ports
+			}
+			set(&tpl.IsPublic, req.IsPublic)
+			set(&tpl.Readme, req.Readme)
+			s.mu.Unlock()
+			writeJSON(w, http.StatusOK, tpl)
+		case http.MethodDelete:
+			s.mu.Lock()
+			delete(s.templates, tplID)
+			s.mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeErr(w, http.StatusNotFound, "route not found")
+		}
+
+	default:
+		writeErr(w, http.StatusNotFound, "route not found")
+	}
+}
+
+// --- endpoints ---
+
+func (s *Server) handleEndpoints(w http.ResponseWriter, r *http.Request, path string) {
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	includeTemplate := r.URL.Query().Get("includeTemplate") == "true"
+
+	switch {
+	case r.Method == http.MethodGet && len(parts) == 1:
+		s.mu.Lock()
+		endpoints := make([]runpod.Endpoint, 0, len(s.endpoints))
+		for _, e := range s.endpoints {
+			endpoints = append(endpoints, s.endpointView(e, includeTemplate))
+		}
+		s.mu.Unlock()
+		writeJSON(w, http.StatusOK, endpoints)
+
+	case r.Method == http.MethodPost && len(parts) == 1:
+		var req runpod.CreateEndpointRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeErr(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+		s.mu.Lock()
+		if s.templates[req.TemplateID] == nil {
+			s.mu.Unlock()
+			writeErr(w, http.StatusBadRequest, "template not found")
+			return
+		}
+		endpoint := &runpod.Endpoint{
+			ID:                  s.newID("ep"),
+			Name:                req.Name,
+			TemplateID:          req.TemplateID,
+			Version:             1,
+			ComputeType:         req.ComputeType,
+			GPUTypeIDs:          req.GPUTypeIDs,
+			GPUCount:            req.GPUCount,
+			CPUFlavorIDs:        req.CPUFlavorIDs,
+			VCPUCount:           req.VCPUCount,
+			AllowedCudaVersions: req.AllowedCudaVersions,
+			DataCenterIDs:       req.DataCenterIDs,
+			NetworkVolumeID:     req.NetworkVolumeID,
+			WorkersMin:          req.WorkersMin,
+			WorkersMax:          req.WorkersMax,
+			ScalerType:          req.ScalerType,
+			ScalerValue:         req.ScalerValue,
+			IdleTimeout:         req.IdleTimeout,
+			ExecutionTimeout:    req.ExecutionTimeout,
+			Flashboot:           req.Flashboot,
+		}
+		if endpoint.ComputeType == "" {
+			endpoint.ComputeType = "GPU"
+		}
+		s.endpoints[endpoint.ID] = endpoint
+		view := s.endpointView(endpoint, false)
+		s.mu.Unlock()
+		writeJSON(w, http.StatusCreated, view)
+
+	case len(parts) == 2:
+		endpointID := parts[1]
+		s.mu.Lock()
+		endpoint := s.endpoints[endpointID]
+		s.mu.Unlock()
+		if endpoint == nil {
+			writeErr(w, http.StatusNotFound, "endpoint not found")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			s.mu.Lock()
+			view := s.endpointView(endpoint, includeTemplate)
+			s.mu.Unlock()
+			writeJSON(w, http.StatusOK, view)
+		case http.MethodPatch:
+			var req runpod.UpdateEndpointRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeErr(w, http.StatusBadRequest, "invalid body")
+				return
+			}
+			s.mu.Lock()
+			if req.TemplateID != nil && s.templates[*req.TemplateID] == nil {
+				s.mu.Unlock()
+				writeErr(w, http.StatusBadRequest, "template not found")
+				return
+			}
+			applyEndpointUpdate(endpoint, &req)
+			endpoint.Version++ // every update is a new release
+			view := s.endpointView(endpoint, false)
+			s.mu.Unlock()
+			writeJSON(w, http.StatusOK, view)
+		case http.MethodDelete:
+			s.mu.Lock()
+			delete(s.endpoints, endpointID)
+			s.mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeErr(w, http.StatusNotFound, "route not found")
+		}
+
+	default:
+		writeErr(w, http.StatusNotFound, "route not found")
+	}
+}
+
+// endpointView copies an endpoint for a response, embedding its template
+// when requested. Callers must hold s.mu.
+func (s *Server) endpointView(e *runpod.Endpoint, includeTemplate bool) runpod.Endpoint {
+	view := *e
+	view.Template = nil
+	if includeTemplate {
+		if tpl := s.templates[e.TemplateID]; tpl != nil {
+			copy := *tpl
+			view.Template = &copy
+		}
+	}
+	return view
+}
+
+func applyEndpointUpdate(e *runpod.Endpoint, req *runpod.UpdateEndpointRequest) {
+	set(&e.Name, req.Name)
+	set(&e.TemplateID, req.TemplateID)
+	if req.GPUTypeIDs != nil {
+		e.GPUTypeIDs = req.GPUTypeIDs
+	}
+	set(&e.GPUCount, req.GPUCount)
+	if req.CPUFlavorIDs != nil {
+		e.CPUFlavorIDs = req.CPUFlavorIDs
+	}
+	set(&e.VCPUCount, req.VCPUCount)
+	if req.AllowedCudaVersions != nil {
+		e.AllowedCudaVersions = req.AllowedCudaVersions
+	}
+	if req.DataCenterIDs != nil {
+		e.DataCenterIDs = req.DataCenterIDs
+	}
+	set(&e.NetworkVolumeID, req.NetworkVolumeID)
+	set(&e.WorkersMin, req.WorkersMin)
+	set(&e.WorkersMax, req.WorkersMax)
+	set(&e.ScalerType, req.ScalerType)
+	set(&e.ScalerValue, req.ScalerValue)
+	set(&e.IdleTimeout, req.IdleTimeout)
+	set(&e.ExecutionTimeout, req.ExecutionTimeout)
+	set(&e.Flashboot, req.Flashboot)
+}
+
+// set copies a PATCH field onto dst when the request carried it.
+func set[T any](dst *T, v *T) {
+	if v != nil {
+		*dst = *v
+	}
+}
+
+// --- secrets ---
+
+func (s *Server) handleSecrets(w http.ResponseWriter, r *http.Request, path string) {
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+
+	switch {
+	case r.Method == http.MethodGet && len(parts) == 1:
+		s.mu.Lock()
+		secrets := make([]runpod.Secret, 0, len(s.secrets))
+		for _, secret := range s.secrets {
+			secrets = append(secrets, secret.secret)
+		}
+		s.mu.Unlock()
+		sort.Slice(secrets, func(i, j int) bool { return secrets[i].Name < secrets[j].Name })
+		secrets, info := paged(r, secrets, func(s runpod.Secret) string { return s.Name })
+		writeJSON(w, http.StatusOK, map[string]interface{}{"secrets": secrets, "pageInfo": info})
+
+	case r.Method == http.MethodPost && len(parts) == 1:
+		var req runpod.CreateSecretRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeErr(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+		s.mu.Lock()
+		if s.secrets[req.Name] != nil {
+			s.mu.Unlock()
+			writeErr(w, http.StatusBadRequest, "name already exists")
+			return
+		}
+		secret := &fakeSecret{secret: runpod.Secret{ID: s.newID("secret"), Name: req.Name}, value: req.Value}
+		s.secrets[req.Name] = secret
+		s.mu.Unlock()
+		writeJSON(w, http.StatusCreated, secret.secret)
+
+	case len(parts) == 2:
+		name := parts[1]
+		s.mu.Lock()
+		secret := s.secrets[name]
+		s.mu.Unlock()
+		if secret == nil {
+			writeErr(w, http.StatusNotFound, "secret not found")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, secret.secret)
+		case http.MethodPut:
+			var req runpod.UpdateSecretRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeErr(w, http.StatusBadRequest, "invalid body")
+				return
+			}
+			s.mu.Lock()
+			secret.value = req.Value
+			s.mu.Unlock()
+			writeJSON(w, http.StatusOK, secret.secret)
+		case http.MethodDelete:
+			s.mu.Lock()
+			delete(s.secrets, name)
+			s.mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeErr(w, http.StatusNotFound, "route not found")
+		}
+
+	default:
+		writeErr(w, http.StatusNotFound, "route not found")
+	}
+}
+
 // --- serverless jobs ---
 
 func (s *Server) handleServerless(w http.ResponseWriter, r *http.Request, path string) {
@@ -592,12 +1148,19 @@ func (s *Server) handleServerless(w http.ResponseWriter, r *http.Request, path s
 	case r.Method == http.MethodGet && (action == "status" || action == "stream") && len(parts) == 4:
 		s.mu.Lock()
 		job := s.jobs[jobKey(parts[3])]
+		var snapshot fakeJob
+		if job != nil {
+			snapshot = *job
+		}
 		s.mu.Unlock()
 		if job == nil {
 			writeErr(w, http.StatusNotFound, "job not found")
 			return
 		}
-		writeJSON(w, http.StatusOK, job)
+		if wait, _ := strconv.Atoi(r.URL.Query().Get("wait")); wait > 0 && action == "status" {
+			snapshot = s.awaitJobChange(r, jobKey(parts[3]), snapshot, time.Duration(wait)*time.Millisecond)
+		}
+		writeJSON(w, http.StatusOK, snapshot)
 
 	case r.Method == http.MethodPost && action == "cancel" && len(parts) == 4:
 		s.mu.Lock()
@@ -624,13 +1187,15 @@ func (s *Server) handleServerless(w http.ResponseWriter, r *http.Request, path s
 
 	case r.Method == http.MethodPost && action == "purge-queue":
 		s.mu.Lock()
+		removed := 0
 		for key, job := range s.jobs {
 			if strings.HasPrefix(key, endpointID+"/") && job.Status == "IN_QUEUE" {
 				job.Status = "CANCELLED"
+				removed++
 			}
 		}
 		s.mu.Unlock()
-		writeJSON(w, http.StatusOK, map[string]string{"message": "queue purged"})
+		writeJSON(w, http.StatusOK, runpod.PurgeQueueResult{Removed: removed, Status: "completed"})
 
 	case r.Method == http.MethodGet && action == "health":
 		s.mu.Lock()
@@ -648,6 +1213,39 @@ func (s *Server) handleServerless(w http.ResponseWriter, r *http.Request, path s
 	}
 }
 
+// awaitJobChange serves a long-poll status request: it holds until the job
+// leaves the status it had when the request arrived, for up to wait, and
+// returns the job as it is then. A job already in a terminal status
+// returns at once.
+func (s *Server) awaitJobChange(r *http.Request, key string, job fakeJob, wait time.Duration) fakeJob {
+	switch job.Status {
+	case "COMPLETED", "FAILED", "CANCELLED", "TIMED_OUT":
+		return job
+	}
+	timeout := time.After(wait)
+	tick := time.NewTicker(10 * time.Millisecond)
+	defer tick.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return job
+		case <-timeout:
+			return job
+		case <-tick.C:
+		}
+		s.mu.Lock()
+		current, ok := s.jobs[key]
+		changed := ok && current.Status != job.Status
+		if changed {
+			job = *current
+		}
+		s.mu.Unlock()
+		if changed || !ok {
+			return job
+		}
+	}
+}
+
 // --- GraphQL ---
 
 func (s *Server) handleGraphQL(w http.ResponseWriter, r *http.Request) {
@@ -659,14 +1257,38 @@ func (s *Server) handleGraphQL(w http.ResponseWriter, r *http.Request) {
 		writeErr(w, http.StatusBadRequest, "invalid graphql request")
 		return
 	}
+	if strings.Contains(req.Query, "updateUserSettings") {
+		input, _ := req.Variables["input"].(map[string]interface{})
+		s.mu.Lock()
+		if limit, ok := input["spendLimit"].(float64); ok {
+			s.account.SpendLimit = limit
+		}
+		if _, ok := input["notifyLowBalance"]; ok {
+			raw, _ := json.Marshal(input)
+			s.notify = runpod.NotificationSettings{}
+			_ = json.Unmarshal(raw, &s.notify)
+		}
+		user := s.userLocked()
+		s.mu.Unlock()
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{"updateUserSettings": user},
+		})
+		return
+	}
+	if strings.Contains(req.Query, "auditLogs") {
+		s.handleAuditLogGraphQL(w, req.Variables)
+		return
+	}
+	if strings.Contains(req.Query, "TeamMember") || strings.Contains(req.Query, "teamMembers") {
+		s.handleTeamGraphQL(w, req.Query, req.Variables)
+		return
+	}
 	if strings.Contains(req.Query, "myself") {
 		s.mu.Lock()
-		accountID := s.accountID
+		user := s.userLocked()
 		s.mu.Unlock()
 		writeJSON(w, http.StatusOK, map[string]interface{}{
-			"data": map[string]interface{}{
-				"myself": map[string]string{"id": accountID},
-			},
+			"data": map[string]interface{}{"myself": user},
 		})
 		return
 	}
@@ -708,6 +1330,24 @@ func (s *Server) handleGraphQL(w http.ResponseWriter, r *http.Request) {
 		writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"pod": result}})
 		return
 	}
+	if strings.Contains(req.Query, "cpuFlavors") {
+		s.mu.Lock()
+		flavors := append([]runpod.CPUFlavor{}, s.cpus...)
+		s.mu.Unlock()
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{"cpuFlavors": flavors},
+		})
+		return
+	}
+	if strings.Contains(req.Query, "dataCenters") {
+		s.mu.Lock()
+		dataCenters := append([]runpod.DataCenter{}, s.dcs...)
+		s.mu.Unlock()
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"data": map[string]interface{}{"dataCenters": dataCenters},
+		})
+		return
+	}
 	if !strings.Contains(req.Query, "gpuTypes") {
 		writeJSON(w, http.StatusOK, map[string]interface{}{
 			"errors": []map[string]string{{"message": "runpodtest: unsupported GraphQL query"}},
@@ -752,3 +1392,124 @@ func (s *Server) handleGraphQL(w http.ResponseWriter, r *http.Request) {
 		"data": map[string]interface{}{"gpuTypes": out},
 	})
 }
+
+func graphQLErr(w http.ResponseWriter, msg string) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"errors": []map[string]string{{"message": msg}},
+	})
+}
+
+func (s *Server) handleTeamGraphQL(w http.ResponseWriter, query string, variables map[string]interface{}) {
+	input, _ := variables["input"].(map[string]interface{})
+	memberID, _ := input["memberId"].(string)
+	role, _ := input["role"].(string)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	find := func() int {
+		for i := range s.team {
+			if s.team[i].ID == memberID {
+				return i
+			}
+		}
+		return -1
+	}
+	switch {
+	case strings.Contains(query, "inviteTeamMember"):
+		email, _ := input["email"].(string)
+		for _, member := range s.team {
+			if strings.EqualFold(member.Email, email) {
+				graphQLErr(w, "user is already a team member or invited")
+				return
+			}
+		}
+		member := runpod.TeamMember{ID: s.newID("member"), Email: email, Role: role, Pending: true}
+		s.team = append(s.team, member)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"inviteTeamMember": member}})
+	case strings.Contains(query, "updateTeamMemberRole"):
+		i := find()
+		if i < 0 {
+			graphQLErr(w, "team member not found")
+			return
+		}
+		s.team[i].Role = role
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"updateTeamMemberRole": s.team[i]}})
+	case strings.Contains(query, "removeTeamMember"):
+		i := find()
+		if i < 0 {
+			graphQLErr(w, "team member not found")
+			return
+		}
+		s.team = append(s.team[:i], s.team[i+1:]...)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"removeTeamMember": true}})
+	default:
+		members := append([]runpod.TeamMember{}, s.team...)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{
+			"myself": map[string]interface{}{"teamMembers": members},
+		}})
+	}
+}
+
+func (s *Server) handleAuditLogGraphQL(w http.ResponseWriter, variables map[string]interface{}) {
+	input, _ := variables["input"].(map[string]interface{})
+	str := func(key string) string { v, _ := input[key].(string); return v }
+	set := func(key string) map[string]bool {
+		values, _ := input[key].([]interface{})
+		out := map[string]bool{}
+		for _, v := range values {
+			if value, ok := v.(string); ok {
+				out[value] = true
+			}
+		}
+		return out
+	}
+	var since, until time.Time
+	if v := str("startTime"); v != "" {
+		since, _ = time.Parse(time.RFC3339, v)
+	}
+	if v := str("endTime"); v != "" {
+		until, _ = time.Parse(time.RFC3339, v)
+	}
+	actors, actions, resourceID := set("actors"), set("actions"), str("resourceId")
+	limit := 100
+	if v, ok := input["limit"].(float64); ok && v > 0 {
+		limit = int(v)
+	}
+	offset, _ := strconv.Atoi(str("cursor"))
+
+	s.mu.Lock()
+	entries := append([]runpod.AuditLogEntry(nil), s.audit...)
+	s.mu.Unlock()
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entryTime(entries[i]).After(entryTime(entries[j]))
+	})
+	var matched []runpod.AuditLogEntry
+	for _, e := range entries {
+		at := entryTime(e)
+		switch {
+		case !since.IsZero() && at.Before(since), !until.IsZero() && !at.Before(until):
+		case len(actors) > 0 && !actors[e.ActorID] && !actors[e.ActorEmail]:
+		case len(actions) > 0 && !actions[e.Action]:
+		case resourceID != "" && e.ResourceID != resourceID:
+		default:
+			matched = append(matched, e)
+		}
+	}
+	page, next := []runpod.AuditLogEntry{}, ""
+	if offset < len(matched) {
+		end := min(offset+limit, len(matched))
+		page = matched[offset:end]
+		if end < len(matched) {
+			next = strconv.Itoa(end)
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{
+		"myself": map[string]interface{}{"auditLogs": map[string]interface{}{"items": page, "nextCursor": next}},
+	}})
+}
+
+func entryTime(e runpod.AuditLogEntry) time.Time {
+	if e.Time == nil {
+		return time.Time{}
+	}
+	return e.Time.Time
+}
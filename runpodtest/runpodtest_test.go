@@ -115,8 +115,8 @@ func TestJobLifecycle(t *testing.T) {
 	if err != nil || health.JobsInQueue != 1 {
 		t.Fatalf("GetHealth: %v %+v", err, health)
 	}
-	if err := client.PurgeQueue(ctx, "ep1"); err != nil {
-		t.Fatalf("PurgeQueue: %v", err)
+	if result, err := client.PurgeQueue(ctx, "ep1", &runpod.PurgeQueueOptions{ExpectedMax: 1}); err != nil || result.Removed != 1 {
+		t.Fatalf("PurgeQueue: %v %+v", err, result)
 	}
 	after, err := client.GetJobStatus(ctx, "ep1", queued.ID)
 	if err != nil || after.Status != "CANCELLED" {
@@ -393,3 +393,55 @@ func TestGPUTypesQueryRejectsRemovedLowestPriceFields(t *testing.T) {
 		t.Fatalf("valid query rejected: %v", err)
 	}
 }
+
+func TestPrivateTemplateWithRegistryAuth(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+	ctx := context.Background()
+
+	if _, err := client.CreateTemplate(ctx, &runpod.CreateTemplateRequest{
+		Name: "private", ImageName: "ghcr.io/acme/worker:1", ContainerRegistryAuthID: "missing",
+	}); err == nil {
+		t.Fatal("unknown registry auth must be rejected")
+	}
+
+	auth, err := client.EnsureContainerRegistryAuth(ctx, &runpod.CreateContainerRegistryAuthRequest{
+		Name: "ghcr", Username: "u", Password: "p",
+	})
+	if err != nil {
+		t.Fatalf("EnsureContainerRegistryAuth: %v", err)
+	}
+	again, err := client.EnsureContainerRegistryAuth(ctx, &runpod.CreateContainerRegistryAuthRequest{
+		Name: "ghcr", Username: "u", Password: "p",
+	})
+	if err != nil || again.ID != auth.ID {
+		t.Fatalf("second ensure must reuse %s: %v %+v", auth.ID, err, again)
+	}
+	if got, err := client.GetContainerRegistryAuth(ctx, auth.ID); err != nil || got.Name != "ghcr" {
+		t.Fatalf("GetContainerRegistryAuth: %v %+v", err, got)
+	}
+
+	tpl, err := client.CreateTemplate(ctx, &runpod.CreateTemplateRequest{
+		Name: "private", ImageName: "ghcr.io/acme/worker:1", ContainerRegistryAuthID: auth.ID,
+	})
+	if err != nil {
+		t.Fatalf("CreateTemplate: %v", err)
+	}
+	if got := srv.Template(tpl.ID); got == nil || got.ContainerRegistryAuthID != auth.ID {
+		t.Fatalf("template lost registry auth: %+v", got)
+	}
+	if _, err := client.UpdateTemplate(ctx, tpl.ID, &runpod.UpdateTemplateRequest{ImageName: runpod.Ptr("ghcr.io/acme/worker:2")}); err != nil {
+		t.Fatalf("UpdateTemplate: %v", err)
+	}
+	templates, err := client.ListTemplates(ctx)
+	if err != nil || len(templates) != 1 || templates[0].ImageName != "ghcr.io/acme/worker:2" {
+		t.Fatalf("ListTemplates: %v %+v", err, templates)
+	}
+	if err := client.DeleteTemplate(ctx, tpl.ID); err != nil {
+		t.Fatalf("DeleteTemplate: %v", err)
+	}
+	if _, err := client.GetTemplate(ctx, tpl.ID); !errors.Is(err, runpod.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound after delete, got %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+package runpod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// DefaultImportSecretsConcurrency is how many secrets ImportSecrets writes
+// at once when ImportSecretsOptions.Concurrency is unset.
+const DefaultImportSecretsConcurrency = 5
+
+// ErrSecretNotImported is the error of a secret ImportSecrets did not
+// write because ctx was done first.
+var ErrSecretNotImported = errors.New("runpod: secret not imported, import cancelled")
+
+// ImportSecretsOptions tunes ImportSecrets. The zero value is usable.
+type ImportSecretsOptions struct {
+	// Concurrency caps the writes in flight at once (default
+	// DefaultImportSecretsConcurrency).
+	Concurrency int
+	// Overwrite replaces the value of secrets that already exist. By
+	// default they are skipped and keep their current value.
+	Overwrite bool
+}
+
+// SecretImportAction is what ImportSecrets did with one secret.
+type SecretImportAction string
+
+const (
+	SecretImportCreated SecretImportAction = "created"
+	SecretImportUpdated SecretImportAction = "updated"
+	SecretImportSkipped SecretImportAction = "skipped"
+)
+
+// SecretImport is the outcome of importing one secret: the action taken,
+// or the error that kept it from being written.
+type SecretImport struct {
+	Name string
+	// Action is empty when Err is set.
+	Action SecretImportAction
+	Err    error
+}
+
+// SecretImports are ImportSecrets' results, one per secret, sorted by name.
+type SecretImports []SecretImport
+
+// Count returns how many secrets were imported with action.
+func (s SecretImports) Count(action SecretImportAction) int {
+	n := 0
+	for _, r := range s {
+		if r.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// Err joins the errors of the secrets that were not written, each
+// prefixed with its name, or returns nil if every secret was.
+func (s SecretImports) Err() error {
+	var errs []error
+	for _, r := range s {
+		if r.Err != nil {
+			errs = append(errs, fmt.Errorf("secret %s: %w", r.Name, r.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ImportSecrets creates a secret for each name in secrets, for
+// bootstrapping an environment in one call. Names that already exist are
+// skipped, or updated with opts.Overwrite. It returns a result per secret
+// rather than failing the whole import when some writes fail; see
+// SecretImports.Err.
+//
+// The returned error is non-nil only when the import could not run or
+// finish: when listing the existing secrets fails, or when ctx is done.
+// In the latter case the results are returned too, with the secrets never
+// written marked ErrSecretNotImported.
+func (c *Client) ImportSecrets(ctx context.Context, secrets map[string]string, opts *ImportSecretsOptions) (SecretImports, error) {
+	o := ImportSecretsOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Concurrency < 0 {
+		return nil, NewValidationErrorWithValue("concurrency", "cannot be negative", o.Concurrency)
+	}
+	if o.Concurrency == 0 {
+		o.Concurrency = DefaultImportSecretsConcurrency
+	}
+
+	existing := map[string]bool{}
+	for secret, err := range c.Secrets(ctx, nil) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to import secrets: %w", err)
+		}
+		existing[secret.Name] = true
+	}
+
+	results := make(SecretImports, 0, len(secrets))
+	for name := range secrets {
+		results = append(results, SecretImport{Name: name, Err: ErrSecretNotImported})
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, o.Concurrency)
+	for i := range results {
+		name := results[i].Name
+		if existing[name] && !o.Overwrite {
+			results[i] = SecretImport{Name: name, Action: SecretImportSkipped}
+			continue
+		}
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			r := SecretImport{Name: name}
+			if existing[name] {
+				r.Action = SecretImportUpdated
+				_, r.Err = c.UpdateSecret(ctx, name, &UpdateSecretRequest{Value: secrets[name]})
+			} else {
+				r.Action = SecretImportCreated
+				_, r.Err = c.CreateSecret(ctx, &CreateSecretRequest{Name: name, Value: secrets[name]})
+			}
+			if r.Err != nil {
+				r.Action = ""
+			}
+			results[i] = r
+		}(i)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return results, err
+	}
+	return results, nil
+}
+
+// ImportSecretsFromEnvFile imports the variables of a .env file as
+// secrets, named by their keys; see LoadEnvFile for envOpts and
+// ImportSecrets for opts and the results.
+func (c *Client) ImportSecretsFromEnvFile(ctx context.Context, path string, envOpts *EnvFileOptions, opts *ImportSecretsOptions) (SecretImports, error) {
+	secrets, err := LoadEnvFile(path, envOpts)
+	if err != nil {
+		return nil, err
+	}
+	return c.ImportSecrets(ctx, secrets, opts)
+}
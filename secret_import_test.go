@@ -0,0 +1,53 @@
+package runpod_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestImportSecrets(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+	ctx := t.Context()
+
+	srv.AddSecret("hf_token", "old")
+	secrets := map[string]string{"hf_token": "new", "s3_key": "k", "empty": ""}
+
+	results, err := client.ImportSecrets(ctx, secrets, &runpod.ImportSecretsOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var validationErr *runpod.ValidationError
+	if len(results) != 3 || results[0].Name != "empty" || !errors.As(results[0].Err, &validationErr) ||
+		results[1] != (runpod.SecretImport{Name: "hf_token", Action: runpod.SecretImportSkipped}) ||
+		results[2] != (runpod.SecretImport{Name: "s3_key", Action: runpod.SecretImportCreated}) {
+		t.Fatalf("results = %+v", results)
+	}
+	if value, _ := srv.SecretValue("hf_token"); value != "old" {
+		t.Fatalf("skipped secret was written: %q", value)
+	}
+	if results.Err() == nil || results.Count(runpod.SecretImportCreated) != 1 {
+		t.Fatalf("Err = %v, created %d", results.Err(), results.Count(runpod.SecretImportCreated))
+	}
+
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte("hf_token=newer\ns3_key=k2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	results, err = client.ImportSecretsFromEnvFile(ctx, path, nil, &runpod.ImportSecretsOptions{Overwrite: true})
+	if err != nil || results.Err() != nil || results.Count(runpod.SecretImportUpdated) != 2 {
+		t.Fatalf("results = %+v, %v", results, err)
+	}
+	hf, _ := srv.SecretValue("hf_token")
+	s3, _ := srv.SecretValue("s3_key")
+	if !reflect.DeepEqual([]string{hf, s3}, []string{"newer", "k2"}) {
+		t.Fatalf("values = %q, %q", hf, s3)
+	}
+}
@@ -2,15 +2,84 @@ package runpod
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
 )
 
+// secretRefPattern matches RunPod's `{{ RUNPOD_SECRET_name }}` env-value
+// references, which the platform substitutes with the stored secret value
+// when a pod or worker starts.
+var secretRefPattern = regexp.MustCompile(`\{\{\s*RUNPOD_SECRET_([A-Za-z0-9_.-]+)\s*\}\}`)
+
+// SecretRef returns the env-value reference RunPod resolves to the stored
+// secret `name` at container start, e.g. Env["HF_TOKEN"] =
+// runpod.SecretRef("hf_token").
+func SecretRef(name string) string {
+	return "{{ RUNPOD_SECRET_" + strings.TrimSpace(name) + " }}"
+}
+
+// SecretRefNames returns the sorted, de-duplicated secret names referenced by
+// the values of env.
+func SecretRefNames(env map[string]string) []string {
+	seen := map[string]struct{}{}
+	for _, value := range env {
+		for _, m := range secretRefPattern.FindAllStringSubmatch(value, -1) {
+			seen[m[1]] = struct{}{}
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateSecretRefs checks that every secret referenced from env exists on
+// the account. RunPod does not reject a dangling reference at submission —
+// the container just starts with the literal template string — so call this
+// before CreatePod / CreateTemplate to fail fast. Returns a *ValidationError
+// listing the missing names.
+func (c *Client) ValidateSecretRefs(ctx context.Context, env map[string]string) error {
+	names := SecretRefNames(env)
+	if len(names) == 0 {
+		return nil
+	}
+
+	secrets, err := c.ListSecrets(ctx, nil)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]struct{}, len(secrets))
+	for _, secret := range secrets {
+		existing[secret.Name] = struct{}{}
+	}
+
+	var missing []string
+	for _, name := range names {
+		if _, ok := existing[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return NewValidationErrorWithValue("env", "references secrets that do not exist", missing)
+	}
+	return nil
+}
+
 // CreateSecret creates a new secret
 func (c *Client) CreateSecret(ctx context.Context, req *CreateSecretRequest) (*Secret, error) {
-	if err := c.validateCreateSecretRequest(req); err != nil {
+	if req == nil {
+		return nil, NewValidationError("request", "cannot be nil")
+	}
+	if err := c.validateRequest(req); err != nil {
 		return nil, err
 	}
+	c.redactions.add(req.Value)
 
 	var secret Secret
 	err := c.Post(ctx, "/secrets", req, &secret)
@@ -42,9 +111,13 @@ func (c *Client) UpdateSecret(ctx context.Context, name string, req *UpdateSecre
 	if err := c.validateRequired("name", name); err != nil {
 		return nil, err
 	}
-	if err := c.validateRequired("value", req.Value); err != nil {
+	if req == nil {
+		return nil, NewValidationError("request", "cannot be nil")
+	}
+	if err := c.validateRequest(req); err != nil {
 		return nil, err
 	}
+	c.redactions.add(req.Value)
 
 	var secret Secret
 	endpoint := fmt.Sprintf("/secrets/%s", name)
@@ -96,32 +169,171 @@ func (c *Client) DeleteSecret(ctx context.Context, name string) error {
 
 // ListSecrets lists all secrets (values not included)
 func (c *Client) ListSecrets(ctx context.Context, opts *ListOptions) ([]*Secret, error) {
+	page, err := c.ListSecretsPage(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return page.Items, nil
+}
+
+// ListSecretsPage returns one page of secrets and its PageInfo; see
+// ListPodsPage.
+func (c *Client) ListSecretsPage(ctx context.Context, opts *ListOptions) (*Page[*Secret], error) {
 	endpoint := c.buildListURL("/secrets", opts)
 
+	// Accept both bare array and object wrapper shapes.
+	var raw json.RawMessage
+	err := c.Get(ctx, endpoint, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %w", err)
+	}
+	if len(raw) == 0 {
+		return &Page[*Secret]{}, nil
+	}
+	if err := c.checkListShape(raw, []*Secret(nil)); err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %w", err)
+	}
+
+	var secrets []*Secret
+	if err := json.Unmarshal(raw, &secrets); err == nil {
+		return &Page[*Secret]{Items: secrets}, nil
+	}
+
 	var response struct {
 		Secrets []*Secret `json:"secrets"`
+		pageEnvelope
+	}
+	if err := json.Unmarshal(raw, &response); err != nil {
+		return nil, fmt.Errorf("failed to list secrets: unexpected response shape")
 	}
 
-	err := c.Get(ctx, endpoint, &response)
+	return &Page[*Secret]{Items: response.Secrets, PageInfo: response.info()}, nil
+}
+
+// RotateSecretOptions selects the endpoints RotateSecret rolls after the
+// value changes. Secret references are resolved when a worker starts, so
+// workers already running keep the old value until replaced.
+type RotateSecretOptions struct {
+	// RefreshEndpointIDs are refreshed unconditionally.
+	RefreshEndpointIDs []string
+	// RefreshReferencingEndpoints additionally refreshes every endpoint whose
+	// template env references the secret (see SecretRef).
+	RefreshReferencingEndpoints bool
+}
+
+// RotateSecretResult reports what RotateSecret changed.
+type RotateSecretResult struct {
+	Secret *Secret
+	// RefreshedEndpoints are the IDs of endpoints a new release was started
+	// for, in the order they were refreshed.
+	RefreshedEndpoints []string
+}
+
+// RotateSecret replaces the value of an existing secret and, per opts,
+// starts a new release of the affected endpoints (RefreshEndpointWorkers) so
+// replacement workers pick up the rotated value. Pods are not restarted.
+//
+// The value update happens first; if it fails nothing is refreshed. Refresh
+// continues past individual endpoint failures, and past a failed lookup of
+// the referencing endpoints: RefreshEndpointIDs, and the referencing
+// endpoints that were found, are still refreshed. The failures are joined
+// into the returned error alongside a non-nil result.
+func (c *Client) RotateSecret(ctx context.Context, name, value string, opts *RotateSecretOptions) (*RotateSecretResult, error) {
+	secret, err := c.UpdateSecret(ctx, name, &UpdateSecretRequest{Value: value})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list secrets: %w", err)
+		return nil, err
+	}
+	result := &RotateSecretResult{Secret: secret}
+	if opts == nil {
+		return result, nil
+	}
+
+	endpointIDs := make([]string, 0, len(opts.RefreshEndpointIDs))
+	seen := map[string]struct{}{}
+	add := func(id string) {
+		id = strings.TrimSpace(id)
+		if _, ok := seen[id]; ok || id == "" {
+			return
+		}
+		seen[id] = struct{}{}
+		endpointIDs = append(endpointIDs, id)
+	}
+	for _, id := range opts.RefreshEndpointIDs {
+		add(id)
+	}
+	var errs []error
+	if opts.RefreshReferencingEndpoints {
+		referencing, err := c.endpointsReferencingSecret(ctx, name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to find endpoints referencing secret %s: %w", name, err))
+		}
+		for _, id := range referencing {
+			add(id)
+		}
 	}
 
-	return response.Secrets, nil
+	for _, id := range endpointIDs {
+		if _, err := c.RefreshEndpointWorkers(ctx, id); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		result.RefreshedEndpoints = append(result.RefreshedEndpoints, id)
+	}
+	return result, errors.Join(errs...)
 }
 
-// validateCreateSecretRequest validates a secret creation request
-func (c *Client) validateCreateSecretRequest(req *CreateSecretRequest) error {
-	if req == nil {
-		return NewValidationError("request", "cannot be nil")
+// endpointsReferencingSecret returns the IDs of endpoints whose env or
+// template env references the secret name. An endpoint whose template
+// cannot be loaded is checked on its own env only; the errors are joined
+// and returned with the IDs found.
+func (c *Client) endpointsReferencingSecret(ctx context.Context, name string) ([]string, error) {
+	endpoints, err := c.ListEndpoints(ctx, &GetEndpointOptions{IncludeTemplate: true})
+	if err != nil {
+		return nil, err
 	}
 
-	if err := c.validateRequired("name", req.Name); err != nil {
-		return err
+	var ids []string
+	var errs []error
+	for _, endpoint := range endpoints {
+		tpl := endpoint.Template
+		if tpl == nil && endpoint.TemplateID != "" {
+			if tpl, err = c.GetTemplate(ctx, endpoint.TemplateID); err != nil {
+				errs = append(errs, fmt.Errorf("endpoint %s: %w", endpoint.ID, err))
+			}
+		}
+		refs := SecretRefNames(endpoint.Env)
+		if tpl != nil {
+			refs = append(refs, SecretRefNames(tpl.Env)...)
+		}
+		for _, ref := range refs {
+			if ref == name {
+				ids = append(ids, endpoint.ID)
+				break
+			}
+		}
 	}
-	if err := c.validateRequired("value", req.Value); err != nil {
-		return err
+	return ids, errors.Join(errs...)
+}
+
+// Validate checks the request. It returns a *ValidationError, or a
+// *ValidationErrors when it finds more than one problem.
+func (req *CreateSecretRequest) Validate() error {
+	if req == nil {
+		return nilRequest()
 	}
+	var p problems
+	p.add(validateRequired("name", req.Name))
+	p.add(validateRequired("value", req.Value))
+	return p.err()
+}
 
-	return nil
+// Validate checks the request. It returns a *ValidationError, or a
+// *ValidationErrors when it finds more than one problem.
+func (req *UpdateSecretRequest) Validate() error {
+	if req == nil {
+		return nilRequest()
+	}
+	var p problems
+	p.add(validateRequired("value", req.Value))
+	return p.err()
 }
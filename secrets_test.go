@@ -0,0 +1,148 @@
+package runpod_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestSecretRef(t *testing.T) {
+	if got := runpod.SecretRef("hf_token"); got != "{{ RUNPOD_SECRET_hf_token }}" {
+		t.Fatalf("SecretRef = %q", got)
+	}
+
+	env := map[string]string{
+		"HF_TOKEN": runpod.SecretRef("hf_token"),
+		"DSN":      "postgres://app:{{RUNPOD_SECRET_db-pass}}@db/app",
+		"AGAIN":    "{{ RUNPOD_SECRET_hf_token }}",
+		"PLAIN":    "value",
+	}
+	want := []string{"db-pass", "hf_token"}
+	if got := runpod.SecretRefNames(env); !reflect.DeepEqual(got, want) {
+		t.Fatalf("SecretRefNames = %v, want %v", got, want)
+	}
+}
+
+func TestValidateSecretRefs(t *testing.T) {
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/secrets" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		hits++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"secrets":[{"id":"s1","name":"hf_token"}]}`))
+	}))
+	defer server.Close()
+
+	client := mustClient(t, "test_key", runpod.WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	if err := client.ValidateSecretRefs(ctx, map[string]string{"PLAIN": "x"}); err != nil || hits != 0 {
+		t.Fatalf("env without references must not call the API: err=%v hits=%d", err, hits)
+	}
+	if err := client.ValidateSecretRefs(ctx, map[string]string{"HF_TOKEN": runpod.SecretRef("hf_token")}); err != nil {
+		t.Fatalf("existing secret: %v", err)
+	}
+
+	err := client.ValidateSecretRefs(ctx, map[string]string{
+		"HF_TOKEN": runpod.SecretRef("hf_token"),
+		"API_KEY":  runpod.SecretRef("missing"),
+	})
+	var valErr *runpod.ValidationError
+	if !errors.As(err, &valErr) {
+		t.Fatalf("expected *ValidationError, got %v", err)
+	}
+	if missing, _ := valErr.Value.([]string); !reflect.DeepEqual(missing, []string{"missing"}) {
+		t.Fatalf("missing names = %v", valErr.Value)
+	}
+}
+
+func TestSecretsAPI(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+	ctx := context.Background()
+
+	if _, err := client.CreateSecret(ctx, &runpod.CreateSecretRequest{Name: "hf_token", Value: "v1"}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	secrets, err := client.ListSecrets(ctx, nil)
+	if err != nil || len(secrets) != 1 || secrets[0].Name != "hf_token" {
+		t.Fatalf("list = %+v, %v", secrets, err)
+	}
+	if got, err := client.GetSecret(ctx, "hf_token"); err != nil || got.Name != "hf_token" {
+		t.Fatalf("get = %+v, %v", got, err)
+	}
+	if _, err := client.UpdateSecret(ctx, "hf_token", nil); err == nil {
+		t.Fatal("nil update request must fail validation")
+	}
+	if err := client.DeleteSecret(ctx, "hf_token"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := client.GetSecret(ctx, "hf_token"); !errors.Is(err, runpod.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestRotateSecret(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+	ctx := context.Background()
+
+	srv.AddSecret("hf_token", "old")
+	srv.AddTemplate(&runpod.Template{ID: "uses", Name: "uses", ImageName: "x", Env: map[string]string{"HF_TOKEN": runpod.SecretRef("hf_token")}})
+	srv.AddTemplate(&runpod.Template{ID: "plain", Name: "plain", ImageName: "x"})
+	srv.AddEndpoint(&runpod.Endpoint{ID: "ep-uses", TemplateID: "uses", Version: 1})
+	srv.AddEndpoint(&runpod.Endpoint{ID: "ep-plain", TemplateID: "plain", Version: 1})
+	srv.AddEndpoint(&runpod.Endpoint{ID: "ep-pinned", TemplateID: "plain", Version: 1})
+
+	result, err := client.RotateSecret(ctx, "hf_token", "new", nil)
+	if err != nil {
+		t.Fatalf("rotate: %v", err)
+	}
+	if value, _ := srv.SecretValue("hf_token"); value != "new" || len(result.RefreshedEndpoints) != 0 {
+		t.Fatalf("value=%q refreshed=%v", value, result.RefreshedEndpoints)
+	}
+
+	result, err = client.RotateSecret(ctx, "hf_token", "newer", &runpod.RotateSecretOptions{
+		RefreshEndpointIDs:          []string{"ep-pinned", "ep-uses"},
+		RefreshReferencingEndpoints: true,
+	})
+	if err != nil {
+		t.Fatalf("rotate with refresh: %v", err)
+	}
+	if !reflect.DeepEqual(result.RefreshedEndpoints, []string{"ep-pinned", "ep-uses"}) {
+		t.Fatalf("refreshed = %v", result.RefreshedEndpoints)
+	}
+	if srv.Endpoint("ep-uses").Version != 2 || srv.Endpoint("ep-pinned").Version != 2 || srv.Endpoint("ep-plain").Version != 1 {
+		t.Fatal("only the selected endpoints must be released")
+	}
+
+	result, err = client.RotateSecret(ctx, "hf_token", "newest", &runpod.RotateSecretOptions{RefreshEndpointIDs: []string{"ep-gone", "ep-uses"}})
+	if err == nil || result == nil || !reflect.DeepEqual(result.RefreshedEndpoints, []string{"ep-uses"}) {
+		t.Fatalf("refresh failures must be reported without aborting: %+v, %v", result, err)
+	}
+
+	// A template that fails to load is reported, but the scan continues:
+	// the explicit endpoints and the other referencing ones are refreshed.
+	srv.AddEndpoint(&runpod.Endpoint{ID: "ep-orphan", TemplateID: "deleted", Version: 1})
+	result, err = client.RotateSecret(ctx, "hf_token", "latest", &runpod.RotateSecretOptions{
+		RefreshEndpointIDs:          []string{"ep-pinned"},
+		RefreshReferencingEndpoints: true,
+	})
+	if !errors.Is(err, runpod.ErrNotFound) || result == nil || !reflect.DeepEqual(result.RefreshedEndpoints, []string{"ep-pinned", "ep-uses"}) {
+		t.Fatalf("lookup failure must not skip other endpoints: %+v, %v", result, err)
+	}
+
+	if _, err := client.RotateSecret(ctx, "missing", "x", &runpod.RotateSecretOptions{RefreshReferencingEndpoints: true}); !errors.Is(err, runpod.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for unknown secret, got %v", err)
+	}
+}
@@ -0,0 +1,438 @@
+package serverless
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+)
+
+// DefaultRunSyncTimeout is how long the dev server holds a /runsync
+// request before answering with the job still in progress, like RunPod.
+const DefaultRunSyncTimeout = 90 * time.Second
+
+// DevServerOptions configures a DevServer.
+type DevServerOptions struct {
+	// Concurrency is how many jobs run at once (default 1); the rest wait
+	// IN_QUEUE.
+	Concurrency int
+	// RunSyncTimeout bounds how long /runsync waits for the job.
+	RunSyncTimeout time.Duration
+	Logger         runpod.Logger
+}
+
+// DevServer serves an endpoint's job API (/run, /runsync, /status,
+// /stream, /cancel, /health) locally against a handler, the counterpart of
+// runpod-python's --rp_serve_api. Routes answer both with and without the
+// /v2/{endpointID} prefix, so a runpod.Client built with
+// WithServerlessBaseURL(server URL) works against it unchanged. Jobs live
+// in memory.
+type DevServer struct {
+	handler Handler
+	opts    DevServerOptions
+	logger  runpod.Logger
+	slots   chan struct{}
+	ctx     context.Context
+	stop    context.CancelFunc
+	running sync.WaitGroup
+
+	mu   sync.Mutex
+	jobs map[string]*devJob
+	seq  int
+}
+
+// devJob is a job's state in the dev server.
+type devJob struct {
+	Job
+	status      runpod.JobStatus
+	output      json.RawMessage
+	err         string
+	stream      []json.RawMessage
+	createdAt   time.Time
+	startedAt   time.Time
+	completedAt time.Time
+	cancel      context.CancelFunc
+	done        chan struct{}
+}
+
+// NewDevServer returns a dev server for handler. Close it to cancel the
+// jobs still running.
+func NewDevServer(handler Handler, opts DevServerOptions) (*DevServer, error) {
+	if handler == nil {
+		return nil, runpod.NewValidationError("handler", "cannot be nil")
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+	if opts.RunSyncTimeout <= 0 {
+		opts.RunSyncTimeout = DefaultRunSyncTimeout
+	}
+	s := &DevServer{
+		handler: handler,
+		opts:    opts,
+		logger:  opts.Logger,
+		slots:   make(chan struct{}, opts.Concurrency),
+		jobs:    map[string]*devJob{},
+	}
+	if s.logger == nil {
+		s.logger = stdLogger{}
+	}
+	s.ctx, s.stop = context.WithCancel(context.Background())
+	return s, nil
+}
+
+// ListenAndServe serves on addr until ctx is done, then closes the server.
+func (s *DevServer) ListenAndServe(ctx context.Context, addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	srv := &http.Server{Handler: s}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+	s.logger.Printf("[INFO] dev server listening on http://%s", ln.Addr())
+	err = srv.Serve(ln)
+	s.Close()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// Close cancels the jobs in progress and waits for their handlers to
+// return.
+func (s *DevServer) Close() {
+	s.stop()
+	s.running.Wait()
+}
+
+// Route returns a client option that sends a runpod.Client's jobs for
+// endpointID to s in-process, with no listener (see
+// runpod.WithLocalEndpoint). Application code then runs against the local
+// handler unchanged:
+//
+//	dev, err := serverless.NewDevServer(handler, serverless.DevServerOptions{})
+//	client, err := runpod.NewClient(apiKey, dev.Route("sdxl-endpoint-id"))
+//	job, err := client.RunSync(ctx, "sdxl-endpoint-id", input) // runs handler
+func (s *DevServer) Route(endpointID string) runpod.ClientOption {
+	return runpod.WithLocalEndpoint(endpointID, s)
+}
+
+// ServeHTTP implements http.Handler.
+func (s *DevServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) >= 2 && parts[0] == "v2" {
+		parts = parts[2:]
+	}
+	if len(parts) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+	route, id := parts[0], ""
+	if len(parts) == 2 {
+		id = parts[1]
+	} else if len(parts) > 2 {
+		http.NotFound(w, r)
+		return
+	}
+	switch {
+	case (route == "run" || route == "runsync") && id == "" && r.Method == http.MethodPost:
+		s.handleRun(w, r, route == "runsync")
+	case route == "status" && id != "":
+		s.withJob(w, id, func(job *devJob) any { return job.view() })
+	case route == "stream" && id != "":
+		s.withJob(w, id, (*devJob).drainStream)
+	case route == "cancel" && id != "" && r.Method == http.MethodPost:
+		s.withJob(w, id, func(job *devJob) any {
+			if !job.finished() {
+				job.cancel()
+				job.finish(runpod.JobStatusCancelled, nil, "")
+			}
+			return job.view()
+		})
+	case route == "health" && id == "":
+		writeJSON(w, http.StatusOK, s.health())
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *DevServer) handleRun(w http.ResponseWriter, r *http.Request, wait bool) {
+	var req struct {
+		Input   json.RawMessage `json:"input"`
+		Webhook string          `json:"webhook"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid JSON body: " + err.Error()})
+		return
+	}
+	if len(bytes.TrimSpace(req.Input)) == 0 || string(req.Input) == "null" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "input is required"})
+		return
+	}
+	job := s.submit(req.Input, req.Webhook)
+	if wait {
+		timer := time.NewTimer(s.opts.RunSyncTimeout)
+		defer timer.Stop()
+		select {
+		case <-job.done:
+		case <-timer.C:
+		case <-r.Context().Done():
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, s.view(job))
+}
+
+// submit stores a job and starts it once a slot is free.
+func (s *DevServer) submit(input json.RawMessage, webhook string) *devJob {
+	s.mu.Lock()
+	s.seq++
+	job := &devJob{
+		Job:       Job{ID: fmt.Sprintf("dev-%d-%d", time.Now().Unix(), s.seq), Input: input, Webhook: webhook},
+		status:    runpod.JobStatusInQueue,
+		createdAt: time.Now(),
+		done:      make(chan struct{}),
+	}
+	ctx, cancel := context.WithCancel(s.ctx)
+	job.cancel = cancel
+	s.jobs[job.ID] = job
+	s.mu.Unlock()
+
+	s.running.Add(1)
+	go func() {
+		defer s.running.Done()
+		defer cancel()
+		s.run(ctx, job)
+	}()
+	return job
+}
+
+func (s *DevServer) run(ctx context.Context, job *devJob) {
+	select {
+	case s.slots <- struct{}{}:
+		defer func() { <-s.slots }()
+	case <-ctx.Done():
+		s.complete(job, runpod.JobStatusCancelled, nil, "")
+		return
+	}
+	s.mu.Lock()
+	if job.status != runpod.JobStatusInQueue {
+		s.mu.Unlock()
+		return
+	}
+	job.status = runpod.JobStatusInProgress
+	job.startedAt = time.Now()
+	s.mu.Unlock()
+
+	s.logger.Printf("[INFO] %s | started", job.ID)
+	ctx = context.WithValue(ctx, reporterKey{}, devReporter{s: s, job: job})
+	output, refresh, err := runHandler(ctx, s.handler, s.logger, &job.Job)
+	if refresh {
+		s.logger.Printf("[INFO] %s | handler asked for a worker refresh (ignored locally)", job.ID)
+	}
+	switch {
+	case ctx.Err() != nil:
+		s.complete(job, runpod.JobStatusCancelled, nil, "")
+	case err != nil:
+		s.logger.Printf("[ERROR] %s | failed: %v", job.ID, err)
+		s.complete(job, runpod.JobStatusFailed, nil, encodeJobError(err, "local"))
+	default:
+		s.logger.Printf("[INFO] %s | finished in %s", job.ID, time.Since(job.startedAt).Round(time.Millisecond))
+		s.complete(job, runpod.JobStatusCompleted, output, "")
+	}
+}
+
+// complete finishes job and calls its webhook, if any.
+func (s *DevServer) complete(job *devJob, status runpod.JobStatus, output json.RawMessage, errText string) {
+	s.mu.Lock()
+	first := job.finish(status, output, errText)
+	view := job.view()
+	s.mu.Unlock()
+	if first && job.Webhook != "" {
+		go s.callWebhook(job.Webhook, view)
+	}
+}
+
+func (s *DevServer) callWebhook(u string, view *runpod.Job) {
+	body, _ := json.Marshal(view)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		s.logger.Printf("[WARN] %s | invalid webhook: %v", view.ID, err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		s.logger.Printf("[WARN] %s | webhook failed: %v", view.ID, err)
+		return
+	}
+	resp.Body.Close()
+}
+
+// withJob answers with fn's result for job id, under the server lock.
+func (s *DevServer) withJob(w http.ResponseWriter, id string, fn func(*devJob) any) {
+	s.mu.Lock()
+	job, ok := s.jobs[id]
+	var body any
+	if ok {
+		body = fn(job)
+	}
+	s.mu.Unlock()
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "job " + id + " not found"})
+		return
+	}
+	writeJSON(w, http.StatusOK, body)
+}
+
+func (s *DevServer) view(job *devJob) *runpod.Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return job.view()
+}
+
+func (s *DevServer) health() runpod.EndpointHealth {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	health := runpod.EndpointHealth{Status: "READY", WorkersTotal: s.opts.Concurrency}
+	for _, job := range s.jobs {
+		switch job.status {
+		case runpod.JobStatusInQueue:
+			health.JobsInQueue++
+		case runpod.JobStatusInProgress:
+			health.WorkersActive++
+		}
+	}
+	health.WorkersIdle = max(health.WorkersTotal-health.WorkersActive, 0)
+	return health
+}
+
+// finish records a final status once; it reports whether this call did.
+// The caller holds the server lock.
+func (j *devJob) finish(status runpod.JobStatus, output json.RawMessage, errText string) bool {
+	if j.finished() {
+		return false
+	}
+	j.status, j.output, j.err = status, output, errText
+	j.completedAt = time.Now()
+	close(j.done)
+	return true
+}
+
+func (j *devJob) finished() bool {
+	select {
+	case <-j.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// view renders the job the way RunPod's /status does.
+func (j *devJob) view() *runpod.Job {
+	v := &runpod.Job{
+		ID:        j.ID,
+		Status:    string(j.status),
+		Output:    j.output,
+		Error:     j.err,
+		CreatedAt: &runpod.JSONTime{Time: j.createdAt},
+	}
+	if !j.startedAt.IsZero() {
+		v.StartedAt = &runpod.JSONTime{Time: j.startedAt}
+		v.DelayTime = int(j.startedAt.Sub(j.createdAt).Milliseconds())
+		if !j.completedAt.IsZero() {
+			v.ExecutionTime = int(j.completedAt.Sub(j.startedAt).Milliseconds())
+		}
+	}
+	if !j.completedAt.IsZero() {
+		v.CompletedAt = &runpod.JSONTime{Time: j.completedAt}
+	}
+	return v
+}
+
+// drainStream returns the partial outputs not yet read, like RunPod's
+// /stream, which hands each one out once.
+func (j *devJob) drainStream() any {
+	chunks := make([]map[string]json.RawMessage, len(j.stream))
+	for i, chunk := range j.stream {
+		chunks[i] = map[string]json.RawMessage{"output": chunk}
+	}
+	j.stream = nil
+	v := &runpod.Job{ID: j.ID, Status: string(j.status)}
+	v.Stream, _ = json.Marshal(chunks)
+	return v
+}
+
+// devReporter collects a handler's updates in the dev server.
+type devReporter struct {
+	s   *DevServer
+	job *devJob
+}
+
+func (r devReporter) stream(_ context.Context, output json.RawMessage) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.job.stream = append(r.job.stream, output)
+	return nil
+}
+
+func (r devReporter) progress(_ context.Context, output json.RawMessage) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if r.job.status == runpod.JobStatusInProgress {
+		r.job.output = output
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// devServerFlags reads runpod-python's local API flags (--rp_serve_api,
+// --rp_api_host, --rp_api_port, --rp_api_concurrency) from args.
+func devServerFlags(args []string) (addr string, concurrency int, ok bool) {
+	host, port := "localhost", "8000"
+	for i := 0; i < len(args); i++ {
+		name, value, hasValue := strings.Cut(args[i], "=")
+		if !hasValue && i+1 < len(args) && name != "--rp_serve_api" {
+			value = args[i+1]
+		}
+		consumed := !hasValue
+		switch name {
+		case "--rp_serve_api":
+			ok = true
+			consumed = false
+		case "--rp_api_host":
+			host = value
+		case "--rp_api_port":
+			port = value
+		case "--rp_api_concurrency":
+			concurrency, _ = strconv.Atoi(value)
+		default:
+			consumed = false
+		}
+		if consumed {
+			i++
+		}
+	}
+	return net.JoinHostPort(host, port), concurrency, ok
+}
@@ -0,0 +1,185 @@
+package serverless_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/serverless"
+)
+
+func TestDevServer(t *testing.T) {
+	release := make(chan struct{})
+	handler := func(ctx context.Context, job *serverless.Job) (any, error) {
+		var in struct {
+			Prompt string `json:"prompt"`
+		}
+		if err := job.DecodeInput(&in); err != nil {
+			return nil, err
+		}
+		switch in.Prompt {
+		case "fail":
+			return nil, errors.New("model exploded")
+		case "stream":
+			if err := serverless.SendProgress(ctx, "halfway"); err != nil {
+				return nil, err
+			}
+			for _, word := range []string{"a", "b"} {
+				if err := serverless.Stream(ctx, word); err != nil {
+					return nil, err
+				}
+			}
+			<-release
+		case "block":
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return map[string]string{"echo": in.Prompt}, nil
+	}
+	dev, err := serverless.NewDevServer(handler, serverless.DevServerOptions{Concurrency: 2, Logger: quietLogger{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(dev)
+	t.Cleanup(func() {
+		srv.Close()
+		dev.Close()
+	})
+	rp, err := runpod.NewClient("test-key", runpod.WithServerlessBaseURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := t.Context()
+
+	job, err := rp.RunSync(ctx, "local", map[string]string{"prompt": "hi"})
+	if err != nil || job.Status != string(runpod.JobStatusCompleted) || string(job.Output) != `{"echo":"hi"}` {
+		t.Fatalf("runsync = %+v, %v", job, err)
+	}
+	job, err = rp.RunSync(ctx, "local", map[string]string{"prompt": "fail"})
+	if err != nil || job.Status != string(runpod.JobStatusFailed) || !strings.Contains(job.Error, "model exploded") {
+		t.Fatalf("failed runsync = %+v, %v", job, err)
+	}
+
+	streaming, err := rp.RunAsync(ctx, "local", map[string]string{"prompt": "stream"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var chunks []struct {
+		Output string `json:"output"`
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for len(chunks) < 2 && time.Now().Before(deadline) {
+		partial, err := rp.StreamResults(ctx, "local", streaming.ID)
+		if err != nil {
+			t.Fatalf("StreamResults: %v", err)
+		}
+		var got []struct {
+			Output string `json:"output"`
+		}
+		if err := json.Unmarshal(partial.Stream, &got); err != nil {
+			t.Fatalf("stream = %s: %v", partial.Stream, err)
+		}
+		chunks = append(chunks, got...)
+		time.Sleep(5 * time.Millisecond)
+	}
+	if len(chunks) != 2 || chunks[0].Output != "a" || chunks[1].Output != "b" {
+		t.Fatalf("chunks = %+v", chunks)
+	}
+	if job, err := rp.GetJobStatus(ctx, "local", streaming.ID); err != nil || string(job.Output) != `"halfway"` {
+		t.Fatalf("progress = %+v, %v", job, err)
+	}
+	if health, err := rp.GetHealth(ctx, "local"); err != nil || health.WorkersActive != 1 {
+		t.Fatalf("health = %+v, %v", health, err)
+	}
+	close(release)
+	waitStatus(t, rp, streaming.ID, runpod.JobStatusCompleted)
+
+	blocked, err := rp.RunAsync(ctx, "local", map[string]string{"prompt": "block"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rp.CancelJob(ctx, "local", blocked.ID); err != nil {
+		t.Fatalf("CancelJob: %v", err)
+	}
+	waitStatus(t, rp, blocked.ID, runpod.JobStatusCancelled)
+
+	if _, err := rp.GetJobStatus(ctx, "local", "missing"); !errors.Is(err, runpod.ErrNotFound) {
+		t.Fatalf("missing job: %v", err)
+	}
+}
+
+func waitStatus(t *testing.T, rp *runpod.Client, id string, want runpod.JobStatus) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		job, err := rp.GetJobStatus(t.Context(), "local", id)
+		if err != nil {
+			t.Fatalf("GetJobStatus: %v", err)
+		}
+		if job.Status == string(want) {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("job %s status = %s, want %s", id, job.Status, want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestDevServerRoute(t *testing.T) {
+	handler := func(ctx context.Context, job *serverless.Job) (any, error) {
+		var in struct {
+			N int `json:"n"`
+		}
+		if err := job.DecodeInput(&in); err != nil {
+			return nil, err
+		}
+		return in.N * 2, nil
+	}
+	dev, err := serverless.NewDevServer(handler, serverless.DevServerOptions{Logger: quietLogger{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(dev.Close)
+	// Other endpoints still reach the network, here a fake RunPod.
+	remote := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"remote-1","status":"IN_QUEUE"}`))
+	}))
+	t.Cleanup(remote.Close)
+	rp, err := runpod.NewClient("test-key", runpod.WithServerlessBaseURL(remote.URL), dev.Route("sdxl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := t.Context()
+
+	job, err := rp.RunSync(ctx, "sdxl", map[string]int{"n": 21})
+	if err != nil || job.Status != string(runpod.JobStatusCompleted) || string(job.Output) != "42" {
+		t.Fatalf("RunSync = %+v, %v", job, err)
+	}
+	job, err = rp.RunAsync(ctx, "sdxl", map[string]int{"n": 1})
+	if err != nil || !strings.HasPrefix(job.ID, "dev-") {
+		t.Fatalf("RunAsync = %+v, %v", job, err)
+	}
+	for job.Status != string(runpod.JobStatusCompleted) {
+		time.Sleep(time.Millisecond)
+		if job, err = rp.GetJobStatus(ctx, "sdxl", job.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if string(job.Output) != "2" {
+		t.Fatalf("job = %+v", job)
+	}
+	if _, err := rp.GetJobStatus(ctx, "sdxl", "missing"); !errors.Is(err, runpod.ErrNotFound) {
+		t.Fatalf("unknown job = %v", err)
+	}
+
+	if job, err := rp.RunAsync(ctx, "other", map[string]int{"n": 1}); err != nil || job.ID != "remote-1" {
+		t.Fatalf("other endpoint = %+v, %v", job, err)
+	}
+}
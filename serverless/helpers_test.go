@@ -0,0 +1,174 @@
+package serverless_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-sdk/serverless"
+)
+
+// fakeJobAPI stands in for RunPod's worker-facing job API.
+type fakeJobAPI struct {
+	srv *httptest.Server
+
+	mu        sync.Mutex
+	queue     []string
+	results   map[string][]map[string]any
+	streams   map[string][]json.RawMessage
+	order     []string
+	pings     []string
+	auth      []string
+	failPosts []int
+	batches   []int
+	changed   chan struct{}
+}
+
+type quietLogger struct{}
+
+func (quietLogger) Printf(string, ...any) {}
+
+func newFakeJobAPI(t *testing.T) *fakeJobAPI {
+	t.Helper()
+	f := &fakeJobAPI{results: map[string][]map[string]any{}, streams: map[string][]json.RawMessage{}, changed: make(chan struct{}, 1)}
+	f.srv = httptest.NewServer(http.HandlerFunc(f.handle))
+	t.Cleanup(f.srv.Close)
+	return f
+}
+
+func (f *fakeJobAPI) options() serverless.Options {
+	return serverless.Options{
+		GetJobURL:     f.srv.URL + "/job-take/$RUNPOD_POD_ID?gpu=test",
+		PostOutputURL: f.srv.URL + "/job-done/$RUNPOD_POD_ID/$ID?gpu=test",
+		PostStreamURL: f.srv.URL + "/job-stream/$RUNPOD_POD_ID/$ID?gpu=test",
+		PingURL:       f.srv.URL + "/ping/$RUNPOD_POD_ID",
+		APIKey:        "ai-key",
+		WorkerID:      "worker-1",
+		PingInterval:  10 * time.Millisecond,
+		RetryBackoff:  time.Millisecond,
+		Logger:        quietLogger{},
+	}
+}
+
+// enqueue adds a job with the given input JSON.
+func (f *fakeJobAPI) enqueue(id, input string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.queue = append(f.queue, `{"id":"`+id+`","input":`+input+`}`)
+}
+
+// failNextPosts makes the next result posts fail with these statuses.
+func (f *fakeJobAPI) failNextPosts(statuses ...int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failPosts = append(f.failPosts, statuses...)
+}
+
+// waitFinished waits until n jobs have a final (non-stream) result.
+func (f *fakeJobAPI) waitFinished(t *testing.T, n int) {
+	t.Helper()
+	deadline := time.After(5 * time.Second)
+	for {
+		f.mu.Lock()
+		done := len(f.order)
+		f.mu.Unlock()
+		if done >= n {
+			return
+		}
+		select {
+		case <-f.changed:
+		case <-deadline:
+			t.Fatalf("timed out waiting for %d results, have %d", n, done)
+		}
+	}
+}
+
+// result returns the last final result posted for a job.
+func (f *fakeJobAPI) result(id string) map[string]any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	posts := f.results[id]
+	if len(posts) == 0 {
+		return nil
+	}
+	return posts[len(posts)-1]
+}
+
+func (f *fakeJobAPI) handle(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	f.auth = append(f.auth, r.Header.Get("Authorization"))
+	f.mu.Unlock()
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case (parts[0] == "job-take" || parts[0] == "job-take-batch") && r.Method == http.MethodGet:
+		batch := parts[0] == "job-take-batch"
+		size, _ := strconv.Atoi(r.URL.Query().Get("batch_size"))
+		// Long-poll briefly when the queue is empty, like the real API.
+		for i := 0; i < 20; i++ {
+			f.mu.Lock()
+			if len(f.queue) > 0 {
+				w.Header().Set("Content-Type", "application/json")
+				if !batch {
+					job := f.queue[0]
+					f.queue = f.queue[1:]
+					f.mu.Unlock()
+					io.WriteString(w, job)
+					return
+				}
+				n := min(max(size, 1), len(f.queue))
+				jobs := f.queue[:n]
+				f.queue = f.queue[n:]
+				f.batches = append(f.batches, n)
+				f.mu.Unlock()
+				io.WriteString(w, "["+strings.Join(jobs, ",")+"]")
+				return
+			}
+			f.mu.Unlock()
+			time.Sleep(time.Millisecond)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case (parts[0] == "job-done" || parts[0] == "job-stream") && r.Method == http.MethodPost && len(parts) == 3:
+		f.mu.Lock()
+		if len(f.failPosts) > 0 {
+			status := f.failPosts[0]
+			f.failPosts = f.failPosts[1:]
+			f.mu.Unlock()
+			http.Error(w, "injected", status)
+			return
+		}
+		f.mu.Unlock()
+		var payload map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			http.Error(w, "bad json", http.StatusBadRequest)
+			return
+		}
+		jobID := parts[2]
+		f.mu.Lock()
+		if r.URL.Query().Get("isStream") == "true" {
+			raw, _ := json.Marshal(payload["output"])
+			f.streams[jobID] = append(f.streams[jobID], raw)
+		} else {
+			f.results[jobID] = append(f.results[jobID], payload)
+			if payload["status"] != "IN_PROGRESS" {
+				f.order = append(f.order, jobID)
+			}
+		}
+		f.mu.Unlock()
+		select {
+		case f.changed <- struct{}{}:
+		default:
+		}
+	case parts[0] == "ping":
+		f.mu.Lock()
+		f.pings = append(f.pings, r.URL.Query().Get("job_id"))
+		f.mu.Unlock()
+	default:
+		http.NotFound(w, r)
+	}
+}
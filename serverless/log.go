@@ -0,0 +1,248 @@
+package serverless
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+)
+
+type jobIDKey struct{}
+
+// JobID returns the ID of the job handled under ctx, or "" outside a
+// handler.
+func JobID(ctx context.Context) string {
+	id, _ := ctx.Value(jobIDKey{}).(string)
+	return id
+}
+
+// LogHandlerOptions configures a LogHandler.
+type LogHandlerOptions struct {
+	// Level is the minimum level logged (default slog.LevelInfo).
+	Level slog.Leveler
+	// JSON writes one JSON object per record, the shape RunPod's log
+	// ingestion indexes by request. It is on by default when running on
+	// RunPod (RUNPOD_ENDPOINT_ID is set).
+	JSON bool
+}
+
+// LogHandler is a slog.Handler that writes records the way runpod-python's
+// logger does, so the console can filter worker logs by request. Each
+// record is tagged with the job ID from the handler context, or from a
+// job_id attribute. On RunPod it writes
+//
+//	{"requestId":"job-1","level":"INFO","message":"loaded","model":"sdxl"}
+//
+// and elsewhere
+//
+//	INFO   | job-1 | loaded model=sdxl
+type LogHandler struct {
+	w      io.Writer
+	mu     *sync.Mutex
+	opts   LogHandlerOptions
+	attrs  []slog.Attr
+	groups []string
+}
+
+// NewLogHandler returns a handler writing to w; nil opts uses defaults.
+func NewLogHandler(w io.Writer, opts *LogHandlerOptions) *LogHandler {
+	h := &LogHandler{w: w, mu: &sync.Mutex{}}
+	if opts != nil {
+		h.opts = *opts
+	}
+	if os.Getenv(EnvEndpointID) != "" {
+		h.opts.JSON = true
+	}
+	if h.opts.Level == nil {
+		h.opts.Level = slog.LevelInfo
+	}
+	return h
+}
+
+// Enabled implements slog.Handler.
+func (h *LogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.opts.Level.Level()
+}
+
+// WithAttrs implements slog.Handler.
+func (h *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.attrs = append(clone.attrs[:len(clone.attrs):len(clone.attrs)], h.qualify(attrs)...)
+	return &clone
+}
+
+// WithGroup implements slog.Handler. Grouped keys are joined with dots.
+func (h *LogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := *h
+	clone.groups = append(clone.groups[:len(clone.groups):len(clone.groups)], name)
+	return &clone
+}
+
+// Handle implements slog.Handler.
+func (h *LogHandler) Handle(ctx context.Context, r slog.Record) error {
+	jobID := JobID(ctx)
+	attrs := append([]slog.Attr(nil), h.attrs...)
+	var recordAttrs []slog.Attr
+	r.Attrs(func(a slog.Attr) bool {
+		recordAttrs = append(recordAttrs, a)
+		return true
+	})
+	attrs = append(attrs, h.qualify(recordAttrs)...)
+	fields := make([]slog.Attr, 0, len(attrs))
+	for _, a := range flatten("", attrs) {
+		if a.Key == "job_id" {
+			jobID = a.Value.String()
+			continue
+		}
+		fields = append(fields, a)
+	}
+
+	var buf bytes.Buffer
+	level := levelName(r.Level)
+	if h.opts.JSON {
+		buf.WriteByte('{')
+		if jobID != "" {
+			writeJSONField(&buf, "requestId", jobID)
+			buf.WriteByte(',')
+		}
+		writeJSONField(&buf, "level", level)
+		buf.WriteByte(',')
+		writeJSONField(&buf, "message", r.Message)
+		for _, a := range fields {
+			buf.WriteByte(',')
+			writeJSONField(&buf, a.Key, a.Value.Any())
+		}
+		buf.WriteString("}\n")
+	} else {
+		fmt.Fprintf(&buf, "%-7s| ", level)
+		if jobID != "" {
+			buf.WriteString(jobID + " | ")
+		}
+		buf.WriteString(r.Message)
+		for _, a := range fields {
+			value := a.Value.String()
+			if strings.ContainsAny(value, " \t\n\"=") {
+				value = strconv.Quote(value)
+			}
+			buf.WriteString(" " + a.Key + "=" + value)
+		}
+		buf.WriteByte('\n')
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := h.w.Write(buf.Bytes())
+	return err
+}
+
+// qualify prefixes attrs with the handler's open groups.
+func (h *LogHandler) qualify(attrs []slog.Attr) []slog.Attr {
+	if len(h.groups) == 0 {
+		return attrs
+	}
+	prefix := strings.Join(h.groups, ".")
+	out := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		if a.Key == "job_id" {
+			out[i] = a
+			continue
+		}
+		out[i] = slog.Attr{Key: prefix + "." + a.Key, Value: a.Value}
+	}
+	return out
+}
+
+// flatten resolves values and expands group attrs into dotted keys.
+func flatten(prefix string, attrs []slog.Attr) []slog.Attr {
+	var out []slog.Attr
+	for _, a := range attrs {
+		a.Value = a.Value.Resolve()
+		if a.Equal(slog.Attr{}) {
+			continue
+		}
+		key := a.Key
+		if prefix != "" && key != "" {
+			key = prefix + "." + key
+		} else if prefix != "" {
+			key = prefix
+		}
+		if a.Value.Kind() == slog.KindGroup {
+			out = append(out, flatten(key, a.Value.Group())...)
+			continue
+		}
+		out = append(out, slog.Attr{Key: key, Value: a.Value})
+	}
+	return out
+}
+
+func writeJSONField(buf *bytes.Buffer, key string, value any) {
+	k, _ := json.Marshal(key)
+	v, err := json.Marshal(value)
+	if err != nil {
+		v, _ = json.Marshal(fmt.Sprint(value))
+	}
+	buf.Write(k)
+	buf.WriteByte(':')
+	buf.Write(v)
+}
+
+// levelName maps slog levels to the names RunPod's console filters on.
+func levelName(level slog.Level) string {
+	switch {
+	case level >= slog.LevelError:
+		return "ERROR"
+	case level >= slog.LevelWarn:
+		return "WARN"
+	case level >= slog.LevelInfo:
+		return "INFO"
+	}
+	return "DEBUG"
+}
+
+// slogLogger adapts a *slog.Logger to runpod.Logger.
+type slogLogger struct {
+	l *slog.Logger
+}
+
+// NewSlogLogger adapts l for Options.Logger and DevServerOptions.Logger,
+// so the worker's own messages carry levels and job IDs too. The
+// "[LEVEL] " prefix of a message sets its level, and a leading "<job ID> |"
+// becomes the job_id attribute.
+func NewSlogLogger(l *slog.Logger) runpod.Logger {
+	return slogLogger{l: l}
+}
+
+func (s slogLogger) Printf(format string, v ...any) {
+	msg := strings.TrimSpace(fmt.Sprintf(format, v...))
+	level := slog.LevelInfo
+	if rest, ok := strings.CutPrefix(msg, "["); ok {
+		if name, after, ok := strings.Cut(rest, "] "); ok {
+			switch name {
+			case "DEBUG":
+				level, msg = slog.LevelDebug, after
+			case "INFO":
+				level, msg = slog.LevelInfo, after
+			case "WARN":
+				level, msg = slog.LevelWarn, after
+			case "ERROR":
+				level, msg = slog.LevelError, after
+			}
+		}
+	}
+	var attrs []slog.Attr
+	if id, after, ok := strings.Cut(msg, " | "); ok && id != "" && !strings.ContainsAny(id, " \t") {
+		attrs = append(attrs, slog.String("job_id", id))
+		msg = after
+	}
+	s.l.LogAttrs(context.Background(), level, msg, attrs...)
+}
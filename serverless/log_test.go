@@ -0,0 +1,64 @@
+package serverless_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/serverless"
+)
+
+func TestLogHandler(t *testing.T) {
+	t.Setenv(serverless.EnvEndpointID, "")
+	var buf bytes.Buffer
+	logger := slog.New(serverless.NewLogHandler(&buf, nil))
+	handler := func(ctx context.Context, job *serverless.Job) (any, error) {
+		logger.With("model", "sdxl").WithGroup("gpu").InfoContext(ctx, "loaded", "vram", 24)
+		logger.DebugContext(ctx, "hidden")
+		return serverless.JobID(ctx), nil
+	}
+	dev, err := serverless.NewDevServer(handler, serverless.DevServerOptions{Logger: quietLogger{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(dev)
+	t.Cleanup(func() {
+		srv.Close()
+		dev.Close()
+	})
+	rp, err := runpod.NewClient("test-key", runpod.WithServerlessBaseURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	job, err := rp.RunSync(t.Context(), "local", map[string]string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "INFO   | " + job.ID + " | loaded model=sdxl gpu.vram=24\n"; buf.String() != want {
+		t.Fatalf("text log = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	logger = slog.New(serverless.NewLogHandler(&buf, &serverless.LogHandlerOptions{JSON: true, Level: slog.LevelDebug}))
+	logger.Warn("slow step", "job_id", "job-9", "took", "3s")
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("json log %q: %v", buf.String(), err)
+	}
+	if record["requestId"] != "job-9" || record["level"] != "WARN" || record["message"] != "slow step" || record["took"] != "3s" {
+		t.Fatalf("json log = %v", record)
+	}
+
+	// Worker messages keep their level and job ID through the bridge.
+	buf.Reset()
+	bridge := serverless.NewSlogLogger(slog.New(serverless.NewLogHandler(&buf, nil)))
+	bridge.Printf("[ERROR] %s | failed after %s: %v", "job-3", "2s", "boom")
+	if got := buf.String(); !strings.HasPrefix(got, "ERROR  | job-3 | failed after 2s: boom") {
+		t.Fatalf("bridged log = %q", got)
+	}
+}
@@ -0,0 +1,217 @@
+package serverless
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/cozy-creator/runpod-go-sdk/jsonschema"
+)
+
+// SchemaFor derives the input schema of struct type T from its json,
+// validate and other tags (see jsonschema.Reflect), so the struct a handler
+// decodes its input into also validates it:
+//
+//	type Input struct {
+//		Prompt string `json:"prompt" validate:"required,min=1"`
+//		Steps  int    `json:"steps" default:"30" validate:"min=1,max=150"`
+//	}
+//	schema, err := serverless.SchemaFor[Input]()
+//	serverless.Start(serverless.Validated(schema, handler))
+func SchemaFor[T any]() (Schema, error) {
+	s, err := jsonschema.For[T]()
+	if err != nil {
+		return nil, err
+	}
+	return FromJSONSchema(s)
+}
+
+// FromJSONSchema converts an object schema, such as a worker's published
+// input contract, to a Schema. Each property's type, required flag and
+// default carry over, and its remaining keywords (bounds, lengths, enum,
+// pattern, and the properties and items of nested values) become its
+// Constraints.
+func FromJSONSchema(s *jsonschema.Schema) (Schema, error) {
+	root := s
+	if s.Ref != "" {
+		_, def, err := s.Resolve(root)
+		if err != nil {
+			return nil, err
+		}
+		s = def
+	}
+	if len(s.Type) > 0 && !s.Type.Has("object") {
+		return nil, fmt.Errorf("input schema must describe an object, not %v", s.Type)
+	}
+	c := &converter{root: root, patterns: map[string]*regexp.Regexp{}}
+	out := make(Schema, len(s.Properties))
+	for name, prop := range s.Properties {
+		resolved, err := c.resolve(prop)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if err := c.compile(prop, map[*jsonschema.Schema]bool{}); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		out[name] = Field{
+			Type:        primaryType(resolved.Type),
+			Required:    s.IsRequired(name),
+			Default:     resolved.Default,
+			Constraints: func(value any) bool { return c.conforms(prop, value) },
+		}
+	}
+	return out, nil
+}
+
+type converter struct {
+	root     *jsonschema.Schema
+	patterns map[string]*regexp.Regexp
+}
+
+func (c *converter) resolve(s *jsonschema.Schema) (*jsonschema.Schema, error) {
+	for s.Ref != "" {
+		if s.Ref == "#" {
+			s = c.root
+			continue
+		}
+		_, def, err := s.Resolve(c.root)
+		if err != nil {
+			return nil, err
+		}
+		s = def
+	}
+	return s, nil
+}
+
+// compile resolves every $ref and pattern reachable from s up front, so
+// conforms cannot fail on a malformed schema.
+func (c *converter) compile(s *jsonschema.Schema, seen map[*jsonschema.Schema]bool) error {
+	s, err := c.resolve(s)
+	if err != nil || seen[s] {
+		return err
+	}
+	seen[s] = true
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("pattern: %w", err)
+		}
+		c.patterns[s.Pattern] = re
+	}
+	for _, sub := range s.Properties {
+		if err := c.compile(sub, seen); err != nil {
+			return err
+		}
+	}
+	for _, sub := range []*jsonschema.Schema{s.Items, s.AdditionalProperties} {
+		if sub != nil {
+			if err := c.compile(sub, seen); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// conforms reports whether value, decoded as Constraints receive it,
+// satisfies s.
+func (c *converter) conforms(s *jsonschema.Schema, value any) bool {
+	s, _ = c.resolve(s)
+	if s.Never {
+		return false
+	}
+	if value == nil {
+		return len(s.Type) == 0 || s.Type.Has("null")
+	}
+	if len(s.Type) > 0 && !c.hasAnyType(value, s.Type) {
+		return false
+	}
+	if len(s.Enum) > 0 && !containsValue(s.Enum, value) {
+		return false
+	}
+
+	switch v := value.(type) {
+	case float64:
+		return (s.Minimum == nil || v >= *s.Minimum) &&
+			(s.Maximum == nil || v <= *s.Maximum) &&
+			(s.ExclusiveMinimum == nil || v > *s.ExclusiveMinimum) &&
+			(s.ExclusiveMaximum == nil || v < *s.ExclusiveMaximum)
+	case string:
+		n := utf8.RuneCountInString(v)
+		if (s.MinLength != nil && n < *s.MinLength) || (s.MaxLength != nil && n > *s.MaxLength) {
+			return false
+		}
+		return s.Pattern == "" || c.patterns[s.Pattern].MatchString(v)
+	case []any:
+		if (s.MinItems != nil && len(v) < *s.MinItems) || (s.MaxItems != nil && len(v) > *s.MaxItems) {
+			return false
+		}
+		for _, item := range v {
+			if s.Items != nil && !c.conforms(s.Items, item) {
+				return false
+			}
+		}
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				return false
+			}
+		}
+		for name, item := range v {
+			sub := s.Properties[name]
+			if sub == nil {
+				sub = s.AdditionalProperties
+			}
+			if sub != nil && !c.conforms(sub, item) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (c *converter) hasAnyType(value any, types jsonschema.Types) bool {
+	for _, typ := range types {
+		switch typ {
+		case "integer":
+			if f, ok := value.(float64); ok && f == float64(int64(f)) {
+				return true
+			}
+		case "number":
+			if _, ok := value.(float64); ok {
+				return true
+			}
+		case "string", "boolean", "object", "array":
+			if hasType(value, typ) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsValue(values []any, value any) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// primaryType is the Field.Type of a possibly nullable JSON Schema type.
+// A union of several types is left to Constraints.
+func primaryType(types jsonschema.Types) string {
+	primary := ""
+	for _, t := range types {
+		if t == "null" {
+			continue
+		}
+		if primary != "" {
+			return ""
+		}
+		primary = t
+	}
+	return primary
+}
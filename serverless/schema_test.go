@@ -0,0 +1,82 @@
+package serverless_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cozy-creator/runpod-go-sdk/jsonschema"
+	"github.com/cozy-creator/runpod-go-sdk/serverless"
+)
+
+type upscaleInput struct {
+	Image  string   `json:"image" validate:"required,url"`
+	Scale  int      `json:"scale" default:"2" validate:"oneof=2 4"`
+	Tiles  []tile   `json:"tiles,omitempty" validate:"max=2"`
+	Prompt *string  `json:"prompt,omitempty" validate:"max=10"`
+	Weight *float64 `json:"weight,omitempty" validate:"gt=0,lte=1"`
+}
+
+type tile struct {
+	X int `json:"x" validate:"min=0"`
+	Y int `json:"y" validate:"min=0"`
+}
+
+func TestSchemaFor(t *testing.T) {
+	schema, err := serverless.SchemaFor[upscaleInput]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := schema.Validate(json.RawMessage(`{"image":"https://x/a.png","tiles":[{"x":0,"y":1}],"weight":1}`))
+	if err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	var in upscaleInput
+	if err := json.Unmarshal(out, &in); err != nil || in.Scale != 2 || len(in.Tiles) != 1 {
+		t.Fatalf("validated input = %s, %v", out, err)
+	}
+
+	var inputErr *serverless.InputError
+	for _, bad := range []string{
+		`{}`,
+		`{"image":"a","scale":3}`,
+		`{"image":"a","tiles":[{"x":-1,"y":0}]}`,
+		`{"image":"a","tiles":[{"x":0}]}`,
+		`{"image":"a","tiles":[{"x":0,"y":0},{"x":0,"y":0},{"x":0,"y":0}]}`,
+		`{"image":"a","prompt":"far too long a prompt"}`,
+		`{"image":"a","weight":0}`,
+		`{"image":"a","unknown":1}`,
+	} {
+		if _, err := schema.Validate(json.RawMessage(bad)); !errors.As(err, &inputErr) {
+			t.Fatalf("Validate(%s) = %v", bad, err)
+		}
+	}
+
+	// A published contract converts the same way.
+	contract, err := jsonschema.Parse([]byte(`{
+		"type": "object",
+		"required": ["text"],
+		"properties": {
+			"text": {"type": "string", "pattern": "^[a-z ]+$"},
+			"voice": {"$ref": "#/$defs/voice"}
+		},
+		"$defs": {"voice": {"type": "string", "enum": ["alto", "bass"], "default": "alto"}}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tts, err := serverless.FromJSONSchema(contract)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out, err := tts.Validate(json.RawMessage(`{"text":"hello"}`)); err != nil || string(out) != `{"text":"hello","voice":"alto"}` {
+		t.Fatalf("Validate = %s, %v", out, err)
+	}
+	if _, err := tts.Validate(json.RawMessage(`{"text":"Hello!","voice":"tenor"}`)); !errors.As(err, &inputErr) || len(inputErr.Errors) != 2 {
+		t.Fatalf("Validate invalid = %v", err)
+	}
+
+	if _, err := serverless.FromJSONSchema(&jsonschema.Schema{Type: jsonschema.Types{"array"}}); err == nil {
+		t.Fatal("array schema accepted")
+	}
+}
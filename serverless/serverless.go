@@ -0,0 +1,299 @@
+// Package serverless runs RunPod serverless workers written in Go: register
+// a handler, and the worker fetches jobs from RunPod's job API, decodes
+// their input, runs the handler and posts the result or error back, with
+// heartbeats and retries of failed calls. It is the Go counterpart of
+// runpod-python's runpod.serverless.start.
+//
+// Usage:
+//
+//	func main() {
+//		serverless.Start(func(ctx context.Context, job *serverless.Job) (any, error) {
+//			var in struct{ Prompt string `json:"prompt"` }
+//			if err := job.DecodeInput(&in); err != nil {
+//				return nil, err
+//			}
+//			return map[string]string{"echo": in.Prompt}, nil
+//		})
+//	}
+package serverless
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+)
+
+// Environment variables RunPod sets on serverless workers.
+const (
+	EnvGetJobURL     = "RUNPOD_WEBHOOK_GET_JOB"
+	EnvPostOutputURL = "RUNPOD_WEBHOOK_POST_OUTPUT"
+	EnvPostStreamURL = "RUNPOD_WEBHOOK_POST_STREAM"
+	EnvPingURL       = "RUNPOD_WEBHOOK_PING"
+	EnvAIAPIKey      = "RUNPOD_AI_API_KEY"
+	EnvPodID         = "RUNPOD_POD_ID"
+	EnvEndpointID    = "RUNPOD_ENDPOINT_ID"
+	// EnvPingInterval is the heartbeat interval in milliseconds.
+	EnvPingInterval = "RUNPOD_PING_INTERVAL"
+)
+
+// Worker defaults.
+const (
+	DefaultPingInterval = 10 * time.Second
+	DefaultPostRetries  = 3
+	DefaultRetryBackoff = time.Second
+	// DefaultGracePeriod is how long a shutting-down worker lets jobs in
+	// progress finish, leaving time to report them before RunPod kills an
+	// interrupted worker.
+	DefaultGracePeriod = 20 * time.Second
+	// maxFetchBackoff caps the delay between failing job fetches.
+	maxFetchBackoff = 30 * time.Second
+)
+
+// ErrWorkerShutdown is the cause of a handler context cancelled because
+// the worker shut down, and the error reported for the jobs it left
+// unfinished.
+var ErrWorkerShutdown = errors.New("worker shut down before the job finished")
+
+// Job is a job handed to a worker.
+type Job struct {
+	ID      string          `json:"id"`
+	Input   json.RawMessage `json:"input,omitempty"`
+	Webhook string          `json:"webhook,omitempty"`
+}
+
+// DecodeInput unmarshals the job input into v.
+func (j *Job) DecodeInput(v any) error {
+	if len(j.Input) == 0 {
+		return runpod.NewValidationError("input", "job has no input")
+	}
+	if err := json.Unmarshal(j.Input, v); err != nil {
+		return runpod.NewValidationError("input", err.Error())
+	}
+	return nil
+}
+
+// Handler processes one job. The returned output is JSON-encoded into the
+// job's result; a non-nil error fails the job instead. A panic fails the
+// job without stopping the worker.
+type Handler func(ctx context.Context, job *Job) (any, error)
+
+// Result is handler output with instructions for the worker. Return it
+// (or *Result) instead of the bare output.
+type Result struct {
+	Output any
+	// RefreshWorker asks RunPod to recycle the worker once the job is
+	// reported, like runpod-python's refresh_worker: the worker stops
+	// taking jobs and Run returns after the jobs in progress finish. Use it
+	// after a model reload or a detected leak. It applies to a failed job
+	// too when the handler returns a Result along with its error.
+	RefreshWorker bool
+}
+
+// Options configures a Worker. OptionsFromEnv fills the URLs, key and
+// worker ID from the environment RunPod provides.
+type Options struct {
+	// GetJobURL, PostOutputURL, PostStreamURL and PingURL are RunPod's job
+	// API URL templates. $RUNPOD_POD_ID is replaced by the worker ID; $ID
+	// by the worker ID in GetJobURL and PingURL and by the job ID in the
+	// others.
+	GetJobURL     string
+	PostOutputURL string
+	PostStreamURL string
+	PingURL       string
+	// APIKey authenticates job API calls (RUNPOD_AI_API_KEY).
+	APIKey   string
+	WorkerID string
+	// PingInterval is the heartbeat interval; negative disables heartbeats.
+	PingInterval time.Duration
+	// Concurrency is how many jobs run at once (default 1). Values above 1
+	// suit IO-bound handlers; jobs are then fetched in batches.
+	Concurrency int
+	// ConcurrencyModifier, if set, is called before each fetch with the
+	// current limit and returns the new one (minimum 1), like
+	// runpod-python's concurrency_modifier. Use it to scale with load or
+	// free memory.
+	ConcurrencyModifier func(current int) int
+	// PostRetries is how many times posting a result is retried after a
+	// network error, 429 or 5xx.
+	PostRetries int
+	// RetryBackoff is the delay before the first retry; it doubles per
+	// attempt.
+	RetryBackoff time.Duration
+	// GracePeriod is how long jobs in progress may keep running once Run's
+	// context is done (default DefaultGracePeriod; negative cancels them at
+	// once). Handlers still running after it see their context cancelled
+	// with cause ErrWorkerShutdown.
+	GracePeriod time.Duration
+	// Requeue, if set, is called for each job the worker shut down before
+	// it finished, after the job is reported failed with ErrWorkerShutdown.
+	// The job API has no requeue call, so this is where to resubmit it,
+	// e.g. with runpod.Client.RetryJob.
+	Requeue    func(ctx context.Context, job *Job) error
+	HTTPClient *http.Client
+	Logger     runpod.Logger
+}
+
+// OptionsFromEnv reads worker options from RunPod's environment variables.
+func OptionsFromEnv() Options {
+	opts := Options{
+		GetJobURL:     os.Getenv(EnvGetJobURL),
+		PostOutputURL: os.Getenv(EnvPostOutputURL),
+		PostStreamURL: os.Getenv(EnvPostStreamURL),
+		PingURL:       os.Getenv(EnvPingURL),
+		APIKey:        os.Getenv(EnvAIAPIKey),
+		WorkerID:      os.Getenv(EnvPodID),
+	}
+	if ms, err := strconv.Atoi(os.Getenv(EnvPingInterval)); err == nil && ms > 0 {
+		opts.PingInterval = time.Duration(ms) * time.Millisecond
+	}
+	return opts
+}
+
+// Start runs handler as a RunPod worker configured from the environment
+// until SIGINT or SIGTERM, then lets jobs in progress finish within the
+// default grace period. It exits the process if the worker cannot
+// start. Run with --rp_serve_api (and optionally --rp_api_host,
+// --rp_api_port, --rp_api_concurrency) to serve a local DevServer instead.
+func Start(handler Handler) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		// A second signal kills the process without waiting.
+		<-ctx.Done()
+		stop()
+	}()
+	if addr, concurrency, ok := devServerFlags(os.Args[1:]); ok {
+		s, err := NewDevServer(handler, DevServerOptions{Concurrency: concurrency})
+		if err != nil {
+			log.Fatalf("runpod dev server: %v", err)
+		}
+		if err := s.ListenAndServe(ctx, addr); err != nil {
+			log.Fatalf("runpod dev server: %v", err)
+		}
+		return
+	}
+	w, err := NewWorker(handler, OptionsFromEnv())
+	if err != nil {
+		log.Fatalf("runpod worker: %v", err)
+	}
+	if err := w.Run(ctx); err != nil {
+		log.Fatalf("runpod worker: %v", err)
+	}
+}
+
+// jobReporter carries a handler's updates for its job back to whoever
+// runs it: the job API for a Worker, the job store for a DevServer.
+type jobReporter interface {
+	stream(ctx context.Context, output json.RawMessage) error
+	progress(ctx context.Context, output json.RawMessage) error
+}
+
+type reporterKey struct{}
+
+// Stream sends a partial output for the job handled under ctx, which
+// clients read from the endpoint's /stream route while the job runs. The
+// handler's return value is still the job's final output.
+func Stream(ctx context.Context, output any) error {
+	r, raw, err := reportArgs(ctx, output)
+	if err != nil {
+		return err
+	}
+	return r.stream(ctx, raw)
+}
+
+// SendProgress publishes a progress update for the job handled under ctx,
+// like runpod-python's progress_update: until the job finishes, clients
+// polling /status see it IN_PROGRESS with payload as its output.
+func SendProgress(ctx context.Context, payload any) error {
+	r, raw, err := reportArgs(ctx, payload)
+	if err != nil {
+		return err
+	}
+	return r.progress(ctx, raw)
+}
+
+func reportArgs(ctx context.Context, v any) (jobReporter, json.RawMessage, error) {
+	r, ok := ctx.Value(reporterKey{}).(jobReporter)
+	if !ok {
+		return nil, nil, runpod.NewValidationError("ctx", "is not a job handler context")
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode job update: %w", err)
+	}
+	return r, raw, nil
+}
+
+// runHandler calls handler and encodes its output, turning a panic into
+// an error. refresh reports whether the handler asked for a new worker.
+func runHandler(ctx context.Context, handler Handler, logger runpod.Logger, job *Job) (output json.RawMessage, refresh bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Printf("[ERROR] %s | handler panic: %v\n%s", job.ID, r, debug.Stack())
+			output, err = nil, fmt.Errorf("handler panic: %v", r)
+		}
+	}()
+	result, err := handler(context.WithValue(ctx, jobIDKey{}, job.ID), job)
+	switch r := result.(type) {
+	case Result:
+		result, refresh = r.Output, r.RefreshWorker
+	case *Result:
+		if r != nil {
+			result, refresh = r.Output, r.RefreshWorker
+		}
+	}
+	if err != nil {
+		return nil, refresh, err
+	}
+	if output, err = json.Marshal(result); err != nil {
+		return nil, refresh, fmt.Errorf("failed to encode handler output: %w", err)
+	}
+	return output, refresh, nil
+}
+
+type stdLogger struct{}
+
+func (stdLogger) Printf(format string, v ...any) { log.Printf(format, v...) }
+
+// expandURL fills a job API URL template.
+func expandURL(template, workerID, id string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(template, "$RUNPOD_POD_ID", workerID), "$ID", id)
+}
+
+// withQuery appends key=value to a URL that may already have a query.
+func withQuery(u, key, value string) string {
+	sep := "?"
+	if strings.Contains(u, "?") {
+		sep = "&"
+	}
+	return u + sep + key + "=" + value
+}
+
+// jobError is the error payload RunPod shows for a failed job, in the
+// shape runpod-python reports.
+type jobError struct {
+	Type     string `json:"error_type"`
+	Message  string `json:"error_message"`
+	Hostname string `json:"hostname,omitempty"`
+	WorkerID string `json:"worker_id,omitempty"`
+	// ValidationErrors lists the problems of an input rejected by a Schema.
+	ValidationErrors []*runpod.ValidationError `json:"validation_errors,omitempty"`
+}
+
+func encodeJobError(err error, workerID string) string {
+	hostname, _ := os.Hostname()
+	data, _ := json.Marshal(jobError{Type: fmt.Sprintf("%T", err), Message: err.Error(), Hostname: hostname, WorkerID: workerID, ValidationErrors: inputErrors(err)})
+	return string(data)
+}
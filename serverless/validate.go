@@ -0,0 +1,191 @@
+package serverless
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+)
+
+// Schema describes a job's input object by field name, like the schema
+// dicts of runpod-python's rp_validator. Fields not in the schema are
+// rejected.
+type Schema map[string]Field
+
+// Field describes one input field.
+type Field struct {
+	// Type is the field's JSON type: "string", "number", "integer",
+	// "boolean", "object" or "array". Empty accepts any type.
+	Type     string
+	Required bool
+	// Default fills the field when it is absent and not required.
+	Default any
+	// Constraints, if set, reports whether a present value is acceptable.
+	// Numbers are passed as float64, objects as map[string]any and arrays
+	// as []any.
+	Constraints func(value any) bool
+}
+
+// InputError reports every problem found validating a job input. A job
+// failed with it carries the problems in its error payload.
+type InputError struct {
+	Errors []*runpod.ValidationError
+}
+
+func (e *InputError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return "invalid input: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap exposes the individual validation errors to errors.As.
+func (e *InputError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Validate checks input against the schema and returns it with defaults
+// filled in, or an *InputError listing every problem.
+func (s Schema) Validate(input json.RawMessage) (json.RawMessage, error) {
+	var fields map[string]any
+	dec := json.NewDecoder(bytes.NewReader(input))
+	dec.UseNumber()
+	if err := dec.Decode(&fields); err != nil || fields == nil {
+		return nil, &InputError{Errors: []*runpod.ValidationError{runpod.NewValidationError("input", "must be a JSON object")}}
+	}
+
+	var problems []*runpod.ValidationError
+	for _, name := range sortedKeys(fields) {
+		if _, ok := s[name]; !ok {
+			problems = append(problems, runpod.NewValidationError(name, "is not a valid input option"))
+		}
+	}
+	for _, name := range sortedKeys(s) {
+		field := s[name]
+		value, present := fields[name]
+		if !present {
+			if field.Required {
+				problems = append(problems, runpod.NewValidationError(name, "is required"))
+			} else if field.Default != nil {
+				fields[name] = field.Default
+			}
+			continue
+		}
+		if value == nil {
+			if field.Required {
+				problems = append(problems, runpod.NewValidationError(name, "cannot be null"))
+			}
+			continue
+		}
+		if !hasType(value, field.Type) {
+			problems = append(problems, runpod.NewValidationErrorWithValue(name, "must be of type "+field.Type, value))
+			continue
+		}
+		if field.Constraints != nil && !field.Constraints(plainValue(value)) {
+			problems = append(problems, runpod.NewValidationErrorWithValue(name, "does not meet the constraints", value))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, &InputError{Errors: problems}
+	}
+	out, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode validated input: %w", err)
+	}
+	return out, nil
+}
+
+// Validated wraps handler so that each job's input is checked against
+// schema first. The handler sees the input with defaults filled in;
+// invalid input fails the job with an *InputError without calling it.
+func Validated(schema Schema, handler Handler) Handler {
+	return func(ctx context.Context, job *Job) (any, error) {
+		input, err := schema.Validate(job.Input)
+		if err != nil {
+			return nil, err
+		}
+		validated := *job
+		validated.Input = input
+		return handler(ctx, &validated)
+	}
+}
+
+// inputErrors returns the validation problems behind err, if any.
+func inputErrors(err error) []*runpod.ValidationError {
+	var inputErr *InputError
+	if errors.As(err, &inputErr) {
+		return inputErr.Errors
+	}
+	return nil
+}
+
+func hasType(value any, typ string) bool {
+	switch typ {
+	case "":
+		return true
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(json.Number)
+		return ok
+	case "integer":
+		n, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		_, err := n.Int64()
+		return err == nil
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	}
+	return false
+}
+
+// plainValue converts json.Numbers, including nested ones, to float64.
+func plainValue(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			out[k] = plainValue(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = plainValue(item)
+		}
+		return out
+	}
+	return value
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
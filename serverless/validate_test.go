@@ -0,0 +1,94 @@
+package serverless_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/serverless"
+)
+
+var promptSchema = serverless.Schema{
+	"prompt": {Type: "string", Required: true},
+	"steps": {Type: "integer", Default: 30, Constraints: func(v any) bool {
+		return v.(float64) >= 1 && v.(float64) <= 150
+	}},
+	"seed": {Type: "integer"},
+}
+
+func TestSchemaValidate(t *testing.T) {
+	out, err := promptSchema.Validate(json.RawMessage(`{"prompt":"a cat"}`))
+	if err != nil || string(out) != `{"prompt":"a cat","steps":30}` {
+		t.Fatalf("Validate = %s, %v", out, err)
+	}
+
+	_, err = promptSchema.Validate(json.RawMessage(`{"steps":2.5,"seed":"x","extra":true}`))
+	var inputErr *serverless.InputError
+	if !errors.As(err, &inputErr) {
+		t.Fatalf("Validate invalid = %v", err)
+	}
+	fields := map[string]bool{}
+	for _, problem := range inputErr.Errors {
+		fields[problem.Field] = true
+	}
+	for _, want := range []string{"extra", "prompt", "steps", "seed"} {
+		if !fields[want] {
+			t.Fatalf("missing problem for %s: %v", want, err)
+		}
+	}
+	var validationErr *runpod.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("InputError does not unwrap: %v", err)
+	}
+
+	if _, err := promptSchema.Validate(json.RawMessage(`{"prompt":"x","steps":500}`)); !errors.As(err, &inputErr) || inputErr.Errors[0].Field != "steps" {
+		t.Fatalf("constraint = %v", err)
+	}
+	if _, err := promptSchema.Validate(json.RawMessage(`[1]`)); !errors.As(err, &inputErr) {
+		t.Fatalf("non-object = %v", err)
+	}
+}
+
+func TestValidatedHandler(t *testing.T) {
+	handler := serverless.Validated(promptSchema, func(ctx context.Context, job *serverless.Job) (any, error) {
+		var in struct {
+			Prompt string `json:"prompt"`
+			Steps  int    `json:"steps"`
+		}
+		if err := job.DecodeInput(&in); err != nil {
+			return nil, err
+		}
+		return in, nil
+	})
+	dev, err := serverless.NewDevServer(handler, serverless.DevServerOptions{Logger: quietLogger{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(dev)
+	t.Cleanup(func() {
+		srv.Close()
+		dev.Close()
+	})
+	rp, err := runpod.NewClient("test-key", runpod.WithServerlessBaseURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	job, err := rp.RunSync(t.Context(), "local", map[string]string{"prompt": "a cat"})
+	if err != nil || string(job.Output) != `{"prompt":"a cat","steps":30}` {
+		t.Fatalf("valid job = %+v, %v", job, err)
+	}
+	job, err = rp.RunSync(t.Context(), "local", map[string]int{"steps": 5})
+	if err != nil || job.Status != string(runpod.JobStatusFailed) {
+		t.Fatalf("invalid job = %+v, %v", job, err)
+	}
+	var payload struct {
+		ValidationErrors []runpod.ValidationError `json:"validation_errors"`
+	}
+	if err := json.Unmarshal([]byte(job.Error), &payload); err != nil || len(payload.ValidationErrors) != 1 || payload.ValidationErrors[0].Field != "prompt" {
+		t.Fatalf("error payload = %s", job.Error)
+	}
+}
@@ -0,0 +1,451 @@
+package serverless
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+)
+
+// Worker fetches and runs jobs. Create it with NewWorker.
+type Worker struct {
+	handler Handler
+	opts    Options
+	http    *http.Client
+	logger  runpod.Logger
+
+	mu          sync.Mutex
+	active      map[string]*Job
+	concurrency int
+	// stopFetching ends the fetch loop once a job asks for a refresh.
+	stopFetching context.CancelFunc
+	// slotFreed is signalled when a job finishes.
+	slotFreed chan struct{}
+}
+
+// NewWorker validates opts and returns a worker for handler.
+func NewWorker(handler Handler, opts Options) (*Worker, error) {
+	if handler == nil {
+		return nil, runpod.NewValidationError("handler", "cannot be nil")
+	}
+	for field, value := range map[string]string{"getJobUrl": opts.GetJobURL, "postOutputUrl": opts.PostOutputURL} {
+		if strings.TrimSpace(value) == "" {
+			return nil, runpod.NewValidationError(field, "cannot be empty (is this running on RunPod?)")
+		}
+		if _, err := url.Parse(value); err != nil {
+			return nil, runpod.NewValidationErrorWithValue(field, "must be a URL", value)
+		}
+	}
+	if opts.WorkerID == "" {
+		opts.WorkerID = "local"
+	}
+	if opts.PingInterval == 0 {
+		opts.PingInterval = DefaultPingInterval
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+	if opts.PostRetries == 0 {
+		opts.PostRetries = DefaultPostRetries
+	}
+	if opts.PostRetries < 0 {
+		opts.PostRetries = 0
+	}
+	if opts.RetryBackoff <= 0 {
+		opts.RetryBackoff = DefaultRetryBackoff
+	}
+	if opts.GracePeriod == 0 {
+		opts.GracePeriod = DefaultGracePeriod
+	}
+	w := &Worker{
+		handler:     handler,
+		opts:        opts,
+		http:        opts.HTTPClient,
+		logger:      opts.Logger,
+		active:      map[string]*Job{},
+		concurrency: opts.Concurrency,
+		slotFreed:   make(chan struct{}, 1),
+	}
+	if w.http == nil {
+		w.http = &http.Client{}
+	}
+	if w.logger == nil {
+		w.logger = stdLogger{}
+	}
+	return w, nil
+}
+
+// Run fetches and handles jobs until ctx is done or a job asks for a
+// worker refresh, then stops fetching, waits for the jobs in progress and
+// returns nil. Once ctx is done, jobs get Options.GracePeriod to finish;
+// the rest are cancelled and reported failed with ErrWorkerShutdown.
+// Fetch failures are logged and retried with backoff.
+func (w *Worker) Run(ctx context.Context) error {
+	w.logger.Printf("[INFO] worker %s started", w.opts.WorkerID)
+	// Heartbeats and jobs outlive ctx until the jobs are done.
+	pingCtx, stopPing := context.WithCancel(context.WithoutCancel(ctx))
+	defer stopPing()
+	jobCtx, cancelJobs := context.WithCancelCause(context.WithoutCancel(ctx))
+	defer cancelJobs(nil)
+	if w.opts.PingURL != "" && w.opts.PingInterval > 0 {
+		go w.heartbeat(pingCtx)
+	}
+	fetchCtx, stopFetching := context.WithCancel(ctx)
+	defer stopFetching()
+	w.mu.Lock()
+	w.stopFetching = stopFetching
+	w.mu.Unlock()
+
+	var jobs sync.WaitGroup
+	defer w.drain(ctx, &jobs, cancelJobs)
+	backoff := w.opts.RetryBackoff
+	for fetchCtx.Err() == nil {
+		free := w.updateConcurrency() - w.activeCount()
+		if free <= 0 {
+			select {
+			case <-w.slotFreed:
+			case <-fetchCtx.Done():
+			}
+			continue
+		}
+		batch, err := w.fetchJobs(fetchCtx, free)
+		if err != nil {
+			if fetchCtx.Err() != nil {
+				break
+			}
+			w.logger.Printf("[ERROR] failed to fetch job, retrying in %s: %v", backoff, err)
+			if sleepCtx(fetchCtx, backoff) != nil {
+				break
+			}
+			backoff = min(backoff*2, maxFetchBackoff)
+			continue
+		}
+		backoff = w.opts.RetryBackoff
+		for _, job := range batch {
+			w.begin(job)
+			jobs.Add(1)
+			go func() {
+				defer jobs.Done()
+				defer w.finish(job)
+				w.runJob(jobCtx, job)
+			}()
+		}
+	}
+	return nil
+}
+
+// drain waits for the jobs in progress. If the worker is shutting down
+// rather than refreshing, jobs still running after the grace period are
+// cancelled.
+func (w *Worker) drain(ctx context.Context, jobs *sync.WaitGroup, cancelJobs context.CancelCauseFunc) {
+	done := make(chan struct{})
+	go func() {
+		jobs.Wait()
+		close(done)
+	}()
+	if n := w.activeCount(); ctx.Err() != nil && n > 0 {
+		grace := max(w.opts.GracePeriod, 0)
+		w.logger.Printf("[INFO] worker %s shutting down; waiting up to %s for %d jobs", w.opts.WorkerID, grace, n)
+		timer := time.NewTimer(grace)
+		defer timer.Stop()
+		select {
+		case <-done:
+		case <-timer.C:
+			w.logger.Printf("[WARN] grace period over; cancelling %s", strings.Join(w.activeIDs(), ", "))
+			cancelJobs(ErrWorkerShutdown)
+		}
+	}
+	<-done
+	w.logger.Printf("[INFO] worker %s stopped", w.opts.WorkerID)
+}
+
+// fetchJobs asks the job API for up to n jobs. No job is not an error.
+func (w *Worker) fetchJobs(ctx context.Context, n int) ([]*Job, error) {
+	u := expandURL(w.opts.GetJobURL, w.opts.WorkerID, w.opts.WorkerID)
+	if n > 1 {
+		// Batches come from the sibling job-take-batch route.
+		u = withQuery(strings.Replace(u, "/job-take/", "/job-take-batch/", 1), "batch_size", strconv.Itoa(n))
+	}
+	inProgress := "0"
+	if w.activeCount() > 0 {
+		inProgress = "1"
+	}
+	u = withQuery(u, "job_in_progress", inProgress)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build job request: %w", err)
+	}
+	w.authorize(req)
+	resp, err := w.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read job: %w", err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusNoContent:
+		return nil, nil
+	case resp.StatusCode == http.StatusBadRequest:
+		// The job API answers 400 when it has nothing for this worker.
+		return nil, nil
+	case resp.StatusCode >= 300:
+		return nil, fmt.Errorf("job API returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return decodeJobs(body)
+}
+
+// decodeJobs accepts a single job object or a batch array.
+func decodeJobs(body []byte) ([]*Job, error) {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 || string(body) == "{}" || string(body) == "null" {
+		return nil, nil
+	}
+	var jobs []*Job
+	if body[0] == '[' {
+		if err := json.Unmarshal(body, &jobs); err != nil {
+			return nil, fmt.Errorf("failed to decode jobs: %w", err)
+		}
+	} else {
+		var job Job
+		if err := json.Unmarshal(body, &job); err != nil {
+			return nil, fmt.Errorf("failed to decode job: %w", err)
+		}
+		jobs = []*Job{&job}
+	}
+	valid := jobs[:0]
+	for _, job := range jobs {
+		if job != nil && job.ID != "" {
+			valid = append(valid, job)
+		}
+	}
+	return valid, nil
+}
+
+// begin marks job in progress; finish releases its slot.
+func (w *Worker) begin(job *Job) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.active[job.ID] = job
+}
+
+func (w *Worker) finish(job *Job) {
+	w.mu.Lock()
+	delete(w.active, job.ID)
+	w.mu.Unlock()
+	select {
+	case w.slotFreed <- struct{}{}:
+	default:
+	}
+}
+
+// updateConcurrency applies the concurrency modifier and returns the
+// current limit.
+func (w *Worker) updateConcurrency() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.opts.ConcurrencyModifier != nil {
+		if n := w.opts.ConcurrencyModifier(w.concurrency); n != w.concurrency {
+			w.concurrency = max(n, 1)
+		}
+	}
+	return w.concurrency
+}
+
+// runJob runs the handler for job and posts its result.
+func (w *Worker) runJob(ctx context.Context, job *Job) {
+	start := time.Now()
+	w.logger.Printf("[INFO] %s | started", job.ID)
+	ctx = context.WithValue(ctx, reporterKey{}, workerReporter{w: w, job: job})
+	output, refresh, err := runHandler(ctx, w.handler, w.logger, job)
+	payload := map[string]any{"output": output}
+	if err != nil {
+		w.logger.Printf("[ERROR] %s | failed after %s: %v", job.ID, time.Since(start).Round(time.Millisecond), err)
+		payload = map[string]any{"error": encodeJobError(err, w.opts.WorkerID)}
+	} else {
+		w.logger.Printf("[INFO] %s | finished in %s", job.ID, time.Since(start).Round(time.Millisecond))
+	}
+	unfinished := err != nil && errors.Is(context.Cause(ctx), ErrWorkerShutdown)
+	if unfinished {
+		payload = map[string]any{"error": encodeJobError(ErrWorkerShutdown, w.opts.WorkerID)}
+	}
+	if refresh {
+		// stopPod tells RunPod to replace this worker.
+		payload["stopPod"] = true
+		w.logger.Printf("[INFO] %s | requested a worker refresh; taking no more jobs", job.ID)
+		w.mu.Lock()
+		if w.stopFetching != nil {
+			w.stopFetching()
+		}
+		w.mu.Unlock()
+	}
+	if err := w.postResult(ctx, job, w.opts.PostOutputURL, false, payload); err != nil {
+		w.logger.Printf("[ERROR] %s | failed to post result: %v", job.ID, err)
+	}
+	if unfinished && w.opts.Requeue != nil {
+		requeueCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+		defer cancel()
+		if err := w.opts.Requeue(requeueCtx, job); err != nil {
+			w.logger.Printf("[ERROR] %s | failed to requeue: %v", job.ID, err)
+		} else {
+			w.logger.Printf("[INFO] %s | requeued", job.ID)
+		}
+	}
+}
+
+// workerReporter sends a handler's updates through the job API.
+type workerReporter struct {
+	w   *Worker
+	job *Job
+}
+
+func (r workerReporter) stream(ctx context.Context, output json.RawMessage) error {
+	if r.w.opts.PostStreamURL == "" {
+		return runpod.NewValidationError("postStreamUrl", "cannot be empty to stream output")
+	}
+	return r.w.postResult(ctx, r.job, r.w.opts.PostStreamURL, true, map[string]any{"output": output})
+}
+
+func (r workerReporter) progress(ctx context.Context, output json.RawMessage) error {
+	return r.w.postResult(ctx, r.job, r.w.opts.PostOutputURL, false, map[string]any{"status": runpod.JobStatusInProgress, "output": output})
+}
+
+// postResult sends payload for job to a job API URL template, retrying
+// transient failures. It outlives ctx so that a worker shutting down still
+// reports what it finished.
+func (w *Worker) postResult(ctx context.Context, job *Job, template string, stream bool, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode result: %w", err)
+	}
+	u := withQuery(expandURL(template, w.opts.WorkerID, job.ID), "isStream", fmt.Sprint(stream))
+	postCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 2*time.Minute)
+	defer cancel()
+
+	backoff := w.opts.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		err = w.post(postCtx, u, body)
+		var status *statusError
+		retryable := err != nil && (!errors.As(err, &status) || status.code == http.StatusTooManyRequests || status.code >= 500)
+		if !retryable || attempt >= w.opts.PostRetries {
+			return err
+		}
+		w.logger.Printf("[WARN] %s | posting result failed, retrying in %s: %v", job.ID, backoff, err)
+		if err := sleepCtx(postCtx, backoff); err != nil {
+			return err
+		}
+		backoff *= 2
+	}
+}
+
+type statusError struct {
+	code int
+	body string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("job API returned %d: %s", e.code, e.body)
+}
+
+func (w *Worker) post(ctx context.Context, u string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build result request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	w.authorize(req)
+	resp, err := w.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		text, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+		return &statusError{code: resp.StatusCode, body: strings.TrimSpace(string(text))}
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// heartbeat pings the job API with the jobs in progress, which keeps them
+// from being handed to another worker.
+func (w *Worker) heartbeat(ctx context.Context) {
+	ticker := time.NewTicker(w.opts.PingInterval)
+	defer ticker.Stop()
+	for {
+		w.ping(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Worker) ping(ctx context.Context) {
+	u := expandURL(w.opts.PingURL, w.opts.WorkerID, w.opts.WorkerID)
+	if ids := w.activeIDs(); len(ids) > 0 {
+		u = withQuery(u, "job_id", url.QueryEscape(strings.Join(ids, ",")))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return
+	}
+	w.authorize(req)
+	resp, err := w.http.Do(req)
+	if err != nil {
+		if ctx.Err() == nil {
+			w.logger.Printf("[WARN] heartbeat failed: %v", err)
+		}
+		return
+	}
+	resp.Body.Close()
+}
+
+func (w *Worker) authorize(req *http.Request) {
+	if w.opts.APIKey != "" {
+		req.Header.Set("Authorization", w.opts.APIKey)
+	}
+}
+
+func (w *Worker) activeCount() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.active)
+}
+
+func (w *Worker) activeIDs() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	ids := make([]string, 0, len(w.active))
+	for id := range w.active {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// sleepCtx waits for d or until ctx is done.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,271 @@
+package serverless_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/serverless"
+)
+
+func TestWorkerRunsJobs(t *testing.T) {
+	api := newFakeJobAPI(t)
+	api.enqueue("job-ok", `{"prompt":"hi"}`)
+	api.enqueue("job-err", `{"prompt":"fail"}`)
+	api.enqueue("job-panic", `{"prompt":"panic"}`)
+	api.enqueue("job-bad", `"not an object"`)
+	// The first result post hits a transient failure and is retried.
+	api.failNextPosts(http.StatusBadGateway)
+
+	handler := func(ctx context.Context, job *serverless.Job) (any, error) {
+		var in struct {
+			Prompt string `json:"prompt"`
+		}
+		if err := job.DecodeInput(&in); err != nil {
+			return nil, err
+		}
+		switch in.Prompt {
+		case "fail":
+			return nil, errors.New("model exploded")
+		case "panic":
+			panic("nil map")
+		}
+		if err := serverless.Stream(ctx, "partial "+in.Prompt); err != nil {
+			return nil, err
+		}
+		if err := serverless.SendProgress(ctx, map[string]int{"percent": 50}); err != nil {
+			return nil, err
+		}
+		return map[string]string{"echo": in.Prompt, "job": job.ID}, nil
+	}
+	w, err := serverless.NewWorker(handler, api.options())
+	if err != nil {
+		t.Fatalf("NewWorker: %v", err)
+	}
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error, 1)
+	go func() { done <- w.Run(ctx) }()
+	api.waitFinished(t, 4)
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	output, _ := json.Marshal(api.result("job-ok")["output"])
+	if string(output) != `{"echo":"hi","job":"job-ok"}` {
+		t.Fatalf("job-ok output = %s", output)
+	}
+	api.mu.Lock()
+	stream := api.streams["job-ok"]
+	api.mu.Unlock()
+	if len(stream) != 1 || string(stream[0]) != `"partial hi"` {
+		t.Fatalf("job-ok stream = %q", stream)
+	}
+	api.mu.Lock()
+	posts := api.results["job-ok"]
+	api.mu.Unlock()
+	if len(posts) != 2 || posts[0]["status"] != "IN_PROGRESS" {
+		t.Fatalf("job-ok posts = %v", posts)
+	}
+	if progress, _ := json.Marshal(posts[0]["output"]); string(progress) != `{"percent":50}` {
+		t.Fatalf("progress = %s", progress)
+	}
+	if err := serverless.Stream(t.Context(), "x"); err == nil {
+		t.Fatal("Stream outside a handler should fail")
+	}
+	for id, want := range map[string]string{"job-err": "model exploded", "job-panic": "handler panic: nil map", "job-bad": "input"} {
+		errText, _ := api.result(id)["error"].(string)
+		var info struct {
+			Type     string `json:"error_type"`
+			Message  string `json:"error_message"`
+			WorkerID string `json:"worker_id"`
+		}
+		if err := json.Unmarshal([]byte(errText), &info); err != nil || !strings.Contains(info.Message, want) || info.WorkerID != "worker-1" {
+			t.Fatalf("%s error = %q", id, errText)
+		}
+	}
+
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	for _, auth := range api.auth {
+		if auth != "ai-key" {
+			t.Fatalf("authorization = %q", auth)
+		}
+	}
+	if len(api.pings) == 0 {
+		t.Fatal("no heartbeats sent")
+	}
+}
+
+func TestNewWorkerOptions(t *testing.T) {
+	handler := func(context.Context, *serverless.Job) (any, error) { return nil, nil }
+	var validationErr *runpod.ValidationError
+	if _, err := serverless.NewWorker(nil, serverless.Options{GetJobURL: "http://x", PostOutputURL: "http://x"}); !errors.As(err, &validationErr) {
+		t.Fatalf("nil handler: %v", err)
+	}
+	if _, err := serverless.NewWorker(handler, serverless.Options{}); !errors.As(err, &validationErr) {
+		t.Fatalf("missing URLs: %v", err)
+	}
+
+	t.Setenv(serverless.EnvGetJobURL, "https://api.runpod.ai/v2/ep/job-take/$RUNPOD_POD_ID")
+	t.Setenv(serverless.EnvPostOutputURL, "https://api.runpod.ai/v2/ep/job-done/$RUNPOD_POD_ID/$ID")
+	t.Setenv(serverless.EnvAIAPIKey, "ai-key")
+	t.Setenv(serverless.EnvPodID, "pod-7")
+	t.Setenv(serverless.EnvPingInterval, "2500")
+	opts := serverless.OptionsFromEnv()
+	if opts.WorkerID != "pod-7" || opts.APIKey != "ai-key" || opts.PingInterval != 2500*time.Millisecond {
+		t.Fatalf("options = %+v", opts)
+	}
+	if _, err := serverless.NewWorker(handler, opts); err != nil {
+		t.Fatalf("NewWorker from env: %v", err)
+	}
+}
+
+func TestWorkerConcurrency(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		opts     func(*serverless.Options)
+		wantPeak int32
+	}{
+		{"fixed", func(o *serverless.Options) { o.Concurrency = 3 }, 3},
+		{"modifier", func(o *serverless.Options) {
+			o.ConcurrencyModifier = func(current int) int { return min(current+1, 2) }
+		}, 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			api := newFakeJobAPI(t)
+			for i := range 6 {
+				api.enqueue(fmt.Sprintf("job-%d", i), `{}`)
+			}
+			var running, peak atomic.Int32
+			handler := func(ctx context.Context, job *serverless.Job) (any, error) {
+				n := running.Add(1)
+				defer running.Add(-1)
+				for {
+					old := peak.Load()
+					if n <= old || peak.CompareAndSwap(old, n) {
+						break
+					}
+				}
+				time.Sleep(30 * time.Millisecond)
+				return job.ID, nil
+			}
+			opts := api.options()
+			tc.opts(&opts)
+			w, err := serverless.NewWorker(handler, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx, cancel := context.WithCancel(t.Context())
+			done := make(chan error, 1)
+			go func() { done <- w.Run(ctx) }()
+			api.waitFinished(t, 6)
+			cancel()
+			<-done
+			if got := peak.Load(); got != tc.wantPeak {
+				t.Fatalf("peak concurrency = %d, want %d", got, tc.wantPeak)
+			}
+			api.mu.Lock()
+			defer api.mu.Unlock()
+			if len(api.batches) == 0 {
+				t.Fatal("no batch fetches")
+			}
+		})
+	}
+}
+
+func TestWorkerRefresh(t *testing.T) {
+	api := newFakeJobAPI(t)
+	api.enqueue("job-1", `{}`)
+	api.enqueue("job-2", `{}`)
+	handler := func(ctx context.Context, job *serverless.Job) (any, error) {
+		return &serverless.Result{Output: "reloaded", RefreshWorker: true}, nil
+	}
+	w, err := serverless.NewWorker(handler, api.options())
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- w.Run(t.Context()) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after a refresh request")
+	}
+	result := api.result("job-1")
+	if result["output"] != "reloaded" || result["stopPod"] != true {
+		t.Fatalf("job-1 result = %v", result)
+	}
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	if len(api.queue) != 1 {
+		t.Fatalf("queue = %v, want job-2 left for another worker", api.queue)
+	}
+}
+
+func TestWorkerGracefulShutdown(t *testing.T) {
+	api := newFakeJobAPI(t)
+	api.enqueue("job-fast", `{}`)
+	api.enqueue("job-slow", `{}`)
+	var started sync.WaitGroup
+	started.Add(2)
+	release := make(chan struct{})
+	var cause atomic.Value
+	handler := func(ctx context.Context, job *serverless.Job) (any, error) {
+		started.Done()
+		if job.ID == "job-fast" {
+			<-release
+			// Shutdown alone does not cancel a job in its grace period.
+			return "done", ctx.Err()
+		}
+		<-ctx.Done()
+		cause.Store(context.Cause(ctx))
+		return nil, ctx.Err()
+	}
+	var requeued []string
+	opts := api.options()
+	opts.Concurrency = 2
+	opts.GracePeriod = 100 * time.Millisecond
+	opts.Requeue = func(ctx context.Context, job *serverless.Job) error {
+		requeued = append(requeued, job.ID)
+		return nil
+	}
+	w, err := serverless.NewWorker(handler, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error, 1)
+	go func() { done <- w.Run(ctx) }()
+	started.Wait()
+	cancel()
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if result := api.result("job-fast"); result["output"] != "done" {
+		t.Fatalf("job-fast result = %v", result)
+	}
+	errText, _ := api.result("job-slow")["error"].(string)
+	if !strings.Contains(errText, serverless.ErrWorkerShutdown.Error()) {
+		t.Fatalf("job-slow error = %q", errText)
+	}
+	if got, _ := cause.Load().(error); !errors.Is(got, serverless.ErrWorkerShutdown) {
+		t.Fatalf("handler context cause = %v", got)
+	}
+	if len(requeued) != 1 || requeued[0] != "job-slow" {
+		t.Fatalf("requeued = %v", requeued)
+	}
+}
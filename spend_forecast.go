@@ -0,0 +1,223 @@
+package runpod
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultSpendTrendWindow is the SpendForecaster's TrendWindow when unset.
+const DefaultSpendTrendWindow = 24 * time.Hour
+
+// SpendForecastOptions configures a SpendForecaster.
+type SpendForecastOptions struct {
+	// MonthToDate is what the account has spent this month before the
+	// first Forecast, from your billing export; the API does not report
+	// it. Spend after that is accumulated from CurrentSpendPerHr as the
+	// forecaster observes it.
+	MonthToDate float64
+	// TrendWindow is how far back each endpoint's observed spend rate is
+	// averaged (DefaultSpendTrendWindow when zero). Serverless spend
+	// follows traffic, so an average says more about the rest of the
+	// month than the workers running at one instant.
+	TrendWindow time.Duration
+	// Location sets where months begin and end; UTC when nil.
+	Location *time.Location
+}
+
+// SpendForecastLine is one resource's share of a forecast.
+type SpendForecastLine struct {
+	ResourceType string `json:"resourceType"` // CostResourcePod or CostResourceEndpoint
+	ResourceID   string `json:"resourceId"`
+	Name         string `json:"name,omitempty"`
+	// PerHour is the current rate; TrendPerHour the rate projected for the
+	// rest of the month, which for endpoints is the average over the trend
+	// window and for pods is PerHour.
+	PerHour      float64 `json:"perHour"`
+	TrendPerHour float64 `json:"trendPerHour"`
+	// Projected is TrendPerHour over the hours left in the month.
+	Projected float64 `json:"projected"`
+}
+
+// SpendForecast projects the account's spend to the end of the month.
+type SpendForecast struct {
+	At       time.Time   `json:"at"`
+	MonthEnd time.Time   `json:"monthEnd"`
+	Account  AccountInfo `json:"account"`
+	// HoursRemaining is the time from At to MonthEnd.
+	HoursRemaining float64 `json:"hoursRemaining"`
+	// OtherPerHr is the part of the account's CurrentSpendPerHr not
+	// attributed to running pods or workers, such as volume storage; it
+	// is projected as it is.
+	OtherPerHr float64 `json:"otherPerHr"`
+	// ProjectedPerHr is the rate projected for the rest of the month: the
+	// lines' TrendPerHour plus OtherPerHr.
+	ProjectedPerHr float64 `json:"projectedPerHr"`
+	// SpentToDate is MonthToDate plus the spend observed since.
+	SpentToDate float64 `json:"spentToDate"`
+	// Projected is the month's expected total: SpentToDate plus
+	// ProjectedPerHr over HoursRemaining.
+	Projected float64             `json:"projected"`
+	Lines     []SpendForecastLine `json:"lines"`
+}
+
+// SpendForecaster projects end-of-month spend from the account's current
+// burn rate, its running pods and the trend of its endpoints' worker
+// spend. Call Forecast on an interval: each call is also an observation,
+// and the endpoint trends and the month-to-date total improve as they
+// accumulate. WatchBalance takes one for its MaxMonthlySpend alert.
+//
+// A SpendForecaster is safe for concurrent use.
+type SpendForecaster struct {
+	client *Client
+	window time.Duration
+	loc    *time.Location
+
+	mu        sync.Mutex
+	spent     float64
+	lastAt    time.Time
+	lastRate  float64
+	endpoints map[string][]spendSample
+}
+
+type spendSample struct {
+	at      time.Time
+	perHour float64
+}
+
+// NewSpendForecaster returns a forecaster; opts may be nil.
+func (c *Client) NewSpendForecaster(opts *SpendForecastOptions) (*SpendForecaster, error) {
+	o := SpendForecastOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.MonthToDate < 0 {
+		return nil, NewValidationErrorWithValue("monthToDate", "cannot be negative", o.MonthToDate)
+	}
+	if o.TrendWindow < 0 {
+		return nil, NewValidationErrorWithValue("trendWindow", "cannot be negative", o.TrendWindow)
+	}
+	if o.TrendWindow == 0 {
+		o.TrendWindow = DefaultSpendTrendWindow
+	}
+	if o.Location == nil {
+		o.Location = time.UTC
+	}
+	return &SpendForecaster{
+		client:    c,
+		window:    o.TrendWindow,
+		loc:       o.Location,
+		spent:     o.MonthToDate,
+		endpoints: map[string][]spendSample{},
+	}, nil
+}
+
+// Forecast reads the account, its pods and its endpoints' workers, and
+// projects the month's spend.
+func (f *SpendForecaster) Forecast(ctx context.Context) (*SpendForecast, error) {
+	account, err := f.client.Whoami(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to forecast spend: %w", err)
+	}
+	pods, err := f.client.ListPods(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to forecast spend: %w", err)
+	}
+	endpoints, err := f.client.ListEndpoints(ctx, &GetEndpointOptions{IncludeWorkers: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to forecast spend: %w", err)
+	}
+
+	now := f.client.clock.Now().In(f.loc)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, f.loc)
+	monthEnd := monthStart.AddDate(0, 1, 0)
+	forecast := &SpendForecast{
+		At:             now,
+		MonthEnd:       monthEnd,
+		Account:        *account,
+		HoursRemaining: roundCost(monthEnd.Sub(now).Hours()),
+	}
+	hours := monthEnd.Sub(now).Hours()
+
+	attributed := 0.0
+	for _, pod := range pods {
+		if perHour := runningCostPerHr(pod); perHour > 0 {
+			attributed += perHour
+			forecast.Lines = append(forecast.Lines, SpendForecastLine{
+				ResourceType: CostResourcePod,
+				ResourceID:   pod.ID,
+				Name:         pod.Name,
+				PerHour:      perHour,
+				TrendPerHour: perHour,
+			})
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, endpoint := range endpoints {
+		perHour := 0.0
+		for _, worker := range endpoint.Workers {
+			if worker != nil {
+				perHour += runningCostPerHr(worker)
+			}
+		}
+		attributed += perHour
+		trend := f.observeEndpoint(endpoint.ID, now, perHour)
+		if perHour > 0 || trend > 0 {
+			forecast.Lines = append(forecast.Lines, SpendForecastLine{
+				ResourceType: CostResourceEndpoint,
+				ResourceID:   endpoint.ID,
+				Name:         endpoint.Name,
+				PerHour:      roundCost(perHour),
+				TrendPerHour: roundCost(trend),
+			})
+		}
+	}
+	f.observeSpend(now, monthStart, account.CurrentSpendPerHr)
+
+	forecast.OtherPerHr = roundCost(max(0, account.CurrentSpendPerHr-attributed))
+	projectedPerHr := forecast.OtherPerHr
+	for i := range forecast.Lines {
+		line := &forecast.Lines[i]
+		line.Projected = roundCost(line.TrendPerHour * hours)
+		projectedPerHr += line.TrendPerHour
+	}
+	sort.SliceStable(forecast.Lines, func(i, j int) bool { return forecast.Lines[i].Projected > forecast.Lines[j].Projected })
+	forecast.ProjectedPerHr = roundCost(projectedPerHr)
+	forecast.SpentToDate = roundCost(f.spent)
+	forecast.Projected = roundCost(f.spent + projectedPerHr*hours)
+	return forecast, nil
+}
+
+// observeEndpoint records an endpoint's spend rate and returns its average
+// over the trend window. f.mu is held.
+func (f *SpendForecaster) observeEndpoint(endpointID string, now time.Time, perHour float64) float64 {
+	samples := append(f.endpoints[endpointID], spendSample{at: now, perHour: perHour})
+	cutoff := now.Add(-f.window)
+	for len(samples) > 1 && samples[0].at.Before(cutoff) {
+		samples = samples[1:]
+	}
+	f.endpoints[endpointID] = samples
+	sum := 0.0
+	for _, s := range samples {
+		sum += s.perHour
+	}
+	return sum / float64(len(samples))
+}
+
+// observeSpend adds the spend since the last observation, at the rate
+// seen then, to the month's total, starting over when a month begins.
+// f.mu is held.
+func (f *SpendForecaster) observeSpend(now, monthStart time.Time, perHour float64) {
+	switch {
+	case f.lastAt.IsZero():
+	case f.lastAt.Before(monthStart):
+		f.spent = f.lastRate * now.Sub(monthStart).Hours()
+	case now.After(f.lastAt):
+		f.spent += f.lastRate * now.Sub(f.lastAt).Hours()
+	}
+	f.lastAt, f.lastRate = now, perHour
+}
@@ -0,0 +1,94 @@
+package runpod_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestSpendForecaster(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	clock := runpodtest.NewClock(time.Date(2026, 3, 30, 0, 0, 0, 0, time.UTC))
+	client := srv.MustClient(runpod.WithClock(clock))
+	ctx := t.Context()
+
+	// $3.50/hr: a $2 pod, a $1 worker and $0.50 of storage.
+	srv.SetAccount(runpod.AccountInfo{ID: "acct", CurrentSpendPerHr: 3.5})
+	srv.AddPod(&runpod.Pod{ID: "train", Name: "train", DesiredStatus: "RUNNING", CostPerHour: 2})
+	srv.AddPod(&runpod.Pod{ID: "idle", DesiredStatus: "EXITED", CostPerHour: 5})
+	srv.AddEndpoint(&runpod.Endpoint{ID: "sd", Name: "sd", Workers: []*runpod.Pod{{ID: "w1", DesiredStatus: "RUNNING", CostPerHour: 1}}})
+
+	forecaster, err := client.NewSpendForecaster(&runpod.SpendForecastOptions{MonthToDate: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := forecaster.Forecast(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.HoursRemaining != 48 || f.OtherPerHr != 0.5 || f.ProjectedPerHr != 3.5 || f.SpentToDate != 100 || f.Projected != 268 || len(f.Lines) != 2 {
+		t.Fatalf("forecast = %+v", f)
+	}
+
+	// Twelve hours on the worker has scaled down: the endpoint's trend
+	// halves, and the spend at the earlier rate is added to the month.
+	clock.Advance(12 * time.Hour)
+	srv.SetAccount(runpod.AccountInfo{ID: "acct", CurrentSpendPerHr: 2.5})
+	srv.AddEndpoint(&runpod.Endpoint{ID: "sd", Name: "sd"})
+	if f, err = forecaster.Forecast(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if f.SpentToDate != 142 || f.ProjectedPerHr != 3 || f.Projected != 250 {
+		t.Fatalf("forecast = %+v", f)
+	}
+	want := []runpod.SpendForecastLine{
+		{ResourceType: runpod.CostResourcePod, ResourceID: "train", Name: "train", PerHour: 2, TrendPerHour: 2, Projected: 72},
+		{ResourceType: runpod.CostResourceEndpoint, ResourceID: "sd", Name: "sd", PerHour: 0, TrendPerHour: 0.5, Projected: 18},
+	}
+	if len(f.Lines) != 2 || f.Lines[0] != want[0] || f.Lines[1] != want[1] {
+		t.Fatalf("lines = %+v", f.Lines)
+	}
+
+	// A new month starts over, from midnight.
+	clock.Advance(48 * time.Hour)
+	if f, err = forecaster.Forecast(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if f.SpentToDate != 30 || !f.MonthEnd.Equal(time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("forecast = %+v", f)
+	}
+
+	var validationErr *runpod.ValidationError
+	if _, err := client.NewSpendForecaster(&runpod.SpendForecastOptions{MonthToDate: -1}); !errors.As(err, &validationErr) {
+		t.Fatalf("negative MonthToDate: %v", err)
+	}
+}
+
+func TestWatchBalanceForecast(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+	srv.SetAccount(runpod.AccountInfo{ID: "acct", ClientBalance: 1000, CurrentSpendPerHr: 10})
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	alerts := make(chan runpod.BalanceAlert, 1)
+	go client.WatchBalance(ctx, &runpod.BalanceWatchOptions{
+		Interval:        10 * time.Millisecond,
+		MaxMonthlySpend: 5,
+		OnAlert:         func(a runpod.BalanceAlert) { alerts <- a },
+	})
+	select {
+	case a := <-alerts:
+		if a.Type != runpod.BalanceAlertForecast || a.Threshold != 5 || a.Forecast == nil || a.Forecast.Projected <= 5 || a.Account.ID != "acct" {
+			t.Fatalf("alert = %+v", a)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for forecast alert")
+	}
+}
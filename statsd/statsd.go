@@ -0,0 +1,126 @@
+// Package statsd is a runpod.MetricsSink that sends the client's per-call
+// metrics to a StatsD or Datadog agent over UDP, so teams on DogStatsD get
+// request counts, errors and latency without writing an adapter:
+//
+//	sink, err := statsd.New("127.0.0.1:8125", statsd.Options{Prefix: "myapp."})
+//	if err != nil { ... }
+//	defer sink.Close()
+//	client, err := runpod.NewClient(apiKey, runpod.WithMetrics(sink))
+//
+// Tags are written in the DogStatsD format; set Options.NoTags for a plain
+// StatsD server.
+package statsd
+
+import (
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+)
+
+// DefaultAddr is the Datadog agent's default DogStatsD address.
+const DefaultAddr = "127.0.0.1:8125"
+
+// Options configures a Sink.
+type Options struct {
+	// Prefix is prepended to every metric name, e.g. "myapp.".
+	Prefix string
+	// Tags are added to every metric, e.g. {"env", "prod"}.
+	Tags []runpod.Tag
+	// NoTags drops tags, for StatsD servers that reject the DogStatsD
+	// extension.
+	NoTags bool
+}
+
+// Sink writes metrics as StatsD lines, one per packet. Writes are
+// fire-and-forget: a missing agent never slows or fails an API call. A Sink
+// is safe for concurrent use.
+type Sink struct {
+	opts Options
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+var _ runpod.MetricsSink = (*Sink)(nil)
+
+// New returns a Sink sending to the agent at addr (DefaultAddr when empty).
+func New(addr string, opts Options) (*Sink, error) {
+	if addr == "" {
+		addr = DefaultAddr
+	}
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return NewWriter(conn, opts), nil
+}
+
+// NewWriter returns a Sink writing each metric line to w in a single
+// Write call.
+func NewWriter(w io.Writer, opts Options) *Sink {
+	return &Sink{opts: opts, w: w}
+}
+
+// Count sends a counter.
+func (s *Sink) Count(name string, value int64, tags []runpod.Tag) {
+	s.send(name, strconv.FormatInt(value, 10), "c", tags)
+}
+
+// Timing sends a timer in milliseconds.
+func (s *Sink) Timing(name string, d time.Duration, tags []runpod.Tag) {
+	ms := strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', -1, 64)
+	s.send(name, ms, "ms", tags)
+}
+
+// Close closes the underlying connection or writer, if it is an io.Closer.
+func (s *Sink) Close() error {
+	if c, ok := s.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+func (s *Sink) send(name, value, typ string, tags []runpod.Tag) {
+	var b strings.Builder
+	b.WriteString(s.opts.Prefix)
+	b.WriteString(clean(name, ":|@"))
+	b.WriteByte(':')
+	b.WriteString(value)
+	b.WriteByte('|')
+	b.WriteString(typ)
+	if !s.opts.NoTags && len(s.opts.Tags)+len(tags) > 0 {
+		b.WriteString("|#")
+		for i, t := range append(s.opts.Tags[:len(s.opts.Tags):len(s.opts.Tags)], tags...) {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(clean(t.Key, ":|,#"))
+			if t.Value != "" {
+				b.WriteByte(':')
+				b.WriteString(clean(t.Value, "|,#"))
+			}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, _ = s.w.Write([]byte(b.String()))
+}
+
+// clean replaces the characters the line format reserves with '_'.
+func clean(s, reserved string) string {
+	if !strings.ContainsAny(s, reserved) {
+		return s
+	}
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(reserved, r) {
+			return '_'
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,72 @@
+package statsd_test
+
+import (
+	"bytes"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+	"github.com/cozy-creator/runpod-go-sdk/statsd"
+)
+
+// lineWriter collects the lines written, one per Write.
+type lineWriter struct{ lines []string }
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.lines = append(w.lines, string(p))
+	return len(p), nil
+}
+
+func TestSink(t *testing.T) {
+	w := &lineWriter{}
+	sink := statsd.NewWriter(w, statsd.Options{Prefix: "app.", Tags: []runpod.Tag{{Key: "env", Value: "prod"}}})
+	sink.Count("runpod.requests", 1, []runpod.Tag{{Key: "route", Value: "/pods/{id}"}, {Key: "note", Value: "a|b,c"}})
+	sink.Timing("runpod.request.duration", 1500*time.Microsecond, nil)
+
+	plain := statsd.NewWriter(w, statsd.Options{NoTags: true})
+	plain.Count("runpod.request.errors", 2, []runpod.Tag{{Key: "status", Value: "500"}})
+
+	want := []string{
+		"app.runpod.requests:1|c|#env:prod,route:/pods/{id},note:a_b_c",
+		"app.runpod.request.duration:1.5|ms|#env:prod",
+		"runpod.request.errors:2|c",
+	}
+	if strings.Join(w.lines, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("lines:\n%s\nwant:\n%s", strings.Join(w.lines, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestNewUDP(t *testing.T) {
+	agent, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer agent.Close()
+
+	sink, err := statsd.New(agent.LocalAddr().String(), statsd.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient(runpod.WithMetrics(sink))
+	if _, err := client.GetHealth(t.Context(), "ep1"); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := make([]byte, 1024)
+	agent.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := agent.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "runpod.requests:1|c|#api:serverless,method:GET,route:/v2/{id}/health,status:200"
+	if got := buf[:n]; !bytes.Equal(got, []byte(want)) {
+		t.Fatalf("packet = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,191 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+)
+
+// BackupManifestVersion is the manifest format Backup writes.
+const BackupManifestVersion = 1
+
+// BackupOptions configures Backup.
+type BackupOptions struct {
+	// ID names the backup; it defaults to the UTC start time
+	// (20060102T150405Z). An existing ID is refused.
+	ID string
+	// Incremental reuses objects unchanged (same key, size and ETag) since
+	// the latest backup under the prefix instead of copying them again.
+	// Without a previous backup it behaves like a full backup.
+	Incremental bool
+	// Concurrency is how many objects copy at once; Upload configures each
+	// object's upload.
+	Concurrency int
+	Upload      *UploadOptions
+	// OnObject, if set, is called after each object is copied. Calls are
+	// serialized.
+	OnObject func(obj Object)
+}
+
+// BackupEntry maps a volume object to its copy in the backup bucket.
+type BackupEntry struct {
+	Key          string    `json:"key"`
+	Size         int64     `json:"size"`
+	ETag         string    `json:"etag"`
+	LastModified time.Time `json:"lastModified"`
+	// Location is the key of the copy in the backup bucket. Incremental
+	// backups point unchanged objects at an earlier backup's copy.
+	Location string `json:"location"`
+}
+
+// BackupManifest describes one backup. It lists every object in the
+// volume at backup time, so a single manifest is enough to restore.
+type BackupManifest struct {
+	Version     int           `json:"version"`
+	ID          string        `json:"id"`
+	VolumeID    string        `json:"volumeId"`
+	CreatedAt   time.Time     `json:"createdAt"`
+	Incremental bool          `json:"incremental"`
+	BaseID      string        `json:"baseId,omitempty"`
+	Objects     []BackupEntry `json:"objects"`
+	Copied      int           `json:"copied"`
+	Reused      int           `json:"reused"`
+	// CopiedBytes counts this run's transfers; TotalBytes the whole volume.
+	CopiedBytes int64 `json:"copiedBytes"`
+	TotalBytes  int64 `json:"totalBytes"`
+}
+
+// Backup copies every object of src into dst, typically a customer-owned
+// bucket from Client.Bucket, streaming through the S3 API. Objects land
+// under prefix/<id>/data/, the manifest at prefix/<id>/manifest.json, and
+// prefix/latest.json is updated to the new manifest once everything has
+// been copied; a failed backup never becomes the latest.
+func Backup(ctx context.Context, src, dst *Volume, prefix string, opts *BackupOptions) (*BackupManifest, error) {
+	if opts == nil {
+		opts = &BackupOptions{}
+	}
+	prefix = strings.Trim(prefix, "/")
+	start := src.client.now().UTC()
+	id := strings.Trim(strings.TrimSpace(opts.ID), "/")
+	if id == "" {
+		id = start.Format("20060102T150405Z")
+	}
+	manifestKey := path.Join(prefix, id, "manifest.json")
+	if _, err := dst.Head(ctx, manifestKey); err == nil {
+		return nil, runpod.NewValidationErrorWithValue("id", "a backup with this ID already exists", id)
+	} else if !errors.Is(err, runpod.ErrNotFound) {
+		return nil, err
+	}
+
+	manifest := &BackupManifest{
+		Version:     BackupManifestVersion,
+		ID:          id,
+		VolumeID:    src.ID,
+		CreatedAt:   start,
+		Incremental: opts.Incremental,
+	}
+	previous := map[string]BackupEntry{}
+	if opts.Incremental {
+		base, err := ReadBackupManifest(ctx, dst, prefix, "")
+		switch {
+		case errors.Is(err, runpod.ErrNotFound):
+		case err != nil:
+			return nil, fmt.Errorf("failed to read previous backup: %w", err)
+		default:
+			// Only reuse copies that are still in the bucket.
+			stored, err := dst.List(ctx, backupPrefix(prefix))
+			if err != nil {
+				return nil, err
+			}
+			sizes := make(map[string]int64, len(stored))
+			for _, obj := range stored {
+				sizes[obj.Key] = obj.Size
+			}
+			manifest.BaseID = base.ID
+			for _, entry := range base.Objects {
+				if size, ok := sizes[entry.Location]; ok && size == entry.Size {
+					previous[entry.Key] = entry
+				}
+			}
+		}
+	}
+
+	objects, err := src.List(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	var jobs []copyJob
+	for _, obj := range objects {
+		entry := BackupEntry{Key: obj.Key, Size: obj.Size, ETag: obj.ETag, LastModified: obj.LastModified}
+		manifest.TotalBytes += obj.Size
+		if prior, ok := previous[obj.Key]; ok && prior.Size == obj.Size && prior.ETag == obj.ETag && obj.ETag != "" {
+			entry.Location = prior.Location
+			manifest.Reused++
+		} else {
+			entry.Location = path.Join(prefix, id, "data", obj.Key)
+			jobs = append(jobs, copyJob{obj: obj, dstKey: entry.Location})
+		}
+		manifest.Objects = append(manifest.Objects, entry)
+	}
+	err = copyObjects(ctx, src, dst, jobs, opts.Concurrency, opts.Upload, func(job copyJob) {
+		manifest.Copied++
+		manifest.CopiedBytes += job.obj.Size
+		if opts.OnObject != nil {
+			opts.OnObject(job.obj)
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to back up volume %s: %w", src.ID, err)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode backup manifest: %w", err)
+	}
+	for _, key := range []string{manifestKey, path.Join(prefix, "latest.json")} {
+		if _, err := dst.Put(ctx, key, bytes.NewReader(data), int64(len(data))); err != nil {
+			return nil, fmt.Errorf("failed to write backup manifest: %w", err)
+		}
+	}
+	return manifest, nil
+}
+
+// ReadBackupManifest reads the manifest of backup id under prefix, or of
+// the latest backup when id is empty. A missing backup matches
+// runpod.ErrNotFound.
+func ReadBackupManifest(ctx context.Context, bucket *Volume, prefix, id string) (*BackupManifest, error) {
+	prefix = strings.Trim(prefix, "/")
+	key := path.Join(prefix, "latest.json")
+	if id = strings.Trim(strings.TrimSpace(id), "/"); id != "" {
+		key = path.Join(prefix, id, "manifest.json")
+	}
+	body, _, err := bucket.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	var manifest BackupManifest
+	if err := json.NewDecoder(io.LimitReader(body, 256<<20)).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode backup manifest %s: %w", key, err)
+	}
+	if manifest.Version != BackupManifestVersion {
+		return nil, fmt.Errorf("unsupported backup manifest version %d in %s", manifest.Version, key)
+	}
+	return &manifest, nil
+}
+
+// backupPrefix is the listing prefix for everything under prefix.
+func backupPrefix(prefix string) string {
+	if prefix == "" {
+		return ""
+	}
+	return prefix + "/"
+}
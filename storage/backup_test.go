@@ -0,0 +1,98 @@
+package storage_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/storage"
+	"github.com/cozy-creator/runpod-go-sdk/storage/storagetest"
+)
+
+func TestBackup(t *testing.T) {
+	srv := storagetest.New()
+	defer srv.Close()
+	ctx := t.Context()
+	vol := srv.MustClient().Volume("vol-1")
+	external, err := storage.New(storage.Options{Credentials: storagetest.Credentials, Endpoint: srv.URL(), Region: "us-east-1"})
+	if err != nil {
+		t.Fatalf("New external: %v", err)
+	}
+	bucket := external.Bucket("dr-backups")
+
+	if _, err := storage.ReadBackupManifest(ctx, bucket, "vol-1", ""); !errors.Is(err, runpod.ErrNotFound) {
+		t.Fatalf("no backups yet: %v", err)
+	}
+
+	srv.PutObject("vol-1", "ckpt/step-100.pt", []byte("step 100"))
+	srv.PutObject("vol-1", "ckpt/step-200.pt", []byte("step 200"))
+	srv.PutObject("vol-1", "config.yaml", []byte("lr: 1"))
+
+	// Incremental without a previous backup is a full backup.
+	full, err := storage.Backup(ctx, vol, bucket, "/vol-1/", &storage.BackupOptions{ID: "b1", Incremental: true})
+	if err != nil {
+		t.Fatalf("full Backup: %v", err)
+	}
+	if full.Copied != 3 || full.Reused != 0 || full.BaseID != "" || full.TotalBytes != 21 {
+		t.Fatalf("full = %+v", full)
+	}
+	if got, _ := srv.Object("dr-backups", "vol-1/b1/data/ckpt/step-100.pt"); string(got) != "step 100" {
+		t.Fatalf("backed up = %q", got)
+	}
+
+	srv.PutObject("vol-1", "config.yaml", []byte("lr: 2"))
+	srv.PutObject("vol-1", "ckpt/step-300.pt", []byte("step 300"))
+	if err := vol.Delete(ctx, "ckpt/step-100.pt"); err != nil {
+		t.Fatal(err)
+	}
+	incr, err := storage.Backup(ctx, vol, bucket, "vol-1", &storage.BackupOptions{ID: "b2", Incremental: true})
+	if err != nil {
+		t.Fatalf("incremental Backup: %v", err)
+	}
+	if incr.Copied != 2 || incr.Reused != 1 || incr.BaseID != "b1" || incr.CopiedBytes != 13 || len(incr.Objects) != 3 {
+		t.Fatalf("incremental = %+v", incr)
+	}
+	locations := map[string]string{}
+	for _, entry := range incr.Objects {
+		locations[entry.Key] = entry.Location
+	}
+	if locations["ckpt/step-200.pt"] != "vol-1/b1/data/ckpt/step-200.pt" || locations["config.yaml"] != "vol-1/b2/data/config.yaml" {
+		t.Fatalf("locations = %v", locations)
+	}
+	if got, _ := srv.Object("dr-backups", "vol-1/b2/data/config.yaml"); string(got) != "lr: 2" {
+		t.Fatalf("changed object = %q", got)
+	}
+
+	latest, err := storage.ReadBackupManifest(ctx, bucket, "vol-1", "")
+	if err != nil || latest.ID != "b2" {
+		t.Fatalf("latest = %+v, %v", latest, err)
+	}
+	if first, err := storage.ReadBackupManifest(ctx, bucket, "vol-1", "b1"); err != nil || len(first.Objects) != 3 {
+		t.Fatalf("b1 = %+v, %v", first, err)
+	}
+
+	var validationErr *runpod.ValidationError
+	if _, err := storage.Backup(ctx, vol, bucket, "vol-1", &storage.BackupOptions{ID: "b2"}); !errors.As(err, &validationErr) {
+		t.Fatalf("duplicate ID: %v", err)
+	}
+
+	// A failed backup does not replace the latest manifest.
+	failed := false
+	_, err = storage.Backup(ctx, vol, bucket, "vol-1", &storage.BackupOptions{
+		ID:          "b3",
+		Concurrency: 1,
+		OnObject: func(storage.Object) {
+			if !failed {
+				failed = true
+				srv.FailNext(http.StatusBadRequest, "InvalidRequest")
+			}
+		},
+	})
+	if err == nil {
+		t.Fatal("expected the backup to fail")
+	}
+	if latest, err := storage.ReadBackupManifest(ctx, bucket, "vol-1", ""); err != nil || latest.ID != "b2" {
+		t.Fatalf("latest after failure = %+v, %v", latest, err)
+	}
+}
@@ -0,0 +1,249 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+)
+
+// DefaultCopyConcurrency is how many objects CopyVolume copies at once.
+const DefaultCopyConcurrency = 4
+
+// CopyOptions configures CopyVolume.
+type CopyOptions struct {
+	// Storage supplies the S3 credentials and HTTP client for both ends.
+	// DataCenterID is ignored; Endpoint, if set, is used for both ends,
+	// which is only useful against a fake.
+	Storage Options
+	// Name and Size (GB) of the new volume; they default to the source's.
+	Name string
+	Size int
+	// DestinationVolumeID resumes an earlier copy into an existing volume
+	// instead of creating one. Objects already there with the same size
+	// and ETag are skipped.
+	DestinationVolumeID string
+	// Concurrency is how many objects copy at once; Upload.Concurrency is
+	// how many parts of each object.
+	Concurrency int
+	Upload      *UploadOptions
+	// OnObject, if set, is called after each object is copied. Calls are
+	// serialized.
+	OnObject func(obj Object)
+}
+
+// CopyResult describes a CopyVolume run.
+type CopyResult struct {
+	// Volume is the destination. It is set even when the copy fails, so
+	// the copy can be resumed with CopyOptions.DestinationVolumeID.
+	Volume  *runpod.NetworkVolume
+	Copied  int
+	Skipped int
+	Bytes   int64
+}
+
+// CopyVolume copies a network volume into another data center: it creates
+// the destination volume and streams every object across through the S3
+// API, source ranged GETs feeding destination part uploads directly, so
+// nothing is buffered beyond a part in flight. Source reads are pinned to
+// each object's ETag, every part is checked against the destination's MD5,
+// and the destination listing is compared with the source's at the end.
+// The source volume is left untouched.
+func CopyVolume(ctx context.Context, rp *runpod.Client, srcVolumeID, dstDataCenterID string, opts *CopyOptions) (*CopyResult, error) {
+	if opts == nil {
+		opts = &CopyOptions{}
+	}
+	dstDataCenterID = strings.TrimSpace(dstDataCenterID)
+	if dstDataCenterID == "" {
+		return nil, runpod.NewValidationError("dstDataCenterId", "cannot be empty")
+	}
+	srcInfo, err := rp.GetNetworkVolume(ctx, srcVolumeID)
+	if err != nil {
+		return nil, err
+	}
+	srcOpts := opts.Storage
+	srcOpts.DataCenterID = srcInfo.DataCenterID
+	srcClient, err := New(srcOpts)
+	if err != nil {
+		return nil, err
+	}
+	src := srcClient.Volume(srcInfo.ID)
+
+	result := &CopyResult{}
+	if id := strings.TrimSpace(opts.DestinationVolumeID); id != "" {
+		result.Volume, err = rp.GetNetworkVolume(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(result.Volume.DataCenterID, dstDataCenterID) {
+			return nil, runpod.NewValidationErrorWithValue("destinationVolumeId", "is not in "+dstDataCenterID, id)
+		}
+	} else {
+		req := &runpod.CreateNetworkVolumeRequest{Name: opts.Name, Size: opts.Size, DataCenterID: dstDataCenterID}
+		if req.Name == "" {
+			req.Name = srcInfo.Name
+		}
+		if req.Size == 0 {
+			req.Size = srcInfo.Size
+		}
+		if req.Size < srcInfo.Size {
+			return nil, runpod.NewValidationErrorWithValue("size", fmt.Sprintf("must be at least the source size (%d GB)", srcInfo.Size), req.Size)
+		}
+		result.Volume, err = rp.CreateNetworkVolume(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create destination volume: %w", err)
+		}
+	}
+	dstOpts := opts.Storage
+	dstOpts.DataCenterID = result.Volume.DataCenterID
+	dstClient, err := New(dstOpts)
+	if err != nil {
+		return result, err
+	}
+	dst := dstClient.Volume(result.Volume.ID)
+
+	objects, err := src.List(ctx, "")
+	if err != nil {
+		return result, err
+	}
+	existing, err := dst.List(ctx, "")
+	if err != nil {
+		return result, err
+	}
+	have := make(map[string]Object, len(existing))
+	for _, obj := range existing {
+		have[obj.Key] = obj
+	}
+
+	var jobs []copyJob
+	for _, obj := range objects {
+		if prior, ok := have[obj.Key]; ok && prior.Size == obj.Size && prior.ETag == obj.ETag {
+			result.Skipped++
+			continue
+		}
+		jobs = append(jobs, copyJob{obj: obj, dstKey: obj.Key})
+	}
+	err = copyObjects(ctx, src, dst, jobs, opts.Concurrency, opts.Upload, func(job copyJob) {
+		result.Copied++
+		result.Bytes += job.obj.Size
+		if opts.OnObject != nil {
+			opts.OnObject(job.obj)
+		}
+	})
+	if err != nil {
+		return result, fmt.Errorf("failed to copy volume %s: %w", src.ID, err)
+	}
+
+	if err := verifyCopy(ctx, objects, dst); err != nil {
+		return result, fmt.Errorf("failed to verify copy of volume %s: %w", src.ID, err)
+	}
+	return result, nil
+}
+
+// copyJob copies obj from a source volume to dstKey.
+type copyJob struct {
+	obj    Object
+	dstKey string
+}
+
+// copyObjects streams jobs from src to dst, concurrency objects at a time.
+// done is called, serialized, after each successful copy. Failed objects
+// do not stop the rest; the error joins every failure.
+func copyObjects(ctx context.Context, src, dst *Volume, jobs []copyJob, concurrency int, uploadOpts *UploadOptions, done func(copyJob)) error {
+	if concurrency <= 0 {
+		concurrency = DefaultCopyConcurrency
+	}
+	var (
+		mu   sync.Mutex
+		errs []error
+		wg   sync.WaitGroup
+	)
+	work := make(chan copyJob)
+	for range min(concurrency, len(jobs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range work {
+				_, err := dst.upload(ctx, job.dstKey, job.obj.Size, uploadOpts, src.rangeOpener(job.obj))
+				mu.Lock()
+				if err != nil {
+					errs = append(errs, err)
+				} else if done != nil {
+					done(job)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, job := range jobs {
+		if ctx.Err() != nil {
+			break
+		}
+		work <- job
+	}
+	close(work)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// rangeOpener reads parts of obj with ranged GETs pinned to its ETag.
+func (v *Volume) rangeOpener(obj Object) partOpener {
+	return func(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+		if length == 0 {
+			return io.NopCloser(strings.NewReader("")), nil
+		}
+		header := http.Header{"Range": {"bytes=" + strconv.FormatInt(offset, 10) + "-" + strconv.FormatInt(offset+length-1, 10)}}
+		if obj.ETag != "" {
+			header.Set("If-Match", obj.ETag)
+		}
+		resp, err := v.client.do(ctx, http.MethodGet, v.client.objectURL(v.ID, obj.Key, nil), header, nil, 0, obj.Key)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusPartialContent && (offset > 0 || length < obj.Size) {
+			resp.Body.Close()
+			return nil, fmt.Errorf("storage ignored the range request for %s (status %d)", obj.Key, resp.StatusCode)
+		}
+		return resp.Body, nil
+	}
+}
+
+// verifyCopy checks that every source object exists in dst with the same
+// size and, where both ETags are plain MD5s, the same ETag.
+func verifyCopy(ctx context.Context, objects []Object, dst *Volume) error {
+	copied, err := dst.List(ctx, "")
+	if err != nil {
+		return err
+	}
+	got := make(map[string]Object, len(copied))
+	for _, obj := range copied {
+		got[obj.Key] = obj
+	}
+	var problems []string
+	for _, want := range objects {
+		obj, ok := got[want.Key]
+		switch {
+		case !ok:
+			problems = append(problems, want.Key+": missing")
+		case obj.Size != want.Size:
+			problems = append(problems, fmt.Sprintf("%s: size %d, want %d", want.Key, obj.Size, want.Size))
+		case singlePartETag.MatchString(obj.ETag) && singlePartETag.MatchString(want.ETag) && !strings.EqualFold(obj.ETag, want.ETag):
+			problems = append(problems, fmt.Sprintf("%s: ETag %s, want %s", want.Key, obj.ETag, want.ETag))
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package storage_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+	"github.com/cozy-creator/runpod-go-sdk/storage"
+	"github.com/cozy-creator/runpod-go-sdk/storage/storagetest"
+)
+
+func TestCopyVolume(t *testing.T) {
+	srv := storagetest.New()
+	defer srv.Close()
+	rp := runpodtest.New()
+	defer rp.Close()
+	client := rp.MustClient()
+	ctx := t.Context()
+
+	rp.AddNetworkVolume(&runpod.NetworkVolume{ID: "vol-src", Name: "checkpoints", Size: 50, DataCenterID: "EU-RO-1"})
+	big := bytes.Repeat([]byte("ckpt"), (2*storage.MinPartSize+3)/4)
+	srv.PutObject("vol-src", "run-1/step-1000.pt", big)
+	srv.PutObject("vol-src", "run-1/config.yaml", []byte("lr: 0.0003\n"))
+
+	var copied []string
+	result, err := storage.CopyVolume(ctx, client, "vol-src", "US-KS-2", &storage.CopyOptions{
+		Storage:  srv.Options(),
+		Upload:   &storage.UploadOptions{PartSize: storage.MinPartSize},
+		OnObject: func(obj storage.Object) { copied = append(copied, obj.Key) },
+	})
+	if err != nil {
+		t.Fatalf("CopyVolume: %v", err)
+	}
+	dst := result.Volume
+	if dst == nil || dst.ID == "vol-src" || dst.DataCenterID != "US-KS-2" || dst.Name != "checkpoints" || dst.Size != 50 {
+		t.Fatalf("destination = %+v", dst)
+	}
+	if result.Copied != 2 || result.Bytes != int64(len(big)+11) || len(copied) != 2 {
+		t.Fatalf("result = %+v, copied = %v", result, copied)
+	}
+	if got, _ := srv.Object(dst.ID, "run-1/step-1000.pt"); !bytes.Equal(got, big) {
+		t.Fatalf("copied checkpoint has %d bytes, want %d", len(got), len(big))
+	}
+	if got, _ := srv.Object("vol-src", "run-1/config.yaml"); string(got) != "lr: 0.0003\n" {
+		t.Fatalf("source changed: %q", got)
+	}
+	if _, err := client.GetNetworkVolume(ctx, dst.ID); err != nil {
+		t.Fatalf("destination volume not created: %v", err)
+	}
+
+	// Resuming skips objects that already match. The checkpoint was copied
+	// in parts, so its ETag differs from the source's and it is copied
+	// again in one piece.
+	result, err = storage.CopyVolume(ctx, client, "vol-src", "US-KS-2", &storage.CopyOptions{
+		Storage:             srv.Options(),
+		DestinationVolumeID: dst.ID,
+	})
+	if err != nil {
+		t.Fatalf("resumed CopyVolume: %v", err)
+	}
+	if result.Skipped != 1 || result.Copied != 1 || result.Volume.ID != dst.ID {
+		t.Fatalf("resumed result = %+v", result)
+	}
+
+	var validationErr *runpod.ValidationError
+	if _, err := storage.CopyVolume(ctx, client, "vol-src", "US-KS-2", &storage.CopyOptions{Storage: srv.Options(), Size: 10}); !errors.As(err, &validationErr) {
+		t.Fatalf("undersized destination: %v", err)
+	}
+	if _, err := storage.CopyVolume(ctx, client, "vol-src", "EU-RO-1", &storage.CopyOptions{Storage: srv.Options(), DestinationVolumeID: dst.ID}); !errors.As(err, &validationErr) {
+		t.Fatalf("destination in wrong data center: %v", err)
+	}
+}
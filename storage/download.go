@@ -0,0 +1,285 @@
+package storage
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultDownloadRetries is how many consecutive failed attempts without
+// progress a download tolerates.
+const DefaultDownloadRetries = 5
+
+// DownloadOptions configures Download and DownloadFile. The zero value
+// uses the defaults.
+type DownloadOptions struct {
+	// Retries is how many consecutive attempts may fail without receiving
+	// any bytes; any progress resets the count. Negative disables retries.
+	Retries int
+	// Backoff is the delay before the first retry (default
+	// DefaultUploadBackoff); it doubles per consecutive failure.
+	Backoff time.Duration
+	// SHA256, if set, is the expected hex SHA-256 of the object. Objects
+	// uploaded in a single PUT are also checked against their MD5 ETag.
+	SHA256 string
+	// Progress, if set, is called as bytes arrive with the bytes received
+	// so far (including any resumed from a partial file) and the size.
+	Progress func(received, total int64)
+}
+
+func (o *DownloadOptions) withDefaults() DownloadOptions {
+	opts := DownloadOptions{}
+	if o != nil {
+		opts = *o
+	}
+	if opts.Retries == 0 {
+		opts.Retries = DefaultDownloadRetries
+	}
+	if opts.Retries < 0 {
+		opts.Retries = 0
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = DefaultUploadBackoff
+	}
+	opts.SHA256 = strings.ToLower(strings.TrimSpace(opts.SHA256))
+	return opts
+}
+
+// ChecksumError reports a downloaded object whose contents do not match
+// the expected digest.
+type ChecksumError struct {
+	Key       string
+	Algorithm string
+	Expected  string
+	Actual    string
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("runpod storage: %s checksum mismatch for %s: expected %s, got %s", e.Algorithm, e.Key, e.Expected, e.Actual)
+}
+
+// Download writes key to w, resuming with ranged requests after connection
+// resets, and verifies the checksum once the whole object has arrived. On
+// a checksum error w has already received the data.
+func (v *Volume) Download(ctx context.Context, key string, w io.Writer, opts *DownloadOptions) (*Object, error) {
+	o := opts.withDefaults()
+	obj, err := v.Head(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	sums := newChecksums(obj.ETag)
+	if _, err := v.download(ctx, obj, io.MultiWriter(w, sums), 0, o); err != nil {
+		return nil, err
+	}
+	if err := sums.verify(key, o.SHA256); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// DownloadFile downloads key to path. Bytes are written to a partial file
+// next to path that is renamed into place once the checksum verifies; if
+// an earlier call was interrupted, the download resumes from the partial
+// file as long as the object has not changed since. A partial file that
+// fails verification is deleted.
+func (v *Volume) DownloadFile(ctx context.Context, key, path string, opts *DownloadOptions) (*Object, error) {
+	o := opts.withDefaults()
+	obj, err := v.Head(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	partial := partialPath(path, obj.ETag)
+	// Partials of other versions of the object can never be resumed.
+	stale, _ := filepath.Glob(glob(path) + ".part-*")
+	for _, name := range stale {
+		if name != partial {
+			os.Remove(name)
+		}
+	}
+	f, err := os.OpenFile(partial, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", partial, err)
+	}
+	defer f.Close()
+	offset, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", partial, err)
+	}
+	if offset > obj.Size {
+		if err := f.Truncate(0); err != nil {
+			return nil, fmt.Errorf("failed to reset %s: %w", partial, err)
+		}
+		offset, _ = f.Seek(0, io.SeekStart)
+	}
+	if _, err := v.download(ctx, obj, f, offset, o); err != nil {
+		return nil, err
+	}
+
+	sums := newChecksums(obj.ETag)
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to verify %s: %w", partial, err)
+	}
+	if _, err := io.Copy(sums, f); err != nil {
+		return nil, fmt.Errorf("failed to verify %s: %w", partial, err)
+	}
+	if err := sums.verify(key, o.SHA256); err != nil {
+		f.Close()
+		os.Remove(partial)
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", partial, err)
+	}
+	if err := os.Rename(partial, path); err != nil {
+		return nil, fmt.Errorf("failed to move download into place: %w", err)
+	}
+	return obj, nil
+}
+
+// download copies obj from offset to w, reissuing a ranged GET after each
+// interrupted response. Every request is pinned to obj's ETag, so a
+// concurrent overwrite fails the download instead of splicing versions.
+func (v *Volume) download(ctx context.Context, obj *Object, w io.Writer, offset int64, o DownloadOptions) (int64, error) {
+	progress := &progressWriter{w: w, received: offset, total: obj.Size, fn: o.Progress}
+	failures, delay := 0, o.Backoff
+	for offset < obj.Size {
+		n, retryable, err := v.getRange(ctx, obj, progress, offset)
+		offset += n
+		if err == nil && offset < obj.Size && n == 0 {
+			err, retryable = io.ErrUnexpectedEOF, true
+		}
+		if err == nil {
+			continue
+		}
+		if n > 0 {
+			failures, delay = 0, o.Backoff
+		}
+		if !retryable || failures >= o.Retries {
+			var storageErr *Error
+			if errors.As(err, &storageErr) && storageErr.StatusCode == http.StatusPreconditionFailed {
+				err = fmt.Errorf("object changed during download: %w", err)
+			}
+			return offset, fmt.Errorf("failed to download %s at byte %d: %w", obj.Key, offset, err)
+		}
+		failures++
+		if err := sleepCtx(ctx, delay); err != nil {
+			return offset, err
+		}
+		delay *= 2
+	}
+	return offset, nil
+}
+
+// getRange streams obj from offset into w. It reports how many bytes were
+// written and whether a failure is worth retrying.
+func (v *Volume) getRange(ctx context.Context, obj *Object, w *progressWriter, offset int64) (int64, bool, error) {
+	header := http.Header{"Range": {"bytes=" + strconv.FormatInt(offset, 10) + "-"}}
+	if obj.ETag != "" {
+		header.Set("If-Match", obj.ETag)
+	}
+	resp, err := v.client.do(ctx, http.MethodGet, v.client.objectURL(v.ID, obj.Key, nil), header, nil, 0, obj.Key)
+	if err != nil {
+		return 0, retryableStorageError(ctx, err), err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusPartialContent && offset > 0 {
+		return 0, false, fmt.Errorf("storage ignored the range request (status %d)", resp.StatusCode)
+	}
+	before := w.received
+	_, err = io.Copy(w, resp.Body)
+	n := w.received - before
+	if w.err != nil {
+		return n, false, w.err
+	}
+	if err != nil {
+		return n, retryableStorageError(ctx, err), err
+	}
+	return n, false, nil
+}
+
+type progressWriter struct {
+	w        io.Writer
+	received int64
+	total    int64
+	fn       func(received, total int64)
+	// err records a local write failure, which retrying will not fix.
+	err error
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.received += int64(n)
+	if err != nil {
+		p.err = err
+		return n, err
+	}
+	if p.fn != nil && n > 0 {
+		p.fn(p.received, p.total)
+	}
+	return n, nil
+}
+
+// singlePartETag matches the ETag of an object uploaded in one PUT, which
+// is the hex MD5 of its contents. Multipart ETags carry a "-N" suffix.
+var singlePartETag = regexp.MustCompile(`^"?([0-9a-fA-F]{32})"?$`)
+
+type checksums struct {
+	md5    hash.Hash
+	sha256 hash.Hash
+	etag   string
+}
+
+func newChecksums(etag string) *checksums {
+	c := &checksums{sha256: sha256.New()}
+	if m := singlePartETag.FindStringSubmatch(etag); m != nil {
+		c.md5, c.etag = md5.New(), strings.ToLower(m[1])
+	}
+	return c
+}
+
+func (c *checksums) Write(b []byte) (int, error) {
+	c.sha256.Write(b)
+	if c.md5 != nil {
+		c.md5.Write(b)
+	}
+	return len(b), nil
+}
+
+func (c *checksums) verify(key, expectedSHA256 string) error {
+	if c.md5 != nil {
+		if got := hex.EncodeToString(c.md5.Sum(nil)); got != c.etag {
+			return &ChecksumError{Key: key, Algorithm: "md5", Expected: c.etag, Actual: got}
+		}
+	}
+	if expectedSHA256 != "" {
+		if got := hex.EncodeToString(c.sha256.Sum(nil)); got != expectedSHA256 {
+			return &ChecksumError{Key: key, Algorithm: "sha256", Expected: expectedSHA256, Actual: got}
+		}
+	}
+	return nil
+}
+
+// partialPath names the partial file after the object version, so a
+// resumed download never mixes bytes from two versions.
+func partialPath(path, etag string) string {
+	sum := sha256.Sum256([]byte(etag))
+	return path + ".part-" + hex.EncodeToString(sum[:6])
+}
+
+// glob escapes the pattern metacharacters in path.
+func glob(path string) string {
+	return strings.NewReplacer(`*`, `\*`, `?`, `\?`, `[`, `\[`).Replace(path)
+}
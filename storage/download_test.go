@@ -0,0 +1,115 @@
+package storage_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-sdk/storage"
+	"github.com/cozy-creator/runpod-go-sdk/storage/storagetest"
+)
+
+func TestDownloadResumesAfterReset(t *testing.T) {
+	srv := storagetest.New()
+	defer srv.Close()
+	vol := srv.MustClient().Volume("vol-1")
+	ctx := t.Context()
+
+	data := bytes.Repeat([]byte("weights!"), 16<<10)
+	srv.PutObject("vol-1", "ckpt/model.bin", data)
+	sum := sha256.Sum256(data)
+
+	// Two resets with progress and one transient error are all survived.
+	srv.CutNext(1000)
+	srv.CutNext(50000)
+	var last int64
+	var buf bytes.Buffer
+	obj, err := vol.Download(ctx, "ckpt/model.bin", &buf, &storage.DownloadOptions{
+		Backoff:  time.Millisecond,
+		SHA256:   hex.EncodeToString(sum[:]),
+		Progress: func(received, total int64) { last = received },
+	})
+	if err != nil {
+		t.Fatalf("Download: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) || obj.Size != int64(len(data)) || last != int64(len(data)) {
+		t.Fatalf("downloaded %d bytes (progress %d), want %d", buf.Len(), last, len(data))
+	}
+
+	// An interrupted DownloadFile leaves a partial file that the next call
+	// resumes from.
+	path := filepath.Join(t.TempDir(), "model.bin")
+	srv.CutNext(5000)
+	if _, err := vol.DownloadFile(ctx, "ckpt/model.bin", path, &storage.DownloadOptions{Retries: -1}); err == nil {
+		t.Fatal("expected the cut download to fail without retries")
+	}
+	partials, _ := filepath.Glob(path + ".part-*")
+	if len(partials) != 1 {
+		t.Fatalf("partials = %v", partials)
+	}
+	var first int64
+	_, err = vol.DownloadFile(ctx, "ckpt/model.bin", path, &storage.DownloadOptions{
+		Progress: func(received, total int64) {
+			if first == 0 {
+				first = received
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("resumed DownloadFile: %v", err)
+	}
+	if first <= 5000 {
+		t.Fatalf("resumed download started at %d, want past 5000", first)
+	}
+	if got, _ := os.ReadFile(path); !bytes.Equal(got, data) {
+		t.Fatalf("file has %d bytes, want %d", len(got), len(data))
+	}
+	if partials, _ := filepath.Glob(path + ".part-*"); len(partials) != 0 {
+		t.Fatalf("partials left behind: %v", partials)
+	}
+}
+
+func TestDownloadVerification(t *testing.T) {
+	srv := storagetest.New()
+	defer srv.Close()
+	vol := srv.MustClient().Volume("vol-1")
+	ctx := t.Context()
+	srv.PutObject("vol-1", "a.bin", []byte("original contents"))
+
+	path := filepath.Join(t.TempDir(), "a.bin")
+	_, err := vol.DownloadFile(ctx, "a.bin", path, &storage.DownloadOptions{SHA256: strings.Repeat("0", 64)})
+	var checksumErr *storage.ChecksumError
+	if !errors.As(err, &checksumErr) || checksumErr.Algorithm != "sha256" {
+		t.Fatalf("checksum error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("file exists after failed verification: %v", err)
+	}
+	if partials, _ := filepath.Glob(path + ".part-*"); len(partials) != 0 {
+		t.Fatalf("partials left behind: %v", partials)
+	}
+
+	// An overwrite mid-download fails rather than splicing two versions.
+	srv.CutNext(4)
+	overwritten := false
+	_, err = vol.Download(ctx, "a.bin", &bytes.Buffer{}, &storage.DownloadOptions{
+		Backoff: time.Millisecond,
+		Progress: func(int64, int64) {
+			if !overwritten {
+				overwritten = true
+				srv.PutObject("vol-1", "a.bin", []byte("replacement contents"))
+			}
+		},
+	})
+	var storageErr *storage.Error
+	if !errors.As(err, &storageErr) || storageErr.StatusCode != http.StatusPreconditionFailed || !strings.Contains(err.Error(), "changed") {
+		t.Fatalf("overwrite error = %v", err)
+	}
+}
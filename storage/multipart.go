@@ -0,0 +1,381 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+)
+
+// Multipart upload defaults and S3 limits.
+const (
+	DefaultPartSize          = 64 << 20
+	MinPartSize              = 5 << 20
+	MaxParts                 = 10000
+	DefaultUploadConcurrency = 4
+	DefaultUploadRetries     = 3
+	DefaultUploadBackoff     = 500 * time.Millisecond
+)
+
+// UploadOptions configures Upload and UploadFile. The zero value uses the
+// defaults above.
+type UploadOptions struct {
+	// PartSize is the multipart chunk size, at least MinPartSize. It grows
+	// automatically when the object would need more than MaxParts parts.
+	// Objects no larger than one part are sent with a single PUT.
+	PartSize int64
+	// Concurrency is how many parts upload at once.
+	Concurrency int
+	// Retries is how many times a failed request (network error, 429 or
+	// 5xx) is retried before the upload is aborted. Negative disables
+	// retries.
+	Retries int
+	// Backoff is the delay before the first retry; it doubles per attempt.
+	Backoff time.Duration
+	// Progress, if set, is called after each part with the bytes uploaded
+	// so far and the object size. Calls are serialized.
+	Progress func(sent, total int64)
+}
+
+func (o *UploadOptions) withDefaults(size int64) UploadOptions {
+	opts := UploadOptions{}
+	if o != nil {
+		opts = *o
+	}
+	if opts.PartSize == 0 {
+		opts.PartSize = DefaultPartSize
+	}
+	if smallest := (size + MaxParts - 1) / MaxParts; opts.PartSize < smallest {
+		// Round up to a whole MiB so part boundaries stay tidy.
+		opts.PartSize = (smallest + 1<<20 - 1) &^ (1<<20 - 1)
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultUploadConcurrency
+	}
+	if opts.Retries == 0 {
+		opts.Retries = DefaultUploadRetries
+	}
+	if opts.Retries < 0 {
+		opts.Retries = 0
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = DefaultUploadBackoff
+	}
+	return opts
+}
+
+// UploadFile uploads a local file as key with Upload.
+func (v *Volume) UploadFile(ctx context.Context, key, path string, opts *UploadOptions) (*Object, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	return v.Upload(ctx, key, f, info.Size(), opts)
+}
+
+// Upload stores size bytes of r as key, splitting large objects into parts
+// that upload in parallel and are retried individually. Each part is
+// checked against the MD5 the server reports for it. If the upload fails,
+// the multipart upload is aborted so no partial parts are left behind.
+func (v *Volume) Upload(ctx context.Context, key string, r io.ReaderAt, size int64, opts *UploadOptions) (*Object, error) {
+	return v.upload(ctx, key, size, opts, func(_ context.Context, offset, length int64) (io.ReadCloser, error) {
+		return io.NopCloser(io.NewSectionReader(r, offset, length)), nil
+	})
+}
+
+// partOpener returns length bytes of the source starting at offset. It is
+// called again for every retry of a part.
+type partOpener func(ctx context.Context, offset, length int64) (io.ReadCloser, error)
+
+func (v *Volume) upload(ctx context.Context, key string, size int64, opts *UploadOptions, open partOpener) (*Object, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	if size < 0 {
+		return nil, runpod.NewValidationErrorWithValue("size", "cannot be negative", size)
+	}
+	if opts != nil && opts.PartSize != 0 && opts.PartSize < MinPartSize {
+		return nil, runpod.NewValidationErrorWithValue("partSize", fmt.Sprintf("must be at least %d bytes", MinPartSize), opts.PartSize)
+	}
+	o := opts.withDefaults(size)
+
+	if size <= o.PartSize {
+		var obj *Object
+		err := o.retry(ctx, func() error {
+			body, err := open(ctx, 0, size)
+			if err != nil {
+				return err
+			}
+			defer body.Close()
+			h := md5.New()
+			obj, err = v.Put(ctx, key, io.TeeReader(body, h), size)
+			if err != nil {
+				return err
+			}
+			return verifyETag(key, obj.ETag, h.Sum(nil))
+		})
+		if err != nil {
+			return nil, err
+		}
+		if o.Progress != nil {
+			o.Progress(size, size)
+		}
+		return obj, nil
+	}
+
+	var uploadID string
+	err := o.retry(ctx, func() error {
+		var err error
+		uploadID, err = v.createMultipartUpload(ctx, key)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start upload of %s: %w", key, err)
+	}
+	parts, err := v.uploadParts(ctx, key, uploadID, open, size, o)
+	if err == nil {
+		var etag string
+		err = o.retry(ctx, func() error {
+			var err error
+			etag, err = v.completeMultipartUpload(ctx, key, uploadID, parts)
+			return err
+		})
+		if err == nil {
+			return &Object{Key: key, Size: size, ETag: etag, LastModified: v.client.now().UTC()}, nil
+		}
+	}
+	// Abort with a fresh context: ctx may be what failed the upload.
+	abortCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+	defer cancel()
+	if abortErr := v.abortMultipartUpload(abortCtx, key, uploadID); abortErr != nil {
+		err = errors.Join(err, fmt.Errorf("failed to abort upload: %w", abortErr))
+	}
+	return nil, fmt.Errorf("failed to upload %s: %w", key, err)
+}
+
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+func (v *Volume) uploadParts(ctx context.Context, key, uploadID string, open partOpener, size int64, o UploadOptions) ([]completedPart, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	count := int((size + o.PartSize - 1) / o.PartSize)
+	parts := make([]completedPart, count)
+	numbers := make(chan int)
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		sent     int64
+	)
+	for range min(o.Concurrency, count) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range numbers {
+				offset := int64(n-1) * o.PartSize
+				length := min(o.PartSize, size-offset)
+				var etag string
+				err := o.retry(ctx, func() error {
+					body, err := open(ctx, offset, length)
+					if err != nil {
+						return err
+					}
+					defer body.Close()
+					etag, err = v.uploadPart(ctx, key, uploadID, n, body, length)
+					return err
+				})
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = fmt.Errorf("part %d: %w", n, err)
+						cancel()
+					}
+				} else {
+					parts[n-1] = completedPart{PartNumber: n, ETag: etag}
+					sent += length
+					if o.Progress != nil {
+						o.Progress(sent, size)
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for n := 1; n <= count; n++ {
+		select {
+		case numbers <- n:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(numbers)
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return parts, nil
+}
+
+// retry runs fn until it succeeds, fails permanently, or retries run out.
+func (o UploadOptions) retry(ctx context.Context, fn func() error) error {
+	delay := o.Backoff
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= o.Retries || !retryableStorageError(ctx, err) {
+			return err
+		}
+		if sleepErr := sleepCtx(ctx, delay); sleepErr != nil {
+			return errors.Join(err, sleepErr)
+		}
+		delay *= 2
+	}
+}
+
+// sleepCtx waits for d or until ctx is done.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryableStorageError reports whether a failed request is worth repeating:
+// throttling, server errors and transport failures, but not client errors
+// or cancellation.
+func retryableStorageError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var storageErr *Error
+	if errors.As(err, &storageErr) {
+		return storageErr.StatusCode == http.StatusTooManyRequests || storageErr.StatusCode >= 500
+	}
+	var validationErr *runpod.ValidationError
+	return !errors.As(err, &validationErr)
+}
+
+func (v *Volume) createMultipartUpload(ctx context.Context, key string) (string, error) {
+	header := http.Header{"Content-Type": {"application/octet-stream"}}
+	u := v.client.objectURL(v.ID, key, url.Values{"uploads": {""}})
+	resp, err := v.client.do(ctx, http.MethodPost, u, header, nil, 0, key)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	var result struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode upload ID: %w", err)
+	}
+	if result.UploadID == "" {
+		return "", errors.New("storage returned an empty upload ID")
+	}
+	return result.UploadID, nil
+}
+
+func (v *Volume) uploadPart(ctx context.Context, key, uploadID string, number int, body io.Reader, size int64) (string, error) {
+	u := v.client.objectURL(v.ID, key, url.Values{"partNumber": {strconv.Itoa(number)}, "uploadId": {uploadID}})
+	h := md5.New()
+	resp, err := v.client.do(ctx, http.MethodPut, u, nil, io.TeeReader(body, h), size, key)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		return "", errors.New("storage returned no part ETag")
+	}
+	if err := verifyETag(key, etag, h.Sum(nil)); err != nil {
+		return "", err
+	}
+	return etag, nil
+}
+
+// verifyETag checks a single-part ETag, which is the content MD5, against
+// the MD5 of the bytes sent. A mismatch is a *ChecksumError, which upload
+// retries treat as transient.
+func verifyETag(key, etag string, sum []byte) error {
+	m := singlePartETag.FindStringSubmatch(etag)
+	if m == nil {
+		return nil
+	}
+	if sent := hex.EncodeToString(sum); !strings.EqualFold(m[1], sent) {
+		return &ChecksumError{Key: key, Algorithm: "md5", Expected: sent, Actual: strings.ToLower(m[1])}
+	}
+	return nil
+}
+
+func (v *Volume) completeMultipartUpload(ctx context.Context, key, uploadID string, parts []completedPart) (string, error) {
+	sort.Slice(parts, func(i, j int) bool { return parts[i].PartNumber < parts[j].PartNumber })
+	payload, err := xml.Marshal(struct {
+		XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+		Parts   []completedPart `xml:"Part"`
+	}{Parts: parts})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode part list: %w", err)
+	}
+	header := http.Header{"Content-Type": {"application/xml"}}
+	u := v.client.objectURL(v.ID, key, url.Values{"uploadId": {uploadID}})
+	resp, err := v.client.do(ctx, http.MethodPost, u, header, bytes.NewReader(payload), int64(len(payload)), key)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	// S3 can report a failed completion inside a 200 response.
+	var result struct {
+		XMLName xml.Name
+		ETag    string `xml:"ETag"`
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode upload completion: %w", err)
+	}
+	if result.XMLName.Local == "Error" {
+		return "", &Error{StatusCode: http.StatusInternalServerError, Code: result.Code, Message: result.Message, Key: key}
+	}
+	return result.ETag, nil
+}
+
+func (v *Volume) abortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	u := v.client.objectURL(v.ID, key, url.Values{"uploadId": {uploadID}})
+	resp, err := v.client.do(ctx, http.MethodDelete, u, nil, nil, 0, key)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
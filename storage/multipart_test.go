@@ -0,0 +1,118 @@
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/storage"
+	"github.com/cozy-creator/runpod-go-sdk/storage/storagetest"
+)
+
+func TestUploadMultipart(t *testing.T) {
+	srv := storagetest.New()
+	defer srv.Close()
+	vol := srv.MustClient().Volume("vol-1")
+	ctx := t.Context()
+
+	data := bytes.Repeat([]byte("0123456789abcdef"), (2*storage.MinPartSize+1024)/16)
+	var (
+		mu       sync.Mutex
+		progress []int64
+	)
+	// Transient failures are retried; the first one hits the initiate call.
+	srv.FailNext(http.StatusInternalServerError, "InternalError")
+	srv.FailNext(http.StatusServiceUnavailable, "SlowDown")
+	obj, err := vol.Upload(ctx, "ckpt/model.safetensors", bytes.NewReader(data), int64(len(data)), &storage.UploadOptions{
+		PartSize:    storage.MinPartSize,
+		Concurrency: 3,
+		Backoff:     time.Millisecond,
+		Progress: func(sent, total int64) {
+			mu.Lock()
+			defer mu.Unlock()
+			if total != int64(len(data)) {
+				t.Errorf("total = %d", total)
+			}
+			progress = append(progress, sent)
+		},
+	})
+	if err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+	if !strings.HasSuffix(obj.ETag, `-3"`) || obj.Size != int64(len(data)) {
+		t.Fatalf("object = %+v", obj)
+	}
+	if got, _ := srv.Object("vol-1", "ckpt/model.safetensors"); !bytes.Equal(got, data) {
+		t.Fatalf("stored %d bytes, want %d", len(got), len(data))
+	}
+	if len(progress) != 3 || progress[2] != int64(len(data)) || progress[0] >= progress[1] {
+		t.Fatalf("progress = %v", progress)
+	}
+	if n := srv.PendingUploads(); n != 0 {
+		t.Fatalf("pending uploads = %d", n)
+	}
+
+	// Client errors are not retried, and a failed upload is aborted.
+	cancelCtx, cancel := context.WithCancel(ctx)
+	_, err = vol.Upload(cancelCtx, "ckpt/cancelled.bin", bytes.NewReader(data), int64(len(data)), &storage.UploadOptions{
+		PartSize:    storage.MinPartSize,
+		Concurrency: 1,
+		Progress:    func(int64, int64) { cancel() },
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("cancelled upload: %v", err)
+	}
+	if n := srv.PendingUploads(); n != 0 {
+		t.Fatalf("cancelled upload left %d pending", n)
+	}
+	if _, ok := srv.Object("vol-1", "ckpt/cancelled.bin"); ok {
+		t.Fatal("cancelled upload stored an object")
+	}
+
+	before := srv.Requests()
+	srv.FailNext(http.StatusForbidden, "AccessDenied")
+	if _, err := vol.Upload(ctx, "ckpt/denied.bin", bytes.NewReader(data), int64(len(data)), &storage.UploadOptions{Backoff: time.Millisecond}); !errors.Is(err, runpod.ErrUnauthorized) {
+		t.Fatalf("denied upload: %v", err)
+	}
+	if n := srv.Requests() - before; n != 1 {
+		t.Fatalf("denied upload made %d requests, want 1", n)
+	}
+}
+
+func TestUploadSmallAndFile(t *testing.T) {
+	srv := storagetest.New()
+	defer srv.Close()
+	vol := srv.MustClient().Volume("vol-1")
+	ctx := t.Context()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"dim":4096}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var sent, total int64
+	obj, err := vol.UploadFile(ctx, "models/config.json", path, &storage.UploadOptions{
+		Progress: func(s, tot int64) { sent, total = s, tot },
+	})
+	if err != nil {
+		t.Fatalf("UploadFile: %v", err)
+	}
+	if strings.Contains(obj.ETag, "-") || sent != 12 || total != 12 {
+		t.Fatalf("object = %+v, progress = %d/%d", obj, sent, total)
+	}
+	if got, _ := srv.Object("vol-1", "models/config.json"); string(got) != `{"dim":4096}` {
+		t.Fatalf("stored = %q", got)
+	}
+
+	var validationErr *runpod.ValidationError
+	if _, err := vol.Upload(ctx, "x", bytes.NewReader(nil), 0, &storage.UploadOptions{PartSize: 1024}); !errors.As(err, &validationErr) {
+		t.Fatalf("small part size: %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+)
+
+func validateKey(key string) error {
+	if strings.Trim(key, "/ ") == "" {
+		return runpod.NewValidationError("key", "cannot be empty")
+	}
+	return nil
+}
+
+// Put uploads body as key in one request. size must be the exact body
+// length.
+func (v *Volume) Put(ctx context.Context, key string, body io.Reader, size int64) (*Object, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	if size < 0 {
+		return nil, runpod.NewValidationErrorWithValue("size", "cannot be negative", size)
+	}
+	if body == nil {
+		body = strings.NewReader("")
+	}
+	header := http.Header{"Content-Type": {"application/octet-stream"}}
+	resp, err := v.client.do(ctx, http.MethodPut, v.client.objectURL(v.ID, key, nil), header, body, size, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to put %s: %w", key, err)
+	}
+	resp.Body.Close()
+	return &Object{Key: key, Size: size, ETag: resp.Header.Get("ETag"), LastModified: v.client.now().UTC()}, nil
+}
+
+// Get opens key for reading. The caller must close the returned reader.
+func (v *Volume) Get(ctx context.Context, key string) (io.ReadCloser, *Object, error) {
+	if err := validateKey(key); err != nil {
+		return nil, nil, err
+	}
+	resp, err := v.client.do(ctx, http.MethodGet, v.client.objectURL(v.ID, key, nil), nil, nil, 0, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get %s: %w", key, err)
+	}
+	return resp.Body, objectFromHeader(key, resp.Header), nil
+}
+
+// Head returns key's metadata. A missing key matches runpod.ErrNotFound.
+func (v *Volume) Head(ctx context.Context, key string) (*Object, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	resp, err := v.client.do(ctx, http.MethodHead, v.client.objectURL(v.ID, key, nil), nil, nil, 0, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %s: %w", key, err)
+	}
+	resp.Body.Close()
+	return objectFromHeader(key, resp.Header), nil
+}
+
+// Delete removes key. Deleting a missing key succeeds.
+func (v *Volume) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	resp, err := v.client.do(ctx, http.MethodDelete, v.client.objectURL(v.ID, key, nil), nil, nil, 0, key)
+	if err != nil {
+		return fmt.Errorf("failed to delete %s: %w", key, err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key          string `xml:"Key"`
+		Size         int64  `xml:"Size"`
+		ETag         string `xml:"ETag"`
+		LastModified string `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List returns every object whose key starts with prefix, in key order,
+// following continuation tokens.
+func (v *Volume) List(ctx context.Context, prefix string) ([]Object, error) {
+	var objects []Object
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}}
+		if prefix != "" {
+			query.Set("prefix", prefix)
+		}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		resp, err := v.client.do(ctx, http.MethodGet, v.client.objectURL(v.ID, "", query), nil, nil, 0, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", prefix, err)
+		}
+		var page listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode object listing: %w", err)
+		}
+		for _, item := range page.Contents {
+			obj := Object{Key: item.Key, Size: item.Size, ETag: item.ETag}
+			obj.LastModified, _ = time.Parse(time.RFC3339, item.LastModified)
+			objects = append(objects, obj)
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" || page.NextContinuationToken == token {
+			return objects, nil
+		}
+		token = page.NextContinuationToken
+	}
+}
@@ -0,0 +1,255 @@
+// Package storage reads and writes files on RunPod network volumes through
+// RunPod's S3-compatible API, so artifacts can be staged without booting a
+// pod. Each data center has its own endpoint; a volume is a bucket named by
+// its ID, and object keys are paths relative to the volume root (the
+// /runpod-volume or /workspace mount inside pods).
+//
+// Usage:
+//
+//	vol, err := storage.OpenVolume(ctx, client, "vol_abc123", storage.Options{
+//		Credentials: storage.CredentialsFromEnv(),
+//	})
+//	_, err = vol.Put(ctx, "models/model.safetensors", f, size)
+package storage
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+)
+
+// Environment variables read by CredentialsFromEnv.
+const (
+	EnvAccessKeyID     = "RUNPOD_S3_ACCESS_KEY_ID"
+	EnvSecretAccessKey = "RUNPOD_S3_SECRET_ACCESS_KEY"
+)
+
+// Credentials are a RunPod S3 API key pair, created under Settings > S3 API
+// Keys in the RunPod console. They are separate from the RunPod API key.
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// CredentialsFromEnv reads credentials from RUNPOD_S3_ACCESS_KEY_ID and
+// RUNPOD_S3_SECRET_ACCESS_KEY.
+func CredentialsFromEnv() Credentials {
+	return Credentials{
+		AccessKeyID:     os.Getenv(EnvAccessKeyID),
+		SecretAccessKey: os.Getenv(EnvSecretAccessKey),
+	}
+}
+
+// EndpointForDataCenter returns the S3 API endpoint of a data center, e.g.
+// https://s3api-eur-is-1.runpod.io for EUR-IS-1.
+func EndpointForDataCenter(dataCenterID string) string {
+	return "https://s3api-" + strings.ToLower(strings.TrimSpace(dataCenterID)) + ".runpod.io"
+}
+
+// Options configures New.
+type Options struct {
+	Credentials Credentials
+	// DataCenterID selects the endpoint and signing region. OpenVolume fills
+	// it from the volume.
+	DataCenterID string
+	// Endpoint overrides the endpoint derived from DataCenterID (tests, or a
+	// new data center before this package knows about it).
+	Endpoint string
+	// Region is the signing region for S3-compatible services outside
+	// RunPod, such as AWS S3 ("us-east-1") or GCS interoperability
+	// ("auto"). Set it with Endpoint instead of DataCenterID and address
+	// buckets with Client.Bucket.
+	Region     string
+	HTTPClient *http.Client
+}
+
+// Client talks to one data center's S3 API.
+type Client struct {
+	endpoint   *url.URL
+	region     string
+	creds      Credentials
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// New returns a client for opts.DataCenterID, or for opts.Endpoint and
+// opts.Region.
+func New(opts Options) (*Client, error) {
+	if strings.TrimSpace(opts.Credentials.AccessKeyID) == "" || strings.TrimSpace(opts.Credentials.SecretAccessKey) == "" {
+		return nil, runpod.NewValidationError("credentials", "S3 access key ID and secret are required")
+	}
+	endpoint := strings.TrimSpace(opts.Endpoint)
+	region := strings.TrimSpace(opts.Region)
+	if region == "" {
+		region = strings.ToUpper(strings.TrimSpace(opts.DataCenterID))
+		if region == "" {
+			return nil, runpod.NewValidationError("dataCenterId", "cannot be empty")
+		}
+		if endpoint == "" {
+			endpoint = EndpointForDataCenter(region)
+		}
+	} else if endpoint == "" {
+		return nil, runpod.NewValidationError("endpoint", "is required with region")
+	}
+	u, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, runpod.NewValidationErrorWithValue("endpoint", "must be an absolute URL", endpoint)
+	}
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	return &Client{endpoint: u, region: region, creds: opts.Credentials, httpClient: httpClient, now: time.Now}, nil
+}
+
+// Volume returns a handle on a network volume in the client's data center.
+func (c *Client) Volume(volumeID string) *Volume {
+	return &Volume{client: c, ID: strings.TrimSpace(volumeID)}
+}
+
+// Bucket returns a handle on a bucket of an S3-compatible service outside
+// RunPod; Volume's methods work on it unchanged.
+func (c *Client) Bucket(name string) *Volume {
+	return c.Volume(name)
+}
+
+// OpenVolume looks up a volume's data center with the RunPod API and returns
+// a handle on it. opts.DataCenterID is ignored.
+func OpenVolume(ctx context.Context, rp *runpod.Client, volumeID string, opts Options) (*Volume, error) {
+	volume, err := rp.GetNetworkVolume(ctx, volumeID)
+	if err != nil {
+		return nil, err
+	}
+	opts.DataCenterID = volume.DataCenterID
+	c, err := New(opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.Volume(volume.ID), nil
+}
+
+// Volume is a network volume addressed as an S3 bucket, or a bucket of
+// another S3-compatible service from Client.Bucket.
+type Volume struct {
+	client *Client
+	ID     string
+}
+
+// Object describes a stored file.
+type Object struct {
+	Key          string
+	Size         int64
+	ETag         string
+	LastModified time.Time
+}
+
+// Error is an S3 API error response. errors.Is matches runpod.ErrNotFound
+// (404) and runpod.ErrUnauthorized (401/403).
+type Error struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Key        string
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("runpod storage: %d %s", e.StatusCode, e.Code)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.Key != "" {
+		msg += " (" + e.Key + ")"
+	}
+	return msg
+}
+
+// Is maps status codes onto the runpod sentinels.
+func (e *Error) Is(target error) bool {
+	switch target {
+	case runpod.ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case runpod.ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	}
+	return false
+}
+
+// objectURL returns the path-style URL of key in bucket, with query.
+func (c *Client) objectURL(bucket, key string, query url.Values) *url.URL {
+	u := *c.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + bucket
+	if key != "" {
+		u.Path += "/" + strings.TrimPrefix(key, "/")
+	}
+	u.RawPath = uriEncode(u.Path, false)
+	u.RawQuery = canonicalQuery(query)
+	return &u
+}
+
+// do signs and sends a request. size is the body length (-1 if unknown);
+// a non-2xx response is returned as *Error with the body consumed.
+func (c *Client) do(ctx context.Context, method string, u *url.URL, header http.Header, body io.Reader, size int64, key string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build storage request: %w", err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if body != nil {
+		req.ContentLength = size
+	}
+	payloadHash := emptyPayloadHash
+	if body != nil {
+		payloadHash = unsignedPayload
+	}
+	signRequest(req, c.creds, c.region, payloadHash, c.now())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("storage request failed: %w", err)
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		return nil, parseError(resp, key)
+	}
+	return resp, nil
+}
+
+func parseError(resp *http.Response, key string) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	var payload struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	_ = xml.Unmarshal(body, &payload)
+	if payload.Code == "" {
+		payload.Code = http.StatusText(resp.StatusCode)
+	}
+	return &Error{StatusCode: resp.StatusCode, Code: payload.Code, Message: payload.Message, Key: key}
+}
+
+func objectFromHeader(key string, header http.Header) *Object {
+	obj := &Object{Key: key, ETag: header.Get("ETag"), Size: -1}
+	if n, err := parseContentLength(header); err == nil {
+		obj.Size = n
+	}
+	if t, err := http.ParseTime(header.Get("Last-Modified")); err == nil {
+		obj.LastModified = t
+	}
+	return obj
+}
+
+func parseContentLength(header http.Header) (int64, error) {
+	var n int64
+	_, err := fmt.Sscan(header.Get("Content-Length"), &n)
+	return n, err
+}
@@ -0,0 +1,112 @@
+package storage_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+	"github.com/cozy-creator/runpod-go-sdk/storage"
+	"github.com/cozy-creator/runpod-go-sdk/storage/storagetest"
+)
+
+func TestVolumeObjects(t *testing.T) {
+	srv := storagetest.New()
+	defer srv.Close()
+	vol := srv.MustClient().Volume("vol-1")
+	ctx := t.Context()
+
+	data := []byte("model weights")
+	put, err := vol.Put(ctx, "models/llama 3/weights.bin", bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if put.ETag == "" || put.Size != int64(len(data)) {
+		t.Fatalf("put = %+v", put)
+	}
+	if got, ok := srv.Object("vol-1", "models/llama 3/weights.bin"); !ok || !bytes.Equal(got, data) {
+		t.Fatalf("stored = %q, %v", got, ok)
+	}
+
+	body, obj, err := vol.Get(ctx, "models/llama 3/weights.bin")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	got, _ := io.ReadAll(body)
+	body.Close()
+	if !bytes.Equal(got, data) || obj.ETag != put.ETag || obj.Size != int64(len(data)) || obj.LastModified.IsZero() {
+		t.Fatalf("get = %q, %+v", got, obj)
+	}
+
+	head, err := vol.Head(ctx, "models/llama 3/weights.bin")
+	if err != nil || head.Size != int64(len(data)) {
+		t.Fatalf("Head = %+v, %v", head, err)
+	}
+	if _, err := vol.Head(ctx, "missing"); !errors.Is(err, runpod.ErrNotFound) {
+		t.Fatalf("Head missing: %v", err)
+	}
+	if _, _, err := vol.Get(ctx, "missing"); !errors.Is(err, runpod.ErrNotFound) {
+		t.Fatalf("Get missing: %v", err)
+	}
+
+	srv.SetMaxKeys(2)
+	for _, key := range []string{"models/a", "models/b", "other/c"} {
+		srv.PutObject("vol-1", key, []byte(key))
+	}
+	listed, err := vol.List(ctx, "models/")
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(listed) != 3 || listed[0].Key != "models/a" || listed[2].Key != "models/llama 3/weights.bin" || listed[2].Size != int64(len(data)) {
+		t.Fatalf("listed = %+v", listed)
+	}
+
+	if err := vol.Delete(ctx, "models/a"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if keys := srv.Keys("vol-1"); len(keys) != 3 || keys[0] != "models/b" {
+		t.Fatalf("keys after delete = %v", keys)
+	}
+}
+
+func TestStorageErrorsAndSetup(t *testing.T) {
+	srv := storagetest.New()
+	defer srv.Close()
+	ctx := t.Context()
+
+	srv.FailNext(http.StatusForbidden, "AccessDenied")
+	_, _, err := srv.MustClient().Volume("vol-1").Get(ctx, "x")
+	var storageErr *storage.Error
+	if !errors.As(err, &storageErr) || storageErr.Code != "AccessDenied" || !errors.Is(err, runpod.ErrUnauthorized) {
+		t.Fatalf("error = %v", err)
+	}
+
+	var validationErr *runpod.ValidationError
+	if _, err := storage.New(storage.Options{DataCenterID: "EU-RO-1"}); !errors.As(err, &validationErr) {
+		t.Fatalf("missing credentials: %v", err)
+	}
+	if _, err := srv.MustClient().Volume("vol-1").Put(ctx, "/", nil, 0); !errors.As(err, &validationErr) {
+		t.Fatalf("empty key: %v", err)
+	}
+	if got := storage.EndpointForDataCenter("EUR-IS-1"); got != "https://s3api-eur-is-1.runpod.io" {
+		t.Fatalf("endpoint = %s", got)
+	}
+
+	// OpenVolume resolves the volume's data center through the RunPod API.
+	rp := runpodtest.New()
+	defer rp.Close()
+	rp.AddNetworkVolume(&runpod.NetworkVolume{ID: "vol-2", Name: "models", Size: 10, DataCenterID: storagetest.DataCenterID})
+	vol, err := storage.OpenVolume(ctx, rp.MustClient(), "vol-2", srv.Options())
+	if err != nil {
+		t.Fatalf("OpenVolume: %v", err)
+	}
+	if _, err := vol.Put(ctx, "hello.txt", bytes.NewReader([]byte("hi")), 2); err != nil {
+		t.Fatalf("Put via OpenVolume: %v", err)
+	}
+	if _, err := storage.OpenVolume(ctx, rp.MustClient(), "vol-missing", srv.Options()); !errors.Is(err, runpod.ErrNotFound) {
+		t.Fatalf("OpenVolume missing: %v", err)
+	}
+}
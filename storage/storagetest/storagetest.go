@@ -0,0 +1,457 @@
+// Package storagetest provides an in-process fake of RunPod's
+// S3-compatible volume storage API for consumer tests: object put, get,
+// head, ranged and conditional get, delete, ListObjectsV2 and multipart
+// uploads, plus one-shot fault injection.
+//
+// Usage:
+//
+//	srv := storagetest.New()
+//	defer srv.Close()
+//	vol := srv.MustClient().Volume("vol-1")
+//	_, err := vol.Put(ctx, "models/a.bin", r, size)
+package storagetest
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-sdk/storage"
+)
+
+// Credentials the fake accepts; MustClient uses them.
+var Credentials = storage.Credentials{AccessKeyID: "storagetest-access", SecretAccessKey: "storagetest-secret"}
+
+// DataCenterID is the data center MustClient signs for. The fake accepts
+// requests signed for any data center.
+const DataCenterID = "EU-RO-1"
+
+type object struct {
+	data     []byte
+	etag     string
+	modified time.Time
+}
+
+type upload struct {
+	bucket, key string
+	parts       map[int][]byte
+}
+
+type fault struct {
+	status int
+	code   string
+}
+
+// Server is a fake S3 API backed by in-memory buckets (one per volume ID).
+// Safe for concurrent use.
+type Server struct {
+	httpServer *httptest.Server
+
+	mu       sync.Mutex
+	buckets  map[string]map[string]*object
+	uploads  map[string]*upload
+	nextID   int
+	faults   []fault
+	cuts     []int
+	requests int
+	// maxKeys caps the ListObjectsV2 page size (default 1000).
+	maxKeys int
+}
+
+// New starts a fake storage server. Call Close when done.
+func New() *Server {
+	s := &Server{buckets: map[string]map[string]*object{}, uploads: map[string]*upload{}, maxKeys: 1000}
+	s.httpServer = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// URL returns the server's endpoint.
+func (s *Server) URL() string { return s.httpServer.URL }
+
+// Close shuts the server down.
+func (s *Server) Close() { s.httpServer.Close() }
+
+// Client returns a storage client pointed at this fake.
+func (s *Server) Client() (*storage.Client, error) {
+	return storage.New(s.Options())
+}
+
+// Options returns storage options pointed at this fake, for OpenVolume.
+func (s *Server) Options() storage.Options {
+	return storage.Options{Credentials: Credentials, DataCenterID: DataCenterID, Endpoint: s.httpServer.URL}
+}
+
+// MustClient is Client but panics on error.
+func (s *Server) MustClient() *storage.Client {
+	c, err := s.Client()
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// SetMaxKeys caps the ListObjectsV2 page size, to exercise pagination.
+func (s *Server) SetMaxKeys(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxKeys = n
+}
+
+// PutObject seeds an object.
+func (s *Server) PutObject(volumeID, key string, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.putLocked(volumeID, key, data)
+}
+
+// Object returns a stored object's contents.
+func (s *Server) Object(volumeID, key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj := s.buckets[volumeID][key]
+	if obj == nil {
+		return nil, false
+	}
+	return append([]byte(nil), obj.data...), true
+}
+
+// Keys returns a volume's object keys in order.
+func (s *Server) Keys(volumeID string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var keys []string
+	for key := range s.buckets[volumeID] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// FailNext makes the next request fail with status and S3 error code.
+// Calls queue.
+func (s *Server) FailNext(status int, code string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, fault{status: status, code: code})
+}
+
+// CutNext makes the next successful GET drop the connection after n body
+// bytes, as a connection reset would. Calls queue.
+func (s *Server) CutNext(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cuts = append(s.cuts, n)
+}
+
+// Requests returns how many requests the fake has served.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// PendingUploads returns how many multipart uploads were started but
+// neither completed nor aborted.
+func (s *Server) PendingUploads() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.uploads)
+}
+
+func (s *Server) putLocked(bucket, key string, data []byte) *object {
+	if s.buckets[bucket] == nil {
+		s.buckets[bucket] = map[string]*object{}
+	}
+	sum := md5.Sum(data)
+	obj := &object{data: data, etag: `"` + hex.EncodeToString(sum[:]) + `"`, modified: time.Now().UTC().Truncate(time.Second)}
+	s.buckets[bucket][key] = obj
+	return obj
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message></Error>", code, message)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests++
+	if len(s.faults) > 0 {
+		f := s.faults[0]
+		s.faults = s.faults[1:]
+		s.mu.Unlock()
+		writeError(w, f.status, f.code, "injected fault")
+		return
+	}
+	s.mu.Unlock()
+
+	auth := r.Header.Get("Authorization")
+	// Any data center is accepted, so one fake can stand in for several
+	// regional endpoints.
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential="+Credentials.AccessKeyID+"/") ||
+		!strings.Contains(auth, "/s3/aws4_request") || r.Header.Get("X-Amz-Date") == "" {
+		writeError(w, http.StatusForbidden, "SignatureDoesNotMatch", "bad or missing signature")
+		return
+	}
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket == "" {
+		writeError(w, http.StatusBadRequest, "InvalidBucketName", "bucket required")
+		return
+	}
+	if key == "" {
+		if r.Method == http.MethodGet && r.URL.Query().Get("list-type") == "2" {
+			s.list(w, bucket, r.URL.Query().Get("prefix"), r.URL.Query().Get("continuation-token"))
+			return
+		}
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "unsupported bucket operation")
+		return
+	}
+
+	query := r.URL.Query()
+	if query.Has("uploads") || query.Has("uploadId") {
+		s.multipart(w, r, bucket, key)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "IncompleteBody", err.Error())
+			return
+		}
+		if r.ContentLength >= 0 && int64(len(data)) != r.ContentLength {
+			writeError(w, http.StatusBadRequest, "IncompleteBody", "body shorter than Content-Length")
+			return
+		}
+		s.mu.Lock()
+		obj := s.putLocked(bucket, key, data)
+		s.mu.Unlock()
+		w.Header().Set("ETag", obj.etag)
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet, http.MethodHead:
+		s.mu.Lock()
+		obj := s.buckets[bucket][key]
+		s.mu.Unlock()
+		if obj == nil {
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			writeError(w, http.StatusNotFound, "NoSuchKey", "The specified key does not exist.")
+			return
+		}
+		if match := r.Header.Get("If-Match"); match != "" && match != obj.etag {
+			writeError(w, http.StatusPreconditionFailed, "PreconditionFailed", "At least one of the pre-conditions you specified did not hold.")
+			return
+		}
+		data, status := obj.data, http.StatusOK
+		if rng := r.Header.Get("Range"); rng != "" {
+			start, end, ok := parseRange(rng, len(obj.data))
+			if !ok {
+				w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", len(obj.data)))
+				writeError(w, http.StatusRequestedRangeNotSatisfiable, "InvalidRange", "The requested range is not satisfiable")
+				return
+			}
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, len(obj.data)))
+			data, status = obj.data[start:end+1], http.StatusPartialContent
+		}
+		w.Header().Set("ETag", obj.etag)
+		w.Header().Set("Last-Modified", obj.modified.Format(http.TimeFormat))
+		w.Header().Set("Accept-Ranges", "bytes")
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.WriteHeader(status)
+		if r.Method == http.MethodGet {
+			s.mu.Lock()
+			if len(s.cuts) > 0 {
+				data = data[:min(s.cuts[0], len(data))]
+				s.cuts = s.cuts[1:]
+			}
+			s.mu.Unlock()
+			// A body shorter than Content-Length makes the server drop the
+			// connection, which the client sees as an unexpected EOF.
+			_, _ = w.Write(data)
+		}
+	case http.MethodDelete:
+		s.mu.Lock()
+		delete(s.buckets[bucket], key)
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "unsupported object operation")
+	}
+}
+
+type listContents struct {
+	Key          string `xml:"Key"`
+	Size         int    `xml:"Size"`
+	ETag         string `xml:"ETag"`
+	LastModified string `xml:"LastModified"`
+}
+
+type listResult struct {
+	XMLName               xml.Name       `xml:"ListBucketResult"`
+	Name                  string         `xml:"Name"`
+	Prefix                string         `xml:"Prefix"`
+	KeyCount              int            `xml:"KeyCount"`
+	IsTruncated           bool           `xml:"IsTruncated"`
+	NextContinuationToken string         `xml:"NextContinuationToken,omitempty"`
+	Contents              []listContents `xml:"Contents"`
+}
+
+func (s *Server) list(w http.ResponseWriter, bucket, prefix, token string) {
+	s.mu.Lock()
+	var keys []string
+	for key := range s.buckets[bucket] {
+		if strings.HasPrefix(key, prefix) && key > token {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	result := listResult{Name: bucket, Prefix: prefix}
+	if len(keys) > s.maxKeys {
+		keys = keys[:s.maxKeys]
+		result.IsTruncated = true
+		result.NextContinuationToken = keys[len(keys)-1]
+	}
+	for _, key := range keys {
+		obj := s.buckets[bucket][key]
+		result.Contents = append(result.Contents, listContents{
+			Key: key, Size: len(obj.data), ETag: obj.etag, LastModified: obj.modified.Format(time.RFC3339),
+		})
+	}
+	s.mu.Unlock()
+	result.KeyCount = len(result.Contents)
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(result)
+}
+
+// parseRange parses a single "bytes=start-end" or "bytes=start-" range
+// into inclusive offsets.
+func parseRange(header string, size int) (int, int, bool) {
+	spec, ok := strings.CutPrefix(header, "bytes=")
+	if !ok || strings.Contains(spec, ",") {
+		return 0, 0, false
+	}
+	from, to, _ := strings.Cut(spec, "-")
+	start, err := strconv.Atoi(from)
+	if err != nil || start < 0 || start >= size {
+		return 0, 0, false
+	}
+	end := size - 1
+	if to != "" {
+		if end, err = strconv.Atoi(to); err != nil || end < start {
+			return 0, 0, false
+		}
+		end = min(end, size-1)
+	}
+	return start, end, true
+}
+
+func xmlText(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// minPartSize is S3's lower bound for every part but the last.
+const minPartSize = 5 << 20
+
+func (s *Server) multipart(w http.ResponseWriter, r *http.Request, bucket, key string) {
+	query := r.URL.Query()
+	if r.Method == http.MethodPost && query.Has("uploads") {
+		s.mu.Lock()
+		s.nextID++
+		id := "upload-" + strconv.Itoa(s.nextID)
+		s.uploads[id] = &upload{bucket: bucket, key: key, parts: map[int][]byte{}}
+		s.mu.Unlock()
+		w.Header().Set("Content-Type", "application/xml")
+		fmt.Fprintf(w, "<InitiateMultipartUploadResult><Bucket>%s</Bucket><Key>%s</Key><UploadId>%s</UploadId></InitiateMultipartUploadResult>", xmlText(bucket), xmlText(key), id)
+		return
+	}
+
+	id := query.Get("uploadId")
+	s.mu.Lock()
+	up := s.uploads[id]
+	s.mu.Unlock()
+	if up == nil || up.bucket != bucket || up.key != key {
+		writeError(w, http.StatusNotFound, "NoSuchUpload", "The specified upload does not exist.")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		number, err := strconv.Atoi(query.Get("partNumber"))
+		if err != nil || number < 1 || number > 10000 {
+			writeError(w, http.StatusBadRequest, "InvalidArgument", "partNumber must be 1-10000")
+			return
+		}
+		data, err := io.ReadAll(r.Body)
+		if err != nil || (r.ContentLength >= 0 && int64(len(data)) != r.ContentLength) {
+			writeError(w, http.StatusBadRequest, "IncompleteBody", "body shorter than Content-Length")
+			return
+		}
+		s.mu.Lock()
+		up.parts[number] = data
+		s.mu.Unlock()
+		sum := md5.Sum(data)
+		w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:])+`"`)
+		w.WriteHeader(http.StatusOK)
+	case http.MethodPost:
+		var req struct {
+			Parts []struct {
+				PartNumber int    `xml:"PartNumber"`
+				ETag       string `xml:"ETag"`
+			} `xml:"Part"`
+		}
+		if err := xml.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Parts) == 0 {
+			writeError(w, http.StatusBadRequest, "MalformedXML", "invalid part list")
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		var data, sums []byte
+		for i, part := range req.Parts {
+			body, ok := up.parts[part.PartNumber]
+			sum := md5.Sum(body)
+			if !ok || part.ETag != `"`+hex.EncodeToString(sum[:])+`"` {
+				writeError(w, http.StatusBadRequest, "InvalidPart", fmt.Sprintf("part %d is missing or its ETag does not match", part.PartNumber))
+				return
+			}
+			if i > 0 && part.PartNumber <= req.Parts[i-1].PartNumber {
+				writeError(w, http.StatusBadRequest, "InvalidPartOrder", "parts must be in ascending order")
+				return
+			}
+			if i < len(req.Parts)-1 && len(body) < minPartSize {
+				writeError(w, http.StatusBadRequest, "EntityTooSmall", fmt.Sprintf("part %d is smaller than the minimum", part.PartNumber))
+				return
+			}
+			data = append(data, body...)
+			sums = append(sums, sum[:]...)
+		}
+		obj := s.putLocked(bucket, key, data)
+		sum := md5.Sum(sums)
+		obj.etag = fmt.Sprintf(`"%s-%d"`, hex.EncodeToString(sum[:]), len(req.Parts))
+		delete(s.uploads, id)
+		w.Header().Set("Content-Type", "application/xml")
+		fmt.Fprintf(w, "<CompleteMultipartUploadResult><Bucket>%s</Bucket><Key>%s</Key><ETag>%s</ETag></CompleteMultipartUploadResult>", xmlText(bucket), xmlText(key), xmlText(obj.etag))
+	case http.MethodDelete:
+		s.mu.Lock()
+		delete(s.uploads, id)
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "unsupported multipart operation")
+	}
+}
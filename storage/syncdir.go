@@ -0,0 +1,298 @@
+package storage
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultSyncConcurrency is how many files SyncDir uploads at once.
+const DefaultSyncConcurrency = 4
+
+// SyncOptions configures SyncDir.
+type SyncOptions struct {
+	// Delete removes objects under the prefix that no longer exist locally.
+	Delete bool
+	// DryRun computes the result without uploading or deleting anything.
+	DryRun bool
+	// Exclude lists path.Match patterns, matched against slash-separated
+	// paths relative to the local directory and against base names.
+	Exclude []string
+	// Concurrency is how many files upload at once.
+	Concurrency int
+	// Upload configures each file's upload. Its Progress callback is
+	// ignored; use OnFile.
+	Upload *UploadOptions
+	// OnFile, if set, is called after each upload or deletion with the key
+	// and the action (SyncUploaded or SyncDeleted). Calls are serialized.
+	OnFile func(key, action string)
+}
+
+// SyncDir actions.
+const (
+	SyncUploaded = "uploaded"
+	SyncDeleted  = "deleted"
+)
+
+// SyncResult summarizes a SyncDir run. Key lists are sorted.
+type SyncResult struct {
+	Uploaded      []string
+	Deleted       []string
+	Unchanged     []string
+	BytesUploaded int64
+}
+
+func (r *SyncResult) String() string {
+	return fmt.Sprintf("%d uploaded (%d bytes), %d deleted, %d unchanged",
+		len(r.Uploaded), r.BytesUploaded, len(r.Deleted), len(r.Unchanged))
+}
+
+type localFile struct {
+	path string
+	key  string
+	size int64
+}
+
+// SyncDir mirrors localDir to vol under prefix, uploading only files that
+// are new or differ from the stored object in size or content (compared
+// through the ETag), and with opts.Delete removing objects that have no
+// local file. Symlinks and other non-regular files are skipped. A failure
+// on one file does not stop the others; the result covers what succeeded
+// and the error joins every failure.
+func SyncDir(ctx context.Context, localDir string, vol *Volume, prefix string, opts *SyncOptions) (*SyncResult, error) {
+	if opts == nil {
+		opts = &SyncOptions{}
+	}
+	prefix = strings.Trim(prefix, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	for _, pattern := range opts.Exclude {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid exclude pattern %q: %w", pattern, err)
+		}
+	}
+
+	var files []localFile
+	err := filepath.WalkDir(localDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(localDir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel != "." && excluded(opts.Exclude, rel) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files = append(files, localFile{path: p, key: prefix + rel, size: info.Size()})
+		return nil
+	})
+	if err != nil {
+		// Never delete remotely on the strength of a partial local listing.
+		return nil, fmt.Errorf("failed to scan %s: %w", localDir, err)
+	}
+
+	remote := map[string]Object{}
+	objects, err := vol.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range objects {
+		remote[obj.Key] = obj
+	}
+
+	uploadOpts := UploadOptions{}
+	if opts.Upload != nil {
+		uploadOpts = *opts.Upload
+	}
+	uploadOpts.Progress = nil
+
+	result := &SyncResult{}
+	var (
+		mu      sync.Mutex
+		errs    []error
+		pending []localFile
+	)
+	for _, file := range files {
+		obj, ok := remote[file.key]
+		delete(remote, file.key)
+		if ok {
+			same, err := sameContent(file, obj, uploadOpts)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if same {
+				result.Unchanged = append(result.Unchanged, file.key)
+				continue
+			}
+		}
+		pending = append(pending, file)
+	}
+
+	record := func(key, action string, size int64, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		switch action {
+		case SyncUploaded:
+			result.Uploaded = append(result.Uploaded, key)
+			result.BytesUploaded += size
+		case SyncDeleted:
+			result.Deleted = append(result.Deleted, key)
+		}
+		if opts.OnFile != nil && !opts.DryRun {
+			opts.OnFile(key, action)
+		}
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultSyncConcurrency
+	}
+	work := make(chan localFile)
+	var wg sync.WaitGroup
+	for range min(concurrency, len(pending)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for file := range work {
+				var err error
+				if !opts.DryRun {
+					_, err = vol.UploadFile(ctx, file.key, file.path, &uploadOpts)
+				}
+				record(file.key, SyncUploaded, file.size, err)
+			}
+		}()
+	}
+	for _, file := range pending {
+		if ctx.Err() != nil {
+			break
+		}
+		work <- file
+	}
+	close(work)
+	wg.Wait()
+
+	if opts.Delete && ctx.Err() == nil {
+		for key := range remote {
+			if excluded(opts.Exclude, strings.TrimPrefix(key, prefix)) {
+				continue
+			}
+			var err error
+			if !opts.DryRun {
+				err = vol.Delete(ctx, key)
+			}
+			record(key, SyncDeleted, 0, err)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+
+	sort.Strings(result.Uploaded)
+	sort.Strings(result.Deleted)
+	sort.Strings(result.Unchanged)
+	if len(errs) > 0 {
+		return result, fmt.Errorf("failed to sync %s: %w", localDir, errors.Join(errs...))
+	}
+	return result, nil
+}
+
+// excluded reports whether rel, or its base name, matches a pattern.
+// Invalid patterns are rejected before the walk.
+func excluded(patterns []string, rel string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, rel); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, path.Base(rel)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// sameContent compares a local file with a stored object. Single-part
+// ETags are the MD5 of the content; multipart ETags are the MD5 of the
+// parts' MD5s, which matches when the object was uploaded with the part
+// size Upload would pick for this file today.
+func sameContent(file localFile, obj Object, opts UploadOptions) (bool, error) {
+	if file.size != obj.Size {
+		return false, nil
+	}
+	etag := strings.Trim(obj.ETag, `"`)
+	digest, parts, multipart := strings.Cut(etag, "-")
+	partSize := file.size
+	if multipart {
+		partSize = opts.withDefaults(file.size).PartSize
+	}
+	local, count, err := fileETag(file.path, partSize)
+	if err != nil {
+		return false, fmt.Errorf("failed to hash %s: %w", file.path, err)
+	}
+	if multipart {
+		return local == strings.ToLower(digest) && fmt.Sprint(count) == parts, nil
+	}
+	return count == 1 && local == strings.ToLower(digest), nil
+}
+
+// fileETag computes the S3 ETag digest of a file uploaded in parts of
+// partSize: the plain MD5 for one part, otherwise the MD5 of the part MD5s.
+func fileETag(name string, partSize int64) (string, int, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+	if partSize <= 0 {
+		partSize = 1
+	}
+	var sums []byte
+	count := 0
+	for {
+		h := md5.New()
+		n, err := io.CopyN(h, f, partSize)
+		if err != nil && err != io.EOF {
+			return "", 0, err
+		}
+		if n == 0 && count > 0 {
+			break
+		}
+		sums = h.Sum(sums)
+		count++
+		if n < partSize {
+			break
+		}
+	}
+	if count == 1 {
+		return hex.EncodeToString(sums), 1, nil
+	}
+	sum := md5.Sum(sums)
+	return hex.EncodeToString(sum[:]), count, nil
+}
@@ -0,0 +1,100 @@
+package storage_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/cozy-creator/runpod-go-sdk/storage"
+	"github.com/cozy-creator/runpod-go-sdk/storage/storagetest"
+)
+
+func TestSyncDir(t *testing.T) {
+	srv := storagetest.New()
+	defer srv.Close()
+	vol := srv.MustClient().Volume("vol-1")
+	ctx := t.Context()
+
+	dir := t.TempDir()
+	write := func(rel string, data []byte) {
+		t.Helper()
+		p := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("config.json", []byte(`{"dim":4096}`))
+	write("shards/model-00001.bin", bytes.Repeat([]byte{7}, 2*storage.MinPartSize+10))
+	write("scratch.tmp", []byte("ignored"))
+	write(".git/HEAD", []byte("ref: refs/heads/main"))
+	srv.PutObject("vol-1", "release/stale.bin", []byte("old"))
+	srv.PutObject("vol-1", "other/keep.bin", []byte("outside the prefix"))
+
+	opts := &storage.SyncOptions{
+		Exclude: []string{"*.tmp", ".git"},
+		Upload:  &storage.UploadOptions{PartSize: storage.MinPartSize},
+	}
+	var mu sync.Mutex
+	var events []string
+	opts.OnFile = func(key, action string) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, action+" "+key)
+	}
+
+	result, err := storage.SyncDir(ctx, dir, vol, "/release/", opts)
+	if err != nil {
+		t.Fatalf("SyncDir: %v", err)
+	}
+	if want := []string{"release/config.json", "release/shards/model-00001.bin"}; !reflect.DeepEqual(result.Uploaded, want) {
+		t.Fatalf("uploaded = %v", result.Uploaded)
+	}
+	if len(result.Deleted) != 0 || len(events) != 2 || result.BytesUploaded != int64(12+2*storage.MinPartSize+10) {
+		t.Fatalf("result = %v, events = %v", result, events)
+	}
+
+	// Nothing changed: the multipart ETag is recomputed locally and matches.
+	result, err = storage.SyncDir(ctx, dir, vol, "release", opts)
+	if err != nil {
+		t.Fatalf("second SyncDir: %v", err)
+	}
+	if len(result.Uploaded) != 0 || len(result.Unchanged) != 2 {
+		t.Fatalf("second sync = %v", result)
+	}
+
+	// Same size, different content is re-uploaded; deletion is opt-in, and a
+	// dry run reports without touching the volume.
+	write("config.json", []byte(`{"dim":8192}`))
+	opts.Delete, opts.DryRun = true, true
+	result, err = storage.SyncDir(ctx, dir, vol, "release", opts)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if !reflect.DeepEqual(result.Uploaded, []string{"release/config.json"}) || !reflect.DeepEqual(result.Deleted, []string{"release/stale.bin"}) {
+		t.Fatalf("dry run = %v", result)
+	}
+	if got, _ := srv.Object("vol-1", "release/config.json"); string(got) != `{"dim":4096}` {
+		t.Fatalf("dry run uploaded: %q", got)
+	}
+
+	opts.DryRun = false
+	result, err = storage.SyncDir(ctx, dir, vol, "release", opts)
+	if err != nil {
+		t.Fatalf("SyncDir with delete: %v", err)
+	}
+	if result.String() != "1 uploaded (12 bytes), 1 deleted, 1 unchanged" {
+		t.Fatalf("summary = %s", result)
+	}
+	if got, _ := srv.Object("vol-1", "release/config.json"); string(got) != `{"dim":8192}` {
+		t.Fatalf("config = %q", got)
+	}
+	if keys := srv.Keys("vol-1"); !reflect.DeepEqual(keys, []string{"other/keep.bin", "release/config.json", "release/shards/model-00001.bin"}) {
+		t.Fatalf("keys = %v", keys)
+	}
+}
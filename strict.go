@@ -0,0 +1,174 @@
+package runpod
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// requiredFields are the response fields strict decoding insists on, by
+// type. Everything else the API may omit.
+var requiredFields = map[reflect.Type][]string{
+	reflect.TypeFor[Pod]():                   {"id", "desiredStatus"},
+	reflect.TypeFor[Endpoint]():              {"id", "name"},
+	reflect.TypeFor[Template]():              {"id", "name", "imageName"},
+	reflect.TypeFor[Job]():                   {"id", "status"},
+	reflect.TypeFor[NetworkVolume]():         {"id", "name", "size", "dataCenterId"},
+	reflect.TypeFor[Secret]():                {"id", "name"},
+	reflect.TypeFor[ContainerRegistryAuth](): {"id", "name"},
+}
+
+// aliasFields are the extra names a type's UnmarshalJSON accepts.
+var aliasFields = map[reflect.Type][]string{
+	reflect.TypeFor[PodRuntime](): {"uptimeInSeconds"},
+}
+
+var unmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+
+// checkShape compares body with the type v decodes it into, when strict
+// decoding is on.
+func (c *Client) checkShape(body []byte, v interface{}) error {
+	if !c.strictDecoding || v == nil {
+		return nil
+	}
+	if _, ok := v.(*json.RawMessage); ok {
+		return nil
+	}
+	return shapeOf(body, reflect.TypeOf(v))
+}
+
+// checkListShape is checkShape for list responses that arrive as a bare
+// array or wrapped in an object: each array in the wrapper is checked
+// against items, a slice value such as []Pod(nil), and other wrapper fields
+// (pageInfo and the like) are left alone.
+func (c *Client) checkListShape(raw json.RawMessage, items interface{}) error {
+	if !c.strictDecoding {
+		return nil
+	}
+	t := reflect.TypeOf(items)
+	var wrapped map[string]json.RawMessage
+	if json.Unmarshal(raw, &wrapped) != nil {
+		return shapeOf(raw, t)
+	}
+	for _, key := range sortedKeys(wrapped) {
+		if v := wrapped[key]; len(v) > 0 && v[0] == '[' {
+			if err := shapeOf(v, t); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func shapeOf(body []byte, t reflect.Type) error {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil // the decode itself reports malformed JSON
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	e := &ShapeError{Type: t.String()}
+	walkShape(e, "", v, t)
+	if len(e.Unknown) == 0 && len(e.Missing) == 0 {
+		return nil
+	}
+	return e
+}
+
+func walkShape(e *ShapeError, path string, v interface{}, t reflect.Type) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if v == nil {
+		return
+	}
+	// Non-struct types with their own decoding (PodPorts, DurationMS, ...)
+	// accept shapes their Go type doesn't spell out.
+	if t.Kind() != reflect.Struct && reflect.PointerTo(t).Implements(unmarshalerType) {
+		return
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return
+		}
+		fields := jsonFields(t)
+		for _, alias := range aliasFields[t] {
+			fields[alias] = nil
+		}
+		for _, key := range sortedKeys(obj) {
+			field, ok := fields[key]
+			if !ok {
+				field, ok = fields[strings.ToLower(key)]
+			}
+			if !ok {
+				e.Unknown = append(e.Unknown, jsonPath(path, key))
+				continue
+			}
+			if field != nil {
+				walkShape(e, jsonPath(path, key), obj[key], field)
+			}
+		}
+		for _, key := range requiredFields[t] {
+			if obj[key] == nil {
+				e.Missing = append(e.Missing, jsonPath(path, key))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if list, ok := v.([]interface{}); ok {
+			for i, item := range list {
+				walkShape(e, fmt.Sprintf("%s[%d]", path, i), item, t.Elem())
+			}
+		}
+	case reflect.Map:
+		if obj, ok := v.(map[string]interface{}); ok {
+			for _, key := range sortedKeys(obj) {
+				walkShape(e, jsonPath(path, key), obj[key], t.Elem())
+			}
+		}
+	}
+}
+
+// jsonFields maps t's JSON field names to their types the way
+// encoding/json does, with lower-cased names added for its
+// case-insensitive matching.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || (f.Anonymous && f.Tag.Get("json") == "") {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+		if _, ok := fields[strings.ToLower(name)]; !ok {
+			fields[strings.ToLower(name)] = f.Type
+		}
+	}
+	return fields
+}
+
+func jsonPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,53 @@
+package runpod_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestStrictDecoding(t *testing.T) {
+	ctx := context.Background()
+	srv := runpodtest.New()
+	defer srv.Close()
+	srv.AddPod(&runpod.Pod{ID: "pod-1", Name: "p", DesiredStatus: "RUNNING"})
+	client := srv.MustClient(runpod.WithStrictDecoding())
+	if _, err := client.GetPod(ctx, "pod-1"); err != nil {
+		t.Fatalf("GetPod: %v", err)
+	}
+	if _, err := client.ListPodsPage(ctx, &runpod.ListOptions{Limit: 1}); err != nil {
+		t.Fatalf("ListPodsPage: %v", err)
+	}
+
+	body := `{"id":"pod-1","desiredStatus":"RUNNING","gpuCount":1,"runtime":{"uptimeSeconds":3,"temperature":71,"ports":{"22/tcp":1}}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/pods" {
+			fmt.Fprint(w, `{"pods":[{"name":"no-id","desiredStatus":"EXITED"}],"pageInfo":{}}`)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	defer server.Close()
+
+	// Lenient by default.
+	if pod, err := mustClient(t, "test_key", runpod.WithBaseURL(server.URL)).GetPod(ctx, "pod-1"); err != nil || pod.Runtime.UptimeSeconds != 3 {
+		t.Fatalf("lenient GetPod = %+v, %v", pod, err)
+	}
+
+	strict := mustClient(t, "test_key", runpod.WithBaseURL(server.URL), runpod.WithStrictDecoding())
+	_, err := strict.GetPod(ctx, "pod-1")
+	var shape *runpod.ShapeError
+	if !errors.As(err, &shape) || fmt.Sprint(shape.Unknown) != "[runtime.temperature]" || len(shape.Missing) != 0 {
+		t.Fatalf("GetPod err = %v", err)
+	}
+	_, err = strict.ListPods(ctx, nil)
+	if !errors.As(err, &shape) || fmt.Sprint(shape.Missing) != "[[0].id]" || shape.Type != "[]*runpod.Pod" {
+		t.Fatalf("ListPods err = %v", err)
+	}
+}
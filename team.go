@@ -0,0 +1,118 @@
+package runpod
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Team member roles, from least to most privileged.
+const (
+	TeamRoleBasic   = "BASIC"
+	TeamRoleBilling = "BILLING"
+	TeamRoleDev     = "DEV"
+	TeamRoleAdmin   = "ADMIN"
+)
+
+// TeamMember is a member of (or a pending invitation to) the team account
+// the client's API key belongs to.
+type TeamMember struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+	Role  string `json:"role"`
+	// Pending is true for invitations that have not been accepted yet.
+	Pending   bool      `json:"pending,omitempty"`
+	CreatedAt *JSONTime `json:"createdAt,omitempty"`
+}
+
+const teamMemberFields = `id email role pending createdAt`
+
+// ListTeamMembers lists the team's members and pending invitations.
+func (c *Client) ListTeamMembers(ctx context.Context) ([]TeamMember, error) {
+	var payload struct {
+		Myself struct {
+			TeamMembers []TeamMember `json:"teamMembers"`
+		} `json:"myself"`
+	}
+	if err := c.GraphQL(ctx, `query { myself { teamMembers { `+teamMemberFields+` } } }`, nil, &payload); err != nil {
+		return nil, fmt.Errorf("failed to list team members: %w", err)
+	}
+	return payload.Myself.TeamMembers, nil
+}
+
+// InviteTeamMember invites email to the team with role. The returned member
+// is Pending until the invitation is accepted.
+func (c *Client) InviteTeamMember(ctx context.Context, email, role string) (*TeamMember, error) {
+	email = strings.TrimSpace(email)
+	if !strings.Contains(email, "@") {
+		return nil, NewValidationErrorWithValue("email", "must be an email address", email)
+	}
+	role, err := normalizeTeamRole(role)
+	if err != nil {
+		return nil, err
+	}
+	var payload struct {
+		Member *TeamMember `json:"inviteTeamMember"`
+	}
+	if err := c.graphQLMutation(ctx, Mutation{ResourceType: "teamMember", ResourceID: email, Action: "invite"}, `mutation InviteTeamMember($input: TeamInviteInput!) {
+  inviteTeamMember(input: $input) { `+teamMemberFields+` }
+}`, map[string]interface{}{
+		"input": map[string]interface{}{"email": email, "role": role},
+	}, &payload); err != nil {
+		return nil, fmt.Errorf("failed to invite team member %s: %w", email, err)
+	}
+	if payload.Member == nil {
+		return nil, fmt.Errorf("failed to invite team member %s: response omitted member", email)
+	}
+	return payload.Member, nil
+}
+
+// UpdateTeamMemberRole assigns role to a member or pending invitation.
+func (c *Client) UpdateTeamMemberRole(ctx context.Context, memberID, role string) (*TeamMember, error) {
+	if err := c.validateRequired("memberID", memberID); err != nil {
+		return nil, err
+	}
+	role, err := normalizeTeamRole(role)
+	if err != nil {
+		return nil, err
+	}
+	var payload struct {
+		Member *TeamMember `json:"updateTeamMemberRole"`
+	}
+	if err := c.graphQLMutation(ctx, Mutation{ResourceType: "teamMember", ResourceID: memberID, Action: "update-role"}, `mutation UpdateTeamMemberRole($input: TeamMemberRoleInput!) {
+  updateTeamMemberRole(input: $input) { `+teamMemberFields+` }
+}`, map[string]interface{}{
+		"input": map[string]interface{}{"memberId": memberID, "role": role},
+	}, &payload); err != nil {
+		return nil, fmt.Errorf("failed to update team member %s: %w", memberID, err)
+	}
+	if payload.Member == nil {
+		return nil, fmt.Errorf("failed to update team member %s: response omitted member", memberID)
+	}
+	return payload.Member, nil
+}
+
+// RemoveTeamMember removes a member from the team or revokes a pending
+// invitation.
+func (c *Client) RemoveTeamMember(ctx context.Context, memberID string) error {
+	if err := c.validateRequired("memberID", memberID); err != nil {
+		return err
+	}
+	if err := c.graphQLMutation(ctx, Mutation{ResourceType: "teamMember", ResourceID: memberID, Action: "remove"}, `mutation RemoveTeamMember($input: TeamMemberInput!) {
+  removeTeamMember(input: $input)
+}`, map[string]interface{}{
+		"input": map[string]interface{}{"memberId": memberID},
+	}, nil); err != nil {
+		return fmt.Errorf("failed to remove team member %s: %w", memberID, err)
+	}
+	return nil
+}
+
+func normalizeTeamRole(role string) (string, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(role))
+	switch normalized {
+	case TeamRoleBasic, TeamRoleBilling, TeamRoleDev, TeamRoleAdmin:
+		return normalized, nil
+	}
+	return "", NewValidationErrorWithValue("role", "must be one of BASIC, BILLING, DEV, ADMIN", role)
+}
@@ -0,0 +1,55 @@
+package runpod_test
+
+import (
+	"errors"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestTeamMembers(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+	ctx := t.Context()
+	srv.AddTeamMember(runpod.TeamMember{ID: "owner", Email: "owner@example.com", Role: runpod.TeamRoleAdmin})
+
+	invited, err := client.InviteTeamMember(ctx, " new.hire@example.com ", "dev")
+	if err != nil {
+		t.Fatalf("InviteTeamMember: %v", err)
+	}
+	if invited.Email != "new.hire@example.com" || invited.Role != runpod.TeamRoleDev || !invited.Pending {
+		t.Fatalf("invited = %+v", invited)
+	}
+	if _, err := client.InviteTeamMember(ctx, "new.hire@example.com", runpod.TeamRoleDev); err == nil {
+		t.Fatal("duplicate invitation must fail")
+	}
+
+	updated, err := client.UpdateTeamMemberRole(ctx, invited.ID, runpod.TeamRoleBilling)
+	if err != nil || updated.Role != runpod.TeamRoleBilling {
+		t.Fatalf("UpdateTeamMemberRole = %+v, %v", updated, err)
+	}
+	members, err := client.ListTeamMembers(ctx)
+	if err != nil || len(members) != 2 || members[1].Role != runpod.TeamRoleBilling {
+		t.Fatalf("ListTeamMembers = %+v, %v", members, err)
+	}
+
+	if err := client.RemoveTeamMember(ctx, invited.ID); err != nil {
+		t.Fatalf("RemoveTeamMember: %v", err)
+	}
+	if members, _ := client.ListTeamMembers(ctx); len(members) != 1 || members[0].ID != "owner" {
+		t.Fatalf("members after remove = %+v", members)
+	}
+	if err := client.RemoveTeamMember(ctx, invited.ID); err == nil {
+		t.Fatal("removing an unknown member must fail")
+	}
+
+	var validationErr *runpod.ValidationError
+	if _, err := client.InviteTeamMember(ctx, "not-an-email", runpod.TeamRoleDev); !errors.As(err, &validationErr) {
+		t.Fatalf("bad email: %v", err)
+	}
+	if _, err := client.UpdateTeamMemberRole(ctx, "owner", "superuser"); !errors.As(err, &validationErr) {
+		t.Fatalf("bad role: %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package runpod
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// TemplateOverrides customizes a cloned template. Zero-valued fields keep the
+// source template's value.
+type TemplateOverrides struct {
+	// ImageName replaces the whole image ref.
+	ImageName string
+	// ImageTag replaces only the tag of the image ref (e.g. "1.5.0"), keeping
+	// the repository. Ignored when ImageName is set.
+	ImageTag string
+	// Env entries are merged over the source env; an entry with an empty
+	// value removes that key.
+	Env                     map[string]string
+	ContainerDiskInGB       int
+	VolumeInGB              int
+	VolumeMountPath         string
+	ContainerRegistryAuthID string
+	Readme                  string
+}
+
+// CloneTemplate creates a new template named newName from an existing one,
+// applying overrides (which may be nil). The clone is always private, even
+// when the source template is public.
+func (c *Client) CloneTemplate(ctx context.Context, templateID, newName string, overrides *TemplateOverrides) (*Template, error) {
+	if err := c.validateRequired("templateID", templateID); err != nil {
+		return nil, err
+	}
+	if err := c.validateRequired("newName", newName); err != nil {
+		return nil, err
+	}
+
+	src, err := c.GetTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	req := cloneTemplateRequest(src, newName, overrides)
+
+	created, err := c.CreateTemplate(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone template %s: %w", templateID, err)
+	}
+	return created, nil
+}
+
+// cloneTemplateRequest builds the create request for a clone of src without
+// aliasing src's maps or slices.
+func cloneTemplateRequest(src *Template, newName string, overrides *TemplateOverrides) *CreateTemplateRequest {
+	req := &CreateTemplateRequest{
+		Name:                    newName,
+		ImageName:               src.ImageName,
+		Category:                src.Category,
+		ContainerDiskInGB:       src.ContainerDiskInGB,
+		VolumeInGB:              src.VolumeInGB,
+		VolumeMountPath:         src.VolumeMountPath,
+		ContainerRegistryAuthID: src.ContainerRegistryAuthID,
+		DockerEntrypoint:        append([]string(nil), src.DockerEntrypoint...),
+		DockerStartCmd:          append([]string(nil), src.DockerStartCmd...),
+		Ports:                   append([]string(nil), src.Ports...),
+		IsServerless:            src.IsServerless,
+		Readme:                  src.Readme,
+	}
+	if overrides == nil {
+		req.Env, _ = MergeEnv(EnvLayer{Name: EnvLayerTemplate, Env: src.Env})
+		return req
+	}
+
+	switch {
+	case strings.TrimSpace(overrides.ImageName) != "":
+		req.ImageName = strings.TrimSpace(overrides.ImageName)
+	case strings.TrimSpace(overrides.ImageTag) != "":
+		req.ImageName = withImageTag(req.ImageName, strings.TrimSpace(overrides.ImageTag))
+	}
+	req.Env, _ = MergeEnv(
+		EnvLayer{Name: EnvLayerTemplate, Env: src.Env},
+		EnvLayer{Name: "override", Env: overrides.Env},
+	)
+	for k, v := range overrides.Env {
+		if v == "" {
+			delete(req.Env, k)
+		}
+	}
+	if overrides.ContainerDiskInGB > 0 {
+		req.ContainerDiskInGB = overrides.ContainerDiskInGB
+	}
+	if overrides.VolumeInGB > 0 {
+		req.VolumeInGB = overrides.VolumeInGB
+	}
+	if overrides.VolumeMountPath != "" {
+		req.VolumeMountPath = overrides.VolumeMountPath
+	}
+	if overrides.ContainerRegistryAuthID != "" {
+		req.ContainerRegistryAuthID = overrides.ContainerRegistryAuthID
+	}
+	if overrides.Readme != "" {
+		req.Readme = overrides.Readme
+	}
+	return req
+}
+
+// splitImageTag splits an image ref into repository and tag. A digest
+// ("@sha256:...") is dropped; the tag is empty when the ref has none. The
+// colon of a registry port ("host:5000/repo") is not mistaken for a tag.
+func splitImageTag(ref string) (repo, tag string) {
+	ref = strings.TrimSpace(ref)
+	if i := strings.Index(ref, "@"); i >= 0 {
+		ref = ref[:i]
+	}
+	slash := strings.LastIndex(ref, "/")
+	if colon := strings.LastIndex(ref, ":"); colon > slash {
+		return ref[:colon], ref[colon+1:]
+	}
+	return ref, ""
+}
+
+// withImageTag replaces the tag (and any digest) of ref.
+func withImageTag(ref, tag string) string {
+	repo, _ := splitImageTag(ref)
+	return repo + ":" + tag
+}
@@ -0,0 +1,67 @@
+package runpod_test
+
+import (
+	"context"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestCloneTemplate(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+	ctx := context.Background()
+
+	srv.AddTemplate(&runpod.Template{
+		ID:                "tpl-src",
+		Name:              "worker-1.4.0",
+		ImageName:         "registry.acme.io:5000/ml/worker:1.4.0@sha256:abc",
+		ContainerDiskInGB: 20,
+		Env:               map[string]string{"MODEL": "sdxl", "DEBUG": "1"},
+		Ports:             []string{"8000/http"},
+		IsPublic:          true,
+		IsServerless:      true,
+	})
+
+	clone, err := client.CloneTemplate(ctx, "tpl-src", "worker-1.5.0", &runpod.TemplateOverrides{
+		ImageTag: "1.5.0",
+		Env:      map[string]string{"MODEL": "flux", "DEBUG": ""},
+	})
+	if err != nil {
+		t.Fatalf("CloneTemplate: %v", err)
+	}
+	if clone.ID == "tpl-src" || clone.Name != "worker-1.5.0" {
+		t.Fatalf("clone identity wrong: %+v", clone)
+	}
+	if clone.ImageName != "registry.acme.io:5000/ml/worker:1.5.0" {
+		t.Fatalf("image tag not replaced: %q", clone.ImageName)
+	}
+	if clone.Env["MODEL"] != "flux" {
+		t.Fatalf("env override not applied: %v", clone.Env)
+	}
+	if _, ok := clone.Env["DEBUG"]; ok {
+		t.Fatalf("empty override must remove the key: %v", clone.Env)
+	}
+	if clone.IsPublic || !clone.IsServerless || clone.ContainerDiskInGB != 20 || len(clone.Ports) != 1 {
+		t.Fatalf("clone did not carry source config: %+v", clone)
+	}
+
+	// The source template is untouched.
+	if src := srv.Template("tpl-src"); src.Env["DEBUG"] != "1" || src.ImageName != "registry.acme.io:5000/ml/worker:1.4.0@sha256:abc" {
+		t.Fatalf("source template mutated: %+v", src)
+	}
+
+	untagged, err := client.CloneTemplate(ctx, "tpl-src", "worker-copy", nil)
+	if err != nil {
+		t.Fatalf("CloneTemplate without overrides: %v", err)
+	}
+	if untagged.ImageName != "registry.acme.io:5000/ml/worker:1.4.0@sha256:abc" {
+		t.Fatalf("nil overrides must keep the image: %q", untagged.ImageName)
+	}
+
+	if _, err := client.CloneTemplate(ctx, "tpl-src", "", nil); err == nil {
+		t.Fatal("empty newName must fail validation")
+	}
+}
@@ -0,0 +1,307 @@
+package runpod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Template versioning convention: a logical template "worker" is stored as
+// one RunPod template per release named "worker-v1.4.0". RunPod has no
+// native template versioning; the SDK owns the name suffix so deploy tooling
+// can resolve "latest compatible" programmatically.
+
+// templateVersionSep separates the logical name from the semver suffix.
+const templateVersionSep = "-v"
+
+// SemVer is a parsed semantic version (https://semver.org).
+type SemVer struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+	Build      string
+}
+
+// ParseSemVer parses "1.2.3", "1.2.3-rc.1" or "1.2.3+build"; a leading "v"
+// is accepted.
+func ParseSemVer(s string) (SemVer, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	var v SemVer
+	if i := strings.Index(s, "+"); i >= 0 {
+		v.Build = s[i+1:]
+		s = s[:i]
+		if v.Build == "" {
+			return SemVer{}, fmt.Errorf("invalid semver: empty build metadata")
+		}
+	}
+	if i := strings.Index(s, "-"); i >= 0 {
+		v.Prerelease = s[i+1:]
+		s = s[:i]
+		if v.Prerelease == "" {
+			return SemVer{}, fmt.Errorf("invalid semver: empty prerelease")
+		}
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return SemVer{}, fmt.Errorf("invalid semver %q: want MAJOR.MINOR.PATCH", s)
+	}
+	nums := make([]int, 3)
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 || (len(p) > 1 && p[0] == '0') {
+			return SemVer{}, fmt.Errorf("invalid semver %q: bad numeric component %q", s, p)
+		}
+		nums[i] = n
+	}
+	v.Major, v.Minor, v.Patch = nums[0], nums[1], nums[2]
+	return v, nil
+}
+
+// String formats the version without a leading "v".
+func (v SemVer) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// Compare returns -1, 0 or +1 by semver precedence. Build metadata is
+// ignored; a prerelease sorts before its release.
+func (v SemVer) Compare(o SemVer) int {
+	for _, d := range []int{v.Major - o.Major, v.Minor - o.Minor, v.Patch - o.Patch} {
+		if d < 0 {
+			return -1
+		}
+		if d > 0 {
+			return 1
+		}
+	}
+	switch {
+	case v.Prerelease == o.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case o.Prerelease == "":
+		return -1
+	}
+	a, b := strings.Split(v.Prerelease, "."), strings.Split(o.Prerelease, ".")
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if c := comparePrereleaseIdent(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+	return 0
+}
+
+// CompatibleWith reports whether v can replace base under caret rules: same
+// major version (same minor while major is 0) and not older than base.
+func (v SemVer) CompatibleWith(base SemVer) bool {
+	if v.Major != base.Major {
+		return false
+	}
+	if v.Major == 0 && v.Minor != base.Minor {
+		return false
+	}
+	return v.Compare(base) >= 0
+}
+
+func comparePrereleaseIdent(a, b string) int {
+	an, aErr := strconv.Atoi(a)
+	bn, bErr := strconv.Atoi(b)
+	switch {
+	case aErr == nil && bErr == nil:
+		if an < bn {
+			return -1
+		}
+		if an > bn {
+			return 1
+		}
+		return 0
+	case aErr == nil:
+		return -1 // numeric identifiers sort before alphanumeric ones
+	case bErr == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// TemplateVersionName returns the stored template name for a logical name
+// and version, e.g. ("worker", "1.4.0") -> "worker-v1.4.0".
+func TemplateVersionName(name, version string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", NewValidationError("name", "cannot be empty")
+	}
+	v, err := ParseSemVer(version)
+	if err != nil {
+		return "", NewValidationErrorWithValue("version", err.Error(), version)
+	}
+	return name + templateVersionSep + v.String(), nil
+}
+
+// ParseTemplateVersionName splits a stored template name into its logical
+// name and version. ok is false for names not following the convention.
+func ParseTemplateVersionName(stored string) (name string, version SemVer, ok bool) {
+	// Walk separators right to left: a prerelease may itself contain "-v"
+	// ("1.0.0-very"), so the last occurrence is not necessarily the suffix.
+	for end := len(stored); end > 0; {
+		i := strings.LastIndex(stored[:end], templateVersionSep)
+		if i <= 0 {
+			break
+		}
+		if v, err := ParseSemVer(stored[i+len(templateVersionSep):]); err == nil {
+			return stored[:i], v, true
+		}
+		end = i
+	}
+	return "", SemVer{}, false
+}
+
+// TemplateVersion is one stored release of a logical template.
+type TemplateVersion struct {
+	Name     string // logical name, without the version suffix
+	Version  SemVer
+	Template Template
+}
+
+// ListTemplateVersions returns every stored version of the logical template
+// name, newest first.
+func (c *Client) ListTemplateVersions(ctx context.Context, name string) ([]TemplateVersion, error) {
+	if err := c.validateRequired("name", name); err != nil {
+		return nil, err
+	}
+	name = strings.TrimSpace(name)
+	templates, err := c.ListTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []TemplateVersion
+	for _, tpl := range templates {
+		logical, v, ok := ParseTemplateVersionName(tpl.Name)
+		if !ok || logical != name {
+			continue
+		}
+		versions = append(versions, TemplateVersion{Name: logical, Version: v, Template: tpl})
+	}
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].Version.Compare(versions[j].Version) > 0
+	})
+	return versions, nil
+}
+
+// GetLatestTemplateVersion returns the newest release of the logical
+// template name. Prereleases are skipped. When compatibleWith is non-empty,
+// only versions satisfying SemVer.CompatibleWith it are considered — a
+// deploy pinned to "1.4.0" picks up 1.x fixes but never 2.0.0. Returns an
+// error matching ErrNotFound when nothing qualifies.
+func (c *Client) GetLatestTemplateVersion(ctx context.Context, name, compatibleWith string) (*TemplateVersion, error) {
+	var base *SemVer
+	if strings.TrimSpace(compatibleWith) != "" {
+		v, err := ParseSemVer(compatibleWith)
+		if err != nil {
+			return nil, NewValidationErrorWithValue("compatibleWith", err.Error(), compatibleWith)
+		}
+		base = &v
+	}
+
+	versions, err := c.ListTemplateVersions(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range versions {
+		if v.Version.Prerelease != "" {
+			continue
+		}
+		if base != nil && !v.Version.CompatibleWith(*base) {
+			continue
+		}
+		found := v
+		return &found, nil
+	}
+	return nil, NewAPIErrorWithDetails(404, "template version not found", name)
+}
+
+// CreateTemplateVersion stores req as release version of the logical
+// template req.Name. It refuses to overwrite an existing version; the
+// caller's request is not mutated.
+func (c *Client) CreateTemplateVersion(ctx context.Context, version string, req *CreateTemplateRequest) (*TemplateVersion, error) {
+	if req == nil {
+		return nil, NewValidationError("request", "cannot be nil")
+	}
+	stored, err := TemplateVersionName(req.Name, version)
+	if err != nil {
+		return nil, err
+	}
+	v, _ := ParseSemVer(version)
+
+	existing, err := c.ListTemplateVersions(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range existing {
+		if e.Version.Compare(v) == 0 {
+			return nil, NewValidationErrorWithValue("version", "already exists", e.Template.Name)
+		}
+	}
+
+	versioned := *req
+	versioned.Name = stored
+	tpl, err := c.CreateTemplate(ctx, &versioned)
+	if err != nil {
+		return nil, err
+	}
+	return &TemplateVersion{Name: strings.TrimSpace(req.Name), Version: v, Template: *tpl}, nil
+}
+
+// PruneTemplateVersions deletes all but the newest keep releases of the
+// logical template name and returns the versions it deleted. Only stable
+// releases count toward keep: prereleases newer than the oldest release
+// kept are kept too, and older ones are deleted. Deletion continues past
+// individual failures (RunPod refuses to delete a template still bound to
+// an endpoint); those are joined into the returned error.
+func (c *Client) PruneTemplateVersions(ctx context.Context, name string, keep int) ([]TemplateVersion, error) {
+	if keep < 1 {
+		return nil, NewValidationErrorWithValue("keep", "must be at least 1", keep)
+	}
+	versions, err := c.ListTemplateVersions(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	cut, stable := len(versions), 0
+	for i, v := range versions {
+		if v.Version.Prerelease != "" {
+			continue
+		}
+		stable++
+		if stable == keep {
+			cut = i + 1
+			break
+		}
+	}
+
+	var deleted []TemplateVersion
+	var errs []error
+	for _, v := range versions[cut:] {
+		if err := c.DeleteTemplate(ctx, v.Template.ID); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		deleted = append(deleted, v)
+	}
+	return deleted, errors.Join(errs...)
+}
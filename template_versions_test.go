@@ -0,0 +1,132 @@
+package runpod_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestSemVer(t *testing.T) {
+	ordered := []string{"0.9.0", "1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.2.0", "1.10.0", "2.0.0"}
+	for i := 1; i < len(ordered); i++ {
+		a, err := runpod.ParseSemVer(ordered[i-1])
+		if err != nil {
+			t.Fatalf("ParseSemVer(%q): %v", ordered[i-1], err)
+		}
+		b, err := runpod.ParseSemVer(ordered[i])
+		if err != nil {
+			t.Fatalf("ParseSemVer(%q): %v", ordered[i], err)
+		}
+		if a.Compare(b) != -1 || b.Compare(a) != 1 {
+			t.Errorf("expected %s < %s", a, b)
+		}
+	}
+	for _, bad := range []string{"", "1.2", "1.2.x", "01.2.3", "1.2.3-", "1.2.3+"} {
+		if _, err := runpod.ParseSemVer(bad); err == nil {
+			t.Errorf("ParseSemVer(%q) must fail", bad)
+		}
+	}
+
+	v, _ := runpod.ParseSemVer("v1.4.2+git.abc")
+	if v.String() != "1.4.2+git.abc" {
+		t.Errorf("String = %q", v.String())
+	}
+	base, _ := runpod.ParseSemVer("1.4.0")
+	if !v.CompatibleWith(base) {
+		t.Error("1.4.2 must be compatible with 1.4.0")
+	}
+	two, _ := runpod.ParseSemVer("2.0.0")
+	if two.CompatibleWith(base) {
+		t.Error("2.0.0 must not be compatible with 1.4.0")
+	}
+}
+
+func TestTemplateVersionName(t *testing.T) {
+	stored, err := runpod.TemplateVersionName("sd-worker-v2", "v1.4.0")
+	if err != nil || stored != "sd-worker-v2-v1.4.0" {
+		t.Fatalf("TemplateVersionName = %q, %v", stored, err)
+	}
+	name, v, ok := runpod.ParseTemplateVersionName(stored)
+	if !ok || name != "sd-worker-v2" || v.String() != "1.4.0" {
+		t.Fatalf("ParseTemplateVersionName = %q %v %v", name, v, ok)
+	}
+	if name, v, ok := runpod.ParseTemplateVersionName("worker-v1.0.0-very.1"); !ok || name != "worker" || v.Prerelease != "very.1" {
+		t.Fatalf("prerelease containing the separator = %q %v %v", name, v, ok)
+	}
+	if _, _, ok := runpod.ParseTemplateVersionName("sd-worker-v2"); ok {
+		t.Fatal("unversioned names must not parse")
+	}
+	if _, err := runpod.TemplateVersionName("worker", "latest"); err == nil {
+		t.Fatal("non-semver version must fail validation")
+	}
+}
+
+func TestTemplateVersionLifecycle(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+	ctx := context.Background()
+
+	for _, version := range []string{"1.0.0", "1.4.0", "2.0.0-rc.1", "1.10.0", "2.0.0"} {
+		if _, err := client.CreateTemplateVersion(ctx, version, &runpod.CreateTemplateRequest{
+			Name: "worker", ImageName: "acme/worker:" + version,
+		}); err != nil {
+			t.Fatalf("CreateTemplateVersion(%s): %v", version, err)
+		}
+	}
+	srv.AddTemplate(&runpod.Template{ID: "other", Name: "other-v9.9.9", ImageName: "x"})
+
+	if _, err := client.CreateTemplateVersion(ctx, "1.4.0", &runpod.CreateTemplateRequest{Name: "worker", ImageName: "x"}); err == nil {
+		t.Fatal("duplicate version must be rejected")
+	}
+
+	versions, err := client.ListTemplateVersions(ctx, " worker ")
+	if err != nil {
+		t.Fatalf("ListTemplateVersions: %v", err)
+	}
+	var got []string
+	for _, v := range versions {
+		got = append(got, v.Version.String())
+	}
+	want := []string{"2.0.0", "2.0.0-rc.1", "1.10.0", "1.4.0", "1.0.0"}
+	if len(got) != len(want) {
+		t.Fatalf("versions = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("versions = %v, want %v", got, want)
+		}
+	}
+
+	latest, err := client.GetLatestTemplateVersion(ctx, "worker", "")
+	if err != nil || latest.Version.String() != "2.0.0" {
+		t.Fatalf("latest = %+v, %v", latest, err)
+	}
+	compatible, err := client.GetLatestTemplateVersion(ctx, "worker", "1.4.0")
+	if err != nil || compatible.Template.ImageName != "acme/worker:1.10.0" {
+		t.Fatalf("latest compatible with 1.4.0 = %+v, %v", compatible, err)
+	}
+	if _, err := client.GetLatestTemplateVersion(ctx, "worker", "3.0.0"); !errors.Is(err, runpod.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	// Only stable releases count toward keep; the release candidate between
+	// the two kept releases stays.
+	deleted, err := client.PruneTemplateVersions(ctx, " worker", 2)
+	if err != nil {
+		t.Fatalf("PruneTemplateVersions: %v", err)
+	}
+	if len(deleted) != 2 || deleted[0].Version.String() != "1.4.0" || deleted[1].Version.String() != "1.0.0" {
+		t.Fatalf("pruned = %+v", deleted)
+	}
+	remaining, _ := client.ListTemplateVersions(ctx, "worker")
+	if len(remaining) != 3 || remaining[1].Version.String() != "2.0.0-rc.1" || remaining[2].Version.String() != "1.10.0" {
+		t.Fatalf("remaining = %+v", remaining)
+	}
+	if srv.Template("other") == nil {
+		t.Fatal("prune must not touch other logical templates")
+	}
+}
@@ -0,0 +1,315 @@
+package runpod
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Template is a reusable pod / serverless worker configuration (REST
+// /templates). Pods reference one via CreatePodRequest.TemplateID.
+type Template struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	ImageName string `json:"imageName"`
+	// Category is the compute class the template targets: "NVIDIA", "AMD"
+	// or "CPU".
+	Category          string `json:"category,omitempty"`
+	ContainerDiskInGB int    `json:"containerDiskInGb"`
+	VolumeInGB        int    `json:"volumeInGb"`
+	VolumeMountPath   string `json:"volumeMountPath,omitempty"`
+	// ContainerRegistryAuthID references a stored registry credential (see
+	// CreateContainerRegistryAuth) used to pull a private ImageName.
+	ContainerRegistryAuthID string            `json:"containerRegistryAuthId,omitempty"`
+	DockerEntrypoint        []string          `json:"dockerEntrypoint,omitempty"`
+	DockerStartCmd          []string          `json:"dockerStartCmd,omitempty"`
+	Env                     map[string]string `json:"env,omitempty"`
+	Ports                   []string          `json:"ports,omitempty"`
+	IsPublic                bool              `json:"isPublic"`
+	IsServerless            bool              `json:"isServerless"`
+	IsRunpod                bool              `json:"isRunpod,omitempty"`
+	Readme                  string            `json:"readme,omitempty"`
+
+	raw string // see Raw
+}
+
+// CreateTemplateRequest configures template creation (REST POST /templates).
+type CreateTemplateRequest struct {
+	Name                    string            `json:"name"`
+	ImageName               string            `json:"imageName"`
+	Category                string            `json:"category,omitempty"`
+	ContainerDiskInGB       int               `json:"containerDiskInGb,omitempty"`
+	VolumeInGB              int               `json:"volumeInGb,omitempty"`
+	VolumeMountPath         string            `json:"volumeMountPath,omitempty"`
+	ContainerRegistryAuthID string            `json:"containerRegistryAuthId,omitempty"`
+	DockerEntrypoint        []string          `json:"dockerEntrypoint,omitempty"`
+	DockerStartCmd          []string          `json:"dockerStartCmd,omitempty"`
+	Env                     map[string]string `json:"env,omitempty"`
+	Ports                   []string          `json:"ports,omitempty"`
+	IsPublic                bool              `json:"isPublic,omitempty"`
+	IsServerless            bool              `json:"isServerless,omitempty"`
+	Readme                  string            `json:"readme,omitempty"`
+}
+
+// UpdateTemplateRequest updates a template (REST PATCH /templates/{id}).
+// Nil fields are left unchanged and set fields are sent even when zero, so
+// IsPublic: runpod.Ptr(false) makes a template private. A non-nil empty
+// slice or map clears it.
+type UpdateTemplateRequest struct {
+	Name                    *string           `json:"name,omitempty"`
+	ImageName               *string           `json:"imageName,omitempty"`
+	ContainerDiskInGB       *int              `json:"containerDiskInGb,omitempty"`
+	VolumeInGB              *int              `json:"volumeInGb,omitempty"`
+	VolumeMountPath         *string           `json:"volumeMountPath,omitempty"`
+	ContainerRegistryAuthID *string           `json:"containerRegistryAuthId,omitempty"`
+	DockerEntrypoint        []string          `json:"dockerEntrypoint,omitzero"`
+	DockerStartCmd          []string          `json:"dockerStartCmd,omitzero"`
+	Env                     map[string]string `json:"env,omitzero"`
+	Ports                   []string          `json:"ports,omitzero"`
+	IsPublic                *bool             `json:"isPublic,omitempty"`
+	Readme                  *string           `json:"readme,omitempty"`
+}
+
+// TemplateSearchOptions filters SearchTemplates. String filters are
+// case-insensitive substring matches; zero values match everything.
+type TemplateSearchOptions struct {
+	// Name matches against the template name.
+	Name string
+	// Image matches against the image ref (e.g. "vllm", "comfyui").
+	Image string
+	// Category matches exactly: "NVIDIA", "AMD" or "CPU".
+	Category string
+	// Serverless, when set, keeps only serverless (true) or pod (false)
+	// templates.
+	Serverless *bool
+	// ExcludeRunpod drops RunPod's official templates, leaving only
+	// community-published ones.
+	ExcludeRunpod bool
+	// Limit caps the number of results (0 = no cap).
+	Limit int
+}
+
+// ListTemplates lists the account's own templates.
+func (c *Client) ListTemplates(ctx context.Context) ([]Template, error) {
+	templates, err := c.listTemplates(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	return templates, nil
+}
+
+// SearchTemplates searches RunPod's public template catalog (community and
+// official RunPod templates, as on the console's Explore page). The account's
+// own private templates are not included. Results keep the API's order and
+// their IDs are directly usable as CreatePodRequest.TemplateID.
+func (c *Client) SearchTemplates(ctx context.Context, opts *TemplateSearchOptions) ([]Template, error) {
+	if opts != nil && opts.Limit < 0 {
+		return nil, NewValidationErrorWithValue("limit", "cannot be negative", opts.Limit)
+	}
+	if opts == nil {
+		opts = &TemplateSearchOptions{}
+	}
+
+	params := map[string]string{"includePublicTemplates": "true"}
+	if !opts.ExcludeRunpod {
+		params["includeRunpodTemplates"] = "true"
+	}
+	templates, err := c.listTemplates(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search templates: %w", err)
+	}
+
+	name := strings.ToLower(strings.TrimSpace(opts.Name))
+	image := strings.ToLower(strings.TrimSpace(opts.Image))
+	category := strings.TrimSpace(opts.Category)
+
+	out := make([]Template, 0, len(templates))
+	for _, tpl := range templates {
+		if !tpl.IsPublic && !tpl.IsRunpod {
+			continue
+		}
+		if opts.ExcludeRunpod && tpl.IsRunpod {
+			continue
+		}
+		if name != "" && !strings.Contains(strings.ToLower(tpl.Name), name) {
+			continue
+		}
+		if image != "" && !strings.Contains(strings.ToLower(tpl.ImageName), image) {
+			continue
+		}
+		if category != "" && !strings.EqualFold(tpl.Category, category) {
+			continue
+		}
+		if opts.Serverless != nil && tpl.IsServerless != *opts.Serverless {
+			continue
+		}
+		out = append(out, tpl)
+		if opts.Limit > 0 && len(out) == opts.Limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+// listTemplates performs GET /templates with optional include* parameters.
+func (c *Client) listTemplates(ctx context.Context, params map[string]string) ([]Template, error) {
+	// Accept both bare array and object wrapper shapes.
+	var raw json.RawMessage
+	if err := c.Get(ctx, c.buildURLWithParams("/templates", params), &raw); err != nil {
+		return nil, err
+	}
+	if err := c.checkListShape(raw, []Template(nil)); err != nil {
+		return nil, err
+	}
+	return decodeTemplateList(raw)
+}
+
+// GetTemplate retrieves a template by ID.
+func (c *Client) GetTemplate(ctx context.Context, templateID string) (*Template, error) {
+	if err := c.validateRequired("templateID", templateID); err != nil {
+		return nil, err
+	}
+
+	var template Template
+	if err := c.cachedGet(ctx, resourceKey{kind: "template", id: templateID}, "/templates/"+templateID, &template); err != nil {
+		return nil, fmt.Errorf("failed to get template %s: %w", templateID, err)
+	}
+	return &template, nil
+}
+
+// CreateTemplate creates a new template. Set ContainerRegistryAuthID to pull
+// a private image (see CreateContainerRegistryAuth /
+// EnsureContainerRegistryAuth).
+func (c *Client) CreateTemplate(ctx context.Context, req *CreateTemplateRequest) (*Template, error) {
+	if req == nil {
+		return nil, NewValidationError("request", "cannot be nil")
+	}
+	if err := c.validateRequest(req); err != nil {
+		return nil, err
+	}
+	if err := c.checkImage(ctx, "imageName", req.ImageName); err != nil {
+		return nil, err
+	}
+
+	var template Template
+	if err := c.Post(ctx, "/templates", req, &template); err != nil {
+		return nil, fmt.Errorf("failed to create template: %w", err)
+	}
+	return &template, nil
+}
+
+// UpdateTemplate updates a template. Pods and endpoints created from the
+// template pick up the change on their next (re)start.
+func (c *Client) UpdateTemplate(ctx context.Context, templateID string, req *UpdateTemplateRequest) (*Template, error) {
+	if err := c.validateRequired("templateID", templateID); err != nil {
+		return nil, err
+	}
+	if req == nil {
+		return nil, NewValidationError("request", "cannot be nil")
+	}
+	if err := c.validateRequest(req); err != nil {
+		return nil, err
+	}
+	if req.ImageName != nil {
+		if err := c.checkImage(ctx, "imageName", *req.ImageName); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.resources != nil {
+		defer c.resources.InvalidateTemplate(templateID)
+	}
+	var template Template
+	if err := c.Patch(ctx, "/templates/"+templateID, req, &template); err != nil {
+		return nil, fmt.Errorf("failed to update template %s: %w", templateID, err)
+	}
+	return &template, nil
+}
+
+// DeleteTemplate deletes a template by ID. RunPod refuses to delete a
+// template still referenced by an endpoint.
+func (c *Client) DeleteTemplate(ctx context.Context, templateID string) error {
+	if err := c.validateRequired("templateID", templateID); err != nil {
+		return err
+	}
+	if c.resources != nil {
+		defer c.resources.InvalidateTemplate(templateID)
+	}
+	if err := c.Delete(ctx, "/templates/"+templateID); err != nil {
+		return fmt.Errorf("failed to delete template %s: %w", templateID, err)
+	}
+	return nil
+}
+
+// Validate checks the request. It returns a *ValidationError, or a
+// *ValidationErrors when it finds more than one problem.
+func (req *CreateTemplateRequest) Validate() error {
+	if req == nil {
+		return nilRequest()
+	}
+	var p problems
+	p.add(validateRequired("name", req.Name))
+	p.add(validateRequired("imageName", req.ImageName))
+	validateTemplateSizing(&p, &req.ContainerDiskInGB, &req.VolumeInGB)
+	if req.Category != "" {
+		switch strings.ToUpper(req.Category) {
+		case "NVIDIA", "AMD", "CPU":
+		default:
+			p.add(NewValidationErrorWithValue("category", "must be one of 'NVIDIA', 'AMD' or 'CPU'", req.Category))
+		}
+	}
+	validatePorts(&p, "ports", req.Ports)
+	return p.err()
+}
+
+// Validate checks the request. It returns a *ValidationError, or a
+// *ValidationErrors when it finds more than one problem.
+func (req *UpdateTemplateRequest) Validate() error {
+	if req == nil {
+		return nilRequest()
+	}
+	var p problems
+	if req.Name != nil && strings.TrimSpace(*req.Name) == "" {
+		p.add(NewValidationError("name", "cannot be empty"))
+	}
+	if req.ImageName != nil && strings.TrimSpace(*req.ImageName) == "" {
+		p.add(NewValidationError("imageName", "cannot be empty"))
+	}
+	validateTemplateSizing(&p, req.ContainerDiskInGB, req.VolumeInGB)
+	validatePorts(&p, "ports", req.Ports)
+	return p.err()
+}
+
+// validateTemplateSizing checks the disk sizes shared by create and update
+// requests; nil fields are not set.
+func validateTemplateSizing(p *problems, containerDiskInGB, volumeInGB *int) {
+	if containerDiskInGB != nil && *containerDiskInGB < 0 {
+		p.add(NewValidationErrorWithValue("containerDiskInGb", "cannot be negative", *containerDiskInGB))
+	}
+	if volumeInGB != nil && *volumeInGB < 0 {
+		p.add(NewValidationErrorWithValue("volumeInGb", "cannot be negative", *volumeInGB))
+	}
+}
+
+// decodeTemplateList accepts both the bare-array and object-wrapper list
+// shapes.
+func decodeTemplateList(raw json.RawMessage) ([]Template, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	var templates []Template
+	if err := json.Unmarshal(raw, &templates); err == nil {
+		return templates, nil
+	}
+
+	var wrapped struct {
+		Templates []Template `json:"templates"`
+	}
+	if err := json.Unmarshal(raw, &wrapped); err == nil && wrapped.Templates != nil {
+		return wrapped.Templates, nil
+	}
+
+	return nil, fmt.Errorf("unexpected response shape")
+}
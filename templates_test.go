@@ -0,0 +1,177 @@
+package runpod_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+)
+
+func newTemplateServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/templates":
+			w.Write([]byte(`[{"id":"tpl1","name":"worker","imageName":"ghcr.io/acme/worker:1.0.0","containerRegistryAuthId":"auth1","isServerless":true}]`))
+		case r.Method == "POST" && r.URL.Path == "/templates":
+			var req runpod.CreateTemplateRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if req.ContainerRegistryAuthID != "auth1" {
+				t.Fatalf("containerRegistryAuthId not marshalled: %+v", req)
+			}
+			json.NewEncoder(w).Encode(runpod.Template{
+				ID: "tpl2", Name: req.Name, ImageName: req.ImageName,
+				ContainerRegistryAuthID: req.ContainerRegistryAuthID, Env: req.Env,
+			})
+		case r.Method == "GET" && r.URL.Path == "/templates/tpl1":
+			w.Write([]byte(`{"id":"tpl1","name":"worker","imageName":"ghcr.io/acme/worker:1.0.0","containerRegistryAuthId":"auth1"}`))
+		case r.Method == "PATCH" && r.URL.Path == "/templates/tpl1":
+			var req map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			// Set fields are sent even when false; unset ones are omitted.
+			if len(req) != 2 || req["isPublic"] != false {
+				t.Fatalf("unexpected update body %v", req)
+			}
+			json.NewEncoder(w).Encode(runpod.Template{ID: "tpl1", Name: "worker", ImageName: req["imageName"].(string)})
+		case r.Method == "DELETE" && r.URL.Path == "/templates/tpl1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+}
+
+func TestTemplateCRUD(t *testing.T) {
+	server := newTemplateServer(t)
+	defer server.Close()
+
+	client := mustClient(t, "test_key", runpod.WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	templates, err := client.ListTemplates(ctx)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(templates) != 1 || templates[0].ContainerRegistryAuthID != "auth1" || !templates[0].IsServerless {
+		t.Fatalf("unexpected list result %+v", templates)
+	}
+
+	created, err := client.CreateTemplate(ctx, &runpod.CreateTemplateRequest{
+		Name:                    "worker-v2",
+		ImageName:               "ghcr.io/acme/worker:2.0.0",
+		ContainerRegistryAuthID: "auth1",
+		ContainerDiskInGB:       20,
+		Env:                     map[string]string{"MODEL": "sdxl"},
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if created.ID != "tpl2" || created.Env["MODEL"] != "sdxl" {
+		t.Fatalf("unexpected create result %+v", created)
+	}
+
+	got, err := client.GetTemplate(ctx, "tpl1")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got.ContainerRegistryAuthID != "auth1" {
+		t.Fatalf("unexpected get result %+v", got)
+	}
+
+	updated, err := client.UpdateTemplate(ctx, "tpl1", &runpod.UpdateTemplateRequest{ImageName: runpod.Ptr("ghcr.io/acme/worker:1.0.1"), IsPublic: runpod.Ptr(false)})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if updated.ImageName != "ghcr.io/acme/worker:1.0.1" {
+		t.Fatalf("unexpected update result %+v", updated)
+	}
+
+	if err := client.DeleteTemplate(ctx, "tpl1"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+}
+
+func TestTemplateValidation(t *testing.T) {
+	client := mustClient(t, "test_key")
+	ctx := context.Background()
+
+	if _, err := client.CreateTemplate(ctx, nil); err == nil {
+		t.Fatal("nil request must fail validation")
+	}
+	if _, err := client.CreateTemplate(ctx, &runpod.CreateTemplateRequest{Name: "x"}); err == nil {
+		t.Fatal("missing imageName must fail validation")
+	}
+	if _, err := client.CreateTemplate(ctx, &runpod.CreateTemplateRequest{Name: "x", ImageName: "img", Category: "TPU"}); err == nil {
+		t.Fatal("unknown category must fail validation")
+	}
+	if _, err := client.UpdateTemplate(ctx, "", &runpod.UpdateTemplateRequest{Name: runpod.Ptr("x")}); err == nil {
+		t.Fatal("empty templateID must fail validation")
+	}
+	if _, err := client.UpdateTemplate(ctx, "tpl1", &runpod.UpdateTemplateRequest{ImageName: runpod.Ptr("")}); err == nil {
+		t.Fatal("clearing imageName must fail validation")
+	}
+	if _, err := client.GetTemplate(ctx, ""); err == nil {
+		t.Fatal("empty templateID must fail validation")
+	}
+}
+
+func TestSearchTemplates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/templates" || q.Get("includePublicTemplates") != "true" {
+			t.Fatalf("unexpected request %s %s", r.URL.Path, r.URL.RawQuery)
+		}
+		runpodTemplates := q.Get("includeRunpodTemplates") == "true"
+		w.Header().Set("Content-Type", "application/json")
+		templates := []runpod.Template{
+			{ID: "mine", Name: "private vllm", ImageName: "acme/vllm:1"},
+			{ID: "c1", Name: "ComfyUI Flux", ImageName: "community/comfyui:latest", Category: "NVIDIA", IsPublic: true},
+			{ID: "c2", Name: "vLLM OpenAI", ImageName: "community/vllm-openai:0.6", Category: "NVIDIA", IsPublic: true, IsServerless: true},
+		}
+		if runpodTemplates {
+			templates = append(templates, runpod.Template{ID: "rp1", Name: "RunPod vLLM", ImageName: "runpod/worker-v1-vllm:stable", Category: "NVIDIA", IsRunpod: true, IsServerless: true})
+		}
+		json.NewEncoder(w).Encode(templates)
+	}))
+	defer server.Close()
+
+	client := mustClient(t, "test_key", runpod.WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	all, err := client.SearchTemplates(ctx, nil)
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("expected 3 public templates, got %+v", all)
+	}
+
+	serverless := true
+	vllm, err := client.SearchTemplates(ctx, &runpod.TemplateSearchOptions{Image: "VLLM", Serverless: &serverless})
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(vllm) != 2 || vllm[0].ID != "c2" || vllm[1].ID != "rp1" {
+		t.Fatalf("unexpected image search result %+v", vllm)
+	}
+
+	community, err := client.SearchTemplates(ctx, &runpod.TemplateSearchOptions{Name: "vllm", ExcludeRunpod: true, Limit: 5})
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(community) != 1 || community[0].ID != "c2" {
+		t.Fatalf("unexpected community search result %+v", community)
+	}
+
+	if _, err := client.SearchTemplates(ctx, &runpod.TemplateSearchOptions{Limit: -1}); err == nil {
+		t.Fatal("negative limit must fail validation")
+	}
+}
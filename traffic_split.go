@@ -0,0 +1,315 @@
+package runpod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultSplitEjectAfter is how many consecutive failed submissions
+	// eject an endpoint from a TrafficSplitter when EjectAfter is unset.
+	DefaultSplitEjectAfter = 3
+	// DefaultSplitEjectFor is how long an endpoint stays ejected when
+	// EjectFor is unset.
+	DefaultSplitEjectFor = 30 * time.Second
+)
+
+// SplitTarget is one endpoint behind a TrafficSplitter.
+type SplitTarget struct {
+	EndpointID string
+	// Weight is the endpoint's relative share of traffic: targets weighted
+	// 90 and 10 get 90% and 10% of jobs. A zero weight makes the endpoint a
+	// standby that takes jobs only when every weighted endpoint failed or
+	// is ejected.
+	Weight int
+}
+
+// TrafficSplitOptions configures a TrafficSplitter.
+type TrafficSplitOptions struct {
+	Targets []SplitTarget
+	// EjectAfter consecutive failed submissions take an endpoint out of
+	// rotation for EjectFor (defaults DefaultSplitEjectAfter and
+	// DefaultSplitEjectFor).
+	EjectAfter int
+	EjectFor   time.Duration
+	// HealthInterval enables health checks: each endpoint's GetHealth is
+	// read at most once per interval, on submission, and an endpoint whose
+	// check fails is ejected for EjectFor. Zero disables them.
+	HealthInterval time.Duration
+	// MaxQueueDelay also ejects, on a health check, an endpoint whose
+	// EstimateQueueDelay exceeds it. Zero disables it.
+	MaxQueueDelay time.Duration
+}
+
+// SplitTargetStatus is a target's state, as reported by Status.
+type SplitTargetStatus struct {
+	SplitTarget
+	// EjectedUntil is set while the endpoint is out of rotation.
+	EjectedUntil time.Time
+	// Submitted and Failed count this splitter's submissions to it.
+	Submitted int
+	Failed    int
+}
+
+// TrafficSplitter distributes RunAsync traffic across endpoints by weight,
+// for canaries (90/10 between the current and the new release, shifted
+// with SetWeight) and for spreading load over regions. A submission the
+// API rejects with a retryable error or 404 fails over to the next
+// endpoint, picked by weight among the rest; other errors, including
+// network errors after which the job may exist, are returned as they are.
+// Endpoints that keep failing, or fail a health check, are ejected for a
+// while; if every endpoint is ejected they are tried anyway.
+//
+// A TrafficSplitter is safe for concurrent use.
+type TrafficSplitter struct {
+	client *Client
+	opts   TrafficSplitOptions
+
+	mu      sync.Mutex
+	targets []*splitTarget
+}
+
+type splitTarget struct {
+	SplitTargetStatus
+	failures  int       // consecutive
+	checkedAt time.Time // last health check
+}
+
+// NewTrafficSplitter returns a TrafficSplitter over opts.Targets.
+func (c *Client) NewTrafficSplitter(opts *TrafficSplitOptions) (*TrafficSplitter, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+	o := *opts
+	if o.EjectAfter == 0 {
+		o.EjectAfter = DefaultSplitEjectAfter
+	}
+	if o.EjectFor == 0 {
+		o.EjectFor = DefaultSplitEjectFor
+	}
+	s := &TrafficSplitter{client: c, opts: o}
+	for _, t := range o.Targets {
+		t.EndpointID = strings.TrimSpace(t.EndpointID)
+		s.targets = append(s.targets, &splitTarget{SplitTargetStatus: SplitTargetStatus{SplitTarget: t}})
+	}
+	s.opts.Targets = nil
+	return s, nil
+}
+
+func (o *TrafficSplitOptions) validate() error {
+	if o == nil || len(o.Targets) == 0 {
+		return NewValidationError("targets", "cannot be empty")
+	}
+	seen := map[string]bool{}
+	weighted := false
+	for _, t := range o.Targets {
+		id := strings.TrimSpace(t.EndpointID)
+		switch {
+		case id == "":
+			return NewValidationError("targets.endpointId", "cannot be empty")
+		case seen[id]:
+			return NewValidationErrorWithValue("targets.endpointId", "is listed twice", id)
+		case t.Weight < 0:
+			return NewValidationErrorWithValue("targets.weight", "cannot be negative", t.Weight)
+		}
+		seen[id] = true
+		weighted = weighted || t.Weight > 0
+	}
+	if !weighted {
+		return NewValidationError("targets.weight", "at least one target needs a positive weight")
+	}
+	if o.EjectAfter < 0 || o.EjectFor < 0 || o.HealthInterval < 0 || o.MaxQueueDelay < 0 {
+		return NewValidationError("opts", "ejectAfter, ejectFor, healthInterval and maxQueueDelay cannot be negative")
+	}
+	return nil
+}
+
+// RunAsync submits input to an endpoint picked by weight, failing over as
+// described on TrafficSplitter. The returned job's EndpointID names the
+// endpoint that took it. If every endpoint failed over, the error wraps the
+// last one's.
+func (s *TrafficSplitter) RunAsync(ctx context.Context, input interface{}) (*Job, error) {
+	s.checkHealth(ctx)
+
+	var lastErr error
+	order := s.order()
+	for _, t := range order {
+		job, err := s.client.RunAsync(ctx, t.EndpointID, input)
+		s.record(t, err)
+		if err == nil {
+			if job.EndpointID == "" {
+				job.EndpointID = t.EndpointID
+			}
+			return job, nil
+		}
+		if ctx.Err() != nil || !failsOver(err) {
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, fmt.Errorf("all %d endpoints failed: %w", len(order), lastErr)
+}
+
+// SetWeight changes an endpoint's weight, e.g. to shift a canary's share.
+func (s *TrafficSplitter) SetWeight(endpointID string, weight int) error {
+	if weight < 0 {
+		return NewValidationErrorWithValue("weight", "cannot be negative", weight)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var target *splitTarget
+	weighted := weight > 0
+	for _, t := range s.targets {
+		if t.EndpointID == endpointID {
+			target = t
+		} else if t.Weight > 0 {
+			weighted = true
+		}
+	}
+	if target == nil {
+		return NewValidationErrorWithValue("endpointID", "is not a target", endpointID)
+	}
+	if !weighted {
+		return NewValidationError("weight", "at least one target needs a positive weight")
+	}
+	target.Weight = weight
+	return nil
+}
+
+// Status returns each target's weight, ejection and counts, in the order
+// they were configured.
+func (s *TrafficSplitter) Status() []SplitTargetStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.client.clock.Now()
+	out := make([]SplitTargetStatus, len(s.targets))
+	for i, t := range s.targets {
+		out[i] = t.SplitTargetStatus
+		if !now.Before(out[i].EjectedUntil) {
+			out[i].EjectedUntil = time.Time{}
+		}
+	}
+	return out
+}
+
+// order returns the targets to try: the endpoints in rotation in weighted
+// random order, then the standbys, then the ejected endpoints, as a last
+// resort.
+func (s *TrafficSplitter) order() []*splitTarget {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.client.clock.Now()
+	var weighted, standby, ejected []*splitTarget
+	for _, t := range s.targets {
+		switch {
+		case now.Before(t.EjectedUntil):
+			ejected = append(ejected, t)
+		case t.Weight > 0:
+			weighted = append(weighted, t)
+		default:
+			standby = append(standby, t)
+		}
+	}
+	return append(append(weightedShuffle(weighted), standby...), weightedShuffle(ejected)...)
+}
+
+// weightedShuffle orders targets by repeated weighted draws without
+// replacement; zero-weight targets go last.
+func weightedShuffle(targets []*splitTarget) []*splitTarget {
+	out := make([]*splitTarget, 0, len(targets))
+	rest := append([]*splitTarget(nil), targets...)
+	for len(rest) > 0 {
+		total := 0
+		for _, t := range rest {
+			total += t.Weight
+		}
+		i := 0
+		if total > 0 {
+			n := rand.Intn(total)
+			for n >= rest[i].Weight {
+				n -= rest[i].Weight
+				i++
+			}
+		}
+		out = append(out, rest[i])
+		rest = append(rest[:i], rest[i+1:]...)
+	}
+	return out
+}
+
+// record counts a submission to t, ejecting it after EjectAfter failures
+// in a row.
+func (s *TrafficSplitter) record(t *splitTarget, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t.Submitted++
+	if err == nil {
+		t.failures = 0
+		return
+	}
+	t.Failed++
+	t.failures++
+	if t.failures >= s.opts.EjectAfter {
+		t.failures = 0
+		t.EjectedUntil = s.client.clock.Now().Add(s.opts.EjectFor)
+	}
+}
+
+// checkHealth checks the endpoints whose last check is older than
+// HealthInterval, ejecting those that fail it.
+func (s *TrafficSplitter) checkHealth(ctx context.Context) {
+	if s.opts.HealthInterval <= 0 {
+		return
+	}
+	s.mu.Lock()
+	now := s.client.clock.Now()
+	var due []*splitTarget
+	for _, t := range s.targets {
+		if now.Sub(t.checkedAt) >= s.opts.HealthInterval {
+			t.checkedAt = now
+			due = append(due, t)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, t := range due {
+		var err error
+		if s.opts.MaxQueueDelay > 0 {
+			var est *QueueDelayEstimate
+			est, err = s.client.EstimateQueueDelay(ctx, t.EndpointID)
+			if errors.Is(err, ErrNoExecutionTimes) {
+				err = nil
+			} else if err == nil && est.Delay > s.opts.MaxQueueDelay {
+				err = fmt.Errorf("estimated queue delay %s exceeds %s", est.Delay, s.opts.MaxQueueDelay)
+			}
+		} else {
+			_, err = s.client.GetHealth(ctx, t.EndpointID)
+		}
+		if err != nil && ctx.Err() == nil {
+			if s.client.debug {
+				s.client.logger.Printf("[DEBUG] traffic split: ejecting endpoint %s: %v", t.EndpointID, err)
+			}
+			s.mu.Lock()
+			t.EjectedUntil = s.client.clock.Now().Add(s.opts.EjectFor)
+			s.mu.Unlock()
+		}
+	}
+}
+
+// failsOver reports whether a failed submission should be tried on
+// another endpoint: the API or the gateway in front of it answered with an
+// error, so no job was queued, and the error is about this endpoint rather
+// than the request.
+func failsOver(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Retryable() || apiErr.StatusCode == 404
+	}
+	var gwErr *GatewayError
+	return errors.As(err, &gwErr) && gwErr.StatusCode >= 500
+}
@@ -0,0 +1,160 @@
+package runpod_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestTrafficSplitter(t *testing.T) {
+	var mu sync.Mutex
+	down := map[string]bool{}
+	runs := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		endpointID := strings.Split(r.URL.Path, "/")[2]
+		mu.Lock()
+		defer mu.Unlock()
+		if down[endpointID] {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error":"unavailable"}`))
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/health") {
+			json.NewEncoder(w).Encode(runpod.EndpointHealth{Status: "healthy"})
+			return
+		}
+		runs[endpointID]++
+		json.NewEncoder(w).Encode(runpod.Job{ID: "job", Status: "IN_QUEUE"})
+	}))
+	defer srv.Close()
+	clock := runpodtest.NewClock(time.Now())
+	client := mustClient(t, "test_key", runpod.WithServerlessBaseURL(srv.URL), runpod.WithClock(clock), runpod.WithMaxRetryAttempts(0))
+	ctx := t.Context()
+
+	split, err := client.NewTrafficSplitter(&runpod.TrafficSplitOptions{
+		Targets:    []runpod.SplitTarget{{EndpointID: "stable", Weight: 90}, {EndpointID: "canary", Weight: 10}, {EndpointID: "standby"}},
+		EjectAfter: 2,
+		EjectFor:   time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 1000 {
+		job, err := split.RunAsync(ctx, map[string]int{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if job.EndpointID != "stable" && job.EndpointID != "canary" {
+			t.Fatalf("job went to %q", job.EndpointID)
+		}
+	}
+	if runs["canary"] < 50 || runs["canary"] > 150 || runs["standby"] != 0 {
+		t.Fatalf("runs = %v, want about 900/100", runs)
+	}
+
+	// The canary fails: its jobs fail over to stable, and after two
+	// failures it is ejected.
+	clear(runs)
+	down["canary"] = true
+	if err := split.SetWeight("canary", 90); err != nil {
+		t.Fatal(err)
+	}
+	for range 20 {
+		if job, err := split.RunAsync(ctx, map[string]int{}); err != nil || job.EndpointID != "stable" {
+			t.Fatalf("RunAsync = %+v, %v", job, err)
+		}
+	}
+	status := split.Status()
+	if runs["stable"] != 20 || status[1].Failed != 2 || status[1].EjectedUntil.IsZero() {
+		t.Fatalf("runs = %v, canary status = %+v", runs, status[1])
+	}
+
+	// With both weighted endpoints down, the standby takes the jobs.
+	down["stable"] = true
+	if job, err := split.RunAsync(ctx, map[string]int{}); err != nil || job.EndpointID != "standby" {
+		t.Fatalf("RunAsync = %+v, %v", job, err)
+	}
+	down["standby"] = true
+	var apiErr *runpod.APIError
+	if _, err := split.RunAsync(ctx, map[string]int{}); !errors.As(err, &apiErr) || !strings.Contains(err.Error(), "all 3 endpoints failed") {
+		t.Fatalf("all down: %v", err)
+	}
+
+	// Ejection expires.
+	clear(down)
+	clock.Advance(time.Minute)
+	for _, s := range split.Status() {
+		if !s.EjectedUntil.IsZero() {
+			t.Fatalf("%s still ejected", s.EndpointID)
+		}
+	}
+
+	var validationErr *runpod.ValidationError
+	if _, err := client.NewTrafficSplitter(&runpod.TrafficSplitOptions{Targets: []runpod.SplitTarget{{EndpointID: "a"}}}); !errors.As(err, &validationErr) {
+		t.Fatalf("no weights: %v", err)
+	}
+	if err := split.SetWeight("missing", 1); !errors.As(err, &validationErr) {
+		t.Fatalf("SetWeight(missing): %v", err)
+	}
+}
+
+func TestTrafficSplitterHealthCheck(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	clock := runpodtest.NewClock(time.Now())
+	client := srv.MustClient(runpod.WithClock(clock))
+	ctx := t.Context()
+
+	// "busy" has a long queue and no idle workers.
+	for range 5 {
+		if _, err := client.RunAsync(ctx, "busy", map[string]int{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	client.RecordExecutionTime("busy", time.Minute)
+	split, err := client.NewTrafficSplitter(&runpod.TrafficSplitOptions{
+		Targets:        []runpod.SplitTarget{{EndpointID: "busy", Weight: 1}, {EndpointID: "quiet", Weight: 1}},
+		HealthInterval: 10 * time.Second,
+		MaxQueueDelay:  time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 10 {
+		if job, err := split.RunAsync(ctx, map[string]int{}); err != nil || job.EndpointID != "quiet" {
+			t.Fatalf("RunAsync = %+v, %v", job, err)
+		}
+	}
+}
+
+func TestTrafficSplitterGatewayFailover(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Split(r.URL.Path, "/")[2] == "primary" {
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte("<html><title>502: Bad gateway</title></html>"))
+			return
+		}
+		json.NewEncoder(w).Encode(runpod.Job{ID: "job", Status: "IN_QUEUE"})
+	}))
+	defer srv.Close()
+	client := mustClient(t, "test_key", runpod.WithServerlessBaseURL(srv.URL), runpod.WithMaxRetryAttempts(0))
+
+	split, err := client.NewTrafficSplitter(&runpod.TrafficSplitOptions{
+		Targets: []runpod.SplitTarget{{EndpointID: "primary", Weight: 100}, {EndpointID: "standby"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job, err := split.RunAsync(t.Context(), map[string]int{}); err != nil || job.EndpointID != "standby" {
+		t.Fatalf("RunAsync = %+v, %v; want a failover to standby", job, err)
+	}
+}
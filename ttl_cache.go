@@ -0,0 +1,113 @@
+package runpod
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// ttlCache holds raw response bodies for a fixed TTL. It backs CatalogCache
+// and ResourceCache.
+//
+// Expired entries are dropped when read, and swept from the whole map at
+// most once per TTL as new entries are stored, so a long-running client
+// does not keep every key it ever read.
+//
+// A read that is in flight when its key is invalidated must not store the
+// result it fetched before the invalidation. Callers bracket the read with
+// begin and done and pass begin's generation to put; invalidate records a
+// newer generation for the in-flight keys it matches, and put drops a
+// result older than that.
+type ttlCache[K comparable] struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[K]ttlEntry
+	sweepAt time.Time
+	gen     uint64
+	// fills counts the reads in flight per key; invalidated holds, for those
+	// keys only, the generation of their last invalidation.
+	fills       map[K]int
+	invalidated map[K]uint64
+}
+
+type ttlEntry struct {
+	data    json.RawMessage
+	expires time.Time
+}
+
+func newTTLCache[K comparable](ttl time.Duration) *ttlCache[K] {
+	return &ttlCache[K]{ttl: ttl, entries: map[K]ttlEntry{}, fills: map[K]int{}, invalidated: map[K]uint64{}}
+}
+
+func (tc *ttlCache[K]) get(key K, now time.Time) (json.RawMessage, bool) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	entry, ok := tc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if now.After(entry.expires) {
+		delete(tc.entries, key)
+		return nil, false
+	}
+	return entry.data, true
+}
+
+// begin registers a read of key and returns the generation to pass to put.
+// Every begin must be matched by a done.
+func (tc *ttlCache[K]) begin(key K) uint64 {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	tc.fills[key]++
+	return tc.gen
+}
+
+// done ends a read registered by begin.
+func (tc *ttlCache[K]) done(key K) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	tc.fills[key]--
+	if tc.fills[key] <= 0 {
+		delete(tc.fills, key)
+		delete(tc.invalidated, key)
+	}
+}
+
+// put stores data read under generation gen, unless key was invalidated
+// since.
+func (tc *ttlCache[K]) put(key K, gen uint64, data json.RawMessage, now time.Time) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	if tc.invalidated[key] > gen {
+		return
+	}
+	if now.After(tc.sweepAt) {
+		for k, entry := range tc.entries {
+			if now.After(entry.expires) {
+				delete(tc.entries, k)
+			}
+		}
+		tc.sweepAt = now.Add(tc.ttl)
+	}
+	tc.entries[key] = ttlEntry{data: data, expires: now.Add(tc.ttl)}
+}
+
+// invalidate drops the entries match returns true for, or every entry when
+// match is nil, and keeps reads of those keys already in flight from
+// storing their results.
+func (tc *ttlCache[K]) invalidate(match func(K) bool) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	tc.gen++
+	for key := range tc.entries {
+		if match == nil || match(key) {
+			delete(tc.entries, key)
+		}
+	}
+	for key := range tc.fills {
+		if match == nil || match(key) {
+			tc.invalidated[key] = tc.gen
+		}
+	}
+}
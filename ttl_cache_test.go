@@ -0,0 +1,63 @@
+package runpod
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTTLCacheSweepsExpiredEntries(t *testing.T) {
+	cache := newTTLCache[string](time.Minute)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, key := range []string{"a", "b", "c"} {
+		cache.put(key, cache.begin(key), json.RawMessage(`1`), now)
+		cache.done(key)
+	}
+	// Entries that are never read again still go once a later put sweeps.
+	now = now.Add(2 * time.Minute)
+	cache.put("d", cache.begin("d"), json.RawMessage(`2`), now)
+	cache.done("d")
+	if len(cache.entries) != 1 {
+		t.Fatalf("entries after sweep = %v, want only d", cache.entries)
+	}
+	if len(cache.fills) != 0 || len(cache.invalidated) != 0 {
+		t.Fatalf("finished reads left state behind: fills=%v invalidated=%v", cache.fills, cache.invalidated)
+	}
+}
+
+func TestTTLCacheInvalidateDuringRead(t *testing.T) {
+	cache := newTTLCache[string](time.Minute)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	stale := cache.begin("ep")
+	other := cache.begin("other")
+	cache.invalidate(func(key string) bool { return key == "ep" })
+
+	// The read that started before the invalidation fetched the old body.
+	cache.put("ep", stale, json.RawMessage(`"old"`), now)
+	cache.put("other", other, json.RawMessage(`"kept"`), now)
+	cache.done("ep")
+	cache.done("other")
+	if _, ok := cache.get("ep", now); ok {
+		t.Fatal("a read in flight during invalidation was cached")
+	}
+	if _, ok := cache.get("other", now); !ok {
+		t.Fatal("an unrelated read was not cached")
+	}
+
+	fresh := cache.begin("ep")
+	cache.put("ep", fresh, json.RawMessage(`"new"`), now)
+	cache.done("ep")
+	if data, ok := cache.get("ep", now); !ok || string(data) != `"new"` {
+		t.Fatalf("read after invalidation = %s, %v", data, ok)
+	}
+
+	stale = cache.begin("ep")
+	cache.invalidate(nil)
+	cache.put("ep", stale, json.RawMessage(`"old"`), now)
+	cache.done("ep")
+	if _, ok := cache.get("ep", now); ok {
+		t.Fatal("Invalidate did not cover a read in flight")
+	}
+}
@@ -3,18 +3,83 @@ package runpod
 import (
 	"encoding/json"
 	"fmt"
+	"math"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
 
-// ListOptions paginates list endpoints.
+// ListOptions paginates list endpoints. Cursor, a previous page's
+// PageInfo.NextCursor, continues after that page's last item and takes
+// precedence over Offset, so items created or deleted meanwhile are neither
+// skipped nor repeated.
 type ListOptions struct {
-	Limit  int `json:"limit,omitempty"`
-	Offset int `json:"offset,omitempty"`
+	Limit  int    `json:"limit,omitempty"`
+	Offset int    `json:"offset,omitempty"`
+	Cursor string `json:"cursor,omitempty"`
+
+	// Filter narrows the list server-side; see ListFilter.
+	Filter ListFilter `json:"filter,omitzero"`
+	// SortBy orders the list by a response field, e.g. "createdAt", in
+	// SortOrder (ascending by default).
+	SortBy    string    `json:"sortBy,omitempty"`
+	SortOrder SortOrder `json:"sortOrder,omitempty"`
+	// IncludeFields limits each item to the named response fields, e.g.
+	// []string{"id", "desiredStatus"}, to keep large lists small. The rest
+	// of the item is left zero.
+	IncludeFields []string `json:"includeFields,omitempty"`
+	// Include expands each pod of ListPods, ListPodsPage and Pods as
+	// GetPodWithOptions does; other lists ignore it.
+	Include GetPodOptions `json:"include,omitzero"`
 }
 
+// ListFilter holds the REST API's equality filters for list endpoints.
+// Empty fields are not sent, and an endpoint ignores filters it does not
+// support, so check the API reference for the list being filtered.
+type ListFilter struct {
+	Name            string      `json:"name,omitempty"`
+	DesiredStatus   string      `json:"desiredStatus,omitempty"`
+	ImageName       string      `json:"imageName,omitempty"`
+	TemplateID      string      `json:"templateId,omitempty"`
+	EndpointID      string      `json:"endpointId,omitempty"`
+	NetworkVolumeID string      `json:"networkVolumeId,omitempty"`
+	GPUTypeID       string      `json:"gpuTypeId,omitempty"`
+	DataCenterID    string      `json:"dataCenterId,omitempty"`
+	ComputeType     ComputeType `json:"computeType,omitempty"`
+}
+
+// params returns f as query parameters, keyed by the json tag names.
+func (f ListFilter) params() map[string]string {
+	return map[string]string{
+		"name":            f.Name,
+		"desiredStatus":   f.DesiredStatus,
+		"imageName":       f.ImageName,
+		"templateId":      f.TemplateID,
+		"endpointId":      f.EndpointID,
+		"networkVolumeId": f.NetworkVolumeID,
+		"gpuTypeId":       f.GPUTypeID,
+		"dataCenterId":    f.DataCenterID,
+		"computeType":     string(f.ComputeType),
+	}
+}
+
+// SortOrder is the direction of ListOptions.SortBy.
+type SortOrder string
+
+const (
+	SortAscending  SortOrder = "asc"
+	SortDescending SortOrder = "desc"
+)
+
+// Ptr returns a pointer to v, for the optional fields of update requests:
+//
+//	client.UpdateEndpoint(ctx, id, &runpod.UpdateEndpointRequest{WorkersMin: runpod.Ptr(0)})
+func Ptr[T any](v T) *T { return &v }
+
 // JSONTime wraps time.Time to tolerate RunPod's non-RFC-3339 timestamp
-// formats on unmarshal; it always marshals as RFC-3339.
+// formats and epoch seconds or milliseconds on unmarshal; it always
+// marshals as RFC-3339.
 type JSONTime struct {
 	time.Time
 }
@@ -80,9 +145,36 @@ func (jt *JSONTime) UnmarshalJSON(b []byte) error {
 		}
 	}
 
+	// Some responses use Unix epoch numbers, in seconds or milliseconds.
+	if t, ok := parseEpoch(s); ok {
+		jt.Time = t
+		return nil
+	}
+
 	return fmt.Errorf("runpod.JSONTime: cannot parse %q as JSONTime", s)
 }
 
+// epochMillisThreshold separates epoch seconds from milliseconds: 1e11
+// seconds is in the year 5138, while 1e11 milliseconds is in 1973.
+const epochMillisThreshold = 1e11
+
+// parseEpoch parses Unix epoch seconds or milliseconds, with an optional
+// fraction.
+func parseEpoch(s string) (time.Time, bool) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return time.Time{}, false
+	}
+	if math.Abs(f) >= epochMillisThreshold {
+		if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return time.UnixMilli(ms).UTC(), true
+		}
+		return time.Unix(0, int64(f*float64(time.Millisecond))).UTC(), true
+	}
+	sec, frac := math.Modf(f)
+	return time.Unix(int64(sec), int64(frac*float64(time.Second))).UTC(), true
+}
+
 // MarshalJSON always emits proper RFC-3339 format
 func (jt JSONTime) MarshalJSON() ([]byte, error) {
 	if jt.Time.IsZero() {
@@ -91,6 +183,38 @@ func (jt JSONTime) MarshalJSON() ([]byte, error) {
 	return json.Marshal(jt.Time.Format(time.RFC3339))
 }
 
+// DurationMS is a time.Duration carried on the wire as integer
+// milliseconds, for RunPod's *Ms fields:
+//
+//	ExecutionTimeout: runpod.DurationMS(10 * time.Minute) // "executionTimeoutMs": 600000
+type DurationMS time.Duration
+
+// Duration returns d as a time.Duration.
+func (d DurationMS) Duration() time.Duration { return time.Duration(d) }
+
+// String formats d like time.Duration.
+func (d DurationMS) String() string { return time.Duration(d).String() }
+
+// MarshalJSON emits whole milliseconds, rounding sub-millisecond values.
+func (d DurationMS) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(time.Duration(d).Round(time.Millisecond).Milliseconds(), 10)), nil
+}
+
+// UnmarshalJSON accepts a number of milliseconds, as a JSON number or
+// numeric string, or null.
+func (d *DurationMS) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	if s == "" || s == "null" {
+		return nil
+	}
+	ms, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(ms) || math.IsInf(ms, 0) {
+		return fmt.Errorf("runpod.DurationMS: cannot parse %q as milliseconds", s)
+	}
+	*d = DurationMS(ms * float64(time.Millisecond))
+	return nil
+}
+
 // Pod is a RunPod GPU or CPU pod.
 type Pod struct {
 	ID               string `json:"id"`
@@ -124,6 +248,8 @@ type Pod struct {
 	Machine           *Machine          `json:"machine,omitempty"`
 	NetworkVolume     *NetworkVolume    `json:"networkVolume,omitempty"`
 	NetworkVolumeID   string            `json:"networkVolumeId,omitempty"`
+	SavingsPlans      []SavingsPlan     `json:"savingsPlans,omitempty"`
+	Template          *Template         `json:"template,omitempty"`
 
 	// CPU-pod-specific response fields. RunPod's REST `POST /pods` returns
 	// `cpuFlavorId` (the family the instance was placed on, e.g. "cpu3c")
@@ -132,6 +258,8 @@ type Pod struct {
 	// as the sentinel "unknown" — callers should treat that as "no GPU"
 	// rather than as a real GPU type.
 	CPUFlavorID string `json:"cpuFlavorId,omitempty"`
+
+	raw string // see Raw
 }
 
 // PodGPU is the allocated GPU shape embedded in current RunPod REST pod
@@ -150,20 +278,121 @@ func (p *Pod) Status() string {
 // PodRuntime is the pod's live runtime block (present once the container
 // is up).
 type PodRuntime struct {
-	UptimeSeconds    int                    `json:"uptimeSeconds"`
-	LastStartedAt    string                 `json:"lastStartedAt"`
-	LastStatusChange string                 `json:"lastStatusChange,omitempty"`
-	LastStatusCharge string                 `json:"lastStatusCharge,omitempty"`
-	PublicIP         string                 `json:"publicIp,omitempty"`
-	Ports            map[string]interface{} `json:"ports,omitempty"`
-	Status           string                 `json:"status,omitempty"`
-	Reason           string                 `json:"reason,omitempty"`
-	Error            string                 `json:"error,omitempty"`
+	// UptimeSeconds is how long the container has been up; GraphQL calls
+	// it uptimeInSeconds, which is accepted too.
+	UptimeSeconds    int    `json:"uptimeSeconds"`
+	LastStartedAt    string `json:"lastStartedAt"`
+	LastStatusChange string `json:"lastStatusChange,omitempty"`
+	LastStatusCharge string `json:"lastStatusCharge,omitempty"`
+	PublicIP         string `json:"publicIp,omitempty"`
+	// Ports are the container's exposed ports and their public mappings.
+	Ports PodPorts `json:"ports,omitempty"`
+	// GPUs are the attached GPUs with their current utilization.
+	GPUs []PodRuntimeGPU `json:"gpus,omitempty"`
+	// Container is the container's current resource usage.
+	Container *PodContainer `json:"container,omitempty"`
+	Status    string        `json:"status,omitempty"`
+	Reason    string        `json:"reason,omitempty"`
+	Error     string        `json:"error,omitempty"`
 	// ContainerExitCode is the container's exit code when the record carries
 	// one; nil distinguishes "not exposed" from a genuine 0 exit (rp#16).
 	ContainerExitCode *int `json:"containerExitCode,omitempty"`
 }
 
+// Uptime returns UptimeSeconds as a Duration.
+func (r *PodRuntime) Uptime() time.Duration {
+	return time.Duration(r.UptimeSeconds) * time.Second
+}
+
+// UnmarshalJSON accepts GraphQL's uptimeInSeconds for UptimeSeconds.
+func (r *PodRuntime) UnmarshalJSON(b []byte) error {
+	type plain PodRuntime
+	aux := struct {
+		*plain
+		UptimeInSeconds *int `json:"uptimeInSeconds"`
+	}{plain: (*plain)(r)}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+	if aux.UptimeInSeconds != nil && r.UptimeSeconds == 0 {
+		r.UptimeSeconds = *aux.UptimeInSeconds
+	}
+	return nil
+}
+
+// PodPort is one exposed container port. PublicPort on IP is where
+// PrivatePort is reachable from outside; IsIPPublic is false for ports
+// reachable only through RunPod's proxy.
+type PodPort struct {
+	IP          string `json:"ip,omitempty"`
+	IsIPPublic  bool   `json:"isIpPublic"`
+	PrivatePort int    `json:"privatePort"`
+	PublicPort  int    `json:"publicPort"`
+	Type        string `json:"type,omitempty"`
+	// Name is the key the port was listed under when the API sent ports
+	// as an object (e.g. "http"); it is empty for the list form.
+	Name string `json:"name,omitempty"`
+}
+
+// Key returns Name, or "<privatePort>/<type>" when the port is unnamed.
+func (p PodPort) Key() string {
+	if p.Name != "" {
+		return p.Name
+	}
+	return strconv.Itoa(p.PrivatePort) + "/" + p.Type
+}
+
+// PodPorts is a pod's exposed ports. The API sends them as a list of
+// objects, and some older responses as an object keyed by name or
+// "<port>/<type>" whose values are a public port number or a port object;
+// both decode to the same slice.
+type PodPorts []PodPort
+
+// UnmarshalJSON accepts the list and object forms described on PodPorts.
+func (ps *PodPorts) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] != '{' {
+		return json.Unmarshal(b, (*[]PodPort)(ps))
+	}
+	var byKey map[string]json.RawMessage
+	if err := json.Unmarshal(b, &byKey); err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(byKey))
+	for key := range byKey {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	out := make(PodPorts, 0, len(keys))
+	for _, key := range keys {
+		port := PodPort{Name: key}
+		if err := json.Unmarshal(byKey[key], &port); err != nil {
+			var v interface{}
+			_ = json.Unmarshal(byKey[key], &v)
+			port.PublicPort = coercePortValue(v)
+		}
+		if private, typ, ok := strings.Cut(key, "/"); ok && port.PrivatePort == 0 {
+			port.PrivatePort, _ = strconv.Atoi(private)
+			port.Type = firstNonEmpty(port.Type, typ)
+		}
+		out = append(out, port)
+	}
+	*ps = out
+	return nil
+}
+
+// PodRuntimeGPU is a GPU attached to a running pod.
+type PodRuntimeGPU struct {
+	ID                string  `json:"id"`
+	GPUUtilPercent    float64 `json:"gpuUtilPercent"`
+	MemoryUtilPercent float64 `json:"memoryUtilPercent"`
+}
+
+// PodContainer is a running container's resource usage.
+type PodContainer struct {
+	CPUPercent    float64 `json:"cpuPercent"`
+	MemoryPercent float64 `json:"memoryPercent"`
+}
+
 // Machine describes the host a pod landed on.
 type Machine struct {
 	ID           string `json:"id"`
@@ -174,6 +403,16 @@ type Machine struct {
 	CountryCode  string `json:"countryCode,omitempty"`
 }
 
+// SavingsPlan is a prepaid discount applied to a pod's GPUs for a term.
+type SavingsPlan struct {
+	ID          string    `json:"id"`
+	PodID       string    `json:"podId,omitempty"`
+	GPUTypeID   string    `json:"gpuTypeId,omitempty"`
+	CostPerHour float64   `json:"costPerHr"`
+	StartTime   *JSONTime `json:"startTime,omitempty"`
+	EndTime     *JSONTime `json:"endTime,omitempty"`
+}
+
 // CreatePodRequest configures pod creation (REST POST /pods).
 type CreatePodRequest struct {
 	Name                    string `json:"name"`
@@ -221,7 +460,7 @@ type CreatePodRequest struct {
 	Ports             []string          `json:"ports,omitempty"`
 	DockerArgs        string            `json:"dockerArgs,omitempty"`
 	NetworkVolumeID   string            `json:"networkVolumeId,omitempty"`
-	CloudType         string            `json:"cloudType,omitempty"`     // "SECURE" or "COMMUNITY"
+	CloudType         CloudType         `json:"cloudType,omitempty"`
 	Interruptible     bool              `json:"interruptible,omitempty"` // spot/interruptible instance
 
 	// BidPerGPU is the per-GPU bid price (USD/hr) for interruptible pods.
@@ -236,11 +475,11 @@ type CreatePodRequest struct {
 	MinCudaVersion      string   `json:"minCudaVersion,omitempty"`
 
 	// Additional REST API fields
-	ComputeType        string   `json:"computeType,omitempty"` // "GPU" or "CPU"
-	DockerEntrypoint   []string `json:"dockerEntrypoint,omitempty"`
-	DockerStartCmd     []string `json:"dockerStartCmd,omitempty"`
-	GPUTypePriority    string   `json:"gpuTypePriority,omitempty"`
-	DataCenterPriority string   `json:"dataCenterPriority,omitempty"`
+	ComputeType        ComputeType        `json:"computeType,omitempty"`
+	DockerEntrypoint   []string           `json:"dockerEntrypoint,omitempty"`
+	DockerStartCmd     []string           `json:"dockerStartCmd,omitempty"`
+	GPUTypePriority    GPUTypePriority    `json:"gpuTypePriority,omitempty"`
+	DataCenterPriority DataCenterPriority `json:"dataCenterPriority,omitempty"`
 }
 
 const (
@@ -283,13 +522,70 @@ type Job struct {
 	StartedAt     *JSONTime       `json:"startedAt,omitempty"`
 	CompletedAt   *JSONTime       `json:"completedAt,omitempty"`
 	ExecutionTime int             `json:"executionTimeMs,omitempty"`
+	DelayTime     int             `json:"delayTime,omitempty"` // ms spent in the queue
 	RetryCount    int             `json:"retryCount,omitempty"`
 	EndpointID    string          `json:"endpointId,omitempty"`
+	// OutputURL and OutputTruncated are set when the output was too large
+	// to return inline; see OutputOversized.
+	OutputURL       string `json:"outputUrl,omitempty"`
+	OutputTruncated bool   `json:"outputTruncated,omitempty"`
+
+	raw string // see Raw
+}
+
+// OutputOversized reports whether the job's output was too large to return
+// inline, and where the full output is if anyone said. The reference is the
+// job's outputUrl, or an output of the form {"output_url": "..."} (or
+// "outputUrl"), the shape workers use for results they upload themselves.
+// Client.FetchFullOutput retrieves it.
+func (j *Job) OutputOversized() (url string, oversized bool) {
+	if j.OutputURL != "" {
+		return j.OutputURL, true
+	}
+	var ref struct {
+		Snake string `json:"output_url"`
+		Camel string `json:"outputUrl"`
+	}
+	if len(j.Output) > 0 && j.Output[0] == '{' && json.Unmarshal(j.Output, &ref) == nil {
+		if url := firstNonEmpty(ref.Snake, ref.Camel); url != "" {
+			return url, true
+		}
+	}
+	return "", j.OutputTruncated
+}
+
+// PurgeQueueOptions guards PurgeQueue against emptying the wrong queue.
+// Set Confirm, or an expected range of queued jobs, or both.
+type PurgeQueueOptions struct {
+	// Confirm allows the purge whatever the queue length.
+	Confirm bool
+	// ExpectedMin and ExpectedMax bound the number of queued jobs the
+	// caller means to purge; outside them PurgeQueue returns a
+	// *PurgeRefusedError. ExpectedMax 0 leaves the range open above. The
+	// queue keeps moving, so the count removed may still differ slightly.
+	ExpectedMin int
+	ExpectedMax int
+}
+
+// PurgeQueueResult is the outcome of PurgeQueue.
+type PurgeQueueResult struct {
+	// Removed is how many queued jobs were removed.
+	Removed int    `json:"removed"`
+	Status  string `json:"status,omitempty"`
 }
 
 // RunJobRequest wraps a serverless job input payload.
 type RunJobRequest struct {
-	Input interface{} `json:"input"`
+	Input  interface{} `json:"input"`
+	Policy *JobPolicy  `json:"policy,omitempty"`
+}
+
+// JobPolicy is a job's server-side limits, overriding the endpoint's for
+// that job. ExecutionTimeout bounds the run once a worker picks the job up;
+// TTL bounds the job's whole life, queue time included.
+type JobPolicy struct {
+	ExecutionTimeout DurationMS `json:"executionTimeout,omitempty"`
+	TTL              DurationMS `json:"ttl,omitempty"`
 }
 
 // JobStatus enumerates serverless job states.
@@ -335,6 +631,20 @@ type GPUTypeFilter struct {
 	SecureCloud         *bool
 	CommunityCloud      *bool
 	GPUCount            int
+
+	// MinVRAMInGB keeps types with at least this much GPU memory.
+	MinVRAMInGB int
+	// CloudType keeps types offered on that cloud and prices LowestPrice on
+	// it; matching is case-insensitive. Combined with SecureCloud or
+	// CommunityCloud set to false for the same cloud it is rejected.
+	CloudType CloudType
+	// DataCenterID prices LowestPrice in one data center.
+	DataCenterID string
+	// MaxPrice keeps types whose on-demand LowestPrice for GPUCount GPUs is
+	// at most this many USD/hr. Types without a price are dropped.
+	MaxPrice float64
+	// InStockOnly drops types whose LowestPrice stock status is unavailable.
+	InStockOnly bool
 }
 
 // GPUTypeWithAvailability is a GPU type with its current stock status.
@@ -343,13 +653,20 @@ type GPUTypeWithAvailability struct {
 	StockStatus string
 }
 
-// GetPodOptions toggles the include* query parameters on GetPodWithOptions.
+// GetPodOptions toggles the include* query parameters on GetPodWithOptions
+// and, as ListOptions.Include, on pod lists, so one call returns details
+// that would otherwise take a GraphQL query per pod. The runtime block
+// (ports, GPUs, uptime) is always returned once the container is up.
 type GetPodOptions struct {
-	IncludeMachine       bool
+	// IncludeMachine fills Pod.Machine: the host, GPU type and datacenter.
+	IncludeMachine bool
+	// IncludeNetworkVolume fills Pod.NetworkVolume.
 	IncludeNetworkVolume bool
-	IncludeSavingsPlans  bool
-	IncludeTemplate      bool
-	IncludeWorkers       bool
+	// IncludeSavingsPlans fills Pod.SavingsPlans.
+	IncludeSavingsPlans bool
+	// IncludeTemplate fills Pod.Template.
+	IncludeTemplate bool
+	IncludeWorkers  bool
 }
 
 // PodDiagnostics is a normalized snapshot for scheduler/bootstrap
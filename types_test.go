@@ -0,0 +1,92 @@
+package runpod_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+)
+
+func TestJSONTimeEpoch(t *testing.T) {
+	want := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	cases := map[string]time.Time{
+		`1714564800`:             want,
+		`"1714564800"`:           want,
+		`1714564800000`:          want,
+		`1714564800.5`:           want.Add(500 * time.Millisecond),
+		`"1714564800250"`:        want.Add(250 * time.Millisecond),
+		`"2024-05-01T12:00:00Z"`: want,
+	}
+	for in, want := range cases {
+		var got runpod.JSONTime
+		if err := json.Unmarshal([]byte(in), &got); err != nil {
+			t.Fatalf("%s: %v", in, err)
+		}
+		if !got.Equal(want) {
+			t.Errorf("%s = %v, want %v", in, got.Time, want)
+		}
+	}
+	var bad runpod.JSONTime
+	if err := json.Unmarshal([]byte(`"yesterday"`), &bad); err == nil {
+		t.Error("expected error for unparseable timestamp")
+	}
+}
+
+func TestDurationMS(t *testing.T) {
+	b, err := json.Marshal(struct {
+		Timeout runpod.DurationMS `json:"executionTimeoutMs"`
+	}{runpod.DurationMS(90 * time.Second)})
+	if err != nil || string(b) != `{"executionTimeoutMs":90000}` {
+		t.Fatalf("marshal = %s, %v", b, err)
+	}
+
+	for in, want := range map[string]time.Duration{
+		`600000`: 10 * time.Minute,
+		`"1500"`: 1500 * time.Millisecond,
+		`2.5`:    2500 * time.Microsecond,
+		`null`:   0,
+	} {
+		var d runpod.DurationMS
+		if err := json.Unmarshal([]byte(in), &d); err != nil || d.Duration() != want {
+			t.Errorf("%s = %v, %v; want %v", in, d, err, want)
+		}
+	}
+	var d runpod.DurationMS
+	if err := json.Unmarshal([]byte(`"ten minutes"`), &d); err == nil {
+		t.Error("expected error for non-numeric duration")
+	}
+}
+
+func TestPodRuntime(t *testing.T) {
+	var pod runpod.Pod
+	err := json.Unmarshal([]byte(`{"id":"p1","runtime":{
+		"uptimeInSeconds": 90,
+		"ports": [{"ip":"1.2.3.4","isIpPublic":true,"privatePort":22,"publicPort":10022,"type":"tcp"},
+		          {"ip":"10.0.0.2","isIpPublic":false,"privatePort":8888,"publicPort":60001,"type":"http"}],
+		"gpus": [{"id":"gpu-0","gpuUtilPercent":87.5,"memoryUtilPercent":40}],
+		"container": {"cpuPercent":12,"memoryPercent":33.3}}}`), &pod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rt := pod.Runtime
+	if rt.Uptime() != 90*time.Second || len(rt.Ports) != 2 || len(rt.GPUs) != 1 || rt.Container == nil {
+		t.Fatalf("runtime = %+v", rt)
+	}
+	if p := rt.Ports[0]; !p.IsIPPublic || p.PublicPort != 10022 || p.Key() != "22/tcp" {
+		t.Fatalf("ssh port = %+v", p)
+	}
+	if rt.GPUs[0].GPUUtilPercent != 87.5 || rt.Container.MemoryPercent != 33.3 {
+		t.Fatalf("gpus = %+v, container = %+v", rt.GPUs, rt.Container)
+	}
+
+	// The older object form keys ports by name or "<port>/<type>".
+	var ports runpod.PodPorts
+	if err := json.Unmarshal([]byte(`{"http":{"publicPort":443},"22/tcp":12345}`), &ports); err != nil {
+		t.Fatal(err)
+	}
+	if len(ports) != 2 || ports[0].Key() != "22/tcp" || ports[0].PrivatePort != 22 || ports[0].PublicPort != 12345 ||
+		ports[1].Key() != "http" || ports[1].PublicPort != 443 {
+		t.Fatalf("ports = %+v", ports)
+	}
+}
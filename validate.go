@@ -0,0 +1,76 @@
+package runpod
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Validate methods on the request types check a request without sending
+// it and report every problem at once: one as a *ValidationError, several
+// as a *ValidationErrors. The client runs them before each create or update
+// unless disabled with WithRequestValidation(false).
+
+// problems collects the failures found by a Validate method.
+type problems []*ValidationError
+
+// add records err if it is a validation failure; nil is ignored.
+func (p *problems) add(err error) {
+	var ve *ValidationError
+	if errors.As(err, &ve) {
+		*p = append(*p, ve)
+	}
+}
+
+// err returns the collected failures: nil if there were none, the
+// *ValidationError itself if there was one, and a *ValidationErrors
+// otherwise.
+func (p problems) err() error {
+	switch len(p) {
+	case 0:
+		return nil
+	case 1:
+		return p[0]
+	}
+	return &ValidationErrors{Errors: p}
+}
+
+// nilRequest is what Validate reports on a nil request.
+func nilRequest() error {
+	return NewValidationError("request", "cannot be nil")
+}
+
+// validateRequest runs req.Validate unless request validation is disabled.
+func (c *Client) validateRequest(req interface{ Validate() error }) error {
+	if c.skipValidation {
+		return nil
+	}
+	return req.Validate()
+}
+
+// validatePorts checks RunPod port specs: "<port>/http" or "<port>/tcp".
+func validatePorts(p *problems, field string, ports []string) {
+	for _, spec := range ports {
+		number, proto, ok := strings.Cut(strings.TrimSpace(spec), "/")
+		port, err := strconv.Atoi(number)
+		switch {
+		case !ok || err != nil:
+			p.add(NewValidationErrorWithValue(field, "must be '<port>/http' or '<port>/tcp'", spec))
+		case port < 1 || port > 65535:
+			p.add(NewValidationErrorWithValue(field, "port must be between 1 and 65535", spec))
+		case proto != "http" && proto != "tcp":
+			p.add(NewValidationErrorWithValue(field, "protocol must be 'http' or 'tcp'", spec))
+		}
+	}
+}
+
+// validateCudaVersions checks versions against AllCudaVersions.
+func validateCudaVersions(p *problems, field string, versions ...string) {
+	known := AllCudaVersions()
+	for _, v := range versions {
+		if !slices.Contains(known, strings.TrimSpace(v)) {
+			p.add(NewValidationErrorWithValue(field, "unknown CUDA version (want one of "+strings.Join(known, ", ")+")", v))
+		}
+	}
+}
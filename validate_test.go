@@ -0,0 +1,99 @@
+package runpod_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestValidateReportsAllProblems(t *testing.T) {
+	req := &runpod.CreatePodRequest{
+		ImageName:           "acme/app:1",
+		GPUTypeIDs:          []string{"NVIDIA GeForce RTX 4090"},
+		GPUCount:            1,
+		ContainerDiskInGB:   20,
+		Ports:               []string{"8888/http", "22/tcp", "8080", "70000/tcp", "53/udp"},
+		AllowedCudaVersions: []string{"12.4", "12.7"},
+	}
+	err := req.Validate()
+	var errs *runpod.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Validate = %v, want *ValidationErrors", err)
+	}
+	var fields []string
+	for _, e := range errs.Errors {
+		fields = append(fields, e.Field)
+	}
+	if got := strings.Join(fields, ","); got != "name,ports,ports,ports,allowedCudaVersions" {
+		t.Fatalf("fields = %s\n%v", got, err)
+	}
+	if !strings.HasPrefix(err.Error(), "5 validation errors: ") {
+		t.Fatalf("Error() = %q", err.Error())
+	}
+	var first *runpod.ValidationError
+	if !errors.As(err, &first) || first.Field != "name" {
+		t.Fatalf("errors.As = %+v", first)
+	}
+
+	// A single problem is returned as is.
+	if _, ok := (&runpod.UpdateSecretRequest{}).Validate().(*runpod.ValidationError); !ok {
+		t.Fatal("a single problem must be a *ValidationError")
+	}
+	if _, ok := (*runpod.CreatePodRequest)(nil).Validate().(*runpod.ValidationError); !ok {
+		t.Fatal("a nil request must be a *ValidationError")
+	}
+
+	for _, v := range []interface{ Validate() error }{
+		(*runpod.CreateEndpointRequest)(nil),
+		&runpod.UpdateEndpointRequest{WorkersMin: runpod.Ptr(-1), IdleTimeout: runpod.Ptr(-5)},
+		&runpod.CreateTemplateRequest{Name: "t", ImageName: "img", Ports: []string{"http/8888"}},
+		&runpod.UpdateTemplateRequest{ImageName: runpod.Ptr(" ")},
+		&runpod.CreateNetworkVolumeRequest{Name: "v"},
+		&runpod.UpdateNetworkVolumeRequest{},
+		&runpod.CreateContainerRegistryAuthRequest{Name: "r"},
+		&runpod.CreateSecretRequest{},
+		&runpod.UpdateSecretRequest{},
+	} {
+		if err := v.Validate(); !errors.As(err, &first) {
+			t.Errorf("%T.Validate() = %v", v, err)
+		}
+	}
+	if err := (&runpod.CreateSecretRequest{Name: "s", Value: "v"}).Validate(); err != nil {
+		t.Fatalf("valid request: %v", err)
+	}
+}
+
+func TestValidationRunsBeforeSending(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	ctx := context.Background()
+	req := &runpod.CreatePodRequest{
+		Name:              "p",
+		ImageName:         "acme/app:1",
+		GPUTypeIDs:        []string{"NVIDIA GeForce RTX 4090"},
+		GPUCount:          1,
+		ContainerDiskInGB: 20,
+		MinCudaVersion:    "13.0",
+	}
+
+	_, err := srv.MustClient().CreatePod(ctx, req)
+	if validationErr, ok := err.(*runpod.ValidationError); !ok || validationErr.Field != "minCudaVersion" {
+		t.Fatalf("CreatePod = %v, want a minCudaVersion validation error", err)
+	}
+	if pods, _ := srv.MustClient().ListPods(ctx, nil); len(pods) != 0 {
+		t.Fatal("invalid request reached the API")
+	}
+
+	// Disabled validation leaves the call to the API; nil is still refused.
+	client := srv.MustClient(runpod.WithRequestValidation(false))
+	if _, err := client.CreatePod(ctx, req); err != nil {
+		t.Fatalf("CreatePod without validation: %v", err)
+	}
+	if _, err := client.CreatePod(ctx, nil); err == nil {
+		t.Fatal("nil request must fail")
+	}
+}
@@ -0,0 +1,240 @@
+package runpod
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"time"
+)
+
+// DefaultWatchInterval is the poll interval of NewWatcher, WatchPod,
+// WatchEndpointHealth and WatchJob when given a zero one.
+const DefaultWatchInterval = 5 * time.Second
+
+// WatchOptions configures NewWatcher.
+type WatchOptions[T any] struct {
+	// Poll reads the current value.
+	Poll func(ctx context.Context) (T, error)
+	// Interval between polls (DefaultWatchInterval when zero).
+	Interval time.Duration
+	// Changed reports whether cur differs from prev, the last value sent,
+	// enough to send. When nil, any difference does (reflect.DeepEqual).
+	Changed func(prev, cur T) bool
+	// Done reports whether a value is final: the watcher sends it and
+	// stops. When nil, the watcher runs until stopped.
+	Done func(T) bool
+	// Clock times the polls; when nil, the real clock.
+	Clock Clock
+}
+
+// WatchEvent is a value a Watcher observed, or a failed poll.
+type WatchEvent[T any] struct {
+	// Value is the polled value; zero for errors.
+	Value T
+	// Err is set when the poll failed; the watcher keeps polling.
+	Err error
+	At  time.Time
+}
+
+// Watcher polls a value and sends it on Events whenever it changes. The
+// first value is always sent, failed polls are sent as errors, and a value
+// for which Done is true is sent last. A slow reader delays the next poll
+// rather than losing events. Events is closed when ctx is done, Stop is
+// called, or Done is true; Err then reports why. Watchers a Client returns
+// also stop when it is closed.
+//
+// WatchPod, WatchEndpointHealth and WatchJob return Watchers for RunPod
+// resources, and WatchGPUAvailability runs on one; NewWatcher builds
+// others:
+//
+//	w, err := runpod.NewWatcher(ctx, runpod.WatchOptions[int]{
+//		Poll: func(ctx context.Context) (int, error) {
+//			pods, err := client.ListPods(ctx, nil)
+//			return len(pods), err
+//		},
+//		Interval: time.Minute,
+//	})
+//	for ev := range w.Events() {
+//		log.Printf("%d pods (err=%v)", ev.Value, ev.Err)
+//	}
+type Watcher[T any] struct {
+	opts   WatchOptions[T]
+	events chan WatchEvent[T]
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	finished chan struct{} // closed, after err is set, when run returns
+	err      error
+	onExit   func() // called last when run returns; see Client.watch
+}
+
+// NewWatcher starts polling opts.Poll until ctx is done or the Watcher is
+// stopped.
+func NewWatcher[T any](ctx context.Context, opts WatchOptions[T]) (*Watcher[T], error) {
+	return newWatcher(ctx, opts, nil)
+}
+
+// watch starts a Watcher that Close stops and waits for.
+func watch[T any](c *Client, ctx context.Context, opts WatchOptions[T]) (*Watcher[T], error) {
+	ctx, release, err := c.lifecycle.start(ctx)
+	if err != nil {
+		return nil, err
+	}
+	w, err := newWatcher(ctx, opts, release)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	return w, nil
+}
+
+func newWatcher[T any](ctx context.Context, opts WatchOptions[T], onExit func()) (*Watcher[T], error) {
+	if opts.Poll == nil {
+		return nil, NewValidationError("poll", "cannot be nil")
+	}
+	if opts.Interval < 0 {
+		return nil, NewValidationErrorWithValue("interval", "cannot be negative", opts.Interval)
+	}
+	if opts.Interval == 0 {
+		opts.Interval = DefaultWatchInterval
+	}
+	if opts.Changed == nil {
+		opts.Changed = func(prev, cur T) bool { return !reflect.DeepEqual(prev, cur) }
+	}
+	if opts.Clock == nil {
+		opts.Clock = realClock{}
+	}
+	w := &Watcher[T]{opts: opts, events: make(chan WatchEvent[T]), finished: make(chan struct{}), onExit: onExit}
+	w.ctx, w.cancel = context.WithCancel(ctx)
+	go w.run()
+	return w, nil
+}
+
+// Events returns the channel values and errors are sent on.
+func (w *Watcher[T]) Events() <-chan WatchEvent[T] {
+	return w.events
+}
+
+// Stop stops polling; Events is closed shortly after. It is safe to call
+// more than once, and after the Watcher has finished.
+func (w *Watcher[T]) Stop() {
+	w.cancel()
+}
+
+// Err reports why Events was closed: nil when a value was Done, otherwise
+// the context's error (context.Canceled after Stop). It returns nil while
+// the Watcher runs.
+func (w *Watcher[T]) Err() error {
+	select {
+	case <-w.finished:
+		return w.err
+	default:
+		return nil
+	}
+}
+
+func (w *Watcher[T]) run() {
+	if w.onExit != nil {
+		defer w.onExit()
+	}
+	defer close(w.events)
+	defer close(w.finished)
+	defer w.cancel()
+	send := func(ev WatchEvent[T]) bool {
+		ev.At = w.opts.Clock.Now()
+		select {
+		case w.events <- ev:
+			return true
+		case <-w.ctx.Done():
+			return false
+		}
+	}
+
+	var prev T
+	sent := false
+	for {
+		value, err := w.opts.Poll(w.ctx)
+		if w.ctx.Err() != nil {
+			w.err = w.ctx.Err()
+			return
+		}
+		switch {
+		case err != nil:
+			if !send(WatchEvent[T]{Err: err}) {
+				w.err = w.ctx.Err()
+				return
+			}
+		case !sent || w.opts.Changed(prev, value):
+			if !send(WatchEvent[T]{Value: value}) {
+				w.err = w.ctx.Err()
+				return
+			}
+			prev, sent = value, true
+		}
+		if err == nil && w.opts.Done != nil && w.opts.Done(value) {
+			return
+		}
+
+		select {
+		case <-w.ctx.Done():
+			w.err = w.ctx.Err()
+			return
+		case <-w.opts.Clock.After(w.opts.Interval):
+		}
+	}
+}
+
+// WatchPod watches a pod, sending it when its desired status, last status
+// change, runtime presence or public IP changes: when it starts, stops,
+// comes up or moves. interval defaults to DefaultWatchInterval.
+func (c *Client) WatchPod(ctx context.Context, podID string, interval time.Duration) (*Watcher[*Pod], error) {
+	if err := c.validateRequired("podID", podID); err != nil {
+		return nil, err
+	}
+	return watch(c, ctx, WatchOptions[*Pod]{
+		Poll:     func(ctx context.Context) (*Pod, error) { return c.GetPod(ctx, podID) },
+		Interval: interval,
+		Changed: func(prev, cur *Pod) bool {
+			return prev.DesiredStatus != cur.DesiredStatus || prev.LastStatusChange != cur.LastStatusChange ||
+				(prev.Runtime == nil) != (cur.Runtime == nil) || prev.PublicIP != cur.PublicIP
+		},
+		Clock: c.clock,
+	})
+}
+
+// WatchEndpointHealth watches an endpoint's health, sending it whenever the
+// queue or worker counts change. interval defaults to
+// DefaultWatchInterval.
+func (c *Client) WatchEndpointHealth(ctx context.Context, endpointID string, interval time.Duration) (*Watcher[*EndpointHealth], error) {
+	if err := c.validateRequired("endpointID", endpointID); err != nil {
+		return nil, err
+	}
+	return watch(c, ctx, WatchOptions[*EndpointHealth]{
+		Poll:     func(ctx context.Context) (*EndpointHealth, error) { return c.GetHealth(ctx, endpointID) },
+		Interval: interval,
+		Changed:  func(prev, cur *EndpointHealth) bool { return *prev != *cur },
+		Clock:    c.clock,
+	})
+}
+
+// WatchJob watches a job, sending it when its status or output changes
+// (workers report progress as output while IN_PROGRESS), and stops after
+// sending it in a terminal status. interval defaults to
+// DefaultWatchInterval.
+func (c *Client) WatchJob(ctx context.Context, endpointID, jobID string, interval time.Duration) (*Watcher[*Job], error) {
+	if err := c.validateRequired("endpointID", endpointID); err != nil {
+		return nil, err
+	}
+	if err := c.validateRequired("jobID", jobID); err != nil {
+		return nil, err
+	}
+	return watch(c, ctx, WatchOptions[*Job]{
+		Poll:     func(ctx context.Context) (*Job, error) { return c.GetJobStatus(ctx, endpointID, jobID) },
+		Interval: interval,
+		Changed: func(prev, cur *Job) bool {
+			return prev.Status != cur.Status || !bytes.Equal(prev.Output, cur.Output)
+		},
+		Done:  func(job *Job) bool { return c.IsJobTerminal(job.Status) },
+		Clock: c.clock,
+	})
+}
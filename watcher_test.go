@@ -0,0 +1,123 @@
+package runpod_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	runpod "github.com/cozy-creator/runpod-go-sdk"
+	"github.com/cozy-creator/runpod-go-sdk/runpodtest"
+)
+
+func TestNewWatcher(t *testing.T) {
+	clock := runpodtest.NewClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	polls := 0
+	boom := errors.New("boom")
+	w, err := runpod.NewWatcher(t.Context(), runpod.WatchOptions[int]{
+		// 0, 0, error, 1, 1, 2: values change every other poll.
+		Poll: func(context.Context) (int, error) {
+			polls++
+			if polls == 3 {
+				return 0, boom
+			}
+			return polls / 3, nil
+		},
+		Interval: time.Second,
+		Done:     func(n int) bool { return n == 2 },
+		Clock:    clock,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		for range 5 {
+			clock.BlockUntil(1)
+			clock.Advance(time.Second)
+		}
+	}()
+
+	var got []runpod.WatchEvent[int]
+	for ev := range w.Events() {
+		got = append(got, ev)
+	}
+	if len(got) != 4 || got[0].Value != 0 || got[1].Err != boom || got[2].Value != 1 || got[3].Value != 2 || polls != 6 {
+		t.Fatalf("events = %+v after %d polls", got, polls)
+	}
+	if !got[3].At.Equal(clock.Now()) || w.Err() != nil {
+		t.Fatalf("last event at %v, Err = %v", got[3].At, w.Err())
+	}
+
+	var validationErr *runpod.ValidationError
+	if _, err := runpod.NewWatcher(t.Context(), runpod.WatchOptions[int]{}); !errors.As(err, &validationErr) {
+		t.Fatalf("nil Poll: %v", err)
+	}
+}
+
+func TestClientWatchers(t *testing.T) {
+	srv := runpodtest.New()
+	defer srv.Close()
+	client := srv.MustClient()
+	ctx := t.Context()
+	next := func(events <-chan runpod.WatchEvent[*runpod.Job]) runpod.WatchEvent[*runpod.Job] {
+		t.Helper()
+		select {
+		case ev := <-events:
+			return ev
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for event")
+			return runpod.WatchEvent[*runpod.Job]{}
+		}
+	}
+
+	job, err := client.RunAsync(ctx, "ep1", map[string]int{"n": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobs, err := client.WatchJob(ctx, "ep1", job.ID, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev := next(jobs.Events()); ev.Err != nil || ev.Value.Status != "IN_QUEUE" {
+		t.Fatalf("first event = %+v", ev)
+	}
+	if err := srv.CompleteJob("ep1", job.ID, "ok"); err != nil {
+		t.Fatal(err)
+	}
+	if ev := next(jobs.Events()); ev.Value.Status != "COMPLETED" || string(ev.Value.Output) != `"ok"` {
+		t.Fatalf("second event = %+v", ev)
+	}
+	if _, open := <-jobs.Events(); open || jobs.Err() != nil {
+		t.Fatalf("watcher still open after a terminal status, Err = %v", jobs.Err())
+	}
+
+	health, err := client.WatchEndpointHealth(ctx, "ep1", 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev := <-health.Events(); ev.Err != nil || ev.Value.Status != "healthy" {
+		t.Fatalf("health event = %+v", ev)
+	}
+	health.Stop()
+	for range health.Events() {
+	}
+	if !errors.Is(health.Err(), context.Canceled) {
+		t.Fatalf("Err after Stop = %v", health.Err())
+	}
+
+	srv.AddPod(&runpod.Pod{ID: "p1", DesiredStatus: "RUNNING"})
+	pods, err := client.WatchPod(ctx, "p1", 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pods.Stop()
+	if ev := <-pods.Events(); ev.Err != nil || ev.Value.DesiredStatus != "RUNNING" {
+		t.Fatalf("pod event = %+v", ev)
+	}
+	if err := client.StopPod(ctx, "p1"); err != nil {
+		t.Fatal(err)
+	}
+	if ev := <-pods.Events(); ev.Err != nil || ev.Value.DesiredStatus != "EXITED" {
+		t.Fatalf("pod event after stop = %+v", ev)
+	}
+}